@@ -0,0 +1,157 @@
+package geom
+
+import "math"
+
+// An arc is a circular arc through three control points.
+type arc struct {
+	cx, cy float64 // center
+	r      float64 // radius
+	a0     float64 // angle of the start point
+	sweep  float64 // signed angle from the start point to the end point, positive if counter-clockwise
+	f1     float64 // fraction of sweep at the middle control point
+	linear bool    // true if the control points are collinear
+}
+
+// newArc returns the arc through p0, p1, and p2. If p0 and p2 are equal then
+// the arc is a full counter-clockwise circle with p0 and p1 diametrically
+// opposed.
+func newArc(p0, p1, p2 []float64) arc {
+	if p0[0] == p2[0] && p0[1] == p2[1] {
+		if p0[0] == p1[0] && p0[1] == p1[1] {
+			return arc{linear: true}
+		}
+		cx, cy := (p0[0]+p1[0])/2, (p0[1]+p1[1])/2
+		return arc{
+			cx:    cx,
+			cy:    cy,
+			r:     math.Hypot(p0[0]-cx, p0[1]-cy),
+			a0:    math.Atan2(p0[1]-cy, p0[0]-cx),
+			sweep: 2 * math.Pi,
+			f1:    0.5,
+		}
+	}
+	bx, by := p1[0]-p0[0], p1[1]-p0[1]
+	cx, cy := p2[0]-p0[0], p2[1]-p0[1]
+	d := 2 * (bx*cy - by*cx)
+	if d == 0 {
+		return arc{linear: true}
+	}
+	b2, c2 := bx*bx+by*by, cx*cx+cy*cy
+	a := arc{
+		cx: p0[0] + (cy*b2-by*c2)/d,
+		cy: p0[1] + (bx*c2-cx*b2)/d,
+	}
+	a.r = math.Hypot(p0[0]-a.cx, p0[1]-a.cy)
+	a.a0 = math.Atan2(p0[1]-a.cy, p0[0]-a.cx)
+	a1 := math.Atan2(p1[1]-a.cy, p1[0]-a.cx)
+	a2 := math.Atan2(p2[1]-a.cy, p2[0]-a.cx)
+	if d > 0 {
+		a.sweep = ccwAngle(a.a0, a2)
+		a.f1 = ccwAngle(a.a0, a1) / a.sweep
+	} else {
+		a.sweep = -ccwAngle(a2, a.a0)
+		a.f1 = ccwAngle(a1, a.a0) / -a.sweep
+	}
+	return a
+}
+
+// contains returns true if the angle theta lies on a.
+func (a arc) contains(theta float64) bool {
+	if a.sweep > 0 {
+		return ccwAngle(a.a0, theta) <= a.sweep
+	}
+	return ccwAngle(theta, a.a0) <= -a.sweep
+}
+
+// ccwAngle returns the counter-clockwise angle from from to to, in the range
+// [0, 2π).
+func ccwAngle(from, to float64) float64 {
+	angle := math.Mod(to-from, 2*math.Pi)
+	if angle < 0 {
+		angle += 2 * math.Pi
+	}
+	return angle
+}
+
+// arcLength returns the length of the arcs in flatCoords. Any trailing control
+// point that does not complete an arc is joined with a straight line.
+func arcLength(flatCoords []float64, stride int) float64 {
+	length := 0.0
+	i := 0
+	for ; i+2*stride < len(flatCoords); i += 2 * stride {
+		p0, p1, p2 := flatCoords[i:i+stride], flatCoords[i+stride:i+2*stride], flatCoords[i+2*stride:i+3*stride]
+		if a := newArc(p0, p1, p2); a.linear {
+			length += math.Hypot(p1[0]-p0[0], p1[1]-p0[1]) + math.Hypot(p2[0]-p1[0], p2[1]-p1[1])
+		} else {
+			length += a.r * math.Abs(a.sweep)
+		}
+	}
+	if i+stride < len(flatCoords) {
+		length += length1(flatCoords, i, len(flatCoords), stride)
+	}
+	return length
+}
+
+// extendArcBounds extends b to include the control points of the arcs in
+// flatCoords and the extreme x and y values of each arc.
+func extendArcBounds(b *Bounds, flatCoords []float64, stride int) *Bounds {
+	b.extendFlatCoords(flatCoords, 0, len(flatCoords), stride)
+	for i := 0; i+2*stride < len(flatCoords); i += 2 * stride {
+		a := newArc(flatCoords[i:i+stride], flatCoords[i+stride:i+2*stride], flatCoords[i+2*stride:i+3*stride])
+		if a.linear {
+			continue
+		}
+		for quadrant := range 4 {
+			theta := float64(quadrant) * math.Pi / 2
+			if !a.contains(theta) {
+				continue
+			}
+			x, y := a.cx+a.r*math.Cos(theta), a.cy+a.r*math.Sin(theta)
+			b.min[0], b.max[0] = math.Min(b.min[0], x), math.Max(b.max[0], x)
+			b.min[1], b.max[1] = math.Min(b.min[1], y), math.Max(b.max[1], y)
+		}
+	}
+	return b
+}
+
+// linearizeArcs appends a linear approximation of the arcs in flatCoords to
+// dst, with segmentsPerQuadrant segments for each quarter circle. Values less
+// than one are treated as one. The control points at the ends of each arc are
+// preserved exactly and the other ordinates, such as z and m, are interpolated
+// linearly between the control points. Any trailing control point that does
+// not complete an arc is joined with a straight line.
+func linearizeArcs(dst, flatCoords []float64, stride, segmentsPerQuadrant int) []float64 {
+	if len(flatCoords) == 0 {
+		return dst
+	}
+	segmentsPerQuadrant = max(segmentsPerQuadrant, 1)
+	dst = append(dst, flatCoords[:stride]...)
+	i := 0
+	for ; i+2*stride < len(flatCoords); i += 2 * stride {
+		p0, p1, p2 := flatCoords[i:i+stride], flatCoords[i+stride:i+2*stride], flatCoords[i+2*stride:i+3*stride]
+		a := newArc(p0, p1, p2)
+		if a.linear {
+			dst = append(dst, p1...)
+			dst = append(dst, p2...)
+			continue
+		}
+		n := int(math.Ceil(math.Abs(a.sweep) / (math.Pi / 2) * float64(segmentsPerQuadrant)))
+		for k := 1; k < n; k++ {
+			t := float64(k) / float64(n)
+			theta := a.a0 + t*a.sweep
+			dst = append(dst, a.cx+a.r*math.Cos(theta), a.cy+a.r*math.Sin(theta))
+			for j := 2; j < stride; j++ {
+				if t <= a.f1 {
+					dst = append(dst, p0[j]+t/a.f1*(p1[j]-p0[j]))
+				} else {
+					dst = append(dst, p1[j]+(t-a.f1)/(1-a.f1)*(p2[j]-p1[j]))
+				}
+			}
+		}
+		dst = append(dst, p2...)
+	}
+	if i+stride < len(flatCoords) {
+		dst = append(dst, flatCoords[i+stride:]...)
+	}
+	return dst
+}
@@ -170,3 +170,66 @@ func orientationBasedOnSignForBig(x big.Float) orientation.Type {
 		return orientation.CounterClockwise
 	}
 }
+
+// inCirclePrec is the precision used for the extended-precision in-circle
+// test. It is large enough that the determinant of typical coordinates is
+// computed without rounding.
+const inCirclePrec = 2048
+
+// IsInCircle tests whether point lies strictly inside the circle passing through
+// a, b and c, which must be in counter-clockwise order.  Points lying exactly on
+// the circle are not considered to be inside it.
+//
+// The determinant is first evaluated with float64 arithmetic and only
+// recomputed using big.Float arithmetic when the result cannot be trusted.
+func IsInCircle(a, b, c, point geom.Coord) bool {
+	adx, ady := a[0]-point[0], a[1]-point[1]
+	bdx, bdy := b[0]-point[0], b[1]-point[1]
+	cdx, cdy := c[0]-point[0], c[1]-point[1]
+
+	bdxcdy, cdxbdy := bdx*cdy, cdx*bdy
+	cdxady, adxcdy := cdx*ady, adx*cdy
+	adxbdy, bdxady := adx*bdy, bdx*ady
+	alift := adx*adx + ady*ady
+	blift := bdx*bdx + bdy*bdy
+	clift := cdx*cdx + cdy*cdy
+
+	det := alift*(bdxcdy-cdxbdy) + blift*(cdxady-adxcdy) + clift*(adxbdy-bdxady)
+	permanent := (math.Abs(bdxcdy)+math.Abs(cdxbdy))*alift +
+		(math.Abs(cdxady)+math.Abs(adxcdy))*blift +
+		(math.Abs(adxbdy)+math.Abs(bdxady))*clift
+	errbound := 11 * dpSafeEpsilon * permanent
+	if det > errbound || -det > errbound {
+		return det > 0
+	}
+
+	return inCircleBig(a, b, c, point).Sign() > 0
+}
+
+func inCircleBig(a, b, c, point geom.Coord) *big.Float {
+	diff := func(x, y float64) *big.Float {
+		d := new(big.Float).SetPrec(inCirclePrec).SetFloat64(x)
+		return d.Sub(d, new(big.Float).SetPrec(inCirclePrec).SetFloat64(y))
+	}
+	mul := func(x, y *big.Float) *big.Float {
+		return new(big.Float).SetPrec(inCirclePrec).Mul(x, y)
+	}
+	add := func(x, y *big.Float) *big.Float {
+		return new(big.Float).SetPrec(inCirclePrec).Add(x, y)
+	}
+	sub := func(x, y *big.Float) *big.Float {
+		return new(big.Float).SetPrec(inCirclePrec).Sub(x, y)
+	}
+
+	adx, ady := diff(a[0], point[0]), diff(a[1], point[1])
+	bdx, bdy := diff(b[0], point[0]), diff(b[1], point[1])
+	cdx, cdy := diff(c[0], point[0]), diff(c[1], point[1])
+
+	alift := add(mul(adx, adx), mul(ady, ady))
+	blift := add(mul(bdx, bdx), mul(bdy, bdy))
+	clift := add(mul(cdx, cdx), mul(cdy, cdy))
+
+	det := mul(alift, sub(mul(bdx, cdy), mul(cdx, bdy)))
+	det = add(det, mul(blift, sub(mul(cdx, ady), mul(adx, cdy))))
+	return add(det, mul(clift, sub(mul(adx, bdy), mul(bdx, ady))))
+}
@@ -29,3 +29,15 @@ func ExampleIntersection() {
 	fmt.Println(intersection)
 	// Output: [0 0]
 }
+
+func ExampleIsInCircle() {
+	a := geom.Coord{0, 0}
+	b := geom.Coord{1, 0}
+	c := geom.Coord{0, 1}
+
+	fmt.Println(bigxy.IsInCircle(a, b, c, geom.Coord{0.5, 0.5}))
+	fmt.Println(bigxy.IsInCircle(a, b, c, geom.Coord{1, 1}))
+	// Output:
+	// true
+	// false
+}
@@ -120,3 +120,57 @@ func TestIntersection(t *testing.T) {
 		})
 	}
 }
+
+func TestIsInCircle(t *testing.T) {
+	for _, tc := range []struct {
+		desc     string
+		a, b, c  geom.Coord
+		point    geom.Coord
+		expected bool
+	}{
+		{
+			desc:     "Center",
+			a:        geom.Coord{0, 0},
+			b:        geom.Coord{1, 0},
+			c:        geom.Coord{0, 1},
+			point:    geom.Coord{0.5, 0.5},
+			expected: true,
+		},
+		{
+			desc:     "Outside",
+			a:        geom.Coord{0, 0},
+			b:        geom.Coord{1, 0},
+			c:        geom.Coord{0, 1},
+			point:    geom.Coord{2, 2},
+			expected: false,
+		},
+		{
+			desc:     "On circle",
+			a:        geom.Coord{0, 0},
+			b:        geom.Coord{1, 0},
+			c:        geom.Coord{0, 1},
+			point:    geom.Coord{1, 1},
+			expected: false,
+		},
+		{
+			desc:     "Just inside",
+			a:        geom.Coord{0, 0},
+			b:        geom.Coord{1, 0},
+			c:        geom.Coord{0, 1},
+			point:    geom.Coord{1, 1 - 1e-15},
+			expected: true,
+		},
+		{
+			desc:     "Large offset on circle",
+			a:        geom.Coord{1e9, 1e9},
+			b:        geom.Coord{1e9 + 1, 1e9},
+			c:        geom.Coord{1e9, 1e9 + 1},
+			point:    geom.Coord{1e9 + 1, 1e9 + 1},
+			expected: false,
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			assert.Equal(t, tc.expected, bigxy.IsInCircle(tc.a, tc.b, tc.c, tc.point))
+		})
+	}
+}
@@ -0,0 +1,284 @@
+// Package cell implements geohash encoding and decoding of points, neighbor
+// cells, and coverings of polygons by geohash cells, and S2 cell IDs and
+// coverings of polygons by S2 cells.
+//
+// H3 hexagonal cells are not implemented.
+//
+// Coordinates are longitudes (x) and latitudes (y) in degrees.
+package cell
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+
+	"github.com/don4get/go-geom"
+	"github.com/don4get/go-geom/internal/cover"
+)
+
+// MaxPrecision is the maximum geohash precision, in characters. Twelve
+// characters give cells smaller than 4cm by 2cm.
+const MaxPrecision = 12
+
+// DefaultMaxCoverCells is the default maximum number of cells that Cover will
+// consider. Covering large polygons at high precision is otherwise unbounded.
+const DefaultMaxCoverCells = 1 << 20
+
+const base32 = "0123456789bcdefghjkmnpqrstuvwxyz"
+
+// ErrInvalidPrecision is returned when a precision is out of range.
+type ErrInvalidPrecision int
+
+func (e ErrInvalidPrecision) Error() string {
+	return fmt.Sprintf("cell: invalid precision %d", int(e))
+}
+
+// ErrInvalidGeohash is returned when a geohash cannot be decoded.
+type ErrInvalidGeohash string
+
+func (e ErrInvalidGeohash) Error() string {
+	return fmt.Sprintf("cell: invalid geohash %q", string(e))
+}
+
+// ErrEmptyPoint is returned by Encode when the point is empty.
+var ErrEmptyPoint = errors.New("cell: empty point")
+
+// ErrTooManyCells is returned by Cover when the covering would need more than
+// the maximum number of cells to be considered.
+var ErrTooManyCells = errors.New("cell: too many cells")
+
+// A Direction is the direction of a neighbor cell.
+type Direction int
+
+// Directions.
+const (
+	North Direction = iota
+	NorthEast
+	East
+	SouthEast
+	South
+	SouthWest
+	West
+	NorthWest
+)
+
+var directionOffsets = [...][2]int{
+	North:     {0, 1},
+	NorthEast: {1, 1},
+	East:      {1, 0},
+	SouthEast: {1, -1},
+	South:     {0, -1},
+	SouthWest: {-1, -1},
+	West:      {-1, 0},
+	NorthWest: {-1, 1},
+}
+
+// Encode returns the geohash of p with precision characters.
+func Encode(p *geom.Point, precision int) (string, error) {
+	if p.IsEmpty() {
+		return "", ErrEmptyPoint
+	}
+	return EncodeCoord(p.X(), p.Y(), precision)
+}
+
+// EncodeCoord returns the geohash of the longitude lon and latitude lat with
+// precision characters.
+func EncodeCoord(lon, lat float64, precision int) (string, error) {
+	if precision < 1 || MaxPrecision < precision {
+		return "", ErrInvalidPrecision(precision)
+	}
+	lonBits, latBits := bits(precision)
+	x := index(lon, -180, 360, lonBits)
+	y := index(lat, -90, 180, latBits)
+	return encode(x, y, precision), nil
+}
+
+// Decode returns the bounds of the cell with geohash hash.
+func Decode(hash string) (*geom.Bounds, error) {
+	x, y, err := decode(hash)
+	if err != nil {
+		return nil, err
+	}
+	return cellBounds(x, y, len(hash)), nil
+}
+
+// Neighbor returns the geohash of the neighbor of hash in direction d.
+// Longitudes wrap around the antimeridian. There are no neighbors beyond the
+// poles, for which Neighbor returns an empty string.
+func Neighbor(hash string, d Direction) (string, error) {
+	if d < North || NorthWest < d {
+		return "", fmt.Errorf("cell: invalid direction %d", d)
+	}
+	x, y, err := decode(hash)
+	if err != nil {
+		return "", err
+	}
+	lonBits, latBits := bits(len(hash))
+	offset := directionOffsets[d]
+	y += int64(offset[1])
+	if y < 0 || 1<<latBits <= y {
+		return "", nil
+	}
+	x = (x + int64(offset[0]) + 1<<lonBits) % (1 << lonBits)
+	return encode(x, y, len(hash)), nil
+}
+
+// Neighbors returns the geohashes of the neighbors of hash, in the order
+// North, NorthEast, East, SouthEast, South, SouthWest, West, NorthWest.
+// Neighbors beyond the poles are omitted.
+func Neighbors(hash string) ([]string, error) {
+	neighbors := make([]string, 0, len(directionOffsets))
+	for d := North; d <= NorthWest; d++ {
+		neighbor, err := Neighbor(hash, d)
+		if err != nil {
+			return nil, err
+		}
+		if neighbor != "" {
+			neighbors = append(neighbors, neighbor)
+		}
+	}
+	return neighbors, nil
+}
+
+// A CoverOption is an option for Cover.
+type CoverOption func(*coverOptions)
+
+// coverOptions are the options for Cover.
+type coverOptions struct {
+	maxCells int
+}
+
+// CoverOptionWithMaxCells sets the maximum number of cells that Cover will
+// consider for each polygon. Zero means no limit. The default is
+// DefaultMaxCoverCells.
+func CoverOptionWithMaxCells(maxCells int) CoverOption {
+	return func(o *coverOptions) {
+		o.maxCells = maxCells
+	}
+}
+
+// Cover returns the sorted geohashes with precision characters of the cells
+// that intersect g, which must be a *geom.Polygon or a *geom.MultiPolygon.
+// Cells that only touch g's boundary are included, so the covering is
+// conservative.
+func Cover(g geom.T, precision int, opts ...CoverOption) ([]string, error) {
+	if precision < 1 || MaxPrecision < precision {
+		return nil, ErrInvalidPrecision(precision)
+	}
+	o := coverOptions{
+		maxCells: DefaultMaxCoverCells,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	polygons, err := cover.Polygons(g)
+	if err != nil {
+		return nil, err
+	}
+	lonBits, latBits := bits(precision)
+	width, height := 360/float64(int64(1)<<lonBits), 180/float64(int64(1)<<latBits)
+	var hashes []string
+	for _, polygon := range polygons {
+		b := polygon.GetBounds()
+		if b.IsEmpty() {
+			continue
+		}
+		minX, maxX := index(b.Min(0), -180, 360, lonBits), index(b.Max(0), -180, 360, lonBits)
+		minY, maxY := index(b.Min(1), -90, 180, latBits), index(b.Max(1), -90, 180, latBits)
+		if n := (maxX - minX + 1) * (maxY - minY + 1); o.maxCells > 0 && n > int64(o.maxCells) {
+			return nil, ErrTooManyCells
+		}
+		for y := minY; y <= maxY; y++ {
+			for x := minX; x <= maxX; x++ {
+				r := cover.Rect{
+					MinX: -180 + float64(x)*width,
+					MinY: -90 + float64(y)*height,
+					MaxX: -180 + float64(x+1)*width,
+					MaxY: -90 + float64(y+1)*height,
+				}
+				if r.IntersectsPolygon(polygon) {
+					hashes = append(hashes, encode(x, y, precision))
+				}
+			}
+		}
+	}
+	slices.Sort(hashes)
+	return slices.Compact(hashes), nil
+}
+
+// bits returns the number of longitude and latitude bits in a geohash with
+// precision characters. Longitude bits come first, so longitude gets the
+// extra bit when the total is odd.
+func bits(precision int) (int, int) {
+	n := 5 * precision
+	return (n + 1) / 2, n / 2
+}
+
+// index returns the index of the cell of size size/2^n containing v, clamped
+// to the range of valid cells.
+func index(v, minV, size float64, n int) int64 {
+	cells := int64(1) << n
+	i := int64(math.Floor((v - minV) / size * float64(cells)))
+	return max(0, min(cells-1, i))
+}
+
+// encode returns the geohash of the cell at x, y.
+func encode(x, y int64, precision int) string {
+	lonBits, latBits := bits(precision)
+	var sb strings.Builder
+	sb.Grow(precision)
+	var c byte
+	lonBit, latBit := lonBits, latBits
+	for i := range 5 * precision {
+		c <<= 1
+		if i%2 == 0 {
+			lonBit--
+			c |= byte(x >> lonBit & 1)
+		} else {
+			latBit--
+			c |= byte(y >> latBit & 1)
+		}
+		if i%5 == 4 {
+			sb.WriteByte(base32[c])
+			c = 0
+		}
+	}
+	return sb.String()
+}
+
+// decode returns the cell indexes of hash.
+func decode(hash string) (int64, int64, error) {
+	if len(hash) < 1 || MaxPrecision < len(hash) {
+		return 0, 0, ErrInvalidGeohash(hash)
+	}
+	var x, y int64
+	i := 0
+	for _, r := range strings.ToLower(hash) {
+		c := strings.IndexRune(base32, r)
+		if c < 0 {
+			return 0, 0, ErrInvalidGeohash(hash)
+		}
+		for bit := 4; bit >= 0; bit-- {
+			b := int64(c >> bit & 1)
+			if i%2 == 0 {
+				x = x<<1 | b
+			} else {
+				y = y<<1 | b
+			}
+			i++
+		}
+	}
+	return x, y, nil
+}
+
+// cellBounds returns the bounds of the cell at x, y.
+func cellBounds(x, y int64, precision int) *geom.Bounds {
+	lonBits, latBits := bits(precision)
+	width, height := 360/float64(int64(1)<<lonBits), 180/float64(int64(1)<<latBits)
+	return geom.NewBounds(geom.XY).Set(
+		-180+float64(x)*width, -90+float64(y)*height,
+		-180+float64(x+1)*width, -90+float64(y+1)*height,
+	)
+}
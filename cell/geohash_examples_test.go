@@ -0,0 +1,22 @@
+package cell_test
+
+import (
+	"fmt"
+
+	"github.com/don4get/go-geom"
+	"github.com/don4get/go-geom/cell"
+)
+
+func ExampleEncode() {
+	p := geom.NewPointFlat(geom.XY, []float64{-5.6, 42.6})
+	hash, err := cell.Encode(p, 5)
+	if err != nil {
+		panic(err)
+	}
+	b, err := cell.Decode(hash)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Printf("%s %.4f %.4f\n", hash, b.Min(0), b.Max(1))
+	// Output: ezs42 -5.6250 42.6270
+}
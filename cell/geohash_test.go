@@ -0,0 +1,133 @@
+package cell_test
+
+import (
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+
+	"github.com/don4get/go-geom"
+	"github.com/don4get/go-geom/cell"
+)
+
+func TestEncode(t *testing.T) {
+	for _, tc := range []struct {
+		lon, lat  float64
+		precision int
+		expected  string
+	}{
+		{lon: -5.6, lat: 42.6, precision: 5, expected: "ezs42"},
+		{lon: 10.40744, lat: 57.64911, precision: 11, expected: "u4pruydqqvj"},
+		{lon: -180, lat: -90, precision: 1, expected: "0"},
+		{lon: 180, lat: 90, precision: 12, expected: "zzzzzzzzzzzz"},
+		{lon: 0, lat: 0, precision: 2, expected: "s0"},
+	} {
+		t.Run(tc.expected, func(t *testing.T) {
+			p := geom.NewPointFlat(geom.XY, []float64{tc.lon, tc.lat})
+			hash, err := cell.Encode(p, tc.precision)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, hash)
+
+			b, err := cell.Decode(hash)
+			assert.NoError(t, err)
+			assert.True(t, b.OverlapsPoint(geom.XY, geom.Coord{tc.lon, tc.lat}))
+		})
+	}
+}
+
+func TestEncodeErrors(t *testing.T) {
+	_, err := cell.Encode(geom.NewPointFlat(geom.XY, []float64{0, 0}), 0)
+	assert.Equal[error](t, cell.ErrInvalidPrecision(0), err)
+	_, err = cell.EncodeCoord(0, 0, 13)
+	assert.Equal[error](t, cell.ErrInvalidPrecision(13), err)
+	_, err = cell.Encode(geom.NewPointEmpty(geom.XY), 5)
+	assert.IsError(t, err, cell.ErrEmptyPoint)
+}
+
+func TestDecode(t *testing.T) {
+	b, err := cell.Decode("ezs42")
+	assert.NoError(t, err)
+	assert.True(t, math.Abs(b.Min(0)-(-5.625)) < 1e-12)
+	assert.True(t, math.Abs(b.Max(0)-(-5.581054688)) < 1e-9)
+	assert.True(t, math.Abs(b.Min(1)-42.583007813) < 1e-9)
+	assert.True(t, math.Abs(b.Max(1)-42.626953125) < 1e-9)
+
+	b, err = cell.Decode("EZS42")
+	assert.NoError(t, err)
+	assert.True(t, math.Abs(b.Min(0)-(-5.625)) < 1e-12)
+
+	for _, hash := range []string{"", "a", "ezs4i", "0123456789bcd"} {
+		_, err := cell.Decode(hash)
+		assert.Equal[error](t, cell.ErrInvalidGeohash(hash), err)
+	}
+}
+
+func TestNeighbors(t *testing.T) {
+	neighbors, err := cell.Neighbors("ezs42")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"ezs48", "ezs49", "ezs43", "ezs41", "ezs40", "ezefp", "ezefr", "ezefx"}, neighbors)
+
+	// Neighbors wrap around the antimeridian and stop at the poles.
+	neighbors, err = cell.Neighbors("0")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"2", "3", "1", "p", "r"}, neighbors)
+
+	west, err := cell.Neighbor("0", cell.West)
+	assert.NoError(t, err)
+	assert.Equal(t, "p", west)
+	south, err := cell.Neighbor("0", cell.South)
+	assert.NoError(t, err)
+	assert.Equal(t, "", south)
+}
+
+func TestCover(t *testing.T) {
+	// Cells that only touch the square's edges are included.
+	square := geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{{{-45, -45}, {45, -45}, {45, 45}, {-45, 45}, {-45, -45}}})
+	hashes, err := cell.Cover(square, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"7", "e", "g", "k", "m", "s", "t", "u", "v"}, hashes)
+
+	// A thin triangle only touches the cells along its diagonal.
+	triangle := geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{{{1, 1}, {89, 44}, {88, 44}, {1, 1}}})
+	hashes, err = cell.Cover(triangle, 2)
+	assert.NoError(t, err)
+	for _, hash := range hashes {
+		b, err := cell.Decode(hash)
+		assert.NoError(t, err)
+		assert.True(t, b.Max(0) > 1 && b.Min(0) < 89 && b.Max(1) > 1 && b.Min(1) < 44)
+	}
+	expected, err := cell.EncodeCoord(1, 1, 2)
+	assert.NoError(t, err)
+	assert.True(t, slices.Contains(hashes, expected))
+
+	// Cells inside holes are not included.
+	withHole := geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{
+		{{0.5, 0.5}, {44.5, 0.5}, {44.5, 44.5}, {0.5, 44.5}, {0.5, 0.5}},
+		{{12, 12}, {34, 12}, {34, 34}, {12, 34}, {12, 12}},
+	})
+	hashes, err = cell.Cover(withHole, 2)
+	assert.NoError(t, err)
+	center, err := cell.EncodeCoord(22.5, 22.5, 2)
+	assert.NoError(t, err)
+	assert.False(t, slices.Contains(hashes, center))
+	assert.Equal(t, 29, len(hashes))
+
+	multiPolygon := geom.NewMultiPolygon(geom.XY).MustSetCoords([][][]geom.Coord{
+		{{{1, 1}, {2, 1}, {2, 2}, {1, 1}}},
+		{{{-2, -2}, {-1, -2}, {-1, -1}, {-2, -2}}},
+	})
+	hashes, err = cell.Cover(multiPolygon, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"7", "s"}, hashes)
+
+	_, err = cell.Cover(geom.NewLineString(geom.XY), 1)
+	assert.Error(t, err)
+	_, err = cell.Cover(square, 12)
+	assert.IsError(t, err, cell.ErrTooManyCells)
+	_, err = cell.Cover(withHole, 2, cell.CoverOptionWithMaxCells(10))
+	assert.IsError(t, err, cell.ErrTooManyCells)
+	hashes, err = cell.Cover(withHole, 2, cell.CoverOptionWithMaxCells(100))
+	assert.NoError(t, err)
+	assert.Equal(t, 29, len(hashes))
+}
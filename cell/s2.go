@@ -0,0 +1,545 @@
+package cell
+
+import (
+	"fmt"
+	"math"
+	mathbits "math/bits"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/don4get/go-geom"
+	"github.com/don4get/go-geom/internal/cover"
+	"github.com/don4get/go-geom/xy"
+)
+
+// MaxS2Level is the maximum S2 cell level. Level 30 cells are about 1cm
+// across.
+const MaxS2Level = 30
+
+const (
+	s2PosBits    = 2*MaxS2Level + 1
+	s2MaxSize    = 1 << MaxS2Level
+	s2LookupBits = 4
+	s2SwapMask   = 0x01
+	s2InvertMask = 0x02
+)
+
+// s2CoverSegments is the number of segments per edge used to approximate the
+// geodesic edges of S2 cells in longitude and latitude when covering
+// polygons.
+const s2CoverSegments = 8
+
+// ErrInvalidLevel is returned when an S2 level is out of range.
+type ErrInvalidLevel int
+
+func (e ErrInvalidLevel) Error() string {
+	return fmt.Sprintf("cell: invalid S2 level %d", int(e))
+}
+
+// ErrInvalidS2Token is returned when an S2 token cannot be parsed.
+type ErrInvalidS2Token string
+
+func (e ErrInvalidS2Token) Error() string {
+	return fmt.Sprintf("cell: invalid S2 token %q", string(e))
+}
+
+// An S2CellID identifies an S2 cell. Cell IDs are compatible with the S2
+// geometry library: the top three bits are the cube face and the remaining
+// bits are the cell's position along the face's Hilbert curve, followed by a
+// single set bit that marks the cell's level.
+type S2CellID uint64
+
+var (
+	s2PosToIJ = [4][4]int{
+		{0, 1, 3, 2}, // Canonical order.
+		{0, 2, 3, 1}, // Axes swapped.
+		{3, 2, 0, 1}, // Bits inverted.
+		{3, 1, 0, 2}, // Axes swapped and bits inverted.
+	}
+	s2PosToOrientation = [4]int{s2SwapMask, 0, 0, s2InvertMask | s2SwapMask}
+	s2LookupPos        [1 << (2*s2LookupBits + 2)]int
+	s2LookupIJ         [1 << (2*s2LookupBits + 2)]int
+)
+
+func init() {
+	for _, orientation := range []int{0, s2SwapMask, s2InvertMask, s2SwapMask | s2InvertMask} {
+		initS2Lookup(0, 0, 0, orientation, 0, orientation)
+	}
+}
+
+// initS2Lookup fills the lookup tables that convert between i, j and Hilbert
+// curve positions s2LookupBits bits at a time.
+func initS2Lookup(level, i, j, origOrientation, pos, orientation int) {
+	if level == s2LookupBits {
+		ij := i<<s2LookupBits + j
+		s2LookupPos[ij<<2+origOrientation] = pos<<2 + orientation
+		s2LookupIJ[pos<<2+origOrientation] = ij<<2 + orientation
+		return
+	}
+	r := s2PosToIJ[orientation]
+	for k := range 4 {
+		initS2Lookup(level+1, i<<1+r[k]>>1, j<<1+r[k]&1, origOrientation, pos<<2+k, orientation^s2PosToOrientation[k])
+	}
+}
+
+// EncodeS2 returns the ID of the S2 cell at level containing p.
+func EncodeS2(p *geom.Point, level int) (S2CellID, error) {
+	if p.IsEmpty() {
+		return 0, ErrEmptyPoint
+	}
+	return EncodeS2Coord(p.X(), p.Y(), level)
+}
+
+// EncodeS2Coord returns the ID of the S2 cell at level containing the
+// longitude lon and latitude lat.
+func EncodeS2Coord(lon, lat float64, level int) (S2CellID, error) {
+	if level < 0 || MaxS2Level < level {
+		return 0, ErrInvalidLevel(level)
+	}
+	lonRad, latRad := lon*math.Pi/180, lat*math.Pi/180
+	x, y, z := math.Cos(latRad)*math.Cos(lonRad), math.Cos(latRad)*math.Sin(lonRad), math.Sin(latRad)
+	face, u, v := s2XYZToFaceUV(x, y, z)
+	id := s2CellIDFromFaceIJ(face, s2STToIJ(s2UVToST(u)), s2STToIJ(s2UVToST(v)))
+	return id.Parent(level), nil
+}
+
+// ParseS2Token returns the S2 cell ID with token.
+func ParseS2Token(token string) (S2CellID, error) {
+	if len(token) < 1 || 16 < len(token) {
+		return 0, ErrInvalidS2Token(token)
+	}
+	n, err := strconv.ParseUint(token+strings.Repeat("0", 16-len(token)), 16, 64)
+	if err != nil || !S2CellID(n).IsValid() {
+		return 0, ErrInvalidS2Token(token)
+	}
+	return S2CellID(n), nil
+}
+
+// IsValid returns whether id is a valid S2 cell ID.
+func (id S2CellID) IsValid() bool {
+	return id.Face() < 6 && id.lsb()&0x1555555555555555 != 0
+}
+
+// Face returns the cube face of id, between 0 and 5.
+func (id S2CellID) Face() int {
+	return int(uint64(id) >> s2PosBits)
+}
+
+// Level returns the level of id, between 0 and MaxS2Level.
+func (id S2CellID) Level() int {
+	return MaxS2Level - mathbits.TrailingZeros64(uint64(id))>>1
+}
+
+// Parent returns the ancestor of id at level, which must be between 0 and
+// id.Level().
+func (id S2CellID) Parent(level int) S2CellID {
+	lsb := uint64(1) << (2 * (MaxS2Level - level))
+	return S2CellID(uint64(id)&-lsb | lsb)
+}
+
+// Children returns the four children of id in Hilbert curve order. id must
+// not be a leaf cell.
+func (id S2CellID) Children() [4]S2CellID {
+	lsb := id.lsb() >> 2
+	begin := uint64(id) - id.lsb() + lsb
+	return [4]S2CellID{
+		S2CellID(begin),
+		S2CellID(begin + 2*lsb),
+		S2CellID(begin + 4*lsb),
+		S2CellID(begin + 6*lsb),
+	}
+}
+
+// Token returns the S2 token of id, its hexadecimal representation without
+// trailing zeros.
+func (id S2CellID) Token() string {
+	if id == 0 {
+		return "X"
+	}
+	return strings.TrimRight(fmt.Sprintf("%016x", uint64(id)), "0")
+}
+
+// String returns the token of id.
+func (id S2CellID) String() string {
+	return id.Token()
+}
+
+// Center returns the longitude and latitude of the center of id.
+func (id S2CellID) Center() (float64, float64) {
+	face, i0, j0, size := id.faceIJBounds()
+	u := s2STToUV((float64(i0) + float64(size)/2) / s2MaxSize)
+	v := s2STToUV((float64(j0) + float64(size)/2) / s2MaxSize)
+	return s2FaceUVToLonLat(face, u, v)
+}
+
+// Polygon returns the polygon with the vertices of id. Longitudes are
+// continuous, so they extend beyond the antimeridian for cells that cross
+// it, and the polygons of the cells that contain a pole are closed along the
+// pole's latitude.
+func (id S2CellID) Polygon() *geom.Polygon {
+	ring := id.ring(1)
+	return geom.NewPolygonFlat(geom.XY, ring, []int{len(ring)})
+}
+
+// CoverS2 returns the sorted IDs of the S2 cells at level that intersect g,
+// which must be a *geom.Polygon or a *geom.MultiPolygon. Cells that only
+// touch g's boundary are included, so the covering is conservative. g's
+// edges are straight lines in longitude and latitude.
+func CoverS2(g geom.T, level int, opts ...CoverOption) ([]S2CellID, error) {
+	if level < 0 || MaxS2Level < level {
+		return nil, ErrInvalidLevel(level)
+	}
+	o := coverOptions{
+		maxCells: DefaultMaxCoverCells,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	polygons, err := cover.Polygons(g)
+	if err != nil {
+		return nil, err
+	}
+	var ids []S2CellID
+	for _, polygon := range polygons {
+		if polygon.IsEmpty() {
+			continue
+		}
+		c := s2Coverer{
+			polygon:  polygon,
+			level:    level,
+			maxCells: o.maxCells,
+			ids:      ids,
+		}
+		for face := range 6 {
+			if err := c.cover(S2CellID(uint64(2*face+1) << (s2PosBits - 1))); err != nil {
+				return nil, err
+			}
+		}
+		ids = c.ids
+	}
+	slices.Sort(ids)
+	return slices.Compact(ids), nil
+}
+
+// An s2Coverer covers a polygon with S2 cells.
+type s2Coverer struct {
+	polygon  *geom.Polygon
+	level    int
+	maxCells int
+	cells    int
+	ids      []S2CellID
+}
+
+// cover appends the descendants of id at c.level that intersect c.polygon
+// to c.ids. Ancestors are pruned using the bounds of their vertices,
+// expanded to allow for their geodesic edges.
+func (c *s2Coverer) cover(id S2CellID) error {
+	c.cells++
+	if c.maxCells > 0 && c.cells > c.maxCells {
+		return ErrTooManyCells
+	}
+	ring := id.ring(s2CoverSegments)
+	if id.Level() == c.level {
+		if s2RingIntersectsPolygon(ring, c.polygon) {
+			c.ids = append(c.ids, id)
+		}
+		return nil
+	}
+	r := s2RingRect(ring)
+	dx, dy := (r.MaxX-r.MinX)/8, (r.MaxY-r.MinY)/8
+	r = cover.Rect{MinX: r.MinX - dx, MinY: r.MinY - dy, MaxX: r.MaxX + dx, MaxY: r.MaxY + dy}
+	intersects := false
+	for _, shift := range []float64{0, -360, 360} {
+		shifted := cover.Rect{MinX: r.MinX + shift, MinY: r.MinY, MaxX: r.MaxX + shift, MaxY: r.MaxY}
+		if shifted.IntersectsPolygon(c.polygon) {
+			intersects = true
+			break
+		}
+	}
+	if !intersects {
+		return nil
+	}
+	for _, child := range id.Children() {
+		if err := c.cover(child); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// lsb returns the lowest set bit of id, which marks its level.
+func (id S2CellID) lsb() uint64 {
+	return uint64(id) & -uint64(id)
+}
+
+// faceIJ returns the face of id and the i, j coordinates of the leaf cell at
+// the start of id on the face's Hilbert curve.
+func (id S2CellID) faceIJ() (int, int, int) {
+	face := id.Face()
+	var i, j int
+	bits := face & s2SwapMask
+	n := MaxS2Level - 7*s2LookupBits
+	for k := 7; k >= 0; k-- {
+		bits += int(uint64(id)>>(k*2*s2LookupBits+1)&(1<<(2*n)-1)) << 2
+		bits = s2LookupIJ[bits]
+		i += bits >> (s2LookupBits + 2) << (k * s2LookupBits)
+		j += bits >> 2 & (1<<s2LookupBits - 1) << (k * s2LookupBits)
+		bits &= s2SwapMask | s2InvertMask
+		n = s2LookupBits
+	}
+	return face, i, j
+}
+
+// faceIJBounds returns the face of id, the i, j coordinates of its minimum
+// corner, and its size in leaf cells.
+func (id S2CellID) faceIJBounds() (int, int, int, int) {
+	face, i, j := id.faceIJ()
+	size := 1 << (MaxS2Level - id.Level())
+	return face, i &^ (size - 1), j &^ (size - 1), size
+}
+
+// ring returns the closed ring of id in longitude and latitude, with
+// segments points along each edge. Longitudes are unwrapped so that the
+// ring is continuous. Vertices at a pole are split into two vertices at the
+// pole's latitude, and rings around a pole are closed along its latitude.
+func (id S2CellID) ring(segments int) []float64 {
+	face, i0, j0, size := id.faceIJBounds()
+	u0, u1 := s2STToUV(float64(i0)/s2MaxSize), s2STToUV(float64(i0+size)/s2MaxSize)
+	v0, v1 := s2STToUV(float64(j0)/s2MaxSize), s2STToUV(float64(j0+size)/s2MaxSize)
+	corners := [...][2]float64{{u0, v0}, {u1, v0}, {u1, v1}, {u0, v1}, {u0, v0}}
+	points := make([][2]float64, 0, 4*segments)
+	for k := range 4 {
+		for s := range segments {
+			t := float64(s) / float64(segments)
+			u := corners[k][0] + t*(corners[k+1][0]-corners[k][0])
+			v := corners[k][1] + t*(corners[k+1][1]-corners[k][1])
+			lon, lat := s2FaceUVToLonLat(face, u, v)
+			points = append(points, [2]float64{lon, lat})
+		}
+	}
+
+	flatCoords := make([]float64, 0, 2*len(points)+8)
+	for k, point := range points {
+		if math.Abs(point[1]) == 90 {
+			prev, next := points[(k+len(points)-1)%len(points)], points[(k+1)%len(points)]
+			flatCoords = s2AppendUnwrapped(flatCoords, prev[0], point[1])
+			flatCoords = s2AppendUnwrapped(flatCoords, next[0], point[1])
+			continue
+		}
+		flatCoords = s2AppendUnwrapped(flatCoords, point[0], point[1])
+	}
+	firstLon, firstLat := flatCoords[0], flatCoords[1]
+	flatCoords = s2AppendUnwrapped(flatCoords, firstLon, firstLat)
+	if lastLon := flatCoords[len(flatCoords)-2]; math.Abs(lastLon-firstLon) < 180 {
+		flatCoords[len(flatCoords)-2] = firstLon
+	} else {
+		poleLat := -90.0
+		sumLat := 0.0
+		for k := 1; k < len(flatCoords); k += 2 {
+			sumLat += flatCoords[k]
+		}
+		if sumLat > 0 {
+			poleLat = 90
+		}
+		flatCoords = append(flatCoords, lastLon, poleLat, firstLon, poleLat, firstLon, firstLat)
+	}
+	return flatCoords
+}
+
+// s2AppendUnwrapped appends lon, lat to flatCoords, shifting lon by
+// multiples of 360 to be within 180 of the previous longitude.
+func s2AppendUnwrapped(flatCoords []float64, lon, lat float64) []float64 {
+	if n := len(flatCoords); n != 0 {
+		prevLon := flatCoords[n-2]
+		lon = prevLon + math.Remainder(lon-prevLon, 360)
+	}
+	return append(flatCoords, lon, lat)
+}
+
+// s2RingRect returns the bounds of ring.
+func s2RingRect(ring []float64) cover.Rect {
+	r := cover.Rect{MinX: math.Inf(1), MinY: math.Inf(1), MaxX: math.Inf(-1), MaxY: math.Inf(-1)}
+	for k := 0; k < len(ring); k += 2 {
+		r.MinX, r.MaxX = min(r.MinX, ring[k]), max(r.MaxX, ring[k])
+		r.MinY, r.MaxY = min(r.MinY, ring[k+1]), max(r.MaxY, ring[k+1])
+	}
+	return r
+}
+
+// s2RingIntersectsPolygon returns whether ring, or ring shifted by 360 in
+// either direction, intersects p.
+func s2RingIntersectsPolygon(ring []float64, p *geom.Polygon) bool {
+	b := p.GetBounds()
+	r := s2RingRect(ring)
+	shifted := make([]float64, len(ring))
+	for _, shift := range []float64{0, -360, 360} {
+		if r.MaxX+shift < b.Min(0) || b.Max(0) < r.MinX+shift || r.MaxY < b.Min(1) || b.Max(1) < r.MinY {
+			continue
+		}
+		for k := 0; k < len(ring); k += 2 {
+			shifted[k], shifted[k+1] = ring[k]+shift, ring[k+1]
+		}
+		if ringIntersectsPolygon(shifted, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// ringIntersectsPolygon returns whether the closed ring with XY flatCoords
+// ring intersects p, that is whether their edges intersect or one contains
+// the other.
+func ringIntersectsPolygon(ring []float64, p *geom.Polygon) bool {
+	stride := p.Stride
+	flatCoords := p.FlatCoords
+	offset := 0
+	for _, end := range p.Ends {
+		for i := offset + stride; i < end; i += stride {
+			for k := 2; k < len(ring); k += 2 {
+				if segmentsIntersect(
+					flatCoords[i-stride], flatCoords[i-stride+1], flatCoords[i], flatCoords[i+1],
+					ring[k-2], ring[k-1], ring[k], ring[k+1],
+				) {
+					return true
+				}
+			}
+		}
+		offset = end
+	}
+	if xy.IsPointInRing(geom.XY, geom.Coord{flatCoords[0], flatCoords[1]}, ring) {
+		return true
+	}
+	point := geom.Coord{ring[0], ring[1]}
+	offset = 0
+	for i, end := range p.Ends {
+		inRing := xy.IsPointInRing(p.Layout, point, flatCoords[offset:end])
+		if i == 0 && !inRing || i != 0 && inRing {
+			return false
+		}
+		offset = end
+	}
+	return true
+}
+
+// segmentsIntersect returns whether the segment from x0, y0 to x1, y1
+// intersects the segment from x2, y2 to x3, y3, including at their
+// endpoints.
+func segmentsIntersect(x0, y0, x1, y1, x2, y2, x3, y3 float64) bool {
+	d0 := cross(x2, y2, x3, y3, x0, y0)
+	d1 := cross(x2, y2, x3, y3, x1, y1)
+	d2 := cross(x0, y0, x1, y1, x2, y2)
+	d3 := cross(x0, y0, x1, y1, x3, y3)
+	if (d0 > 0 && d1 < 0 || d0 < 0 && d1 > 0) && (d2 > 0 && d3 < 0 || d2 < 0 && d3 > 0) {
+		return true
+	}
+	return d0 == 0 && onSegment(x2, y2, x3, y3, x0, y0) ||
+		d1 == 0 && onSegment(x2, y2, x3, y3, x1, y1) ||
+		d2 == 0 && onSegment(x0, y0, x1, y1, x2, y2) ||
+		d3 == 0 && onSegment(x0, y0, x1, y1, x3, y3)
+}
+
+// cross returns the cross product of the vectors from x0, y0 to x1, y1 and
+// from x0, y0 to x2, y2.
+func cross(x0, y0, x1, y1, x2, y2 float64) float64 {
+	return (x1-x0)*(y2-y0) - (y1-y0)*(x2-x0)
+}
+
+// onSegment returns whether x2, y2, which is collinear with the segment from
+// x0, y0 to x1, y1, lies on it.
+func onSegment(x0, y0, x1, y1, x2, y2 float64) bool {
+	return min(x0, x1) <= x2 && x2 <= max(x0, x1) && min(y0, y1) <= y2 && y2 <= max(y0, y1)
+}
+
+// s2CellIDFromFaceIJ returns the ID of the leaf cell at i, j on face.
+func s2CellIDFromFaceIJ(face, i, j int) S2CellID {
+	n := uint64(face) << (s2PosBits - 1)
+	bits := face & s2SwapMask
+	for k := 7; k >= 0; k-- {
+		bits += i >> (k * s2LookupBits) & (1<<s2LookupBits - 1) << (s2LookupBits + 2)
+		bits += j >> (k * s2LookupBits) & (1<<s2LookupBits - 1) << 2
+		bits = s2LookupPos[bits]
+		n |= uint64(bits>>2) << (k * 2 * s2LookupBits)
+		bits &= s2SwapMask | s2InvertMask
+	}
+	return S2CellID(n*2 + 1)
+}
+
+// s2XYZToFaceUV returns the cube face that x, y, z projects onto and its
+// coordinates on that face.
+func s2XYZToFaceUV(x, y, z float64) (int, float64, float64) {
+	ax, ay, az := math.Abs(x), math.Abs(y), math.Abs(z)
+	var face int
+	switch {
+	case ax < ay && ay < az, ax >= ay && ax < az:
+		face = 2
+		if z < 0 {
+			face = 5
+		}
+	case ax < ay:
+		face = 1
+		if y < 0 {
+			face = 4
+		}
+	default:
+		if x < 0 {
+			face = 3
+		}
+	}
+	switch face {
+	case 0:
+		return face, y / x, z / x
+	case 1:
+		return face, -x / y, z / y
+	case 2:
+		return face, -x / z, -y / z
+	case 3:
+		return face, z / x, y / x
+	case 4:
+		return face, z / y, -x / y
+	default:
+		return face, -y / z, -x / z
+	}
+}
+
+// s2FaceUVToLonLat returns the longitude and latitude of u, v on face.
+func s2FaceUVToLonLat(face int, u, v float64) (float64, float64) {
+	var x, y, z float64
+	switch face {
+	case 0:
+		x, y, z = 1, u, v
+	case 1:
+		x, y, z = -u, 1, v
+	case 2:
+		x, y, z = -u, -v, 1
+	case 3:
+		x, y, z = -1, -v, -u
+	case 4:
+		x, y, z = v, -1, -u
+	default:
+		x, y, z = v, u, -1
+	}
+	return math.Atan2(y, x) * 180 / math.Pi, math.Atan2(z, math.Hypot(x, y)) * 180 / math.Pi
+}
+
+// s2UVToST applies S2's quadratic transform, which makes cells at the same
+// level closer in area, to u.
+func s2UVToST(u float64) float64 {
+	if u >= 0 {
+		return 0.5 * math.Sqrt(1+3*u)
+	}
+	return 1 - 0.5*math.Sqrt(1-3*u)
+}
+
+// s2STToUV is the inverse of s2UVToST.
+func s2STToUV(s float64) float64 {
+	if s >= 0.5 {
+		return (4*s*s - 1) / 3
+	}
+	return (1 - 4*(1-s)*(1-s)) / 3
+}
+
+// s2STToIJ returns the index of the leaf cell containing s.
+func s2STToIJ(s float64) int {
+	return max(0, min(s2MaxSize-1, int(math.Floor(s2MaxSize*s))))
+}
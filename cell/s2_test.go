@@ -0,0 +1,166 @@
+package cell_test
+
+import (
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+
+	"github.com/don4get/go-geom"
+	"github.com/don4get/go-geom/cell"
+	"github.com/don4get/go-geom/xy"
+)
+
+func TestEncodeS2(t *testing.T) {
+	for _, tc := range []struct {
+		lon, lat float64
+		level    int
+		expected string
+	}{
+		{lon: 0, lat: 0, level: 30, expected: "1000000000000001"},
+		{lon: 0, lat: 0, level: 1, expected: "14"},
+		{lon: 0, lat: 0, level: 0, expected: "1"},
+		{lon: 90, lat: 0, level: 0, expected: "3"},
+		{lon: 0, lat: 90, level: 0, expected: "5"},
+		{lon: 180, lat: 0, level: 0, expected: "7"},
+		{lon: -90, lat: 0, level: 0, expected: "9"},
+		{lon: 0, lat: -90, level: 0, expected: "b"},
+	} {
+		t.Run(tc.expected, func(t *testing.T) {
+			p := geom.NewPointFlat(geom.XY, []float64{tc.lon, tc.lat})
+			id, err := cell.EncodeS2(p, tc.level)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, id.Token())
+			assert.Equal(t, tc.level, id.Level())
+			assert.True(t, id.IsValid())
+		})
+	}
+}
+
+func TestEncodeS2Errors(t *testing.T) {
+	_, err := cell.EncodeS2(geom.NewPointFlat(geom.XY, []float64{0, 0}), -1)
+	assert.Equal[error](t, cell.ErrInvalidLevel(-1), err)
+	_, err = cell.EncodeS2Coord(0, 0, 31)
+	assert.Equal[error](t, cell.ErrInvalidLevel(31), err)
+	_, err = cell.EncodeS2(geom.NewPointEmpty(geom.XY), 10)
+	assert.Equal(t, cell.ErrEmptyPoint, err)
+}
+
+func TestS2CellID(t *testing.T) {
+	for _, c := range [][2]float64{
+		{-122.4194, 37.7749},
+		{2.3522, 48.8566},
+		{151.2093, -33.8688},
+		{179.9999, 0.0001},
+		{-45, 89.9},
+		{45, -89.9},
+	} {
+		leaf, err := cell.EncodeS2Coord(c[0], c[1], cell.MaxS2Level)
+		assert.NoError(t, err)
+		lon, lat := leaf.Center()
+		assert.True(t, math.Abs(lon-c[0]) < 1e-6 && math.Abs(lat-c[1]) < 1e-6)
+
+		for level := range cell.MaxS2Level {
+			id, err := cell.EncodeS2Coord(c[0], c[1], level)
+			assert.NoError(t, err)
+			assert.Equal(t, id, leaf.Parent(level))
+			assert.Equal(t, leaf.Face(), id.Face())
+
+			children := id.Children()
+			assert.True(t, slices.Contains(children[:], leaf.Parent(level+1)))
+			for _, child := range children {
+				assert.Equal(t, level+1, child.Level())
+				assert.Equal(t, id, child.Parent(level))
+			}
+
+			parsed, err := cell.ParseS2Token(id.Token())
+			assert.NoError(t, err)
+			assert.Equal(t, id, parsed)
+		}
+
+		id := leaf.Parent(12)
+		polygon := id.Polygon()
+		assert.True(t, xy.IsPointInRing(geom.XY, geom.Coord{c[0], c[1]}, polygon.FlatCoords))
+		lon, lat = id.Center()
+		assert.True(t, xy.IsPointInRing(geom.XY, geom.Coord{lon, lat}, polygon.FlatCoords))
+	}
+}
+
+func TestParseS2TokenErrors(t *testing.T) {
+	for _, token := range []string{"", "X", "zz", "0", "2", "10000000000000000"} {
+		t.Run(token, func(t *testing.T) {
+			_, err := cell.ParseS2Token(token)
+			assert.Equal[error](t, cell.ErrInvalidS2Token(token), err)
+		})
+	}
+}
+
+func TestCoverS2(t *testing.T) {
+	// The square is covered by the four cells that meet at its center.
+	square := geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{{{-1, -1}, {1, -1}, {1, 1}, {-1, 1}, {-1, -1}}})
+	ids, err := cell.CoverS2(square, 6)
+	assert.NoError(t, err)
+	var expected []cell.S2CellID
+	for _, c := range [][2]float64{{-0.5, -0.5}, {0.5, -0.5}, {0.5, 0.5}, {-0.5, 0.5}} {
+		id, err := cell.EncodeS2Coord(c[0], c[1], 6)
+		assert.NoError(t, err)
+		expected = append(expected, id)
+	}
+	slices.Sort(expected)
+	assert.Equal(t, expected, ids)
+
+	// Every cell covering a thin triangle intersects it.
+	triangle := geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{{{1, 1}, {9, 4}, {8, 4}, {1, 1}}})
+	ids, err = cell.CoverS2(triangle, 8)
+	assert.NoError(t, err)
+	for _, c := range [][2]float64{{1, 1}, {9, 4}, {8, 4}, {6, 3}} {
+		id, err := cell.EncodeS2Coord(c[0], c[1], 8)
+		assert.NoError(t, err)
+		assert.True(t, slices.Contains(ids, id))
+	}
+	far, err := cell.EncodeS2Coord(1, 4, 8)
+	assert.NoError(t, err)
+	assert.False(t, slices.Contains(ids, far))
+
+	// Cells inside holes are not included.
+	withHole := geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{
+		{{0, 0}, {10, 0}, {10, 10}, {0, 10}, {0, 0}},
+		{{2, 2}, {8, 2}, {8, 8}, {2, 8}, {2, 2}},
+	})
+	ids, err = cell.CoverS2(withHole, 6)
+	assert.NoError(t, err)
+	center, err := cell.EncodeS2Coord(5, 5, 6)
+	assert.NoError(t, err)
+	assert.False(t, slices.Contains(ids, center))
+	corner, err := cell.EncodeS2Coord(9, 9, 6)
+	assert.NoError(t, err)
+	assert.True(t, slices.Contains(ids, corner))
+
+	// Cells that cross the antimeridian or contain a pole are included.
+	antimeridian := geom.NewMultiPolygon(geom.XY).MustSetCoords([][][]geom.Coord{
+		{{{179, -1}, {180, -1}, {180, 1}, {179, 1}, {179, -1}}},
+		{{{-180, -1}, {-179, -1}, {-179, 1}, {-180, 1}, {-180, -1}}},
+	})
+	ids, err = cell.CoverS2(antimeridian, 5)
+	assert.NoError(t, err)
+	for _, c := range [][2]float64{{179.5, 0.5}, {-179.5, -0.5}} {
+		id, err := cell.EncodeS2Coord(c[0], c[1], 5)
+		assert.NoError(t, err)
+		assert.True(t, slices.Contains(ids, id))
+	}
+	polarCap := geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{{{-180, 80}, {180, 80}, {180, 90}, {-180, 90}, {-180, 80}}})
+	ids, err = cell.CoverS2(polarCap, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, len(ids))
+	for _, id := range ids {
+		assert.Equal(t, 2, id.Face())
+	}
+
+	_, err = cell.CoverS2(geom.NewLineString(geom.XY), 1)
+	assert.Error(t, err)
+	_, err = cell.CoverS2(square, 31)
+	assert.Equal[error](t, cell.ErrInvalidLevel(31), err)
+	_, err = cell.CoverS2(square, 20, cell.CoverOptionWithMaxCells(100))
+	assert.IsError(t, err, cell.ErrTooManyCells)
+}
@@ -0,0 +1,77 @@
+package geom
+
+// A CircularString represents a sequence of circular arcs. Each arc is
+// defined by three control points: a start point, any point on the arc, and an
+// end point. The end point of each arc is the start point of the next, so a
+// non-empty CircularString has an odd number of at least three control points.
+type CircularString struct {
+	Geom1
+}
+
+// NewCircularString returns a new CircularString with layout l and no control
+// points.
+func NewCircularString(l Layout) *CircularString {
+	return NewCircularStringFlat(l, nil)
+}
+
+// NewCircularStringFlat returns a new CircularString with layout l and
+// control points flatCoords.
+func NewCircularStringFlat(layout Layout, flatCoords []float64) *CircularString {
+	g := new(CircularString)
+	g.Layout = layout
+	g.Stride = layout.Stride()
+	g.FlatCoords = flatCoords
+	return g
+}
+
+// Area returns the area of g, i.e. zero.
+func (g *CircularString) Area() float64 {
+	return 0
+}
+
+// Clone returns a copy of g that does not alias g.
+func (g *CircularString) Clone() *CircularString {
+	return deriveCloneCircularString(g)
+}
+
+// GetBounds returns the bounds of g, including the extremes of its arcs.
+func (g *CircularString) GetBounds() *Bounds {
+	return extendArcBounds(NewBounds(g.Layout), g.FlatCoords, g.Stride)
+}
+
+// Length returns the length of g's arcs.
+func (g *CircularString) Length() float64 {
+	return arcLength(g.FlatCoords, g.Stride)
+}
+
+// Linearize returns a LineString approximating g, with segmentsPerQuadrant
+// segments for each quarter circle of each arc.
+func (g *CircularString) Linearize(segmentsPerQuadrant int) *LineString {
+	flatCoords := linearizeArcs(nil, g.FlatCoords, g.Stride, segmentsPerQuadrant)
+	return NewLineStringFlat(g.Layout, flatCoords).SetSRID(g.Srid)
+}
+
+// MustSetCoords is like SetCoords but it panics on any error.
+func (g *CircularString) MustSetCoords(coords []Coord) *CircularString {
+	Must(g.SetCoords(coords))
+	return g
+}
+
+// SetCoords sets the coordinates of g.
+func (g *CircularString) SetCoords(coords []Coord) (*CircularString, error) {
+	if err := g.setCoords(coords); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+// SetSRID sets the SRID of g.
+func (g *CircularString) SetSRID(srid int) *CircularString {
+	g.Srid = srid
+	return g
+}
+
+// Swap swaps the values of g and g2.
+func (g *CircularString) Swap(g2 *CircularString) {
+	*g, *g2 = *g2, *g
+}
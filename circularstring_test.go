@@ -0,0 +1,134 @@
+package geom
+
+import (
+	"math"
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+)
+
+// CircularString implements interface T.
+var _ T = &CircularString{}
+
+func TestCircularStringBounds(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		cs       *CircularString
+		expected *Bounds
+	}{
+		{
+			name:     "empty",
+			cs:       NewCircularString(XY),
+			expected: NewBounds(XY),
+		},
+		{
+			name:     "upper semicircle",
+			cs:       NewCircularString(XY).MustSetCoords([]Coord{{-1, 0}, {0, 1}, {1, 0}}),
+			expected: NewBounds(XY).Set(-1, 0, 1, 1),
+		},
+		{
+			name:     "full circle",
+			cs:       NewCircularString(XYZ).MustSetCoords([]Coord{{0, 0, 1}, {2, 0, 2}, {0, 0, 1}}),
+			expected: NewBounds(XYZ).Set(0, -1, 1, 2, 1, 2),
+		},
+		{
+			name:     "collinear",
+			cs:       NewCircularString(XY).MustSetCoords([]Coord{{0, 0}, {1, 1}, {2, 2}}),
+			expected: NewBounds(XY).Set(0, 0, 2, 2),
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.cs.GetBounds()
+			assert.Equal(t, tc.expected.Layout(), got.Layout())
+			for i := range tc.expected.Layout().Stride() {
+				assert.True(t, math.Abs(tc.expected.Min(i)-got.Min(i)) < 1e-12 || tc.expected.Min(i) == got.Min(i))
+				assert.True(t, math.Abs(tc.expected.Max(i)-got.Max(i)) < 1e-12 || tc.expected.Max(i) == got.Max(i))
+			}
+		})
+	}
+}
+
+func TestCircularStringLength(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		cs       *CircularString
+		expected float64
+	}{
+		{
+			name:     "quarter circle",
+			cs:       NewCircularString(XY).MustSetCoords([]Coord{{1, 0}, {math.Sqrt2 / 2, math.Sqrt2 / 2}, {0, 1}}),
+			expected: math.Pi / 2,
+		},
+		{
+			name:     "clockwise semicircle",
+			cs:       NewCircularString(XY).MustSetCoords([]Coord{{-1, 0}, {0, 1}, {1, 0}}),
+			expected: math.Pi,
+		},
+		{
+			name:     "full circle",
+			cs:       NewCircularString(XY).MustSetCoords([]Coord{{0, 0}, {2, 0}, {0, 0}}),
+			expected: 2 * math.Pi,
+		},
+		{
+			name:     "collinear",
+			cs:       NewCircularString(XY).MustSetCoords([]Coord{{0, 0}, {3, 4}, {6, 8}}),
+			expected: 10,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.True(t, math.Abs(tc.expected-tc.cs.Length()) < 1e-12)
+		})
+	}
+}
+
+func TestCircularStringLinearize(t *testing.T) {
+	t.Run("semicircle", func(t *testing.T) {
+		cs := NewCircularString(XY).MustSetCoords([]Coord{{-1, 0}, {0, 1}, {1, 0}}).SetSRID(4326)
+		ls := cs.Linearize(2)
+		assert.Equal(t, 4326, ls.GetSRID())
+		assert.Equal(t, 5, ls.NumCoords())
+		assert.Equal(t, Coord{-1, 0}, ls.Coord(0))
+		assert.Equal(t, Coord{1, 0}, ls.Coord(4))
+		for i := range ls.NumCoords() {
+			c := ls.Coord(i)
+			assert.True(t, math.Abs(math.Hypot(c[0], c[1])-1) < 1e-12)
+			assert.True(t, c[1] >= 0)
+		}
+		c := ls.Coord(2)
+		assert.True(t, math.Abs(c[0]) < 1e-12 && math.Abs(c[1]-1) < 1e-12)
+	})
+
+	t.Run("interpolates z", func(t *testing.T) {
+		cs := NewCircularString(XYZ).MustSetCoords([]Coord{{-1, 0, 0}, {0, 1, 10}, {1, 0, 30}})
+		ls := cs.Linearize(2)
+		assert.Equal(t, 5, ls.NumCoords())
+		for i, z := range []float64{0, 5, 10, 20, 30} {
+			assert.True(t, math.Abs(ls.Coord(i)[2]-z) < 1e-9)
+		}
+	})
+
+	t.Run("multiple arcs", func(t *testing.T) {
+		cs := NewCircularString(XY).MustSetCoords([]Coord{{0, 0}, {1, 1}, {2, 0}, {3, -1}, {4, 0}})
+		ls := cs.Linearize(4)
+		assert.Equal(t, 17, ls.NumCoords())
+		assert.Equal(t, Coord{2, 0}, ls.Coord(8))
+		assert.True(t, math.Abs(ls.Length()-cs.Length()) < 0.05)
+	})
+
+	t.Run("collinear", func(t *testing.T) {
+		cs := NewCircularString(XY).MustSetCoords([]Coord{{0, 0}, {1, 1}, {2, 2}})
+		assert.Equal(t, []float64{0, 0, 1, 1, 2, 2}, cs.Linearize(32).FlatCoords)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		assert.True(t, NewCircularString(XY).Linearize(32).IsEmpty())
+	})
+}
+
+func TestCircularStringClone(t *testing.T) {
+	cs := NewCircularString(XY).MustSetCoords([]Coord{{0, 0}, {1, 1}, {2, 0}}).SetSRID(4326)
+	clone := cs.Clone()
+	assert.Equal(t, cs, clone)
+	clone.FlatCoords[0] = 1
+	assert.Equal(t, 0.0, cs.FlatCoords[0])
+}
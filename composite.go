@@ -0,0 +1,380 @@
+package geom
+
+import (
+	"math"
+	"slices"
+)
+
+// A SegmentKind is the kind of a segment of a curved geometry.
+type SegmentKind int
+
+// Segment kinds.
+const (
+	// SegmentLinear is a LineString or a LinearRing, whose control points are
+	// joined by straight lines.
+	SegmentLinear SegmentKind = iota
+	// SegmentCircular is a CircularString, whose control points define
+	// circular arcs.
+	SegmentCircular
+)
+
+// A curveType is the type of a curve of a composite.
+type curveType int
+
+const (
+	curveLinear   curveType = iota // a LineString or LinearRing, with one linear segment
+	curveCircular                  // a CircularString, with one circular segment
+	curveCompound                  // a CompoundCurve, with any number of segments
+)
+
+// A composite is a geometry made of LineStrings, LinearRings, and
+// CircularStrings, its segments, such as a CompoundCurve or a CurvePolygon.
+// Like the other geometries, it stores the control points of all its segments
+// in flat coordinates. Each segment is stored in full, so consecutive segments
+// of a CompoundCurve repeat the point that joins them. The segments are grouped
+// into curves, and the curves into surfaces, as the type requires.
+type composite struct {
+	layout       Layout
+	stride       int
+	flatCoords   []float64
+	segmentEnds  []int         // end of each segment in flatCoords
+	segmentKinds []SegmentKind // kind of each segment
+	curveEnds    []int         // end of each curve in segmentEnds
+	curveTypes   []curveType   // type of each curve
+	surfaceEnds  []int         // end of each surface in curveEnds
+	surfaceTypes []bool        // whether each surface is a CurvePolygon
+	srid         int
+}
+
+// newComposite returns a new empty composite with layout l.
+func newComposite(l Layout) composite {
+	return composite{layout: l, stride: l.Stride()}
+}
+
+// GetLayout returns g's layout.
+func (g *composite) GetLayout() Layout {
+	return g.layout
+}
+
+// GetStride returns the stride of g's layout.
+func (g *composite) GetStride() int {
+	return g.stride
+}
+
+// GetBounds returns the bounds of g, including the extremes of its arcs.
+func (g *composite) GetBounds() *Bounds {
+	b := NewBounds(g.layout)
+	for k, kind := range g.segmentKinds {
+		flatCoords := g.flatCoords[g.segmentOffset(k):g.segmentEnds[k]]
+		if kind == SegmentCircular {
+			extendArcBounds(b, flatCoords, g.stride)
+		} else {
+			b.extendFlatCoords(flatCoords, 0, len(flatCoords), g.stride)
+		}
+	}
+	return b
+}
+
+// GetFlatCoords returns the flat coordinates of the control points of g.
+func (g *composite) GetFlatCoords() []float64 {
+	return g.flatCoords
+}
+
+// GetSRID returns g's SRID.
+func (g *composite) GetSRID() int {
+	return g.srid
+}
+
+// IsEmpty returns true if g contains no control points.
+func (g *composite) IsEmpty() bool {
+	return len(g.flatCoords) == 0
+}
+
+// SegmentEnds returns the end of each segment of g in its flat coordinates.
+func (g *composite) SegmentEnds() []int {
+	return g.segmentEnds
+}
+
+// SegmentKinds returns the kind of each segment of g.
+func (g *composite) SegmentKinds() []SegmentKind {
+	return g.segmentKinds
+}
+
+// clone returns a deep copy of g.
+func (g *composite) clone() composite {
+	return composite{
+		layout:       g.layout,
+		stride:       g.stride,
+		flatCoords:   slices.Clone(g.flatCoords),
+		segmentEnds:  slices.Clone(g.segmentEnds),
+		segmentKinds: slices.Clone(g.segmentKinds),
+		curveEnds:    slices.Clone(g.curveEnds),
+		curveTypes:   slices.Clone(g.curveTypes),
+		surfaceEnds:  slices.Clone(g.surfaceEnds),
+		surfaceTypes: slices.Clone(g.surfaceTypes),
+		srid:         g.srid,
+	}
+}
+
+// segmentOffset returns the offset of the kth segment in g.flatCoords.
+func (g *composite) segmentOffset(k int) int {
+	if k == 0 {
+		return 0
+	}
+	return g.segmentEnds[k-1]
+}
+
+// curveOffset returns the index of the first segment of the ith curve.
+func (g *composite) curveOffset(i int) int {
+	if i == 0 {
+		return 0
+	}
+	return g.curveEnds[i-1]
+}
+
+// surfaceOffset returns the index of the first curve of the ith surface.
+func (g *composite) surfaceOffset(i int) int {
+	if i == 0 {
+		return 0
+	}
+	return g.surfaceEnds[i-1]
+}
+
+// curveFlatEnds returns the ends in g.flatCoords of the curves from first to
+// last, exclusive.
+func (g *composite) curveFlatEnds(first, last int) []int {
+	if first == last {
+		return nil
+	}
+	ends := make([]int, 0, last-first)
+	for i := first; i < last; i++ {
+		ends = append(ends, g.segmentOffset(g.curveEnds[i]))
+	}
+	return ends
+}
+
+// segment returns the kth segment of g. Linear segments are returned as
+// LinearRings if ring is true and as LineStrings otherwise. The flat
+// coordinates alias g.
+func (g *composite) segment(k int, ring bool) T {
+	flatCoords := g.flatCoords[g.segmentOffset(k):g.segmentEnds[k]]
+	switch {
+	case g.segmentKinds[k] == SegmentCircular:
+		return NewCircularStringFlat(g.layout, flatCoords)
+	case ring:
+		return NewLinearRingFlat(g.layout, flatCoords)
+	default:
+		return NewLineStringFlat(g.layout, flatCoords)
+	}
+}
+
+// segments returns the segments from first to last, exclusive, as a
+// CompoundCurve. The flat coordinates alias g.
+func (g *composite) segments(first, last int) *CompoundCurve {
+	return &CompoundCurve{g.segmentRange(first, last)}
+}
+
+// segmentRange returns a composite containing the segments from first to
+// last, exclusive. The flat coordinates alias g.
+func (g *composite) segmentRange(first, last int) composite {
+	offset, end := g.segmentOffset(first), g.segmentOffset(last)
+	c := newComposite(g.layout)
+	c.flatCoords = g.flatCoords[offset:end:end]
+	if first != last {
+		c.segmentKinds = g.segmentKinds[first:last:last]
+		c.segmentEnds = make([]int, 0, last-first)
+		for _, end := range g.segmentEnds[first:last] {
+			c.segmentEnds = append(c.segmentEnds, end-offset)
+		}
+	}
+	return c
+}
+
+// curveRange returns a composite containing the curves from first to last,
+// exclusive. The flat coordinates alias g.
+func (g *composite) curveRange(first, last int) composite {
+	offset := g.curveOffset(first)
+	c := g.segmentRange(offset, g.curveOffset(last))
+	if first != last {
+		c.curveTypes = g.curveTypes[first:last:last]
+		c.curveEnds = make([]int, 0, last-first)
+		for _, end := range g.curveEnds[first:last] {
+			c.curveEnds = append(c.curveEnds, end-offset)
+		}
+	}
+	return c
+}
+
+// curve returns the ith curve of g. Linear curves are returned as LinearRings
+// if ring is true and as LineStrings otherwise. The flat coordinates alias g.
+func (g *composite) curve(i int, ring bool) T {
+	first := g.curveOffset(i)
+	if g.curveTypes[i] == curveCompound {
+		return g.segments(first, g.curveEnds[i])
+	}
+	return g.segment(first, ring)
+}
+
+// curves returns the curves of g from first to last, exclusive.
+func (g *composite) curves(first, last int, ring bool) []T {
+	curves := make([]T, 0, last-first)
+	for i := first; i < last; i++ {
+		curves = append(curves, g.curve(i, ring))
+	}
+	return curves
+}
+
+// pushSegment appends a segment with the control points flatCoords to g.
+func (g *composite) pushSegment(kind SegmentKind, flatCoords []float64) {
+	g.flatCoords = append(g.flatCoords, flatCoords...)
+	g.segmentEnds = append(g.segmentEnds, len(g.flatCoords))
+	g.segmentKinds = append(g.segmentKinds, kind)
+}
+
+// pushCurve appends the curve c, which must be a *LineString, *LinearRing,
+// *CircularString, or *CompoundCurve, to g.
+func (g *composite) pushCurve(c T) {
+	var t curveType
+	switch c := c.(type) {
+	case *LineString:
+		t = curveLinear
+		g.pushSegment(SegmentLinear, c.FlatCoords)
+	case *LinearRing:
+		t = curveLinear
+		g.pushSegment(SegmentLinear, c.FlatCoords)
+	case *CircularString:
+		t = curveCircular
+		g.pushSegment(SegmentCircular, c.FlatCoords)
+	case *CompoundCurve:
+		t = curveCompound
+		g.pushSegments(&c.composite)
+	}
+	g.curveEnds = append(g.curveEnds, len(g.segmentEnds))
+	g.curveTypes = append(g.curveTypes, t)
+}
+
+// pushSegments appends the segments of c to g.
+func (g *composite) pushSegments(c *composite) {
+	for k, kind := range c.segmentKinds {
+		g.pushSegment(kind, c.flatCoords[c.segmentOffset(k):c.segmentEnds[k]])
+	}
+}
+
+// pushCurves appends the segments and the curves of c to g.
+func (g *composite) pushCurves(c *composite) {
+	offset := len(g.segmentEnds)
+	g.pushSegments(c)
+	for _, end := range c.curveEnds {
+		g.curveEnds = append(g.curveEnds, end+offset)
+	}
+	g.curveTypes = append(g.curveTypes, c.curveTypes...)
+}
+
+// checkPush returns an error if any of gs is not one of the allowed types or
+// does not have g's layout.
+func (g *composite) checkPush(allowed func(T) bool, gs []T) error {
+	for _, geom := range gs {
+		if !allowed(geom) {
+			return ErrUnsupportedType{Value: geom}
+		}
+		if geomLayout := geom.GetLayout(); geomLayout != g.layout {
+			return ErrLayoutMismatch{
+				Got:  geomLayout,
+				Want: g.layout,
+			}
+		}
+	}
+	return nil
+}
+
+// lengthSegments returns the length of the segments from first to last,
+// exclusive.
+func (g *composite) lengthSegments(first, last int) float64 {
+	length := 0.0
+	for k := first; k < last; k++ {
+		offset, end := g.segmentOffset(k), g.segmentEnds[k]
+		if g.segmentKinds[k] == SegmentCircular {
+			length += arcLength(g.flatCoords[offset:end], g.stride)
+		} else {
+			length += length1(g.flatCoords, offset, end, g.stride)
+		}
+	}
+	return length
+}
+
+// doubleAreaSegments returns twice the signed area enclosed by the segments
+// from first to last, exclusive, which must form a closed ring. It is positive
+// if the ring is counter-clockwise.
+func (g *composite) doubleAreaSegments(first, last int) float64 {
+	stride := g.stride
+	doubleArea := 0.0
+	for k := first; k < last; k++ {
+		offset, end := g.segmentOffset(k), g.segmentEnds[k]
+		if g.segmentKinds[k] != SegmentCircular {
+			doubleArea += doubleArea1(g.flatCoords, offset, end, stride)
+			continue
+		}
+		i := offset
+		for ; i+2*stride < end; i += 2 * stride {
+			p0, p1, p2 := g.flatCoords[i:i+stride], g.flatCoords[i+stride:i+2*stride], g.flatCoords[i+2*stride:i+3*stride]
+			a := newArc(p0, p1, p2)
+			if a.linear {
+				doubleArea += doubleArea1(g.flatCoords, i, i+3*stride, stride)
+				continue
+			}
+			// Add the area between the chord and the arc to that of the chord.
+			sweep := math.Abs(a.sweep)
+			doubleArea += (p2[1] - p0[1]) * (p2[0] + p0[0])
+			doubleArea += math.Copysign(a.r*a.r*(sweep-math.Sin(sweep)), a.sweep)
+		}
+		if i+stride < end {
+			doubleArea += doubleArea1(g.flatCoords, i, end, stride)
+		}
+	}
+	return doubleArea
+}
+
+// linearizeSegments appends a linear approximation of the segments from first
+// to last, exclusive, to dst. Consecutive segments share an end point, which
+// is appended only once.
+func (g *composite) linearizeSegments(dst []float64, first, last, segmentsPerQuadrant int) []float64 {
+	start := len(dst)
+	for k := first; k < last; k++ {
+		flatCoords := g.flatCoords[g.segmentOffset(k):g.segmentEnds[k]]
+		if g.segmentKinds[k] == SegmentCircular {
+			flatCoords = linearizeArcs(nil, flatCoords, g.stride, segmentsPerQuadrant)
+		}
+		dst = appendJoined(dst, start, flatCoords, g.stride)
+	}
+	return dst
+}
+
+// linearizeCurves returns the flat coordinates and ends of a linear
+// approximation of the curves from first to last, exclusive.
+func (g *composite) linearizeCurves(first, last, segmentsPerQuadrant int) ([]float64, []int) {
+	var flatCoords []float64
+	var ends []int
+	for i := first; i < last; i++ {
+		flatCoords = g.linearizeSegments(flatCoords, g.curveOffset(i), g.curveEnds[i], segmentsPerQuadrant)
+		ends = append(ends, len(flatCoords))
+	}
+	return flatCoords, ends
+}
+
+// appendJoined appends flatCoords to dst, omitting the first coordinate of
+// flatCoords if it is equal to the last coordinate of dst after start.
+func appendJoined(dst []float64, start int, flatCoords []float64, stride int) []float64 {
+	if n := len(dst); n-start >= stride && len(flatCoords) >= stride {
+		joined := true
+		for i := range stride {
+			if dst[n-stride+i] != flatCoords[i] {
+				joined = false
+				break
+			}
+		}
+		if joined {
+			flatCoords = flatCoords[stride:]
+		}
+	}
+	return append(dst, flatCoords...)
+}
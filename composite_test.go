@@ -0,0 +1,141 @@
+package geom
+
+import (
+	"math"
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+)
+
+// CompoundCurve implements interface T.
+var _ T = &CompoundCurve{}
+
+func TestCompoundCurve(t *testing.T) {
+	cc := NewCompoundCurve(XY).MustPush(
+		NewLineString(XY).MustSetCoords([]Coord{{-2, 0}, {-1, 0}}),
+		NewCircularString(XY).MustSetCoords([]Coord{{-1, 0}, {0, 1}, {1, 0}}),
+		NewLineString(XY).MustSetCoords([]Coord{{1, 0}, {2, 0}}),
+	).SetSRID(4326)
+	assert.Equal(t, XY, cc.GetLayout())
+	assert.Equal(t, 2, cc.GetStride())
+	assert.Equal(t, 3, cc.NumCurves())
+	assert.False(t, cc.IsEmpty())
+	assert.Equal(t, 4326, cc.GetSRID())
+	assert.Equal(t, NewBounds(XY).Set(-2, 0, 2, 1), cc.GetBounds())
+	assert.True(t, math.Abs(cc.Length()-(2+math.Pi)) < 1e-12)
+
+	ls := cc.Linearize(2)
+	assert.Equal(t, 4326, ls.GetSRID())
+	assert.Equal(t, 7, ls.NumCoords())
+	assert.Equal(t, Coord{-2, 0}, ls.Coord(0))
+	assert.Equal(t, Coord{-1, 0}, ls.Coord(1))
+	assert.Equal(t, Coord{1, 0}, ls.Coord(5))
+	assert.Equal(t, Coord{2, 0}, ls.Coord(6))
+
+	clone := cc.Clone()
+	assert.Equal(t, cc, clone)
+	clone.Curve(0).GetFlatCoords()[0] = 0
+	assert.Equal(t, -2.0, cc.Curve(0).GetFlatCoords()[0])
+
+	assert.Equal(t, []float64{-2, 0, -1, 0, -1, 0, 0, 1, 1, 0, 1, 0, 2, 0}, cc.GetFlatCoords())
+	assert.Equal(t, []int{4, 10, 14}, cc.GetEnds())
+	assert.Equal(t, []SegmentKind{SegmentLinear, SegmentCircular, SegmentLinear}, cc.SegmentKinds())
+	assert.Zero(t, cc.GetEndss())
+}
+
+func TestCompoundCurvePushErrors(t *testing.T) {
+	cc := NewCompoundCurve(XY)
+	assert.Equal(t, error(ErrLayoutMismatch{Got: XYZ, Want: XY}), cc.Push(NewLineString(XYZ)))
+	assert.Equal(t, error(ErrUnsupportedType{Value: NewPolygon(XY)}), cc.Push(NewPolygon(XY)))
+	assert.Equal(t, 0, cc.NumCurves())
+	assert.True(t, cc.IsEmpty())
+}
+
+func TestCurvePolygonLinearize(t *testing.T) {
+	cp := NewCurvePolygon(XY).MustPush(
+		NewCircularString(XY).MustSetCoords([]Coord{{0, 0}, {4, 0}, {0, 0}}),
+		NewLinearRing(XY).MustSetCoords([]Coord{{1, -1}, {3, 1}, {3, -1}, {1, -1}}),
+	)
+	assert.Equal(t, 2, cp.NumRings())
+	assert.Equal(t, NewBounds(XY).Set(0, -2, 4, 2), cp.GetBounds())
+	p := cp.Linearize(8)
+	assert.Equal(t, 2, p.NumLinearRings())
+	assert.Equal(t, 33, p.LinearRing(0).NumCoords())
+	assert.Equal(t, p.LinearRing(0).Coord(0), p.LinearRing(0).Coord(32))
+	assert.Equal(t, []float64{1, -1, 3, 1, 3, -1, 1, -1}, p.LinearRing(1).FlatCoords)
+	assert.True(t, math.Abs(p.Area()-(4*math.Pi-2)) < 0.2)
+	assert.Equal(t, []int{6, 14}, cp.GetEnds())
+	assert.True(t, math.Abs(cp.Area()-(4*math.Pi-2)) < 1e-12)
+}
+
+func TestCurvePolygonArea(t *testing.T) {
+	// A half disc of radius 2 with a clockwise exterior ring, and a square
+	// hole.
+	cp := NewCurvePolygon(XY).MustPush(
+		NewCompoundCurve(XY).MustPush(
+			NewCircularString(XY).MustSetCoords([]Coord{{-2, 0}, {0, 2}, {2, 0}}),
+			NewLineString(XY).MustSetCoords([]Coord{{2, 0}, {-2, 0}}),
+		),
+		NewLinearRing(XY).MustSetCoords([]Coord{{-1, 0.5}, {1, 0.5}, {1, 1}, {-1, 1}, {-1, 0.5}}),
+	)
+	assert.True(t, math.Abs(cp.Area()-(2*math.Pi-1)) < 1e-12)
+	assert.Equal(t, 0.0, NewCurvePolygon(XY).Area())
+}
+
+func TestMultiCurveLinearize(t *testing.T) {
+	mc := NewMultiCurve(XYM).MustPush(
+		NewLineString(XYM).MustSetCoords([]Coord{{0, 0, 0}, {1, 1, 1}}),
+		NewCircularString(XYM).MustSetCoords([]Coord{{0, 0, 0}, {1, 1, 1}, {2, 0, 2}}),
+		NewCompoundCurve(XYM).MustPush(
+			NewLineString(XYM).MustSetCoords([]Coord{{5, 5, 0}, {6, 6, 1}}),
+		),
+	)
+	assert.Equal(t, []int{6, 15, 21}, mc.GetEnds())
+	assert.Equal(t, mc.Curve(2), T(NewCompoundCurve(XYM).MustPush(
+		NewLineString(XYM).MustSetCoords([]Coord{{5, 5, 0}, {6, 6, 1}}),
+	)))
+	mls := mc.Linearize(1)
+	assert.Equal(t, 3, mls.NumLineStrings())
+	assert.Equal(t, []float64{0, 0, 0, 1, 1, 1}, mls.LineString(0).FlatCoords)
+	assert.Equal(t, 3, mls.LineString(1).NumCoords())
+	assert.Equal(t, []float64{5, 5, 0, 6, 6, 1}, mls.LineString(2).FlatCoords)
+}
+
+func TestMultiSurfaceLinearize(t *testing.T) {
+	ms := NewMultiSurface(XY).MustPush(
+		NewPolygon(XY).MustSetCoords([][]Coord{{{0, 0}, {1, 0}, {1, 1}, {0, 0}}}),
+		NewCurvePolygon(XY).MustPush(
+			NewCircularString(XY).MustSetCoords([]Coord{{10, 0}, {12, 0}, {10, 0}}),
+		),
+	).SetSRID(3857)
+	assert.Equal(t, [][]int{{8}, {14}}, ms.GetEndss())
+	assert.Equal(t, T(NewPolygon(XY).MustSetCoords([][]Coord{{{0, 0}, {1, 0}, {1, 1}, {0, 0}}})), ms.Surface(0))
+	assert.Equal(t, T(NewCurvePolygon(XY).MustPush(
+		NewCircularString(XY).MustSetCoords([]Coord{{10, 0}, {12, 0}, {10, 0}}),
+	)), ms.Surface(1))
+	mp := ms.Linearize(4)
+	assert.Equal(t, 3857, mp.GetSRID())
+	assert.Equal(t, 2, mp.NumPolygons())
+	assert.Equal(t, []float64{0, 0, 1, 0, 1, 1, 0, 0}, mp.Polygon(0).FlatCoords)
+	assert.Equal(t, 17, mp.Polygon(1).NumCoords())
+	assert.Equal(t, error(ErrUnsupportedType{Value: NewLineString(XY)}), ms.Push(NewLineString(XY)))
+}
+
+func TestLinearize(t *testing.T) {
+	ls := NewLineString(XY).MustSetCoords([]Coord{{0, 0}, {1, 1}})
+	got, err := Linearize(ls, 32)
+	assert.NoError(t, err)
+	assert.Equal(t, T(ls), got)
+
+	gc := NewGeometryCollection().MustPush(
+		NewCircularString(XY).MustSetCoords([]Coord{{0, 0}, {1, 1}, {2, 0}}),
+		ls,
+	)
+	got, err = Linearize(gc, 1)
+	assert.NoError(t, err)
+	linearGC, ok := got.(*GeometryCollection)
+	assert.True(t, ok)
+	assert.Equal(t, 2, linearGC.NumGeoms())
+	assert.Equal(t, 3, linearGC.Geom(0).(*LineString).NumCoords()) //nolint:forcetypeassert
+	assert.Equal(t, T(ls), linearGC.Geom(1))
+}
@@ -0,0 +1,103 @@
+package geom
+
+// A CompoundCurve is a single continuous curve made of LineStrings and
+// CircularStrings, its segments, where each segment starts at the end point
+// of the previous one.
+type CompoundCurve struct {
+	composite
+}
+
+// NewCompoundCurve returns a new empty CompoundCurve with layout l.
+func NewCompoundCurve(l Layout) *CompoundCurve {
+	return &CompoundCurve{newComposite(l)}
+}
+
+// Area returns the area of g, i.e. zero.
+func (g *CompoundCurve) Area() float64 {
+	return 0
+}
+
+// Clone returns a deep copy of g.
+func (g *CompoundCurve) Clone() *CompoundCurve {
+	return &CompoundCurve{g.clone()}
+}
+
+// Curve returns the ith segment of g, either a *LineString or a
+// *CircularString. The flat coordinates alias g.
+func (g *CompoundCurve) Curve(i int) T {
+	return g.segment(i, false)
+}
+
+// Curves returns the segments of g.
+func (g *CompoundCurve) Curves() []T {
+	curves := make([]T, 0, g.NumCurves())
+	for k := range g.NumCurves() {
+		curves = append(curves, g.segment(k, false))
+	}
+	return curves
+}
+
+// GetEnds returns the end of each segment of g in its flat coordinates.
+func (g *CompoundCurve) GetEnds() []int {
+	return g.segmentEnds
+}
+
+// GetEndss returns nil.
+func (g *CompoundCurve) GetEndss() [][]int {
+	return nil
+}
+
+// Length returns the length of g.
+func (g *CompoundCurve) Length() float64 {
+	return g.lengthSegments(0, g.NumCurves())
+}
+
+// Linearize returns a LineString approximating g, with segmentsPerQuadrant
+// segments for each quarter circle of each arc.
+func (g *CompoundCurve) Linearize(segmentsPerQuadrant int) *LineString {
+	flatCoords := g.linearizeSegments(nil, 0, g.NumCurves(), segmentsPerQuadrant)
+	return NewLineStringFlat(g.layout, flatCoords).SetSRID(g.srid)
+}
+
+// MustPush pushes curves to g. It panics on any error.
+func (g *CompoundCurve) MustPush(curves ...T) *CompoundCurve {
+	if err := g.Push(curves...); err != nil {
+		panic(err)
+	}
+	return g
+}
+
+// NumCurves returns the number of segments in g.
+func (g *CompoundCurve) NumCurves() int {
+	return len(g.segmentKinds)
+}
+
+// Push appends curves to g. Each curve must be a *LineString or a
+// *CircularString with g's layout.
+func (g *CompoundCurve) Push(curves ...T) error {
+	if err := g.checkPush(func(curve T) bool {
+		switch curve.(type) {
+		case *LineString, *CircularString:
+			return true
+		default:
+			return false
+		}
+	}, curves); err != nil {
+		return err
+	}
+	for _, curve := range curves {
+		switch curve := curve.(type) {
+		case *LineString:
+			g.pushSegment(SegmentLinear, curve.FlatCoords)
+		case *CircularString:
+			g.pushSegment(SegmentCircular, curve.FlatCoords)
+		}
+	}
+	return nil
+}
+
+// SetSRID sets the SRID of g.
+func (g *CompoundCurve) SetSRID(srid int) *CompoundCurve {
+	g.srid = srid
+	return g
+}
@@ -0,0 +1,100 @@
+package geom
+
+import "math"
+
+// A CurvePolygon is a polygon whose rings may be curved. Its first ring is
+// the exterior ring and any other rings are holes. Each ring is a
+// *LinearRing, a *CircularString, or a *CompoundCurve.
+type CurvePolygon struct {
+	composite
+}
+
+// NewCurvePolygon returns a new empty CurvePolygon with layout l.
+func NewCurvePolygon(l Layout) *CurvePolygon {
+	return &CurvePolygon{newComposite(l)}
+}
+
+// Area returns the area of g's exterior ring minus the areas of its holes,
+// including the areas between the chords and the arcs of curved rings.
+func (g *CurvePolygon) Area() float64 {
+	area := 0.0
+	for i := range g.NumRings() {
+		ringArea := math.Abs(g.doubleAreaSegments(g.curveOffset(i), g.curveEnds[i])) / 2
+		if i == 0 {
+			area = ringArea
+		} else {
+			area -= ringArea
+		}
+	}
+	return area
+}
+
+// Clone returns a deep copy of g.
+func (g *CurvePolygon) Clone() *CurvePolygon {
+	return &CurvePolygon{g.clone()}
+}
+
+// GetEnds returns the end of each ring of g in its flat coordinates.
+func (g *CurvePolygon) GetEnds() []int {
+	return g.curveFlatEnds(0, g.NumRings())
+}
+
+// GetEndss returns nil.
+func (g *CurvePolygon) GetEndss() [][]int {
+	return nil
+}
+
+// Linearize returns a Polygon approximating g, with segmentsPerQuadrant
+// segments for each quarter circle of each arc.
+func (g *CurvePolygon) Linearize(segmentsPerQuadrant int) *Polygon {
+	flatCoords, ends := g.linearizeCurves(0, g.NumRings(), segmentsPerQuadrant)
+	return NewPolygonFlat(g.layout, flatCoords, ends).SetSRID(g.srid)
+}
+
+// MustPush pushes rings to g. It panics on any error.
+func (g *CurvePolygon) MustPush(rings ...T) *CurvePolygon {
+	if err := g.Push(rings...); err != nil {
+		panic(err)
+	}
+	return g
+}
+
+// NumRings returns the number of rings in g.
+func (g *CurvePolygon) NumRings() int {
+	return len(g.curveTypes)
+}
+
+// Push appends rings to g. Each ring must be a *LinearRing, a
+// *CircularString, or a *CompoundCurve with g's layout.
+func (g *CurvePolygon) Push(rings ...T) error {
+	if err := g.checkPush(func(ring T) bool {
+		switch ring.(type) {
+		case *LinearRing, *CircularString, *CompoundCurve:
+			return true
+		default:
+			return false
+		}
+	}, rings); err != nil {
+		return err
+	}
+	for _, ring := range rings {
+		g.pushCurve(ring)
+	}
+	return nil
+}
+
+// Ring returns the ith ring of g. The flat coordinates alias g.
+func (g *CurvePolygon) Ring(i int) T {
+	return g.curve(i, true)
+}
+
+// Rings returns the rings of g.
+func (g *CurvePolygon) Rings() []T {
+	return g.curves(0, g.NumRings(), true)
+}
+
+// SetSRID sets the SRID of g.
+func (g *CurvePolygon) SetSRID(srid int) *CurvePolygon {
+	g.srid = srid
+	return g
+}
@@ -12,6 +12,16 @@ func deriveCloneBounds(src *Bounds) *Bounds {
 	return dst
 }
 
+// deriveCloneCircularString returns a clone of the src parameter.
+func deriveCloneCircularString(src *CircularString) *CircularString {
+	if src == nil {
+		return nil
+	}
+	dst := new(CircularString)
+	deriveDeepCopy_13(dst, src)
+	return dst
+}
+
 // deriveCloneCoord returns a clone of the src parameter.
 func deriveCloneCoord(src Coord) Coord {
 	if src == nil {
@@ -82,6 +92,16 @@ func deriveClonePoint(src *Point) *Point {
 	return dst
 }
 
+// deriveClonePolyhedralSurface returns a clone of the src parameter.
+func deriveClonePolyhedralSurface(src *PolyhedralSurface) *PolyhedralSurface {
+	if src == nil {
+		return nil
+	}
+	dst := new(PolyhedralSurface)
+	deriveDeepCopy_14(dst, src)
+	return dst
+}
+
 // deriveClonePolygon returns a clone of the src parameter.
 func deriveClonePolygon(src *Polygon) *Polygon {
 	if src == nil {
@@ -92,6 +112,26 @@ func deriveClonePolygon(src *Polygon) *Polygon {
 	return dst
 }
 
+// deriveCloneTIN returns a clone of the src parameter.
+func deriveCloneTIN(src *TIN) *TIN {
+	if src == nil {
+		return nil
+	}
+	dst := new(TIN)
+	deriveDeepCopy_15(dst, src)
+	return dst
+}
+
+// deriveCloneTriangle returns a clone of the src parameter.
+func deriveCloneTriangle(src *Triangle) *Triangle {
+	if src == nil {
+		return nil
+	}
+	dst := new(Triangle)
+	deriveDeepCopy_16(dst, src)
+	return dst
+}
+
 // deriveDeepCopy recursively copies the contents of src into dst.
 func deriveDeepCopy(dst, src *Bounds) {
 	dst.layout = src.layout
@@ -312,3 +352,39 @@ func deriveDeepCopy_12(dst, src [][]int) {
 		}
 	}
 }
+
+// deriveDeepCopy_13 recursively copies the contents of src into dst.
+func deriveDeepCopy_13(dst, src *CircularString) {
+	func() {
+		field := new(Geom1)
+		deriveDeepCopy_8(field, &src.Geom1)
+		dst.Geom1 = *field
+	}()
+}
+
+// deriveDeepCopy_14 recursively copies the contents of src into dst.
+func deriveDeepCopy_14(dst, src *PolyhedralSurface) {
+	func() {
+		field := new(geom3)
+		deriveDeepCopy_10(field, &src.geom3)
+		dst.geom3 = *field
+	}()
+}
+
+// deriveDeepCopy_15 recursively copies the contents of src into dst.
+func deriveDeepCopy_15(dst, src *TIN) {
+	func() {
+		field := new(geom3)
+		deriveDeepCopy_10(field, &src.geom3)
+		dst.geom3 = *field
+	}()
+}
+
+// deriveDeepCopy_16 recursively copies the contents of src into dst.
+func deriveDeepCopy_16(dst, src *Triangle) {
+	func() {
+		field := new(Geom2)
+		deriveDeepCopy_9(field, &src.Geom2)
+		dst.Geom2 = *field
+	}()
+}
@@ -0,0 +1,403 @@
+// Package csvgeom implements reading and writing features in CSV files, whose
+// geometries are stored in a WKT column, in an EWKB hex column, or in
+// separate X, Y, and optional Z columns.
+package csvgeom
+
+import (
+	"encoding/binary"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/don4get/go-geom"
+	"github.com/don4get/go-geom/encoding/wkbcommon"
+	"github.com/don4get/go-geom/encoding/wkbhex"
+	"github.com/don4get/go-geom/encoding/wkt"
+)
+
+// DefaultWKTColumn is the name of the default geometry column.
+const DefaultWKTColumn = "WKT"
+
+// errMissingCoordinate is returned when only some of the X, Y, and Z columns
+// are empty.
+var errMissingCoordinate = errors.New("missing coordinate")
+
+// An ErrMissingColumn is returned when a geometry column is missing from the
+// header.
+type ErrMissingColumn string
+
+func (e ErrMissingColumn) Error() string {
+	return fmt.Sprintf("csvgeom: missing column %q", string(e))
+}
+
+// A Feature is a geometry with string properties.
+type Feature struct {
+	Geometry   geom.T
+	Properties map[string]string
+}
+
+// A format is a way of storing a geometry in columns.
+type format int
+
+const (
+	formatWKT format = iota
+	formatEWKBHex
+	formatXY
+)
+
+// options are the options of a Reader or a Writer.
+type options struct {
+	format           format
+	columns          []string
+	delimiter        rune
+	srid             int
+	byteOrder        binary.ByteOrder
+	wktEncodeOptions []wkt.EncodeOption
+}
+
+// An Option is an option for a Reader or a Writer.
+type Option func(*options)
+
+// OptionWithWKTColumn stores geometries as WKT in the column name. This is the
+// default, with the column DefaultWKTColumn.
+func OptionWithWKTColumn(name string) Option {
+	return func(o *options) {
+		o.format = formatWKT
+		o.columns = []string{name}
+	}
+}
+
+// OptionWithEWKBHexColumn stores geometries as hex encoded EWKB in the column
+// name. ISO WKB is also accepted when reading.
+func OptionWithEWKBHexColumn(name string) Option {
+	return func(o *options) {
+		o.format = formatEWKBHex
+		o.columns = []string{name}
+	}
+}
+
+// OptionWithXYColumns stores geometries, which must be XY Points, as their
+// coordinates in the columns x and y.
+func OptionWithXYColumns(x, y string) Option {
+	return func(o *options) {
+		o.format = formatXY
+		o.columns = []string{x, y}
+	}
+}
+
+// OptionWithXYZColumns stores geometries, which must be XYZ Points, as their
+// coordinates in the columns x, y, and z.
+func OptionWithXYZColumns(x, y, z string) Option {
+	return func(o *options) {
+		o.format = formatXY
+		o.columns = []string{x, y, z}
+	}
+}
+
+// OptionWithDelimiter sets the field delimiter, which is ',' by default.
+func OptionWithDelimiter(delimiter rune) Option {
+	return func(o *options) {
+		o.delimiter = delimiter
+	}
+}
+
+// OptionWithSRID sets the SRID of the Points read from X, Y, and Z columns.
+func OptionWithSRID(srid int) Option {
+	return func(o *options) {
+		o.srid = srid
+	}
+}
+
+// OptionWithByteOrder sets the byte order of the EWKB written to EWKB hex
+// columns, which is NDR by default.
+func OptionWithByteOrder(byteOrder binary.ByteOrder) Option {
+	return func(o *options) {
+		o.byteOrder = byteOrder
+	}
+}
+
+// OptionWithWKTEncodeOptions sets the options used to write WKT columns.
+func OptionWithWKTEncodeOptions(opts ...wkt.EncodeOption) Option {
+	return func(o *options) {
+		o.wktEncodeOptions = opts
+	}
+}
+
+// newOptions returns the options with opts applied.
+func newOptions(opts []Option) options {
+	o := options{
+		format:    formatWKT,
+		columns:   []string{DefaultWKTColumn},
+		delimiter: ',',
+		byteOrder: binary.LittleEndian,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// A Reader reads features from a CSV file with a header.
+type Reader struct {
+	r       *csv.Reader
+	opts    options
+	header  []string
+	indexes []int
+	err     error
+}
+
+// NewReader returns a new Reader that reads from r.
+func NewReader(r io.Reader, opts ...Option) *Reader {
+	o := newOptions(opts)
+	csvReader := csv.NewReader(r)
+	csvReader.Comma = o.delimiter
+	csvReader.ReuseRecord = true
+	return &Reader{
+		r:    csvReader,
+		opts: o,
+	}
+}
+
+// Header returns the header of the CSV file, reading it if needed.
+func (r *Reader) Header() ([]string, error) {
+	if r.header == nil && r.err == nil {
+		r.err = r.readHeader()
+	}
+	return r.header, r.err
+}
+
+// readHeader reads the header and finds the geometry columns. Column names are
+// matched case-insensitively.
+func (r *Reader) readHeader() error {
+	record, err := r.r.Read()
+	if err != nil {
+		return err
+	}
+	header := append([]string(nil), record...)
+	indexes := make([]int, 0, len(r.opts.columns))
+	for _, column := range r.opts.columns {
+		index := -1
+		for i, name := range header {
+			if strings.EqualFold(name, column) {
+				index = i
+				break
+			}
+		}
+		if index == -1 {
+			return ErrMissingColumn(column)
+		}
+		indexes = append(indexes, index)
+	}
+	r.header, r.indexes = header, indexes
+	return nil
+}
+
+// Read reads the next feature, or returns io.EOF if there are no more
+// features. Empty geometry columns result in a nil Geometry. All other columns
+// are stored as properties. Errors decoding a geometry are returned as a
+// *csv.ParseError.
+func (r *Reader) Read() (*Feature, error) {
+	if _, err := r.Header(); err != nil {
+		return nil, err
+	}
+	record, err := r.r.Read()
+	if err != nil {
+		return nil, err
+	}
+	g, err := r.readGeometry(record)
+	if err != nil {
+		return nil, err
+	}
+	properties := make(map[string]string, len(record)-len(r.indexes))
+	for i, value := range record {
+		if !slices.Contains(r.indexes, i) {
+			properties[r.header[i]] = value
+		}
+	}
+	return &Feature{
+		Geometry:   g,
+		Properties: properties,
+	}, nil
+}
+
+// ReadAll reads all remaining features.
+func (r *Reader) ReadAll() ([]*Feature, error) {
+	var features []*Feature
+	for {
+		f, err := r.Read()
+		switch {
+		case errors.Is(err, io.EOF):
+			return features, nil
+		case err != nil:
+			return nil, err
+		}
+		features = append(features, f)
+	}
+}
+
+// readGeometry reads the geometry in the geometry columns of record.
+func (r *Reader) readGeometry(record []string) (geom.T, error) {
+	switch r.opts.format {
+	case formatWKT, formatEWKBHex:
+		value := strings.TrimSpace(record[r.indexes[0]])
+		if value == "" {
+			return nil, nil //nolint:nilnil
+		}
+		var g geom.T
+		var err error
+		if r.opts.format == formatWKT {
+			g, err = wkt.Unmarshal(value)
+		} else {
+			g, err = wkbhex.Decode(value, wkbcommon.WKBOptionDialect(wkbcommon.DialectAuto))
+		}
+		if err != nil {
+			return nil, r.parseError(0, err)
+		}
+		return g, nil
+	default:
+		empty := 0
+		for _, index := range r.indexes {
+			if strings.TrimSpace(record[index]) == "" {
+				empty++
+			}
+		}
+		if empty == len(r.indexes) {
+			return nil, nil //nolint:nilnil
+		}
+		coord := make(geom.Coord, len(r.indexes))
+		for i, index := range r.indexes {
+			value := strings.TrimSpace(record[index])
+			if value == "" {
+				return nil, r.parseError(i, errMissingCoordinate)
+			}
+			x, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return nil, r.parseError(i, err)
+			}
+			coord[i] = x
+		}
+		layout := geom.XY
+		if len(coord) == 3 {
+			layout = geom.XYZ
+		}
+		return geom.NewPointFlat(layout, coord).SetSRID(r.opts.srid), nil
+	}
+}
+
+// parseError returns err at the i-th geometry column of the last record read.
+func (r *Reader) parseError(i int, err error) error {
+	line, column := r.r.FieldPos(r.indexes[i])
+	return &csv.ParseError{
+		StartLine: line,
+		Line:      line,
+		Column:    column,
+		Err:       err,
+	}
+}
+
+// A Writer writes features to a CSV file with a header.
+type Writer struct {
+	w           *csv.Writer
+	opts        options
+	properties  []string
+	wroteHeader bool
+	record      []string
+}
+
+// NewWriter returns a new Writer that writes to w. The header contains the
+// geometry columns followed by properties, which are the names of the
+// properties written for each feature.
+func NewWriter(w io.Writer, properties []string, opts ...Option) *Writer {
+	o := newOptions(opts)
+	csvWriter := csv.NewWriter(w)
+	csvWriter.Comma = o.delimiter
+	return &Writer{
+		w:          csvWriter,
+		opts:       o,
+		properties: properties,
+	}
+}
+
+// Write writes a feature, preceded by the header if it is the first feature.
+// A nil Geometry is written as empty geometry columns, and missing properties
+// as empty columns.
+func (w *Writer) Write(f *Feature) error {
+	if !w.wroteHeader {
+		if err := w.WriteHeader(); err != nil {
+			return err
+		}
+	}
+	record := w.record[:0]
+	record, err := w.appendGeometry(record, f.Geometry)
+	if err != nil {
+		return err
+	}
+	for _, property := range w.properties {
+		record = append(record, f.Properties[property])
+	}
+	w.record = record
+	return w.w.Write(record)
+}
+
+// WriteHeader writes the header. It is called by the first call to Write, so
+// it is only needed to write a file without features.
+func (w *Writer) WriteHeader() error {
+	w.wroteHeader = true
+	header := make([]string, 0, len(w.opts.columns)+len(w.properties))
+	header = append(header, w.opts.columns...)
+	header = append(header, w.properties...)
+	return w.w.Write(header)
+}
+
+// Flush writes any buffered data to the underlying io.Writer.
+func (w *Writer) Flush() error {
+	w.w.Flush()
+	return w.w.Error()
+}
+
+// appendGeometry appends the geometry columns of g to record.
+func (w *Writer) appendGeometry(record []string, g geom.T) ([]string, error) {
+	switch w.opts.format {
+	case formatWKT, formatEWKBHex:
+		if g == nil {
+			return append(record, ""), nil
+		}
+		var value string
+		var err error
+		if w.opts.format == formatWKT {
+			value, err = wkt.Marshal(g, w.opts.wktEncodeOptions...)
+		} else {
+			value, err = wkbhex.Encode(g, w.opts.byteOrder, wkbcommon.WKBOptionDialect(wkbcommon.DialectExtended))
+		}
+		if err != nil {
+			return nil, err
+		}
+		return append(record, value), nil
+	default:
+		if g == nil {
+			return append(record, make([]string, len(w.opts.columns))...), nil
+		}
+		point, ok := g.(*geom.Point)
+		if !ok {
+			return nil, geom.ErrUnsupportedType{Value: g}
+		}
+		layout := geom.XY
+		if len(w.opts.columns) == 3 {
+			layout = geom.XYZ
+		}
+		if point.GetLayout() != layout {
+			return nil, geom.ErrLayoutMismatch{Got: point.GetLayout(), Want: layout}
+		}
+		if point.IsEmpty() {
+			return append(record, make([]string, len(w.opts.columns))...), nil
+		}
+		for _, x := range point.GetFlatCoords() {
+			record = append(record, strconv.FormatFloat(x, 'f', -1, 64))
+		}
+		return record, nil
+	}
+}
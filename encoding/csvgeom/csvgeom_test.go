@@ -0,0 +1,268 @@
+package csvgeom
+
+import (
+	"encoding/binary"
+	"encoding/csv"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+
+	"github.com/don4get/go-geom"
+	"github.com/don4get/go-geom/encoding/wkt"
+)
+
+func TestReader(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		opts     []Option
+		s        string
+		expected []*Feature
+	}{
+		{
+			name: "wkt",
+			s:    "name,wkt\na,POINT (1 2)\nb,\"LINESTRING (1 2, 3 4)\"\nc,\n",
+			expected: []*Feature{
+				{
+					Geometry:   geom.NewPointFlat(geom.XY, []float64{1, 2}),
+					Properties: map[string]string{"name": "a"},
+				},
+				{
+					Geometry:   geom.NewLineStringFlat(geom.XY, []float64{1, 2, 3, 4}),
+					Properties: map[string]string{"name": "b"},
+				},
+				{
+					Properties: map[string]string{"name": "c"},
+				},
+			},
+		},
+		{
+			name: "wkt_column",
+			opts: []Option{OptionWithWKTColumn("geom"), OptionWithDelimiter(';')},
+			s:    "id;geom;name\n1;\"SRID=4326;POINT (1 2)\";a\n",
+			expected: []*Feature{
+				{
+					Geometry:   geom.NewPointFlat(geom.XY, []float64{1, 2}).SetSRID(4326),
+					Properties: map[string]string{"id": "1", "name": "a"},
+				},
+			},
+		},
+		{
+			name: "ewkb_hex",
+			opts: []Option{OptionWithEWKBHexColumn("geom")},
+			s: "geom,name\n" +
+				"0101000020e6100000000000000000f03f0000000000000040,a\n" +
+				"01e9030000000000000000f03f00000000000000400000000000000840,b\n",
+			expected: []*Feature{
+				{
+					Geometry:   geom.NewPointFlat(geom.XY, []float64{1, 2}).SetSRID(4326),
+					Properties: map[string]string{"name": "a"},
+				},
+				{
+					Geometry:   geom.NewPointFlat(geom.XYZ, []float64{1, 2, 3}),
+					Properties: map[string]string{"name": "b"},
+				},
+			},
+		},
+		{
+			name: "xy",
+			opts: []Option{OptionWithXYColumns("lon", "lat"), OptionWithSRID(4326), OptionWithDelimiter('\t')},
+			s:    "name\tlat\tlon\na\t2\t1\nb\t \t\n",
+			expected: []*Feature{
+				{
+					Geometry:   geom.NewPointFlat(geom.XY, []float64{1, 2}).SetSRID(4326),
+					Properties: map[string]string{"name": "a"},
+				},
+				{
+					Properties: map[string]string{"name": "b"},
+				},
+			},
+		},
+		{
+			name: "xyz",
+			opts: []Option{OptionWithXYZColumns("x", "y", "z")},
+			s:    "X,Y,Z\n1,2,3\n",
+			expected: []*Feature{
+				{
+					Geometry:   geom.NewPointFlat(geom.XYZ, []float64{1, 2, 3}),
+					Properties: map[string]string{},
+				},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			features, err := NewReader(strings.NewReader(tc.s), tc.opts...).ReadAll()
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, features)
+		})
+	}
+}
+
+func TestReaderErrors(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		opts     []Option
+		s        string
+		expected error
+	}{
+		{
+			name:     "missing_column",
+			s:        "name,geom\na,POINT (1 2)\n",
+			expected: ErrMissingColumn("WKT"),
+		},
+		{
+			name: "invalid_wkt",
+			s:    "name,wkt\na,POINT (1 2)\nb,POINT (1\n",
+			expected: &csv.ParseError{
+				StartLine: 3,
+				Line:      3,
+				Column:    3,
+			},
+		},
+		{
+			name: "invalid_ewkb_hex",
+			opts: []Option{OptionWithEWKBHexColumn("geom")},
+			s:    "geom\n0101\n",
+			expected: &csv.ParseError{
+				StartLine: 2,
+				Line:      2,
+				Column:    1,
+			},
+		},
+		{
+			name: "invalid_coordinate",
+			opts: []Option{OptionWithXYColumns("x", "y")},
+			s:    "x,y\n1,a\n",
+			expected: &csv.ParseError{
+				StartLine: 2,
+				Line:      2,
+				Column:    3,
+			},
+		},
+		{
+			name: "missing_coordinate",
+			opts: []Option{OptionWithXYColumns("x", "y")},
+			s:    "x,y\n,2\n",
+			expected: &csv.ParseError{
+				StartLine: 2,
+				Line:      2,
+				Column:    1,
+				Err:       errMissingCoordinate,
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewReader(strings.NewReader(tc.s), tc.opts...).ReadAll()
+			var parseErr *csv.ParseError
+			if expected, ok := tc.expected.(*csv.ParseError); ok {
+				assert.True(t, errors.As(err, &parseErr))
+				if expected.Err == nil {
+					expected.Err = parseErr.Err
+				}
+				assert.NotZero(t, parseErr.Err)
+				assert.Equal(t, expected, parseErr)
+			} else {
+				assert.Equal(t, tc.expected, err)
+			}
+		})
+	}
+}
+
+func TestWriter(t *testing.T) {
+	features := []*Feature{
+		{
+			Geometry:   geom.NewPointFlat(geom.XY, []float64{1, 2}).SetSRID(4326),
+			Properties: map[string]string{"name": "a", "description": "x, y"},
+		},
+		{
+			Properties: map[string]string{"name": "b"},
+		},
+	}
+	for _, tc := range []struct {
+		name     string
+		opts     []Option
+		expected string
+	}{
+		{
+			name:     "wkt",
+			expected: "WKT,name,description\nPOINT (1 2),a,\"x, y\"\n,b,\n",
+		},
+		{
+			name:     "ewkt",
+			opts:     []Option{OptionWithWKTColumn("geom"), OptionWithWKTEncodeOptions(wkt.EncodeOptionWithEWKT()), OptionWithDelimiter(';')},
+			expected: "geom;name;description\n\"SRID=4326;POINT (1 2)\";a;x, y\n;b;\n",
+		},
+		{
+			name:     "ewkb_hex",
+			opts:     []Option{OptionWithEWKBHexColumn("geom")},
+			expected: "geom,name,description\n0101000020e6100000000000000000f03f0000000000000040,a,\"x, y\"\n,b,\n",
+		},
+		{
+			name:     "ewkb_hex_xdr",
+			opts:     []Option{OptionWithEWKBHexColumn("geom"), OptionWithByteOrder(binary.BigEndian)},
+			expected: "geom,name,description\n0020000001000010e63ff00000000000004000000000000000,a,\"x, y\"\n,b,\n",
+		},
+		{
+			name:     "xy",
+			opts:     []Option{OptionWithXYColumns("lon", "lat"), OptionWithDelimiter('\t')},
+			expected: "lon\tlat\tname\tdescription\n1\t2\ta\tx, y\n\t\tb\t\n",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			sb := &strings.Builder{}
+			w := NewWriter(sb, []string{"name", "description"}, tc.opts...)
+			for _, f := range features {
+				assert.NoError(t, w.Write(f))
+			}
+			assert.NoError(t, w.Flush())
+			assert.Equal(t, tc.expected, sb.String())
+
+			got, err := NewReader(strings.NewReader(sb.String()), tc.opts...).ReadAll()
+			assert.NoError(t, err)
+			assert.Equal(t, len(features), len(got))
+			for i, f := range got {
+				assert.Equal(t, features[i].Properties["name"], f.Properties["name"])
+			}
+		})
+	}
+}
+
+func TestWriterErrors(t *testing.T) {
+	for i, tc := range []struct {
+		opts     []Option
+		g        geom.T
+		expected error
+	}{
+		{
+			opts:     []Option{OptionWithXYColumns("x", "y")},
+			g:        geom.NewLineStringFlat(geom.XY, []float64{1, 2, 3, 4}),
+			expected: geom.ErrUnsupportedType{Value: geom.NewLineStringFlat(geom.XY, []float64{1, 2, 3, 4})},
+		},
+		{
+			opts:     []Option{OptionWithXYColumns("x", "y")},
+			g:        geom.NewPointFlat(geom.XYZ, []float64{1, 2, 3}),
+			expected: geom.ErrLayoutMismatch{Got: geom.XYZ, Want: geom.XY},
+		},
+		{
+			opts:     []Option{OptionWithXYZColumns("x", "y", "z")},
+			g:        geom.NewPointFlat(geom.XY, []float64{1, 2}),
+			expected: geom.ErrLayoutMismatch{Got: geom.XY, Want: geom.XYZ},
+		},
+	} {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			w := NewWriter(io.Discard, nil, tc.opts...)
+			assert.Equal(t, tc.expected, w.Write(&Feature{Geometry: tc.g}))
+		})
+	}
+}
+
+func TestWriteHeader(t *testing.T) {
+	sb := &strings.Builder{}
+	w := NewWriter(sb, []string{"name"}, OptionWithXYZColumns("x", "y", "z"))
+	assert.NoError(t, w.WriteHeader())
+	assert.NoError(t, w.Flush())
+	assert.Equal(t, "x,y,z,name\n", sb.String())
+}
@@ -28,8 +28,15 @@ const (
 	ewkbSRID = 0x20000000
 )
 
-// Read reads an arbitrary geometry from r.
-func Read(r io.Reader) (geom.T, error) {
+// Read reads an arbitrary geometry from r. Of the options, only Limits are
+// used.
+func Read(r io.Reader, opts ...wkbcommon.WKBOption) (geom.T, error) {
+	params := wkbcommon.InitWKBParams(wkbcommon.WKBParams{}, opts...)
+	if params.Limits != (geom.Limits{}) && wkbcommon.CounterOf(r) == nil {
+		r = wkbcommon.NewLimitedReader(r, params.Limits)
+	}
+	counter := wkbcommon.CounterOf(r)
+
 	ewkbByteOrder, err := wkbcommon.ReadByte(r)
 	if err != nil {
 		return nil, err
@@ -99,9 +106,12 @@ func Read(r io.Reader) (geom.T, error) {
 		if limit := wkbcommon.MaxGeometryElements[1]; limit >= 0 && int(n) > limit {
 			return nil, wkbcommon.ErrGeometryTooLarge{Level: 1, N: int(n), Limit: limit}
 		}
+		if err := counter.CheckParts(int(n)); err != nil {
+			return nil, err
+		}
 		mp := geom.NewMultiPoint(layout).SetSRID(int(srid))
 		for range n {
-			g, err := Read(r)
+			g, err := Read(r, opts...)
 			if err != nil {
 				return nil, err
 			}
@@ -122,9 +132,12 @@ func Read(r io.Reader) (geom.T, error) {
 		if limit := wkbcommon.MaxGeometryElements[2]; limit >= 0 && int(n) > limit {
 			return nil, wkbcommon.ErrGeometryTooLarge{Level: 2, N: int(n), Limit: limit}
 		}
+		if err := counter.CheckParts(int(n)); err != nil {
+			return nil, err
+		}
 		mls := geom.NewMultiLineString(layout).SetSRID(int(srid))
 		for range n {
-			g, err := Read(r)
+			g, err := Read(r, opts...)
 			if err != nil {
 				return nil, err
 			}
@@ -145,9 +158,12 @@ func Read(r io.Reader) (geom.T, error) {
 		if limit := wkbcommon.MaxGeometryElements[3]; limit >= 0 && int(n) > limit {
 			return nil, wkbcommon.ErrGeometryTooLarge{Level: 3, N: int(n), Limit: limit}
 		}
+		if err := counter.CheckParts(int(n)); err != nil {
+			return nil, err
+		}
 		mp := geom.NewMultiPolygon(layout).SetSRID(int(srid))
 		for range n {
-			g, err := Read(r)
+			g, err := Read(r, opts...)
 			if err != nil {
 				return nil, err
 			}
@@ -168,9 +184,16 @@ func Read(r io.Reader) (geom.T, error) {
 		if limit := wkbcommon.MaxGeometryElements[1]; limit >= 0 && int(n) > limit {
 			return nil, wkbcommon.ErrGeometryTooLarge{Level: 1, N: int(n), Limit: limit}
 		}
+		if err := counter.CheckParts(int(n)); err != nil {
+			return nil, err
+		}
+		if err := counter.Enter(); err != nil {
+			return nil, err
+		}
+		defer counter.Leave()
 		gc := geom.NewGeometryCollection().SetSRID(int(srid))
 		for range n {
-			g, err := Read(r)
+			g, err := Read(r, opts...)
 			if err != nil {
 				return nil, err
 			}
@@ -186,14 +209,112 @@ func Read(r io.Reader) (geom.T, error) {
 			}
 		}
 		return gc, nil
+	case wkbcommon.TriangleID:
+		flatCoords, ends, err := wkbcommon.ReadFlatCoords2(r, byteOrder, layout.Stride())
+		if err != nil {
+			return nil, err
+		}
+		if err := wkbcommon.CheckTriangle(layout, flatCoords, 0, ends); err != nil {
+			return nil, err
+		}
+		return geom.NewTriangleFlat(layout, flatCoords, ends).SetSRID(int(srid)), nil
+	case wkbcommon.TINID:
+		n, err := wkbcommon.ReadUInt32(r, byteOrder)
+		if err != nil {
+			return nil, err
+		}
+		if limit := wkbcommon.MaxGeometryElements[3]; limit >= 0 && int(n) > limit {
+			return nil, wkbcommon.ErrGeometryTooLarge{Level: 3, N: int(n), Limit: limit}
+		}
+		if err := counter.CheckParts(int(n)); err != nil {
+			return nil, err
+		}
+		tin := geom.NewTIN(layout).SetSRID(int(srid))
+		for range n {
+			g, err := Read(r, opts...)
+			if err != nil {
+				return nil, err
+			}
+			t, ok := g.(*geom.Triangle)
+			if !ok {
+				return nil, wkbcommon.ErrUnexpectedType{Got: g, Want: &geom.Triangle{}}
+			}
+			if err = tin.Push(t); err != nil {
+				return nil, err
+			}
+		}
+		return tin, nil
+	case wkbcommon.PolyhedralSurfaceID:
+		n, err := wkbcommon.ReadUInt32(r, byteOrder)
+		if err != nil {
+			return nil, err
+		}
+		if limit := wkbcommon.MaxGeometryElements[3]; limit >= 0 && int(n) > limit {
+			return nil, wkbcommon.ErrGeometryTooLarge{Level: 3, N: int(n), Limit: limit}
+		}
+		if err := counter.CheckParts(int(n)); err != nil {
+			return nil, err
+		}
+		ps := geom.NewPolyhedralSurface(layout).SetSRID(int(srid))
+		for range n {
+			g, err := Read(r, opts...)
+			if err != nil {
+				return nil, err
+			}
+			p, ok := g.(*geom.Polygon)
+			if !ok {
+				return nil, wkbcommon.ErrUnexpectedType{Got: g, Want: &geom.Polygon{}}
+			}
+			if err = ps.Push(p); err != nil {
+				return nil, err
+			}
+		}
+		return ps, nil
+	case wkbcommon.CircularStringID:
+		flatCoords, err := wkbcommon.ReadFlatCoords1(r, byteOrder, layout.Stride())
+		if err != nil {
+			return nil, err
+		}
+		return geom.NewCircularStringFlat(layout, flatCoords).SetSRID(int(srid)), nil
+	case wkbcommon.CompoundCurveID, wkbcommon.CurvePolygonID, wkbcommon.MultiCurveID, wkbcommon.MultiSurfaceID:
+		n, err := wkbcommon.ReadUInt32(r, byteOrder)
+		if err != nil {
+			return nil, err
+		}
+		if limit := wkbcommon.MaxGeometryElements[2]; limit >= 0 && int(n) > limit {
+			return nil, wkbcommon.ErrGeometryTooLarge{Level: 2, N: int(n), Limit: limit}
+		}
+		if err := counter.CheckParts(int(n)); err != nil {
+			return nil, err
+		}
+		var components []geom.T
+		for range n {
+			g, err := Read(r, opts...)
+			if err != nil {
+				return nil, err
+			}
+			components = append(components, g)
+		}
+		g, err := wkbcommon.NewComposite(t&^(ewkbZ|ewkbM|ewkbSRID), layout, components)
+		if err != nil {
+			return nil, err
+		}
+		return geom.SetSRID(g, int(srid))
 	default:
 		return nil, wkbcommon.ErrUnsupportedType(ewkbGeometryType)
 	}
 }
 
 // Unmarshal unmrshals an arbitrary geometry from a []byte.
-func Unmarshal(data []byte) (geom.T, error) {
-	return Read(bytes.NewBuffer(data))
+func Unmarshal(data []byte, opts ...wkbcommon.WKBOption) (geom.T, error) {
+	return Read(bytes.NewBuffer(data), opts...)
+}
+
+// Inspect returns information about the geometry in data, including its SRID
+// and bounds, without decoding it. Of the options, only Limits are used.
+func Inspect(data []byte, opts ...wkbcommon.WKBOption) (*wkbcommon.Info, error) {
+	params := wkbcommon.InitWKBParams(wkbcommon.WKBParams{}, opts...)
+	return wkbcommon.Inspect(data, wkbcommon.DialectExtended, params.Limits)
 }
 
 // Write writes an arbitrary geometry to w.
@@ -227,6 +348,22 @@ func Write(w io.Writer, byteOrder binary.ByteOrder, g geom.T) error {
 		ewkbGeometryType = wkbcommon.MultiPolygonID
 	case *geom.GeometryCollection:
 		ewkbGeometryType = wkbcommon.GeometryCollectionID
+	case *geom.Triangle:
+		ewkbGeometryType = wkbcommon.TriangleID
+	case *geom.TIN:
+		ewkbGeometryType = wkbcommon.TINID
+	case *geom.PolyhedralSurface:
+		ewkbGeometryType = wkbcommon.PolyhedralSurfaceID
+	case *geom.CircularString:
+		ewkbGeometryType = wkbcommon.CircularStringID
+	case *geom.CompoundCurve:
+		ewkbGeometryType = wkbcommon.CompoundCurveID
+	case *geom.CurvePolygon:
+		ewkbGeometryType = wkbcommon.CurvePolygonID
+	case *geom.MultiCurve:
+		ewkbGeometryType = wkbcommon.MultiCurveID
+	case *geom.MultiSurface:
+		ewkbGeometryType = wkbcommon.MultiSurfaceID
 	default:
 		return geom.ErrUnsupportedType{Value: g}
 	}
@@ -313,6 +450,43 @@ func Write(w io.Writer, byteOrder binary.ByteOrder, g geom.T) error {
 			}
 		}
 		return nil
+	case *geom.Triangle:
+		return wkbcommon.WriteFlatCoords2(w, byteOrder, g.GetFlatCoords(), g.GetEnds(), g.GetStride())
+	case *geom.TIN:
+		n := g.NumTriangles()
+		if err := binary.Write(w, byteOrder, uint32(n)); err != nil {
+			return err
+		}
+		for i := range n {
+			if err := Write(w, byteOrder, g.Triangle(i)); err != nil {
+				return err
+			}
+		}
+		return nil
+	case *geom.PolyhedralSurface:
+		n := g.NumPolygons()
+		if err := binary.Write(w, byteOrder, uint32(n)); err != nil {
+			return err
+		}
+		for i := range n {
+			if err := Write(w, byteOrder, g.Polygon(i)); err != nil {
+				return err
+			}
+		}
+		return nil
+	case *geom.CircularString:
+		return wkbcommon.WriteFlatCoords1(w, byteOrder, g.GetFlatCoords(), g.GetStride())
+	case *geom.CompoundCurve, *geom.CurvePolygon, *geom.MultiCurve, *geom.MultiSurface:
+		components, _ := wkbcommon.Components(g)
+		if err := binary.Write(w, byteOrder, uint32(len(components))); err != nil {
+			return err
+		}
+		for _, component := range components {
+			if err := Write(w, byteOrder, component); err != nil {
+				return err
+			}
+		}
+		return nil
 	default:
 		return geom.ErrUnsupportedType{Value: g}
 	}
@@ -7,6 +7,7 @@ import (
 	"github.com/alecthomas/assert/v2"
 
 	"github.com/don4get/go-geom"
+	"github.com/don4get/go-geom/encoding/wkbcommon"
 	"github.com/don4get/go-geom/internal/geomtest"
 )
 
@@ -261,3 +262,125 @@ func Test(t *testing.T) {
 		})
 	}
 }
+
+func TestCurves(t *testing.T) {
+	for _, tc := range []struct {
+		g   geom.T
+		ndr []byte
+	}{
+		{
+			g:   geom.NewCircularString(geom.XY).MustSetCoords([]geom.Coord{{0, 0}, {1, 1}, {2, 0}}),
+			ndr: geomtest.MustHexDecode("01080000000300000000000000000000000000000000000000000000000000f03f000000000000f03f00000000000000400000000000000000"),
+		},
+		{
+			g: geom.NewCompoundCurve(geom.XY).MustPush(
+				geom.NewLineString(geom.XY).MustSetCoords([]geom.Coord{{0, 0}, {1, 1}}),
+				geom.NewCircularString(geom.XY).MustSetCoords([]geom.Coord{{1, 1}, {2, 0}, {3, 1}}),
+			),
+			ndr: geomtest.MustHexDecode("01090000000200000001020000000200000000000000000000000000000000000000000000000000f03f000000000000f03f010800000003000000000000000000f03f000000000000f03f000000000000004000000000000000000000000000000840000000000000f03f"),
+		},
+		{
+			g: geom.NewCurvePolygon(geom.XYZ).MustPush(
+				geom.NewCircularString(geom.XYZ).MustSetCoords([]geom.Coord{{0, 0, 1}, {4, 0, 2}, {0, 0, 1}}),
+				geom.NewLinearRing(geom.XYZ).MustSetCoords([]geom.Coord{{1, 1, 1}, {2, 1, 1}, {1, 2, 1}, {1, 1, 1}}),
+			),
+			ndr: geomtest.MustHexDecode("010a0000800200000001080000800300000000000000000000000000000000000000000000000000f03f00000000000010400000000000000000000000000000004000000000000000000000000000000000000000000000f03f010200008004000000000000000000f03f000000000000f03f000000000000f03f0000000000000040000000000000f03f000000000000f03f000000000000f03f0000000000000040000000000000f03f000000000000f03f000000000000f03f000000000000f03f"),
+		},
+		{
+			g: geom.NewMultiCurve(geom.XY).MustPush(
+				geom.NewLineString(geom.XY).MustSetCoords([]geom.Coord{{0, 0}, {1, 1}}),
+				geom.NewCompoundCurve(geom.XY).MustPush(
+					geom.NewCircularString(geom.XY).MustSetCoords([]geom.Coord{{0, 0}, {1, 1}, {2, 0}}),
+				),
+			),
+			ndr: geomtest.MustHexDecode("010b0000000200000001020000000200000000000000000000000000000000000000000000000000f03f000000000000f03f01090000000100000001080000000300000000000000000000000000000000000000000000000000f03f000000000000f03f00000000000000400000000000000000"),
+		},
+		{
+			g: geom.NewMultiSurface(geom.XY).MustPush(
+				geom.NewCurvePolygon(geom.XY).MustPush(
+					geom.NewCircularString(geom.XY).MustSetCoords([]geom.Coord{{0, 0}, {4, 0}, {0, 0}}),
+				),
+				geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{{{0, 0}, {1, 0}, {1, 1}, {0, 0}}}),
+			),
+			ndr: geomtest.MustHexDecode("010c00000002000000010a000000010000000108000000030000000000000000000000000000000000000000000000000010400000000000000000000000000000000000000000000000000103000000010000000400000000000000000000000000000000000000000000000000f03f0000000000000000000000000000f03f000000000000f03f00000000000000000000000000000000"),
+		},
+		{
+			g: geom.NewMultiSurface(geom.XY).MustPush(
+				geom.NewCurvePolygon(geom.XY).MustPush(
+					geom.NewCircularString(geom.XY).MustSetCoords([]geom.Coord{{0, 0}, {4, 0}, {0, 0}}),
+				),
+				geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{{{0, 0}, {1, 0}, {1, 1}, {0, 0}}}),
+			).SetSRID(4326),
+			ndr: geomtest.MustHexDecode("010c000020e610000002000000010a000000010000000108000000030000000000000000000000000000000000000000000000000010400000000000000000000000000000000000000000000000000103000000010000000400000000000000000000000000000000000000000000000000f03f0000000000000000000000000000f03f000000000000f03f00000000000000000000000000000000"),
+		},
+	} {
+		t.Run(fmt.Sprintf("ndr:%x", tc.ndr), func(t *testing.T) {
+			test(t, tc.g, nil, tc.ndr)
+		})
+	}
+}
+
+func TestSurfaces(t *testing.T) {
+	for _, tc := range []struct {
+		g   geom.T
+		ndr []byte
+	}{
+		{
+			g:   geom.NewTriangle(geom.XY).MustSetCoords([][]geom.Coord{{{0, 0}, {1, 0}, {0, 1}, {0, 0}}}),
+			ndr: geomtest.MustHexDecode("0111000000010000000400000000000000000000000000000000000000000000000000f03f00000000000000000000000000000000000000000000f03f00000000000000000000000000000000"),
+		},
+		{
+			g:   geom.NewTIN(geom.XYZ).MustSetCoords([][][]geom.Coord{{{{0, 0, 1}, {1, 0, 1}, {0, 1, 1}, {0, 0, 1}}}}).SetSRID(4326),
+			ndr: geomtest.MustHexDecode("01100000a0e6100000010000000111000080010000000400000000000000000000000000000000000000000000000000f03f000000000000f03f0000000000000000000000000000f03f0000000000000000000000000000f03f000000000000f03f00000000000000000000000000000000000000000000f03f"),
+		},
+		{
+			g:   geom.NewPolyhedralSurface(geom.XY).MustSetCoords([][][]geom.Coord{{{{0, 0}, {1, 0}, {1, 1}, {0, 0}}}, {{{0, 0}, {1, 1}, {0, 1}, {0, 0}}}}),
+			ndr: geomtest.MustHexDecode("010f000000020000000103000000010000000400000000000000000000000000000000000000000000000000f03f0000000000000000000000000000f03f000000000000f03f000000000000000000000000000000000103000000010000000400000000000000000000000000000000000000000000000000f03f000000000000f03f0000000000000000000000000000f03f00000000000000000000000000000000"),
+		},
+	} {
+		t.Run(fmt.Sprintf("ndr:%x", tc.ndr), func(t *testing.T) {
+			test(t, tc.g, nil, tc.ndr)
+		})
+	}
+}
+
+func TestInvalidTriangles(t *testing.T) {
+	for _, g := range []geom.T{
+		geom.NewTriangleFlat(geom.XY, []float64{0, 0, 1, 0, 0, 1, 1, 1}, []int{8}).SetSRID(4326),
+		geom.NewTINFlat(geom.XY, []float64{0, 0, 1, 0, 0, 1, 0, 0, 0, 0, 1, 0, 0, 1, 0, 0, 1, 1}, [][]int{{8}, {18}}),
+	} {
+		data, err := Marshal(g, NDR)
+		assert.NoError(t, err)
+		_, err = Unmarshal(data)
+		assert.Equal[error](t, wkbcommon.ErrInvalidTriangle{}, err)
+	}
+}
+
+func TestInspect(t *testing.T) {
+	data, err := Marshal(geom.NewPolygonFlat(geom.XYZ, []float64{0, 0, 1, 4, 0, 2, 0, 3, 3, 0, 0, 1}, []int{12}).SetSRID(4326), NDR)
+	assert.NoError(t, err)
+	actual, err := Inspect(data)
+	assert.NoError(t, err)
+	assert.Equal(t, &wkbcommon.Info{
+		Type:      wkbcommon.PolygonID,
+		Layout:    geom.XYZ,
+		SRID:      4326,
+		NumPoints: 4,
+		Bounds:    geom.NewBounds(geom.XYZ).Set(0, 0, 1, 4, 3, 3),
+	}, actual)
+}
+
+func TestLimits(t *testing.T) {
+	data, err := Marshal(geom.NewMultiLineStringFlat(geom.XY, []float64{1, 2, 3, 4, 5, 6, 7, 8}, []int{4, 8}).SetSRID(4326), NDR)
+	assert.NoError(t, err)
+
+	opt := wkbcommon.WKBOptionLimits(geom.Limits{MaxCoords: 3})
+	expected := geom.ErrLimitExceeded{Limit: geom.LimitCoords, N: 4, Max: 3}
+	_, err = Unmarshal(data, opt)
+	assert.Equal[error](t, expected, err)
+	_, err = Inspect(data, opt)
+	assert.Equal[error](t, expected, err)
+
+	_, err = Unmarshal(data, wkbcommon.WKBOptionLimits(geom.Limits{MaxParts: 1}))
+	assert.Equal[error](t, geom.ErrLimitExceeded{Limit: geom.LimitParts, N: 2, Max: 1}, err)
+}
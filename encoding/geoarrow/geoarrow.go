@@ -0,0 +1,489 @@
+// Package geoarrow implements conversion between slices of geometries and the
+// buffers of GeoArrow arrays, in the native encodings and in the WKB encoding.
+//
+// The arrays are represented by their Arrow buffers, so that they can be used
+// with any Arrow implementation. In the native encodings, coordinates are
+// either interleaved, in a single buffer, or separated, in one buffer per
+// dimension. The offsets of nested lists count elements of the next level, so
+// the offsets into the coordinates correspond to the ends returned by GetEnds
+// and GetEndss divided by the stride. Null geometries are represented by nil.
+//
+// See https://geoarrow.org/format.html.
+package geoarrow
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/don4get/go-geom"
+)
+
+// A GeometryType is a GeoArrow geometry type, whose value is the Arrow
+// extension name.
+type GeometryType string
+
+// Geometry types.
+const (
+	TypePoint           GeometryType = "geoarrow.point"
+	TypeLineString      GeometryType = "geoarrow.linestring"
+	TypePolygon         GeometryType = "geoarrow.polygon"
+	TypeMultiPoint      GeometryType = "geoarrow.multipoint"
+	TypeMultiLineString GeometryType = "geoarrow.multilinestring"
+	TypeMultiPolygon    GeometryType = "geoarrow.multipolygon"
+	TypeWKB             GeometryType = "geoarrow.wkb"
+)
+
+// A CoordType is a way of storing coordinates.
+type CoordType int
+
+// Coordinate types.
+const (
+	// CoordTypeSeparated stores each dimension in its own buffer, as an Arrow
+	// struct array.
+	CoordTypeSeparated CoordType = iota
+	// CoordTypeInterleaved stores the coordinates in a single buffer, as an
+	// Arrow fixed size list array.
+	CoordTypeInterleaved
+)
+
+// ErrInvalidArray is returned when the buffers of an array are not
+// consistent.
+var ErrInvalidArray = errors.New("geoarrow: invalid array")
+
+// An ErrGeometryTypeMismatch is returned when geometries of different types
+// are stored in the same native array.
+type ErrGeometryTypeMismatch struct {
+	Got  GeometryType
+	Want GeometryType
+}
+
+func (e ErrGeometryTypeMismatch) Error() string {
+	return fmt.Sprintf("geoarrow: geometry type mismatch, got %s, want %s", e.Got, e.Want)
+}
+
+// An ErrSRIDMismatch is returned when geometries with different SRIDs are
+// stored in the same array.
+type ErrSRIDMismatch struct {
+	Got  int
+	Want int
+}
+
+func (e ErrSRIDMismatch) Error() string {
+	return fmt.Sprintf("geoarrow: SRID mismatch, got %d, want %d", e.Got, e.Want)
+}
+
+// An Array is a GeoArrow array in a native encoding.
+type Array struct {
+	// Type is the geometry type.
+	Type GeometryType
+	// Layout is the layout of the coordinates.
+	Layout geom.Layout
+	// SRID is the SRID of all geometries.
+	SRID int
+	// CoordType is the way in which coordinates are stored.
+	CoordType CoordType
+	// Offsets are the offset buffers of the nested lists, outermost first.
+	// Points have none, LineStrings and MultiPoints have one, Polygons and
+	// MultiLineStrings have two, and MultiPolygons have three.
+	Offsets [][]int32
+	// Interleaved is the coordinate buffer if CoordType is
+	// CoordTypeInterleaved.
+	Interleaved []float64
+	// Separated are the coordinate buffers, one per dimension, if CoordType
+	// is CoordTypeSeparated.
+	Separated [][]float64
+	// Validity is the validity bitmap, or nil if no geometries are null.
+	Validity []byte
+	// Length is the number of geometries.
+	Length int
+}
+
+// NewArray returns a native array containing gs, which must have the same
+// type, layout, and SRID. Empty points are stored as NaN coordinates. If gs
+// contains only nil geometries, the array is an array of XY Points.
+func NewArray(gs []geom.T, coordType CoordType) (*Array, error) {
+	a := &Array{
+		Type:      TypePoint,
+		Layout:    geom.XY,
+		CoordType: coordType,
+		Length:    len(gs),
+	}
+	for _, g := range gs {
+		if g == nil {
+			continue
+		}
+		t, err := geometryType(g)
+		if err != nil {
+			return nil, err
+		}
+		a.Type, a.Layout, a.SRID = t, g.GetLayout(), g.GetSRID()
+		break
+	}
+	stride := a.Layout.Stride()
+	a.Offsets = make([][]int32, a.Type.depth())
+	for i := range a.Offsets {
+		a.Offsets[i] = []int32{0}
+	}
+	var flatCoords []float64
+	appendOffset := func(level, n int) {
+		a.Offsets[level] = append(a.Offsets[level], int32(n))
+	}
+	appendRings := func(level int, ringFlatCoords []float64, offset int, ends []int) {
+		for _, end := range ends {
+			flatCoords = append(flatCoords, ringFlatCoords[offset:end]...)
+			appendOffset(level, len(flatCoords)/stride)
+			offset = end
+		}
+	}
+	validity := make([]byte, (len(gs)+7)/8)
+	hasNulls := false
+	for i, g := range gs {
+		if g == nil {
+			hasNulls = true
+			if a.Type == TypePoint {
+				flatCoords = appendEmptyCoord(flatCoords, stride)
+			}
+			if len(a.Offsets) > 0 {
+				appendOffset(0, int(a.Offsets[0][len(a.Offsets[0])-1]))
+			}
+			continue
+		}
+		validity[i/8] |= 1 << (i % 8)
+		t, err := geometryType(g)
+		switch {
+		case err != nil:
+			return nil, err
+		case t != a.Type:
+			return nil, ErrGeometryTypeMismatch{Got: t, Want: a.Type}
+		case g.GetLayout() != a.Layout:
+			return nil, geom.ErrLayoutMismatch{Got: g.GetLayout(), Want: a.Layout}
+		case g.GetSRID() != a.SRID:
+			return nil, ErrSRIDMismatch{Got: g.GetSRID(), Want: a.SRID}
+		}
+		switch g := g.(type) {
+		case *geom.Point:
+			if g.IsEmpty() {
+				flatCoords = appendEmptyCoord(flatCoords, stride)
+			} else {
+				flatCoords = append(flatCoords, g.GetFlatCoords()...)
+			}
+		case *geom.LineString:
+			flatCoords = append(flatCoords, g.GetFlatCoords()...)
+			appendOffset(0, len(flatCoords)/stride)
+		case *geom.MultiPoint:
+			for j := range g.NumPoints() {
+				if point := g.Point(j); point.IsEmpty() {
+					flatCoords = appendEmptyCoord(flatCoords, stride)
+				} else {
+					flatCoords = append(flatCoords, point.GetFlatCoords()...)
+				}
+			}
+			appendOffset(0, len(flatCoords)/stride)
+		case *geom.Polygon:
+			appendRings(1, g.GetFlatCoords(), 0, g.GetEnds())
+			appendOffset(0, len(a.Offsets[1])-1)
+		case *geom.MultiLineString:
+			appendRings(1, g.GetFlatCoords(), 0, g.GetEnds())
+			appendOffset(0, len(a.Offsets[1])-1)
+		case *geom.MultiPolygon:
+			offset := 0
+			for _, ends := range g.GetEndss() {
+				appendRings(2, g.GetFlatCoords(), offset, ends)
+				appendOffset(1, len(a.Offsets[2])-1)
+				if len(ends) > 0 {
+					offset = ends[len(ends)-1]
+				}
+			}
+			appendOffset(0, len(a.Offsets[1])-1)
+		}
+	}
+	if len(flatCoords)/max(stride, 1) > math.MaxInt32 {
+		return nil, ErrInvalidArray
+	}
+	if hasNulls {
+		a.Validity = validity
+	}
+	switch coordType {
+	case CoordTypeInterleaved:
+		a.Interleaved = flatCoords
+	default:
+		a.Separated = make([][]float64, stride)
+		for i := range stride {
+			a.Separated[i] = make([]float64, 0, len(flatCoords)/stride)
+			for j := i; j < len(flatCoords); j += stride {
+				a.Separated[i] = append(a.Separated[i], flatCoords[j])
+			}
+		}
+	}
+	return a, nil
+}
+
+// Geometries returns the geometries in a.
+func (a *Array) Geometries() ([]geom.T, error) {
+	stride := a.Layout.Stride()
+	if a.Type != TypePoint && a.Type.depth() == 0 {
+		return nil, ErrInvalidArray
+	}
+	if stride == 0 || len(a.Offsets) != a.Type.depth() || !isValidBitmap(a.Validity, a.Length) {
+		return nil, ErrInvalidArray
+	}
+	flatCoords, err := a.flatCoords()
+	if err != nil {
+		return nil, err
+	}
+	n := a.Length
+	for _, offsets := range a.Offsets {
+		if !isValidOffsets(offsets, n) {
+			return nil, ErrInvalidArray
+		}
+		n = int(offsets[len(offsets)-1])
+	}
+	if n*stride > len(flatCoords) {
+		return nil, ErrInvalidArray
+	}
+
+	// coords returns the flat coordinates from the start-th to the end-th
+	// coordinate.
+	coords := func(start, end int32) []float64 {
+		if start == end {
+			return nil
+		}
+		return slices.Clone(flatCoords[int(start)*stride : int(end)*stride])
+	}
+	// ends returns the coordinates of the rings from start to end, and their
+	// ends.
+	ends := func(ringOffsets []int32, start, end int32) ([]float64, []int) {
+		var ends []int
+		for ring := start; ring < end; ring++ {
+			ends = append(ends, int(ringOffsets[ring+1]-ringOffsets[start])*stride)
+		}
+		return coords(ringOffsets[start], ringOffsets[end]), ends
+	}
+
+	gs := make([]geom.T, a.Length)
+	for i := range a.Length {
+		if !isValid(a.Validity, i) {
+			continue
+		}
+		var g geom.T
+		switch a.Type {
+		case TypePoint:
+			if coord := flatCoords[i*stride : (i+1)*stride]; isEmptyCoord(coord) {
+				g = geom.NewPointEmpty(a.Layout)
+			} else {
+				g = geom.NewPointFlat(a.Layout, slices.Clone(coord))
+			}
+		case TypeLineString:
+			g = geom.NewLineStringFlat(a.Layout, coords(a.Offsets[0][i], a.Offsets[0][i+1]))
+		case TypeMultiPoint:
+			g = newMultiPoint(a.Layout, coords(a.Offsets[0][i], a.Offsets[0][i+1]))
+		case TypePolygon:
+			flatCoords, ends := ends(a.Offsets[1], a.Offsets[0][i], a.Offsets[0][i+1])
+			g = geom.NewPolygonFlat(a.Layout, flatCoords, ends)
+		case TypeMultiLineString:
+			flatCoords, ends := ends(a.Offsets[1], a.Offsets[0][i], a.Offsets[0][i+1])
+			g = geom.NewMultiLineStringFlat(a.Layout, flatCoords, ends)
+		case TypeMultiPolygon:
+			start, end := a.Offsets[0][i], a.Offsets[0][i+1]
+			var endss [][]int
+			for polygon := start; polygon < end; polygon++ {
+				_, polygonEnds := ends(a.Offsets[2], a.Offsets[1][polygon], a.Offsets[1][polygon+1])
+				offset := int(a.Offsets[2][a.Offsets[1][polygon]]-a.Offsets[2][a.Offsets[1][start]]) * stride
+				for j := range polygonEnds {
+					polygonEnds[j] += offset
+				}
+				endss = append(endss, polygonEnds)
+			}
+			rings := a.Offsets[2]
+			g = geom.NewMultiPolygonFlat(a.Layout, coords(rings[a.Offsets[1][start]], rings[a.Offsets[1][end]]), endss)
+		}
+		if a.SRID != 0 {
+			var err error
+			if g, err = geom.SetSRID(g, a.SRID); err != nil {
+				return nil, err
+			}
+		}
+		gs[i] = g
+	}
+	return gs, nil
+}
+
+// ExtensionMetadata returns the Arrow extension metadata of a.
+func (a *Array) ExtensionMetadata() string {
+	return extensionMetadata(a.SRID)
+}
+
+// flatCoords returns the interleaved coordinates of a.
+func (a *Array) flatCoords() ([]float64, error) {
+	stride := a.Layout.Stride()
+	switch a.CoordType {
+	case CoordTypeInterleaved:
+		if len(a.Interleaved)%stride != 0 {
+			return nil, ErrInvalidArray
+		}
+		return a.Interleaved, nil
+	case CoordTypeSeparated:
+		if len(a.Separated) != stride {
+			return nil, ErrInvalidArray
+		}
+		n := len(a.Separated[0])
+		flatCoords := make([]float64, 0, n*stride)
+		for i := range n {
+			for _, dimension := range a.Separated {
+				if len(dimension) != n {
+					return nil, ErrInvalidArray
+				}
+				flatCoords = append(flatCoords, dimension[i])
+			}
+		}
+		return flatCoords, nil
+	default:
+		return nil, ErrInvalidArray
+	}
+}
+
+// depth returns the number of offset buffers of arrays of type t.
+func (t GeometryType) depth() int {
+	switch t {
+	case TypeLineString, TypeMultiPoint:
+		return 1
+	case TypePolygon, TypeMultiLineString:
+		return 2
+	case TypeMultiPolygon:
+		return 3
+	default:
+		return 0
+	}
+}
+
+// geometryType returns the native geometry type of g.
+func geometryType(g geom.T) (GeometryType, error) {
+	switch g.(type) {
+	case *geom.Point:
+		return TypePoint, nil
+	case *geom.LineString:
+		return TypeLineString, nil
+	case *geom.Polygon:
+		return TypePolygon, nil
+	case *geom.MultiPoint:
+		return TypeMultiPoint, nil
+	case *geom.MultiLineString:
+		return TypeMultiLineString, nil
+	case *geom.MultiPolygon:
+		return TypeMultiPolygon, nil
+	default:
+		return "", geom.ErrUnsupportedType{Value: g}
+	}
+}
+
+// newMultiPoint returns a new MultiPoint with the coordinates in flatCoords,
+// in which NaN coordinates are empty points.
+func newMultiPoint(layout geom.Layout, flatCoords []float64) *geom.MultiPoint {
+	stride := layout.Stride()
+	var nonEmptyFlatCoords []float64
+	ends := make([]int, 0, len(flatCoords)/stride)
+	hasEmpty := false
+	for i := 0; i < len(flatCoords); i += stride {
+		if isEmptyCoord(flatCoords[i : i+stride]) {
+			hasEmpty = true
+		} else {
+			nonEmptyFlatCoords = append(nonEmptyFlatCoords, flatCoords[i:i+stride]...)
+		}
+		ends = append(ends, len(nonEmptyFlatCoords))
+	}
+	if !hasEmpty {
+		return geom.NewMultiPointFlat(layout, flatCoords)
+	}
+	return geom.NewMultiPointFlat(layout, nonEmptyFlatCoords, geom.NewMultiPointFlatOptionWithEnds(ends))
+}
+
+// appendEmptyCoord appends the coordinate of an empty point to flatCoords.
+func appendEmptyCoord(flatCoords []float64, stride int) []float64 {
+	for range stride {
+		flatCoords = append(flatCoords, geom.PointEmptyCoord())
+	}
+	return flatCoords
+}
+
+// isEmptyCoord returns whether coord is the coordinate of an empty point,
+// whose ordinates are all NaN. GeoArrow producers may write any NaN payload,
+// so unlike WKB the NaN need not be geom.PointEmptyCoord.
+func isEmptyCoord(coord []float64) bool {
+	for _, x := range coord {
+		if !math.IsNaN(x) {
+			return false
+		}
+	}
+	return true
+}
+
+// isValid returns whether the i-th element of an array with validity bitmap
+// validity is not null.
+func isValid(validity []byte, i int) bool {
+	return validity == nil || validity[i/8]&(1<<(i%8)) != 0
+}
+
+// isValidBitmap returns whether validity is a valid bitmap for n elements.
+func isValidBitmap(validity []byte, n int) bool {
+	return validity == nil || len(validity) >= (n+7)/8
+}
+
+// isValidOffsets returns whether offsets are valid offsets for n lists.
+func isValidOffsets(offsets []int32, n int) bool {
+	if len(offsets) != n+1 || offsets[0] < 0 {
+		return false
+	}
+	for i := 1; i < len(offsets); i++ {
+		if offsets[i] < offsets[i-1] {
+			return false
+		}
+	}
+	return true
+}
+
+// extensionMetadata returns the Arrow extension metadata for srid.
+func extensionMetadata(srid int) string {
+	if srid == 0 {
+		return "{}"
+	}
+	data, _ := json.Marshal(metadata{
+		CRS:     "EPSG:" + strconv.Itoa(srid),
+		CRSType: "authority_code",
+	})
+	return string(data)
+}
+
+// A metadata is Arrow extension metadata.
+type metadata struct {
+	CRS     any    `json:"crs,omitempty"`
+	CRSType string `json:"crs_type,omitempty"`
+}
+
+// ParseExtensionMetadata returns the SRID in the Arrow extension metadata s.
+// The SRID is zero if there is no CRS or if it is not an EPSG code.
+func ParseExtensionMetadata(s string) (int, error) {
+	if s == "" {
+		return 0, nil
+	}
+	var m metadata
+	if err := json.Unmarshal([]byte(s), &m); err != nil {
+		return 0, err
+	}
+	crs, ok := m.CRS.(string)
+	if !ok {
+		return 0, nil
+	}
+	code, ok := strings.CutPrefix(strings.ToUpper(crs), "EPSG:")
+	if !ok {
+		return 0, nil
+	}
+	if srid, err := strconv.Atoi(code); err == nil {
+		return srid, nil
+	}
+	return 0, nil
+}
@@ -0,0 +1,385 @@
+package geoarrow
+
+import (
+	"math"
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+
+	"github.com/don4get/go-geom"
+)
+
+func TestArray(t *testing.T) {
+	nan := math.NaN()
+	for _, tc := range []struct {
+		name     string
+		gs       []geom.T
+		expected *Array
+	}{
+		{
+			name: "point",
+			gs: []geom.T{
+				geom.NewPointFlat(geom.XY, []float64{1, 2}),
+				nil,
+				geom.NewPointEmpty(geom.XY),
+				geom.NewPointFlat(geom.XY, []float64{3, 4}),
+			},
+			expected: &Array{
+				Type:        TypePoint,
+				Layout:      geom.XY,
+				Offsets:     [][]int32{},
+				Interleaved: []float64{1, 2, nan, nan, nan, nan, 3, 4},
+				Validity:    []byte{0b1101},
+				Length:      4,
+			},
+		},
+		{
+			name: "linestring",
+			gs: []geom.T{
+				geom.NewLineStringFlat(geom.XYZ, []float64{1, 2, 3, 4, 5, 6}).SetSRID(4326),
+				geom.NewLineString(geom.XYZ).SetSRID(4326),
+				geom.NewLineStringFlat(geom.XYZ, []float64{7, 8, 9, 10, 11, 12}).SetSRID(4326),
+			},
+			expected: &Array{
+				Type:        TypeLineString,
+				Layout:      geom.XYZ,
+				SRID:        4326,
+				Offsets:     [][]int32{{0, 2, 2, 4}},
+				Interleaved: []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12},
+				Length:      3,
+			},
+		},
+		{
+			name: "polygon",
+			gs: []geom.T{
+				geom.NewPolygonFlat(geom.XY, []float64{0, 0, 4, 0, 4, 4, 0, 0, 1, 1, 2, 1, 2, 2, 1, 1}, []int{8, 16}),
+				nil,
+				geom.NewPolygonFlat(geom.XY, []float64{0, 0, 1, 0, 1, 1, 0, 0}, []int{8}),
+			},
+			expected: &Array{
+				Type:   TypePolygon,
+				Layout: geom.XY,
+				Offsets: [][]int32{
+					{0, 2, 2, 3},
+					{0, 4, 8, 12},
+				},
+				Interleaved: []float64{0, 0, 4, 0, 4, 4, 0, 0, 1, 1, 2, 1, 2, 2, 1, 1, 0, 0, 1, 0, 1, 1, 0, 0},
+				Validity:    []byte{0b101},
+				Length:      3,
+			},
+		},
+		{
+			name: "multipoint",
+			gs: []geom.T{
+				geom.NewMultiPointFlat(geom.XYM, []float64{1, 2, 3, 4, 5, 6}),
+				geom.NewMultiPointFlat(geom.XYM, []float64{7, 8, 9}, geom.NewMultiPointFlatOptionWithEnds([]int{3, 3})),
+			},
+			expected: &Array{
+				Type:        TypeMultiPoint,
+				Layout:      geom.XYM,
+				Offsets:     [][]int32{{0, 2, 4}},
+				Interleaved: []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, nan, nan, nan},
+				Length:      2,
+			},
+		},
+		{
+			name: "multilinestring",
+			gs: []geom.T{
+				geom.NewMultiLineStringFlat(geom.XY, []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, []int{4, 10}),
+			},
+			expected: &Array{
+				Type:   TypeMultiLineString,
+				Layout: geom.XY,
+				Offsets: [][]int32{
+					{0, 2},
+					{0, 2, 5},
+				},
+				Interleaved: []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10},
+				Length:      1,
+			},
+		},
+		{
+			name: "multipolygon",
+			gs: []geom.T{
+				geom.NewMultiPolygonFlat(geom.XY, []float64{0, 0, 1, 0, 1, 1, 0, 0}, [][]int{{8}}),
+				geom.NewMultiPolygonFlat(geom.XY, []float64{
+					0, 0, 4, 0, 4, 4, 0, 0,
+					1, 1, 2, 1, 2, 2, 1, 1,
+					5, 5, 6, 5, 6, 6, 5, 5,
+				}, [][]int{{8, 16}, {24}}),
+			},
+			expected: &Array{
+				Type:   TypeMultiPolygon,
+				Layout: geom.XY,
+				Offsets: [][]int32{
+					{0, 1, 3},
+					{0, 1, 3, 4},
+					{0, 4, 8, 12, 16},
+				},
+				Interleaved: []float64{
+					0, 0, 1, 0, 1, 1, 0, 0,
+					0, 0, 4, 0, 4, 4, 0, 0,
+					1, 1, 2, 1, 2, 2, 1, 1,
+					5, 5, 6, 5, 6, 6, 5, 5,
+				},
+				Length: 2,
+			},
+		},
+		{
+			name: "nulls",
+			gs:   []geom.T{nil, nil},
+			expected: &Array{
+				Type:        TypePoint,
+				Layout:      geom.XY,
+				Offsets:     [][]int32{},
+				Interleaved: []float64{nan, nan, nan, nan},
+				Validity:    []byte{0},
+				Length:      2,
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			interleaved, err := NewArray(tc.gs, CoordTypeInterleaved)
+			assert.NoError(t, err)
+			tc.expected.CoordType = CoordTypeInterleaved
+			assertArrayEqual(t, tc.expected, interleaved)
+			gs, err := interleaved.Geometries()
+			assert.NoError(t, err)
+			assert.Equal(t, tc.gs, gs)
+
+			separated, err := NewArray(tc.gs, CoordTypeSeparated)
+			assert.NoError(t, err)
+			assert.Equal(t, CoordTypeSeparated, separated.CoordType)
+			assert.Zero(t, separated.Interleaved)
+			assert.Equal(t, tc.expected.Layout.Stride(), len(separated.Separated))
+			for i, dimension := range separated.Separated {
+				for j, x := range dimension {
+					assertFloatEqual(t, tc.expected.Interleaved[j*len(separated.Separated)+i], x)
+				}
+			}
+			gs, err = separated.Geometries()
+			assert.NoError(t, err)
+			assert.Equal(t, tc.gs, gs)
+		})
+	}
+}
+
+func TestNewArrayErrors(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		gs       []geom.T
+		expected error
+	}{
+		{
+			name: "unsupported_type",
+			gs: []geom.T{
+				geom.NewGeometryCollection(),
+			},
+			expected: geom.ErrUnsupportedType{Value: geom.NewGeometryCollection()},
+		},
+		{
+			name: "type_mismatch",
+			gs: []geom.T{
+				geom.NewPointFlat(geom.XY, []float64{1, 2}),
+				geom.NewMultiPointFlat(geom.XY, []float64{1, 2}),
+			},
+			expected: ErrGeometryTypeMismatch{Got: TypeMultiPoint, Want: TypePoint},
+		},
+		{
+			name: "layout_mismatch",
+			gs: []geom.T{
+				geom.NewPointFlat(geom.XY, []float64{1, 2}),
+				geom.NewPointFlat(geom.XYZ, []float64{1, 2, 3}),
+			},
+			expected: geom.ErrLayoutMismatch{Got: geom.XYZ, Want: geom.XY},
+		},
+		{
+			name: "srid_mismatch",
+			gs: []geom.T{
+				geom.NewPointFlat(geom.XY, []float64{1, 2}).SetSRID(4326),
+				geom.NewPointFlat(geom.XY, []float64{1, 2}),
+			},
+			expected: ErrSRIDMismatch{Got: 0, Want: 4326},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewArray(tc.gs, CoordTypeInterleaved)
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+}
+
+func TestGeometriesEmptyPointNaNPayloads(t *testing.T) {
+	nan := math.Float64frombits(0x7ff8000000000001)
+	negativeNaN := math.Float64frombits(0xfff8000000000000)
+	for _, tc := range []struct {
+		name     string
+		a        *Array
+		expected []geom.T
+	}{
+		{
+			name: "point",
+			a: &Array{
+				Type:        TypePoint,
+				Layout:      geom.XY,
+				CoordType:   CoordTypeInterleaved,
+				Interleaved: []float64{nan, negativeNaN, 1, 2},
+				Length:      2,
+			},
+			expected: []geom.T{
+				geom.NewPointEmpty(geom.XY),
+				geom.NewPointFlat(geom.XY, []float64{1, 2}),
+			},
+		},
+		{
+			name: "multipoint",
+			a: &Array{
+				Type:        TypeMultiPoint,
+				Layout:      geom.XY,
+				CoordType:   CoordTypeInterleaved,
+				Offsets:     [][]int32{{0, 2}},
+				Interleaved: []float64{1, 2, nan, nan},
+				Length:      1,
+			},
+			expected: []geom.T{
+				geom.NewMultiPointFlat(geom.XY, []float64{1, 2}, geom.NewMultiPointFlatOptionWithEnds([]int{2, 2})),
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := tc.a.Geometries()
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestGeometriesErrors(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		a    *Array
+	}{
+		{
+			name: "unknown_type",
+			a:    &Array{Type: TypeWKB, Layout: geom.XY, CoordType: CoordTypeInterleaved},
+		},
+		{
+			name: "missing_offsets",
+			a:    &Array{Type: TypeLineString, Layout: geom.XY, CoordType: CoordTypeInterleaved, Length: 1},
+		},
+		{
+			name: "decreasing_offsets",
+			a: &Array{
+				Type:        TypeLineString,
+				Layout:      geom.XY,
+				CoordType:   CoordTypeInterleaved,
+				Offsets:     [][]int32{{0, 2, 1}},
+				Interleaved: []float64{1, 2, 3, 4},
+				Length:      2,
+			},
+		},
+		{
+			name: "offsets_out_of_range",
+			a: &Array{
+				Type:        TypeLineString,
+				Layout:      geom.XY,
+				CoordType:   CoordTypeInterleaved,
+				Offsets:     [][]int32{{0, 3}},
+				Interleaved: []float64{1, 2, 3, 4},
+				Length:      1,
+			},
+		},
+		{
+			name: "short_coordinates",
+			a: &Array{
+				Type:        TypePoint,
+				Layout:      geom.XY,
+				CoordType:   CoordTypeInterleaved,
+				Offsets:     [][]int32{},
+				Interleaved: []float64{1, 2},
+				Length:      2,
+			},
+		},
+		{
+			name: "separated_lengths",
+			a: &Array{
+				Type:      TypePoint,
+				Layout:    geom.XY,
+				CoordType: CoordTypeSeparated,
+				Offsets:   [][]int32{},
+				Separated: [][]float64{{1, 2}, {3}},
+				Length:    1,
+			},
+		},
+		{
+			name: "short_validity",
+			a: &Array{
+				Type:        TypePoint,
+				Layout:      geom.XY,
+				CoordType:   CoordTypeInterleaved,
+				Offsets:     [][]int32{},
+				Interleaved: make([]float64, 18),
+				Validity:    []byte{0xff},
+				Length:      9,
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := tc.a.Geometries()
+			assert.Equal(t, ErrInvalidArray, err)
+		})
+	}
+}
+
+func TestExtensionMetadata(t *testing.T) {
+	for _, tc := range []struct {
+		srid     int
+		metadata string
+	}{
+		{srid: 0, metadata: "{}"},
+		{srid: 4326, metadata: `{"crs":"EPSG:4326","crs_type":"authority_code"}`},
+	} {
+		a := &Array{SRID: tc.srid}
+		assert.Equal(t, tc.metadata, a.ExtensionMetadata())
+		srid, err := ParseExtensionMetadata(tc.metadata)
+		assert.NoError(t, err)
+		assert.Equal(t, tc.srid, srid)
+	}
+
+	for _, metadata := range []string{
+		"",
+		`{"crs":"OGC:CRS84"}`,
+		`{"crs":{"type":"GeographicCRS"}}`,
+	} {
+		srid, err := ParseExtensionMetadata(metadata)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, srid)
+	}
+
+	_, err := ParseExtensionMetadata("{")
+	assert.Error(t, err)
+}
+
+// assertArrayEqual asserts that a is equal to expected, treating NaNs in the
+// coordinates as equal.
+func assertArrayEqual(t *testing.T, expected, a *Array) {
+	t.Helper()
+	assert.Equal(t, len(expected.Interleaved), len(a.Interleaved))
+	for i, x := range a.Interleaved {
+		assertFloatEqual(t, expected.Interleaved[i], x)
+	}
+	expectedCopy, aCopy := *expected, *a
+	expectedCopy.Interleaved, aCopy.Interleaved = nil, nil
+	assert.Equal(t, expectedCopy, aCopy)
+}
+
+// assertFloatEqual asserts that x is equal to expected, treating NaNs as
+// equal.
+func assertFloatEqual(t *testing.T, expected, x float64) {
+	t.Helper()
+	if math.IsNaN(expected) {
+		assert.True(t, math.IsNaN(x))
+	} else {
+		assert.Equal(t, expected, x)
+	}
+}
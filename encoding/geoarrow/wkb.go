@@ -0,0 +1,99 @@
+package geoarrow
+
+import (
+	"math"
+
+	"github.com/don4get/go-geom"
+	"github.com/don4get/go-geom/encoding/wkb"
+	"github.com/don4get/go-geom/encoding/wkbcommon"
+)
+
+// A WKBArray is a GeoArrow array in the WKB encoding, which is an Arrow binary
+// array of ISO WKB.
+type WKBArray struct {
+	// SRID is the SRID of all geometries.
+	SRID int
+	// Offsets is the offset buffer, with one more element than the number of
+	// geometries.
+	Offsets []int32
+	// Data is the data buffer.
+	Data []byte
+	// Validity is the validity bitmap, or nil if no geometries are null.
+	Validity []byte
+}
+
+// NewWKBArray returns a WKB array containing gs, which must have the same
+// SRID. Geometries are encoded as little endian ISO WKB.
+func NewWKBArray(gs []geom.T) (*WKBArray, error) {
+	a := &WKBArray{
+		Offsets: make([]int32, 1, len(gs)+1),
+	}
+	for _, g := range gs {
+		if g != nil {
+			a.SRID = g.GetSRID()
+			break
+		}
+	}
+	validity := make([]byte, (len(gs)+7)/8)
+	hasNulls := false
+	for i, g := range gs {
+		if g == nil {
+			hasNulls = true
+		} else {
+			if g.GetSRID() != a.SRID {
+				return nil, ErrSRIDMismatch{Got: g.GetSRID(), Want: a.SRID}
+			}
+			data, err := wkb.Marshal(g, wkb.NDR)
+			if err != nil {
+				return nil, err
+			}
+			if len(a.Data)+len(data) > math.MaxInt32 {
+				return nil, ErrInvalidArray
+			}
+			a.Data = append(a.Data, data...)
+			validity[i/8] |= 1 << (i % 8)
+		}
+		a.Offsets = append(a.Offsets, int32(len(a.Data)))
+	}
+	if hasNulls {
+		a.Validity = validity
+	}
+	return a, nil
+}
+
+// Len returns the number of geometries in a.
+func (a *WKBArray) Len() int {
+	return max(len(a.Offsets)-1, 0)
+}
+
+// Geometries returns the geometries in a. WKB in the extended dialect is also
+// accepted.
+func (a *WKBArray) Geometries(opts ...wkbcommon.WKBOption) ([]geom.T, error) {
+	n := a.Len()
+	if !isValidOffsets(a.Offsets, n) || int(a.Offsets[n]) > len(a.Data) || !isValidBitmap(a.Validity, n) {
+		return nil, ErrInvalidArray
+	}
+	opts = append([]wkbcommon.WKBOption{wkbcommon.WKBOptionDialect(wkbcommon.DialectAuto)}, opts...)
+	gs := make([]geom.T, n)
+	for i := range n {
+		if !isValid(a.Validity, i) {
+			continue
+		}
+		g, err := wkb.Unmarshal(a.Data[a.Offsets[i]:a.Offsets[i+1]], opts...)
+		if err != nil {
+			return nil, err
+		}
+		if a.SRID != 0 {
+			if g, err = geom.SetSRID(g, a.SRID); err != nil {
+				return nil, err
+			}
+		}
+		gs[i] = g
+	}
+	return gs, nil
+}
+
+// ExtensionMetadata returns the Arrow extension metadata of a.
+func (a *WKBArray) ExtensionMetadata() string {
+	return extensionMetadata(a.SRID)
+}
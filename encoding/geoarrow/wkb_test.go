@@ -0,0 +1,52 @@
+package geoarrow
+
+import (
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+
+	"github.com/don4get/go-geom"
+	"github.com/don4get/go-geom/encoding/wkb"
+)
+
+func TestWKBArray(t *testing.T) {
+	gs := []geom.T{
+		geom.NewPointFlat(geom.XY, []float64{1, 2}).SetSRID(4326),
+		nil,
+		geom.NewGeometryCollection().MustPush(
+			geom.NewLineStringFlat(geom.XYZ, []float64{1, 2, 3, 4, 5, 6}),
+		).SetSRID(4326),
+	}
+	a, err := NewWKBArray(gs)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, a.Len())
+	assert.Equal(t, 4326, a.SRID)
+	assert.Equal(t, []byte{0b101}, a.Validity)
+	assert.Equal(t, `{"crs":"EPSG:4326","crs_type":"authority_code"}`, a.ExtensionMetadata())
+
+	point, err := wkb.Marshal(gs[0], wkb.NDR)
+	assert.NoError(t, err)
+	assert.Equal(t, point, a.Data[a.Offsets[0]:a.Offsets[1]])
+	assert.Equal(t, a.Offsets[1], a.Offsets[2])
+
+	got, err := a.Geometries()
+	assert.NoError(t, err)
+	assert.Equal(t, gs, got)
+}
+
+func TestWKBArrayErrors(t *testing.T) {
+	_, err := NewWKBArray([]geom.T{
+		geom.NewPointFlat(geom.XY, []float64{1, 2}),
+		geom.NewPointFlat(geom.XY, []float64{1, 2}).SetSRID(3857),
+	})
+	assert.Equal[error](t, ErrSRIDMismatch{Got: 3857, Want: 0}, err)
+
+	for _, a := range []*WKBArray{
+		{Offsets: []int32{0, 4}, Data: []byte{1, 2}},
+		{Offsets: []int32{2, 1}, Data: []byte{1, 2}},
+		{Offsets: make([]int32, 10), Validity: []byte{0xff}},
+	} {
+		_, err := a.Geometries()
+		assert.Equal(t, ErrInvalidArray, err)
+	}
+}
@@ -111,8 +111,43 @@ func guessLayout3(coords3 [][][]geom.Coord) (geom.Layout, error) {
 	return guessLayout2(coords3[0])
 }
 
+// A DecodeGeometryOption is an option for decoding a GeoJSON geometry.
+type DecodeGeometryOption func(*decodeGeometryOptions)
+
+// decodeGeometryOptions are the options for decoding a GeoJSON geometry.
+type decodeGeometryOptions struct {
+	limits geom.Limits
+}
+
+// DecodeGeometryWithLimits sets the limits on the size of the input and of the
+// decoded geometry.
+func DecodeGeometryWithLimits(limits geom.Limits) DecodeGeometryOption {
+	return func(o *decodeGeometryOptions) {
+		o.limits = limits
+	}
+}
+
 // Decode decodes g to a geometry.
-func (g *Geometry) Decode() (geom.T, error) {
+func (g *Geometry) Decode(opts ...DecodeGeometryOption) (geom.T, error) {
+	var o decodeGeometryOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	t, err := g.decode(o.limits, 0)
+	if err != nil {
+		return nil, err
+	}
+	if t != nil {
+		if err := o.limits.Check(t); err != nil {
+			return nil, err
+		}
+	}
+	return t, nil
+}
+
+// decode decodes g, which is nested in depth GeometryCollections, to a
+// geometry.
+func (g *Geometry) decode(limits geom.Limits, depth int) (geom.T, error) {
 	if g == nil {
 		return nil, nil //nolint:nilnil
 	}
@@ -206,10 +241,16 @@ func (g *Geometry) Decode() (geom.T, error) {
 				return nil, err
 			}
 		}
+		if err := limits.CheckDepth(depth + 1); err != nil {
+			return nil, err
+		}
+		if err := limits.CheckParts(len(geometries)); err != nil {
+			return nil, err
+		}
 		geoms := make([]geom.T, len(geometries))
 		for i, subGeometry := range geometries {
 			var err error
-			geoms[i], err = subGeometry.Decode()
+			geoms[i], err = subGeometry.decode(limits, depth+1)
 			if err != nil {
 				return nil, err
 			}
@@ -492,7 +533,14 @@ func Marshal(g geom.T, opts ...EncodeGeometryOption) ([]byte, error) {
 }
 
 // Unmarshal unmarshalls a []byte to an arbitrary geometry.
-func Unmarshal(data []byte, g *geom.T) error {
+func Unmarshal(data []byte, g *geom.T, opts ...DecodeGeometryOption) error {
+	var o decodeGeometryOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if err := o.limits.CheckBytes(len(data)); err != nil {
+		return err
+	}
 	gg := &Geometry{}
 	if err := json.Unmarshal(data, &gg); err != nil {
 		return err
@@ -502,7 +550,7 @@ func Unmarshal(data []byte, g *geom.T) error {
 		return nil
 	}
 	var err error
-	*g, err = gg.Decode()
+	*g, err = gg.Decode(opts...)
 	return err
 }
 
@@ -568,8 +616,26 @@ func (f *Feature) MarshalJSON() ([]byte, error) {
 	return json.Marshal(&gf)
 }
 
+// UnmarshalFeature unmarshals data to f. The limits in opts apply to the size
+// of data and to the geometry of f.
+func UnmarshalFeature(data []byte, f *Feature, opts ...DecodeGeometryOption) error {
+	var o decodeGeometryOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if err := o.limits.CheckBytes(len(data)); err != nil {
+		return err
+	}
+	return f.unmarshal(data, opts)
+}
+
 // UnmarshalJSON implements json.Unmarshaler.UnmarshalJSON.
 func (f *Feature) UnmarshalJSON(data []byte) error {
+	return f.unmarshal(data, nil)
+}
+
+// unmarshal unmarshals data to f, decoding its geometry with opts.
+func (f *Feature) unmarshal(data []byte, opts []DecodeGeometryOption) error {
 	var gf geojsonFeature
 	if err := json.Unmarshal(data, &gf); err != nil {
 		return err
@@ -600,7 +666,7 @@ func (f *Feature) UnmarshalJSON(data []byte) error {
 	if err != nil {
 		return err
 	}
-	f.Geometry, err = gf.Geometry.Decode()
+	f.Geometry, err = gf.Geometry.Decode(opts...)
 	if err != nil {
 		return err
 	}
@@ -629,9 +695,32 @@ func (fc *FeatureCollection) MarshalJSON() ([]byte, error) {
 	return json.Marshal(gfc)
 }
 
+// UnmarshalFeatureCollection unmarshals data to fc. The limits in opts apply to
+// the size of data and separately to the geometry of each feature of fc.
+func UnmarshalFeatureCollection(data []byte, fc *FeatureCollection, opts ...DecodeGeometryOption) error {
+	var o decodeGeometryOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if err := o.limits.CheckBytes(len(data)); err != nil {
+		return err
+	}
+	return fc.unmarshal(data, opts)
+}
+
 // UnmarshalJSON implements json.Unmarshaler.UnmarshalJSON.
 func (fc *FeatureCollection) UnmarshalJSON(data []byte) error {
-	var gfc geojsonFeatureCollection
+	return fc.unmarshal(data, nil)
+}
+
+// unmarshal unmarshals data to fc, decoding the geometries of its features
+// with opts.
+func (fc *FeatureCollection) unmarshal(data []byte, opts []DecodeGeometryOption) error {
+	var gfc struct {
+		Type     string            `json:"type"`
+		BBox     []float64         `json:"bbox,omitempty"`
+		Features []json.RawMessage `json:"features"`
+	}
 	if err := json.Unmarshal(data, &gfc); err != nil {
 		return err
 	}
@@ -645,6 +734,20 @@ func (fc *FeatureCollection) UnmarshalJSON(data []byte) error {
 	if gfc.Type != "FeatureCollection" {
 		return ErrUnsupportedType(gfc.Type)
 	}
-	fc.Features = gfc.Features
+	var features []*Feature
+	if gfc.Features != nil {
+		features = make([]*Feature, 0, len(gfc.Features))
+	}
+	for _, data := range gfc.Features {
+		var f *Feature
+		if string(data) != "null" {
+			f = &Feature{}
+			if err := f.unmarshal(data, opts); err != nil {
+				return err
+			}
+		}
+		features = append(features, f)
+	}
+	fc.Features = features
 	return nil
 }
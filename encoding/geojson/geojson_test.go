@@ -433,3 +433,84 @@ func TestFeatureCollection(t *testing.T) {
 		})
 	}
 }
+
+func TestUnmarshalLimits(t *testing.T) {
+	data := []byte(`{"type":"GeometryCollection","geometries":[{"type":"GeometryCollection","geometries":[{"type":"Point","coordinates":[1,2]}]},{"type":"MultiLineString","coordinates":[[[1,2],[3,4]],[[5,6],[7,8]]]}]}`)
+	for _, tc := range []struct {
+		name     string
+		limits   geom.Limits
+		expected error
+	}{
+		{
+			name:   "within limits",
+			limits: geom.Limits{MaxBytes: len(data), MaxCoords: 5, MaxParts: 2, MaxDepth: 2},
+		},
+		{
+			name:     "bytes",
+			limits:   geom.Limits{MaxBytes: 16},
+			expected: geom.ErrLimitExceeded{Limit: geom.LimitBytes, N: len(data), Max: 16},
+		},
+		{
+			name:     "coordinates",
+			limits:   geom.Limits{MaxCoords: 4},
+			expected: geom.ErrLimitExceeded{Limit: geom.LimitCoords, N: 5, Max: 4},
+		},
+		{
+			name:     "parts",
+			limits:   geom.Limits{MaxParts: 1},
+			expected: geom.ErrLimitExceeded{Limit: geom.LimitParts, N: 2, Max: 1},
+		},
+		{
+			name:     "depth",
+			limits:   geom.Limits{MaxDepth: 1},
+			expected: geom.ErrLimitExceeded{Limit: geom.LimitDepth, N: 2, Max: 1},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var g geom.T
+			err := Unmarshal(data, &g, DecodeGeometryWithLimits(tc.limits))
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+}
+
+func TestUnmarshalFeatureLimits(t *testing.T) {
+	feature := `{"type":"Feature","geometry":{"type":"LineString","coordinates":[[1,2],[3,4],[5,6]]},"properties":null}`
+	data := []byte(`{"type":"FeatureCollection","features":[` + feature + `]}`)
+	for _, tc := range []struct {
+		name     string
+		limits   geom.Limits
+		expected error
+	}{
+		{
+			name:   "within limits",
+			limits: geom.Limits{MaxBytes: len(data), MaxCoords: 3},
+		},
+		{
+			name:     "bytes",
+			limits:   geom.Limits{MaxBytes: 16},
+			expected: geom.ErrLimitExceeded{Limit: geom.LimitBytes, N: len(data), Max: 16},
+		},
+		{
+			name:     "coordinates",
+			limits:   geom.Limits{MaxCoords: 2},
+			expected: geom.ErrLimitExceeded{Limit: geom.LimitCoords, N: 3, Max: 2},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var fc FeatureCollection
+			err := UnmarshalFeatureCollection(data, &fc, DecodeGeometryWithLimits(tc.limits))
+			assert.Equal(t, tc.expected, err)
+			if tc.expected == nil {
+				assert.Equal(t, 1, len(fc.Features))
+				assert.Equal[geom.T](t, geom.NewLineStringFlat(geom.XY, []float64{1, 2, 3, 4, 5, 6}), fc.Features[0].Geometry)
+			}
+		})
+	}
+
+	var f Feature
+	err := UnmarshalFeature([]byte(feature), &f, DecodeGeometryWithLimits(geom.Limits{MaxCoords: 2}))
+	assert.Equal[error](t, geom.ErrLimitExceeded{Limit: geom.LimitCoords, N: 3, Max: 2}, err)
+	assert.NoError(t, UnmarshalFeature([]byte(feature), &f, DecodeGeometryWithLimits(geom.Limits{MaxCoords: 3})))
+	assert.Equal[geom.T](t, geom.NewLineStringFlat(geom.XY, []float64{1, 2, 3, 4, 5, 6}), f.Geometry)
+}
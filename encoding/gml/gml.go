@@ -0,0 +1,569 @@
+// Package gml implements GML 3.2 encoding and decoding of Points, LineStrings,
+// Polygons, MultiPoints, MultiCurves, MultiSurfaces, and MultiGeometries.
+//
+// GML MultiCurves of LineStrings correspond to MultiLineStrings, GML
+// MultiSurfaces of Polygons to MultiPolygons, and GML MultiGeometries to
+// GeometryCollections. Coordinates are encoded with gml:pos and gml:posList,
+// in the order in which they are stored, regardless of the axis order of the
+// coordinate reference system, so srsNames are written in the legacy EPSG:%d
+// form by default, see DefaultSRSNameFormat. The srsName attribute corresponds
+// to the SRID and the srsDimension attribute to the layout, which must be XY or
+// XYZ. Members of a MultiGeometry whose layout differs from the layout of the
+// MultiGeometry have their own srsDimension attribute.
+package gml
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/don4get/go-geom"
+)
+
+// Namespace is the GML 3.2 namespace.
+const Namespace = "http://www.opengis.net/gml/3.2"
+
+// DefaultSRSNameFormat is the default format of srsName attributes. The legacy
+// EPSG:%d form is conventionally read with x/y axis order, i.e. longitude
+// before latitude for geographic coordinate reference systems, which matches
+// the order in which coordinates are encoded. The urn:ogc:def:crs:EPSG::%d and
+// http://www.opengis.net/def/crs/EPSG/0/%d forms imply the axis order of the
+// EPSG definition, which is latitude before longitude for EPSG:4326, so
+// coordinates must be swapped before encoding them with those forms.
+const DefaultSRSNameFormat = "EPSG:%d"
+
+// ErrInvalidCoordinates is returned when the number of values in a gml:pos or
+// gml:posList element is not a multiple of the dimension.
+var ErrInvalidCoordinates = errors.New("gml: invalid number of coordinates")
+
+// An ErrUnsupportedDimension is returned when an srsDimension is not supported.
+type ErrUnsupportedDimension int
+
+func (e ErrUnsupportedDimension) Error() string {
+	return fmt.Sprintf("gml: unsupported srsDimension %d", int(e))
+}
+
+// An ErrUnsupportedElement is returned when an element is not supported.
+type ErrUnsupportedElement string
+
+func (e ErrUnsupportedElement) Error() string {
+	return fmt.Sprintf("gml: unsupported element %q", string(e))
+}
+
+// encodeOptions are the options for encoding GML.
+type encodeOptions struct {
+	srsNameFormat string
+	id            string
+}
+
+// An EncodeOption is an encoder option.
+type EncodeOption func(*encodeOptions)
+
+// EncodeOptionWithSRSNameFormat sets the format of the srsName attribute of
+// geometries with a non-zero SRID, which is formatted with the SRID. The
+// default is DefaultSRSNameFormat.
+func EncodeOptionWithSRSNameFormat(format string) EncodeOption {
+	return func(o *encodeOptions) {
+		o.srsNameFormat = format
+	}
+}
+
+// EncodeOptionWithID sets the gml:id attribute of the encoded geometry.
+func EncodeOptionWithID(id string) EncodeOption {
+	return func(o *encodeOptions) {
+		o.id = id
+	}
+}
+
+// Marshal returns the GML encoding of g.
+func Marshal(g geom.T, opts ...EncodeOption) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	e := xml.NewEncoder(buf)
+	if err := EncodeElement(e, g, opts...); err != nil {
+		return nil, err
+	}
+	if err := e.Flush(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// EncodeElement writes the GML encoding of g to e, for example inside a
+// feature. It does not flush e.
+func EncodeElement(e *xml.Encoder, g geom.T, opts ...EncodeOption) error {
+	o := encodeOptions{
+		srsNameFormat: DefaultSRSNameFormat,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	var dim int
+	switch layout := g.GetLayout(); layout {
+	case geom.XY, geom.XYZ:
+		dim = layout.Stride()
+	case geom.NoLayout:
+		// Empty GeometryCollections have no layout.
+		if _, ok := g.(*geom.GeometryCollection); !ok {
+			return geom.ErrUnsupportedLayout(layout)
+		}
+		dim = 2
+	default:
+		return geom.ErrUnsupportedLayout(layout)
+	}
+	attrs := []xml.Attr{
+		{Name: xml.Name{Local: "xmlns:gml"}, Value: Namespace},
+	}
+	if o.id != "" {
+		attrs = append(attrs, xml.Attr{Name: xml.Name{Local: "gml:id"}, Value: o.id})
+	}
+	if srid := g.GetSRID(); srid != 0 {
+		attrs = append(attrs, xml.Attr{Name: xml.Name{Local: "srsName"}, Value: fmt.Sprintf(o.srsNameFormat, srid)})
+	}
+	attrs = append(attrs, srsDimensionAttr(dim))
+	return encodeGeometry(e, g, attrs, dim)
+}
+
+// srsDimensionAttr returns an srsDimension attribute with value dim.
+func srsDimensionAttr(dim int) xml.Attr {
+	return xml.Attr{Name: xml.Name{Local: "srsDimension"}, Value: strconv.Itoa(dim)}
+}
+
+// encodeGeometry writes g, whose dimension is dim, as an element with attrs.
+func encodeGeometry(e *xml.Encoder, g geom.T, attrs []xml.Attr, dim int) error {
+	switch g := g.(type) {
+	case *geom.Point:
+		return encodeElement(e, "Point", attrs, func() error {
+			if g.IsEmpty() {
+				return nil
+			}
+			return encodeText(e, "pos", g.GetFlatCoords())
+		})
+	case *geom.LineString:
+		return encodeElement(e, "LineString", attrs, func() error {
+			return encodeText(e, "posList", g.GetFlatCoords())
+		})
+	case *geom.Polygon:
+		return encodeElement(e, "Polygon", attrs, func() error {
+			return encodeRings(e, g.GetFlatCoords(), 0, g.GetEnds())
+		})
+	case *geom.MultiPoint:
+		return encodeElement(e, "MultiPoint", attrs, func() error {
+			for i := range g.NumPoints() {
+				if err := encodeMember(e, "pointMember", g.Point(i), nil, dim); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	case *geom.MultiLineString:
+		return encodeElement(e, "MultiCurve", attrs, func() error {
+			for i := range g.NumLineStrings() {
+				if err := encodeMember(e, "curveMember", g.LineString(i), nil, dim); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	case *geom.MultiPolygon:
+		return encodeElement(e, "MultiSurface", attrs, func() error {
+			for i := range g.NumPolygons() {
+				if err := encodeMember(e, "surfaceMember", g.Polygon(i), nil, dim); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	case *geom.GeometryCollection:
+		return encodeElement(e, "MultiGeometry", attrs, func() error {
+			// The members of a GeometryCollection may have different
+			// layouts, so members with a different dimension have their
+			// own srsDimension.
+			for _, g := range g.Geoms() {
+				var memberAttrs []xml.Attr
+				memberDim := dim
+				switch layout := g.GetLayout(); layout {
+				case geom.XY, geom.XYZ:
+					memberDim = layout.Stride()
+				case geom.NoLayout:
+				default:
+					return geom.ErrUnsupportedLayout(layout)
+				}
+				if memberDim != dim {
+					memberAttrs = []xml.Attr{srsDimensionAttr(memberDim)}
+				}
+				if err := encodeMember(e, "geometryMember", g, memberAttrs, memberDim); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	default:
+		return geom.ErrUnsupportedType{Value: g}
+	}
+}
+
+// encodeMember writes g, whose dimension is dim, with attrs in a member
+// element called name.
+func encodeMember(e *xml.Encoder, name string, g geom.T, attrs []xml.Attr, dim int) error {
+	return encodeElement(e, name, nil, func() error {
+		return encodeGeometry(e, g, attrs, dim)
+	})
+}
+
+// encodeRings writes the exterior and interior rings of a polygon.
+func encodeRings(e *xml.Encoder, flatCoords []float64, offset int, ends []int) error {
+	for i, end := range ends {
+		name := "interior"
+		if i == 0 {
+			name = "exterior"
+		}
+		if err := encodeElement(e, name, nil, func() error {
+			return encodeElement(e, "LinearRing", nil, func() error {
+				return encodeText(e, "posList", flatCoords[offset:end])
+			})
+		}); err != nil {
+			return err
+		}
+		offset = end
+	}
+	return nil
+}
+
+// encodeElement writes an element called name with attrs, whose content is
+// written by encodeContent.
+func encodeElement(e *xml.Encoder, name string, attrs []xml.Attr, encodeContent func() error) error {
+	start := xml.StartElement{Name: xml.Name{Local: "gml:" + name}, Attr: attrs}
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+	if err := encodeContent(); err != nil {
+		return err
+	}
+	return e.EncodeToken(start.End())
+}
+
+// encodeText writes an element called name containing values.
+func encodeText(e *xml.Encoder, name string, values []float64) error {
+	ss := make([]string, len(values))
+	for i, value := range values {
+		ss[i] = strconv.FormatFloat(value, 'f', -1, 64)
+	}
+	return encodeElement(e, name, nil, func() error {
+		return e.EncodeToken(xml.CharData(strings.Join(ss, " ")))
+	})
+}
+
+// Unmarshal decodes the GML geometry in data.
+func Unmarshal(data []byte) (geom.T, error) {
+	return DecodeElement(xml.NewDecoder(bytes.NewReader(data)), nil)
+}
+
+// DecodeElement decodes a GML geometry from d, for example inside a feature.
+// If start is not nil, it is the start element of the geometry, otherwise the
+// geometry is the next element read from d. The SRID is parsed from the
+// srsName attribute of the geometry if it is an EPSG code, and is otherwise
+// zero.
+func DecodeElement(d *xml.Decoder, start *xml.StartElement) (geom.T, error) {
+	var n node
+	if err := d.DecodeElement(&n, start); err != nil {
+		return nil, err
+	}
+	g, err := decodeGeometry(&n, 0)
+	if err != nil {
+		return nil, err
+	}
+	return geom.SetSRID(g, parseSRSName(n.attr("srsName")))
+}
+
+// A node is a generic XML element.
+type node struct {
+	XMLName xml.Name
+	Attrs   []xml.Attr `xml:",any,attr"`
+	Nodes   []node     `xml:",any"`
+	Text    string     `xml:",chardata"`
+}
+
+// attr returns the value of the attribute called name, or the empty string if
+// there is no such attribute.
+func (n *node) attr(name string) string {
+	for _, attr := range n.Attrs {
+		if attr.Name.Local == name {
+			return attr.Value
+		}
+	}
+	return ""
+}
+
+// isGML returns whether n is a GML element called name.
+func (n *node) isGML(name string) bool {
+	switch n.XMLName.Space {
+	case Namespace, "http://www.opengis.net/gml", "gml":
+		return n.XMLName.Local == name
+	default:
+		return false
+	}
+}
+
+// dimension returns the srsDimension of n, or dim if n has no srsDimension.
+func (n *node) dimension(dim int) (int, error) {
+	value := n.attr("srsDimension")
+	if value == "" {
+		return dim, nil
+	}
+	dim, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, err
+	}
+	if dim != 2 && dim != 3 {
+		return 0, ErrUnsupportedDimension(dim)
+	}
+	return dim, nil
+}
+
+// decodeGeometry decodes the geometry n, with the dimension dim inherited from
+// its ancestors, or zero if it is not known yet.
+func decodeGeometry(n *node, dim int) (geom.T, error) {
+	dim, err := n.dimension(dim)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case n.isGML("Point"):
+		flatCoords, dim, err := decodeFlatCoords(n, dim)
+		if err != nil {
+			return nil, err
+		}
+		switch len(flatCoords) {
+		case 0:
+			return geom.NewPointEmpty(layout(dim)), nil
+		case dim:
+			return geom.NewPointFlat(layout(dim), flatCoords), nil
+		default:
+			return nil, ErrInvalidCoordinates
+		}
+	case n.isGML("LineString"):
+		flatCoords, dim, err := decodeFlatCoords(n, dim)
+		if err != nil {
+			return nil, err
+		}
+		return geom.NewLineStringFlat(layout(dim), flatCoords), nil
+	case n.isGML("Polygon"):
+		return decodePolygon(n, dim)
+	case n.isGML("MultiPoint"):
+		var members []*geom.Point
+		if err := decodeMembers(n, dim, "pointMember", "pointMembers", &members); err != nil {
+			return nil, err
+		}
+		multiPoint := geom.NewMultiPoint(memberLayout(members, dim))
+		for _, point := range members {
+			if err := multiPoint.Push(point); err != nil {
+				return nil, err
+			}
+		}
+		return multiPoint, nil
+	case n.isGML("MultiCurve"), n.isGML("MultiLineString"):
+		var members []*geom.LineString
+		if err := decodeMembers(n, dim, "curveMember", "curveMembers", &members); err != nil {
+			return nil, err
+		}
+		if err := decodeMembers(n, dim, "lineStringMember", "", &members); err != nil {
+			return nil, err
+		}
+		multiLineString := geom.NewMultiLineString(memberLayout(members, dim))
+		for _, lineString := range members {
+			if err := multiLineString.Push(lineString); err != nil {
+				return nil, err
+			}
+		}
+		return multiLineString, nil
+	case n.isGML("MultiSurface"), n.isGML("MultiPolygon"):
+		var members []*geom.Polygon
+		if err := decodeMembers(n, dim, "surfaceMember", "surfaceMembers", &members); err != nil {
+			return nil, err
+		}
+		if err := decodeMembers(n, dim, "polygonMember", "", &members); err != nil {
+			return nil, err
+		}
+		multiPolygon := geom.NewMultiPolygon(memberLayout(members, dim))
+		for _, polygon := range members {
+			if err := multiPolygon.Push(polygon); err != nil {
+				return nil, err
+			}
+		}
+		return multiPolygon, nil
+	case n.isGML("MultiGeometry"):
+		var members []geom.T
+		if err := decodeMembers(n, dim, "geometryMember", "geometryMembers", &members); err != nil {
+			return nil, err
+		}
+		geometryCollection := geom.NewGeometryCollection()
+		if err := geometryCollection.Push(members...); err != nil {
+			return nil, err
+		}
+		if len(members) == 0 && dim == 0 {
+			return geometryCollection, nil
+		}
+		// Keep the layout of members with different layouts unset.
+		if layout := memberLayout(members, dim); geometryCollection.CheckLayout(layout) == nil {
+			if err := geometryCollection.SetLayout(layout); err != nil {
+				return nil, err
+			}
+		}
+		return geometryCollection, nil
+	default:
+		return nil, ErrUnsupportedElement(n.XMLName.Local)
+	}
+}
+
+// decodeMembers appends the geometries in the children of n called name, each
+// of which contains one geometry, and called namePlural, each of which
+// contains any number of geometries, to members.
+func decodeMembers[T geom.T](n *node, dim int, name, namePlural string, members *[]T) error {
+	for i := range n.Nodes {
+		child := &n.Nodes[i]
+		if !child.isGML(name) && (namePlural == "" || !child.isGML(namePlural)) {
+			continue
+		}
+		for j := range child.Nodes {
+			g, err := decodeGeometry(&child.Nodes[j], dim)
+			if err != nil {
+				return err
+			}
+			member, ok := g.(T)
+			if !ok {
+				return ErrUnsupportedElement(child.Nodes[j].XMLName.Local)
+			}
+			*members = append(*members, member)
+		}
+	}
+	return nil
+}
+
+// decodePolygon decodes the polygon n with dimension dim.
+func decodePolygon(n *node, dim int) (*geom.Polygon, error) {
+	var flatCoords []float64
+	var ends []int
+	for _, name := range []string{"exterior", "interior"} {
+		for i := range n.Nodes {
+			child := &n.Nodes[i]
+			if !child.isGML(name) {
+				continue
+			}
+			for j := range child.Nodes {
+				ring := &child.Nodes[j]
+				if !ring.isGML("LinearRing") {
+					return nil, ErrUnsupportedElement(ring.XMLName.Local)
+				}
+				ringFlatCoords, ringDim, err := decodeFlatCoords(ring, dim)
+				if err != nil {
+					return nil, err
+				}
+				if dim != 0 && ringDim != dim {
+					return nil, geom.ErrStrideMismatch{Got: ringDim, Want: dim}
+				}
+				dim = ringDim
+				flatCoords = append(flatCoords, ringFlatCoords...)
+				ends = append(ends, len(flatCoords))
+			}
+		}
+	}
+	return geom.NewPolygonFlat(layout(dim), flatCoords, ends), nil
+}
+
+// decodeFlatCoords decodes the coordinates in the gml:posList child or the
+// gml:pos children of n, and returns them with their dimension. If the
+// dimension dim is zero, then it is the number of values in a gml:pos, or two
+// for a gml:posList.
+func decodeFlatCoords(n *node, dim int) ([]float64, int, error) {
+	dim, err := n.dimension(dim)
+	if err != nil {
+		return nil, 0, err
+	}
+	var flatCoords []float64
+	for i := range n.Nodes {
+		child := &n.Nodes[i]
+		var values []float64
+		switch {
+		case child.isGML("pos"), child.isGML("posList"):
+			childDim, err := child.dimension(dim)
+			if err != nil {
+				return nil, 0, err
+			}
+			values, err = parseValues(child.Text)
+			if err != nil {
+				return nil, 0, err
+			}
+			switch {
+			case childDim != 0:
+			case child.isGML("pos"):
+				childDim = len(values)
+			default:
+				childDim = 2
+			}
+			if childDim != 2 && childDim != 3 {
+				return nil, 0, ErrUnsupportedDimension(childDim)
+			}
+			if len(values)%childDim != 0 || child.isGML("pos") && len(values) != childDim {
+				return nil, 0, ErrInvalidCoordinates
+			}
+			if dim != 0 && childDim != dim {
+				return nil, 0, geom.ErrStrideMismatch{Got: childDim, Want: dim}
+			}
+			dim = childDim
+		default:
+			return nil, 0, ErrUnsupportedElement(child.XMLName.Local)
+		}
+		flatCoords = append(flatCoords, values...)
+	}
+	return flatCoords, dim, nil
+}
+
+// parseValues parses the whitespace-separated values in s.
+func parseValues(s string) ([]float64, error) {
+	fields := strings.Fields(s)
+	values := make([]float64, len(fields))
+	for i, field := range fields {
+		value, err := strconv.ParseFloat(field, 64)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = value
+	}
+	return values, nil
+}
+
+// parseSRSName returns the EPSG code in srsName, or zero if there is none.
+// Names like EPSG:4326, urn:ogc:def:crs:EPSG::4326,
+// http://www.opengis.net/def/crs/EPSG/0/4326, and
+// http://www.opengis.net/gml/srs/epsg.xml#4326 are recognized.
+func parseSRSName(srsName string) int {
+	if !strings.Contains(strings.ToUpper(srsName), "EPSG") {
+		return 0
+	}
+	code := srsName[strings.LastIndexAny(srsName, ":/#")+1:]
+	srid, err := strconv.Atoi(code)
+	if err != nil || srid <= 0 {
+		return 0
+	}
+	return srid
+}
+
+// layout returns the layout for dimension dim, which is XY if dim is not known.
+func layout(dim int) geom.Layout {
+	if dim == 3 {
+		return geom.XYZ
+	}
+	return geom.XY
+}
+
+// memberLayout returns the layout of the first member, or the layout for
+// dimension dim if there are no members.
+func memberLayout[T geom.T](members []T, dim int) geom.Layout {
+	if len(members) == 0 {
+		return layout(dim)
+	}
+	return members[0].GetLayout()
+}
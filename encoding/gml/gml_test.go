@@ -0,0 +1,284 @@
+package gml
+
+import (
+	"encoding/xml"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+
+	"github.com/don4get/go-geom"
+)
+
+func TestMarshalAndUnmarshal(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		g    geom.T
+		opts []EncodeOption
+		s    string
+	}{
+		{
+			name: "point",
+			g:    geom.NewPointFlat(geom.XY, []float64{1, 2}),
+			s:    `<gml:Point xmlns:gml="http://www.opengis.net/gml/3.2" srsDimension="2"><gml:pos>1 2</gml:pos></gml:Point>`,
+		},
+		{
+			name: "point_srid_id",
+			g:    geom.NewPointFlat(geom.XYZ, []float64{1.5, -2, 3}).SetSRID(4326),
+			opts: []EncodeOption{EncodeOptionWithID("p1")},
+			s:    `<gml:Point xmlns:gml="http://www.opengis.net/gml/3.2" gml:id="p1" srsName="EPSG:4326" srsDimension="3"><gml:pos>1.5 -2 3</gml:pos></gml:Point>`,
+		},
+		{
+			name: "point_empty",
+			g:    geom.NewPointEmpty(geom.XY),
+			s:    `<gml:Point xmlns:gml="http://www.opengis.net/gml/3.2" srsDimension="2"></gml:Point>`,
+		},
+		{
+			name: "linestring",
+			g:    geom.NewLineStringFlat(geom.XY, []float64{1, 2, 3, 4}).SetSRID(3857),
+			opts: []EncodeOption{EncodeOptionWithSRSNameFormat("urn:ogc:def:crs:EPSG::%d")},
+			s:    `<gml:LineString xmlns:gml="http://www.opengis.net/gml/3.2" srsName="urn:ogc:def:crs:EPSG::3857" srsDimension="2"><gml:posList>1 2 3 4</gml:posList></gml:LineString>`,
+		},
+		{
+			name: "polygon",
+			g:    geom.NewPolygonFlat(geom.XY, []float64{0, 0, 4, 0, 4, 4, 0, 0, 1, 1, 2, 1, 2, 2, 1, 1}, []int{8, 16}),
+			s: `<gml:Polygon xmlns:gml="http://www.opengis.net/gml/3.2" srsDimension="2">` +
+				`<gml:exterior><gml:LinearRing><gml:posList>0 0 4 0 4 4 0 0</gml:posList></gml:LinearRing></gml:exterior>` +
+				`<gml:interior><gml:LinearRing><gml:posList>1 1 2 1 2 2 1 1</gml:posList></gml:LinearRing></gml:interior>` +
+				`</gml:Polygon>`,
+		},
+		{
+			name: "multipoint",
+			g:    geom.NewMultiPointFlat(geom.XYZ, []float64{1, 2, 3, 4, 5, 6}),
+			s: `<gml:MultiPoint xmlns:gml="http://www.opengis.net/gml/3.2" srsDimension="3">` +
+				`<gml:pointMember><gml:Point><gml:pos>1 2 3</gml:pos></gml:Point></gml:pointMember>` +
+				`<gml:pointMember><gml:Point><gml:pos>4 5 6</gml:pos></gml:Point></gml:pointMember>` +
+				`</gml:MultiPoint>`,
+		},
+		{
+			name: "multicurve",
+			g:    geom.NewMultiLineStringFlat(geom.XY, []float64{1, 2, 3, 4, 5, 6, 7, 8}, []int{4, 8}),
+			s: `<gml:MultiCurve xmlns:gml="http://www.opengis.net/gml/3.2" srsDimension="2">` +
+				`<gml:curveMember><gml:LineString><gml:posList>1 2 3 4</gml:posList></gml:LineString></gml:curveMember>` +
+				`<gml:curveMember><gml:LineString><gml:posList>5 6 7 8</gml:posList></gml:LineString></gml:curveMember>` +
+				`</gml:MultiCurve>`,
+		},
+		{
+			name: "multisurface",
+			g:    geom.NewMultiPolygonFlat(geom.XY, []float64{0, 0, 1, 0, 1, 1, 0, 0}, [][]int{{8}}),
+			s: `<gml:MultiSurface xmlns:gml="http://www.opengis.net/gml/3.2" srsDimension="2">` +
+				`<gml:surfaceMember><gml:Polygon><gml:exterior><gml:LinearRing><gml:posList>0 0 1 0 1 1 0 0</gml:posList></gml:LinearRing></gml:exterior></gml:Polygon></gml:surfaceMember>` +
+				`</gml:MultiSurface>`,
+		},
+		{
+			name: "multigeometry",
+			g: geom.NewGeometryCollection().MustPush(
+				geom.NewPointFlat(geom.XY, []float64{1, 2}),
+				geom.NewLineStringFlat(geom.XY, []float64{3, 4, 5, 6}),
+			).MustSetLayout(geom.XY),
+			s: `<gml:MultiGeometry xmlns:gml="http://www.opengis.net/gml/3.2" srsDimension="2">` +
+				`<gml:geometryMember><gml:Point><gml:pos>1 2</gml:pos></gml:Point></gml:geometryMember>` +
+				`<gml:geometryMember><gml:LineString><gml:posList>3 4 5 6</gml:posList></gml:LineString></gml:geometryMember>` +
+				`</gml:MultiGeometry>`,
+		},
+		{
+			name: "multigeometry_mixed_layouts",
+			g: geom.NewGeometryCollection().MustPush(
+				geom.NewPointFlat(geom.XYZ, []float64{1, 2, 3}),
+				geom.NewLineStringFlat(geom.XY, []float64{0, 0, 1, 1, 2, 2}),
+			),
+			s: `<gml:MultiGeometry xmlns:gml="http://www.opengis.net/gml/3.2" srsDimension="3">` +
+				`<gml:geometryMember><gml:Point><gml:pos>1 2 3</gml:pos></gml:Point></gml:geometryMember>` +
+				`<gml:geometryMember><gml:LineString srsDimension="2"><gml:posList>0 0 1 1 2 2</gml:posList></gml:LineString></gml:geometryMember>` +
+				`</gml:MultiGeometry>`,
+		},
+		{
+			name: "multigeometry_empty",
+			g:    geom.NewGeometryCollection().MustSetLayout(geom.XY),
+			s:    `<gml:MultiGeometry xmlns:gml="http://www.opengis.net/gml/3.2" srsDimension="2"></gml:MultiGeometry>`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			data, err := Marshal(tc.g, tc.opts...)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.s, string(data))
+			g, err := Unmarshal(data)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.g, g)
+		})
+	}
+}
+
+func TestUnmarshal(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		s        string
+		expected geom.T
+	}{
+		{
+			name:     "inferred_dimension",
+			s:        `<gml:Point xmlns:gml="http://www.opengis.net/gml/3.2"><gml:pos>1 2 3</gml:pos></gml:Point>`,
+			expected: geom.NewPointFlat(geom.XYZ, []float64{1, 2, 3}),
+		},
+		{
+			name:     "poslist_dimension",
+			s:        `<gml:LineString xmlns:gml="http://www.opengis.net/gml/3.2"><gml:posList srsDimension="3">1 2 3 4 5 6</gml:posList></gml:LineString>`,
+			expected: geom.NewLineStringFlat(geom.XYZ, []float64{1, 2, 3, 4, 5, 6}),
+		},
+		{
+			name: "pos_sequence",
+			s: `<LineString xmlns="http://www.opengis.net/gml/3.2" srsName="http://www.opengis.net/def/crs/EPSG/0/4326">
+				<pos>1 2</pos>
+				<pos>3 4</pos>
+			</LineString>`,
+			expected: geom.NewLineStringFlat(geom.XY, []float64{1, 2, 3, 4}).SetSRID(4326),
+		},
+		{
+			name:     "srs_name_epsg_xml",
+			s:        `<gml:Point xmlns:gml="http://www.opengis.net/gml" srsName="http://www.opengis.net/gml/srs/epsg.xml#27700"><gml:pos>1 2</gml:pos></gml:Point>`,
+			expected: geom.NewPointFlat(geom.XY, []float64{1, 2}).SetSRID(27700),
+		},
+		{
+			name:     "srs_name_crs84",
+			s:        `<gml:Point xmlns:gml="http://www.opengis.net/gml/3.2" srsName="urn:ogc:def:crs:OGC:1.3:CRS84"><gml:pos>1 2</gml:pos></gml:Point>`,
+			expected: geom.NewPointFlat(geom.XY, []float64{1, 2}),
+		},
+		{
+			name: "members",
+			s: `<gml:MultiPoint xmlns:gml="http://www.opengis.net/gml/3.2" srsDimension="3">
+				<gml:pointMembers>
+					<gml:Point><gml:pos>1 2 3</gml:pos></gml:Point>
+					<gml:Point><gml:pos>4 5 6</gml:pos></gml:Point>
+				</gml:pointMembers>
+			</gml:MultiPoint>`,
+			expected: geom.NewMultiPointFlat(geom.XYZ, []float64{1, 2, 3, 4, 5, 6}),
+		},
+		{
+			name: "gml3_1_multilinestring",
+			s: `<gml:MultiLineString xmlns:gml="http://www.opengis.net/gml">
+				<gml:lineStringMember><gml:LineString><gml:posList>1 2 3 4</gml:posList></gml:LineString></gml:lineStringMember>
+			</gml:MultiLineString>`,
+			expected: geom.NewMultiLineStringFlat(geom.XY, []float64{1, 2, 3, 4}, []int{4}),
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			g, err := Unmarshal([]byte(tc.s))
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, g)
+		})
+	}
+}
+
+func TestUnmarshalErrors(t *testing.T) {
+	for i, tc := range []struct {
+		s        string
+		expected error
+	}{
+		{
+			s:        `<gml:Curve xmlns:gml="http://www.opengis.net/gml/3.2"/>`,
+			expected: ErrUnsupportedElement("Curve"),
+		},
+		{
+			s:        `<Point><pos>1 2</pos></Point>`,
+			expected: ErrUnsupportedElement("Point"),
+		},
+		{
+			s:        `<gml:Point xmlns:gml="http://www.opengis.net/gml/3.2" srsDimension="4"><gml:pos>1 2 3 4</gml:pos></gml:Point>`,
+			expected: ErrUnsupportedDimension(4),
+		},
+		{
+			s:        `<gml:Point xmlns:gml="http://www.opengis.net/gml/3.2" srsDimension="2"><gml:pos>1 2 3</gml:pos></gml:Point>`,
+			expected: ErrInvalidCoordinates,
+		},
+		{
+			s:        `<gml:LineString xmlns:gml="http://www.opengis.net/gml/3.2"><gml:posList>1 2 3</gml:posList></gml:LineString>`,
+			expected: ErrInvalidCoordinates,
+		},
+		{
+			s:        `<gml:LineString xmlns:gml="http://www.opengis.net/gml/3.2"><gml:pos>1 2</gml:pos><gml:pos srsDimension="3">1 2 3</gml:pos></gml:LineString>`,
+			expected: geom.ErrStrideMismatch{Got: 3, Want: 2},
+		},
+		{
+			s:        `<gml:MultiCurve xmlns:gml="http://www.opengis.net/gml/3.2"><gml:curveMember><gml:Point><gml:pos>1 2</gml:pos></gml:Point></gml:curveMember></gml:MultiCurve>`,
+			expected: ErrUnsupportedElement("Point"),
+		},
+		{
+			s: `<gml:MultiPoint xmlns:gml="http://www.opengis.net/gml/3.2">` +
+				`<gml:pointMember><gml:Point><gml:pos>1 2</gml:pos></gml:Point></gml:pointMember>` +
+				`<gml:pointMember><gml:Point><gml:pos>1 2 3</gml:pos></gml:Point></gml:pointMember>` +
+				`</gml:MultiPoint>`,
+			expected: geom.ErrLayoutMismatch{Got: geom.XYZ, Want: geom.XY},
+		},
+	} {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			_, err := Unmarshal([]byte(tc.s))
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+}
+
+func TestMarshalErrors(t *testing.T) {
+	for i, tc := range []struct {
+		g        geom.T
+		expected error
+	}{
+		{
+			g:        geom.NewPointFlat(geom.XYM, []float64{1, 2, 3}),
+			expected: geom.ErrUnsupportedLayout(geom.XYM),
+		},
+		{
+			g:        geom.NewLinearRingFlat(geom.XY, []float64{0, 0, 1, 0, 1, 1, 0, 0}),
+			expected: geom.ErrUnsupportedType{Value: geom.NewLinearRingFlat(geom.XY, []float64{0, 0, 1, 0, 1, 1, 0, 0})},
+		},
+	} {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			_, err := Marshal(tc.g)
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+}
+
+func TestDecodeElement(t *testing.T) {
+	type feature struct {
+		Name     string
+		Geometry geom.T
+	}
+	s := `<wfs:FeatureCollection xmlns:wfs="http://www.opengis.net/wfs/2.0" xmlns:gml="http://www.opengis.net/gml/3.2" xmlns:app="http://example.com/app">
+		<wfs:member>
+			<app:place>
+				<app:name>a</app:name>
+				<app:geometry><gml:Point srsName="EPSG:4326"><gml:pos>1 2</gml:pos></gml:Point></app:geometry>
+			</app:place>
+		</wfs:member>
+	</wfs:FeatureCollection>`
+	d := xml.NewDecoder(strings.NewReader(s))
+	var f feature
+	inGeometry := false
+	for {
+		token, err := d.Token()
+		if err != nil {
+			break
+		}
+		switch token := token.(type) {
+		case xml.StartElement:
+			switch {
+			case token.Name.Local == "name":
+				assert.NoError(t, d.DecodeElement(&f.Name, &token))
+			case token.Name.Local == "geometry":
+				inGeometry = true
+			case inGeometry:
+				f.Geometry, err = DecodeElement(d, &token)
+				assert.NoError(t, err)
+			}
+		case xml.EndElement:
+			if token.Name.Local == "geometry" {
+				inGeometry = false
+			}
+		}
+	}
+	assert.Equal(t, feature{
+		Name:     "a",
+		Geometry: geom.NewPointFlat(geom.XY, []float64{1, 2}).SetSRID(4326),
+	}, f)
+}
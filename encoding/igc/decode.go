@@ -47,6 +47,12 @@ func (es Errors) Error() string {
 	return strings.Join(ss, "\n")
 }
 
+// Unwrap returns the errors in es, so that they can be inspected with
+// errors.Is and errors.As.
+func (es Errors) Unwrap() []error {
+	return es
+}
+
 // parseDec parses a decimal value in s[start:stop].
 func parseDec(s string, start, stop int) (int, error) {
 	result := 0
@@ -92,11 +98,27 @@ type parser struct {
 	lodStart, lodStop int
 	tdsStart, tdsStop int
 	bRecordLen        int
+	limits            geom.Limits
+}
+
+// A ReadOption sets an option on Read.
+type ReadOption func(*parser)
+
+// ReadWithLimits sets the limits on the size of the input and on the number
+// of coordinates. Reading stops when a limit is exceeded.
+func ReadWithLimits(limits geom.Limits) ReadOption {
+	return func(p *parser) {
+		p.limits = limits
+	}
 }
 
 // newParser creates a new parser.
-func newParser() *parser {
-	return &parser{bRecordLen: 35}
+func newParser(options ...ReadOption) *parser {
+	p := &parser{bRecordLen: 35}
+	for _, o := range options {
+		o(p)
+	}
+	return p
 }
 
 // parseB parses a B record from line and updates the state of p.
@@ -194,6 +216,9 @@ func (p *parser) parseB(line string) error {
 		return err
 	}
 
+	if err := p.limits.CheckCoords(len(p.coords)/5 + 1); err != nil {
+		return err
+	}
 	p.coords = append(p.coords, lng, lat, float64(ellipsoidAlt), float64(date.UnixNano())/1e9, float64(pressureAlt))
 	p.lastDate = date
 
@@ -295,19 +320,34 @@ func (p *parser) parseLine(line string) error {
 }
 
 // doParse reads r, parsers all the records it finds, updating the state of p.
-func doParse(r io.Reader) (*parser, Errors) {
-	var errors Errors
-	p := newParser()
+func doParse(r io.Reader, options ...ReadOption) (*parser, Errors) {
+	var errs Errors
+	p := newParser(options...)
 	s := bufio.NewScanner(r)
+	// Count the bytes read, including line endings, to enforce the limits.
+	n := 0
+	s.Split(func(data []byte, atEOF bool) (int, []byte, error) {
+		advance, token, err := bufio.ScanLines(data, atEOF)
+		n += advance
+		return advance, token, err
+	})
 	foundA := false
 	leadingNoise := false
+LINES:
 	for lineno := 1; s.Scan(); lineno++ {
+		if err := p.limits.CheckBytes(n); err != nil {
+			errs = append(errs, err)
+			break
+		}
 		line := strings.TrimSuffix(s.Text(), "\r")
 		switch {
 		case len(line) == 0:
 		case foundA:
 			if err := p.parseLine(line); err != nil {
-				errors = append(errors, fmt.Errorf("line %d: %q: %w", lineno, line, err))
+				errs = append(errs, fmt.Errorf("line %d: %q: %w", lineno, line, err))
+				if errors.As(err, &geom.ErrLimitExceeded{}) {
+					break LINES
+				}
 			}
 		default:
 			if c := line[0]; c == 'A' {
@@ -331,11 +371,11 @@ func doParse(r io.Reader) (*parser, Errors) {
 		}
 	}
 	if !foundA {
-		errors = append(Errors{errMissingARecord}, errors...)
+		errs = append(Errors{errMissingARecord}, errs...)
 	} else if leadingNoise {
-		errors = append(Errors{errInvalidCharactersBeforeARecord}, errors...)
+		errs = append(Errors{errInvalidCharactersBeforeARecord}, errs...)
 	}
-	return p, errors
+	return p, errs
 }
 
 // Read reads a igc.T from r, which should contain IGC records.
@@ -345,8 +385,8 @@ func doParse(r io.Reader) (*parser, Errors) {
 // tolerant of what it accepts and ignores several common errors. Consequently,
 // the returned T might still contain headers and coordinates, even if the
 // returned error is non-nil.
-func Read(r io.Reader) (*T, error) {
-	p, errors := doParse(r)
+func Read(r io.Reader, options ...ReadOption) (*T, error) {
+	p, errors := doParse(r, options...)
 	var err error = errors
 	if len(errors) == 0 {
 		err = nil
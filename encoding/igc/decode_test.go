@@ -2,6 +2,7 @@ package igc
 
 import (
 	"bytes"
+	"errors"
 	"testing"
 
 	"github.com/alecthomas/assert/v2"
@@ -152,3 +153,22 @@ func TestDecodeHeaders(t *testing.T) {
 		})
 	}
 }
+
+func TestReadWithLimits(t *testing.T) {
+	s := "AXCC64BCompCheck-3.2\r\n" +
+		"HFDTE100810\r\n" +
+		"B1146174031985N00726775WA0100401149\r\n" +
+		"B1146184031985N00726775WA0100401149\r\n" +
+		"B1146194031985N00726775WA0100401149\r\n"
+
+	got, err := Read(bytes.NewBufferString(s), ReadWithLimits(geom.Limits{MaxCoords: 2}))
+	assert.True(t, errors.As(err, &geom.ErrLimitExceeded{}))
+	assert.Equal(t, 2, got.LineString.NumCoords())
+
+	_, err = Read(bytes.NewBufferString(s), ReadWithLimits(geom.Limits{MaxBytes: 32}))
+	assert.Equal[error](t, Errors{geom.ErrLimitExceeded{Limit: geom.LimitBytes, N: 35, Max: 32}}, err)
+
+	got, err = Read(bytes.NewBufferString(s), ReadWithLimits(geom.Limits{MaxBytes: len(s), MaxCoords: 3}))
+	assert.NoError(t, err)
+	assert.Equal(t, 3, got.LineString.NumCoords())
+}
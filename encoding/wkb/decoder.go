@@ -0,0 +1,366 @@
+package wkb
+
+import (
+	"encoding/binary"
+	"io"
+	"math"
+	"slices"
+
+	"github.com/don4get/go-geom"
+	"github.com/don4get/go-geom/encoding/wkbcommon"
+)
+
+// A Decoder decodes geometries from WKB byte slices. Unlike Unmarshal, it
+// decodes directly from the slice, and it stores the flat coordinates of the
+// geometries that it decodes in a single buffer that is reused by every call
+// to Decode. This avoids most allocations when decoding many geometries, for
+// example when loading rows from a database.
+type Decoder struct {
+	params     wkbcommon.WKBParams
+	counter    wkbcommon.Counter
+	flatCoords []float64
+	data       []byte
+	offset     int
+}
+
+// NewDecoder returns a new Decoder that stores flat coordinates in
+// flatCoords, which is grown as needed and may be nil.
+func NewDecoder(flatCoords []float64, opts ...wkbcommon.WKBOption) *Decoder {
+	return &Decoder{
+		params: wkbcommon.InitWKBParams(
+			wkbcommon.WKBParams{
+				EmptyPointHandling: wkbcommon.EmptyPointHandlingError,
+			},
+			opts...,
+		),
+		flatCoords: flatCoords[:0],
+	}
+}
+
+// Decode decodes a geometry from data. The flat coordinates of the returned
+// geometry share the Decoder's buffer, so the geometry is only valid until the
+// next call to Decode. Clone it to keep it for longer.
+func (d *Decoder) Decode(data []byte) (geom.T, error) {
+	if err := d.params.Limits.CheckBytes(len(data)); err != nil {
+		return nil, err
+	}
+	d.counter = wkbcommon.Counter{Limits: d.params.Limits}
+	d.data, d.offset = data, 0
+	d.flatCoords = d.flatCoords[:0]
+	g, err := d.decode()
+	d.data = nil
+	return g, err
+}
+
+// decode decodes an arbitrary geometry.
+func (d *Decoder) decode() (geom.T, error) {
+	byteOrder, t, layout, srid, err := d.decodeHeader()
+	if err != nil {
+		return nil, err
+	}
+	g, err := d.decodeBody(byteOrder, t, layout)
+	if err != nil || srid == 0 {
+		return g, err
+	}
+	return geom.SetSRID(g, int(srid))
+}
+
+// decodeHeader decodes the byte order, the type code, and the optional SRID of
+// a geometry.
+func (d *Decoder) decodeHeader() (binary.ByteOrder, wkbcommon.Type, geom.Layout, uint32, error) {
+	if d.offset >= len(d.data) {
+		return nil, 0, geom.NoLayout, 0, io.ErrUnexpectedEOF
+	}
+	var byteOrder binary.ByteOrder
+	switch wkbByteOrder := d.data[d.offset]; wkbByteOrder {
+	case wkbcommon.XDRID:
+		byteOrder = XDR
+	case wkbcommon.NDRID:
+		byteOrder = NDR
+	default:
+		return nil, 0, geom.NoLayout, 0, wkbcommon.ErrUnknownByteOrder(wkbByteOrder)
+	}
+	d.offset++
+	wkbGeometryType, err := d.decodeUInt32(byteOrder)
+	if err != nil {
+		return nil, 0, geom.NoLayout, 0, err
+	}
+	t, layout, hasSRID, err := wkbcommon.ParseType(wkbGeometryType, d.params.Dialect)
+	if err != nil {
+		return nil, 0, geom.NoLayout, 0, err
+	}
+	var srid uint32
+	if hasSRID {
+		if srid, err = d.decodeUInt32(byteOrder); err != nil {
+			return nil, 0, geom.NoLayout, 0, err
+		}
+	}
+	return byteOrder, t, layout, srid, nil
+}
+
+// decodeBody decodes the rest of a geometry with type t and the given layout.
+func (d *Decoder) decodeBody(byteOrder binary.ByteOrder, t wkbcommon.Type, layout geom.Layout) (geom.T, error) {
+	stride := layout.Stride()
+	start := len(d.flatCoords)
+	switch t {
+	case wkbcommon.PointID:
+		if err := d.decodeCoords(byteOrder, 1, stride); err != nil {
+			return nil, err
+		}
+		if d.params.EmptyPointHandling == wkbcommon.EmptyPointHandlingNaN {
+			return geom.NewPointFlatMaybeEmpty(layout, d.flat(start)), nil
+		}
+		return geom.NewPointFlat(layout, d.flat(start)), nil
+	case wkbcommon.LineStringID, wkbcommon.CircularStringID:
+		if err := d.decodeLine(byteOrder, stride); err != nil {
+			return nil, err
+		}
+		if t == wkbcommon.CircularStringID {
+			return geom.NewCircularStringFlat(layout, d.flat(start)), nil
+		}
+		return geom.NewLineStringFlat(layout, d.flat(start)), nil
+	case wkbcommon.PolygonID, wkbcommon.TriangleID:
+		ends, err := d.decodeRings(byteOrder, stride, start)
+		if err != nil {
+			return nil, err
+		}
+		if t == wkbcommon.TriangleID {
+			if err := wkbcommon.CheckTriangle(layout, d.flatCoords[start:], 0, ends); err != nil {
+				return nil, err
+			}
+			return geom.NewTriangleFlat(layout, d.flat(start), ends), nil
+		}
+		return geom.NewPolygonFlat(layout, d.flat(start), ends), nil
+	case wkbcommon.MultiPointID:
+		n, err := d.decodeParts(byteOrder, 1, 5)
+		if err != nil {
+			return nil, err
+		}
+		ends := make([]int, 0, n)
+		for range n {
+			memberByteOrder, err := d.decodeMember(wkbcommon.PointID, &geom.Point{}, layout)
+			if err != nil {
+				return nil, err
+			}
+			pointStart := len(d.flatCoords)
+			if err := d.decodeCoords(memberByteOrder, 1, stride); err != nil {
+				return nil, err
+			}
+			if d.params.EmptyPointHandling == wkbcommon.EmptyPointHandlingNaN && wkbcommon.IsEmptyPointCoord(d.flatCoords[pointStart:]) {
+				d.flatCoords = d.flatCoords[:pointStart]
+			}
+			ends = append(ends, len(d.flatCoords)-start)
+		}
+		return geom.NewMultiPointFlat(layout, d.flat(start), geom.NewMultiPointFlatOptionWithEnds(ends)), nil
+	case wkbcommon.MultiLineStringID:
+		n, err := d.decodeParts(byteOrder, 2, 5)
+		if err != nil {
+			return nil, err
+		}
+		ends := make([]int, 0, n)
+		for range n {
+			memberByteOrder, err := d.decodeMember(wkbcommon.LineStringID, &geom.LineString{}, layout)
+			if err != nil {
+				return nil, err
+			}
+			if err := d.decodeLine(memberByteOrder, stride); err != nil {
+				return nil, err
+			}
+			ends = append(ends, len(d.flatCoords)-start)
+		}
+		return geom.NewMultiLineStringFlat(layout, d.flat(start), ends), nil
+	case wkbcommon.MultiPolygonID, wkbcommon.PolyhedralSurfaceID, wkbcommon.TINID:
+		n, err := d.decodeParts(byteOrder, 3, 5)
+		if err != nil {
+			return nil, err
+		}
+		memberType, member := wkbcommon.Type(wkbcommon.PolygonID), geom.T(&geom.Polygon{})
+		if t == wkbcommon.TINID {
+			memberType, member = wkbcommon.TriangleID, &geom.Triangle{}
+		}
+		endss := make([][]int, 0, n)
+		for range n {
+			memberByteOrder, err := d.decodeMember(memberType, member, layout)
+			if err != nil {
+				return nil, err
+			}
+			offset := len(d.flatCoords) - start
+			ends, err := d.decodeRings(memberByteOrder, stride, start)
+			if err != nil {
+				return nil, err
+			}
+			if t == wkbcommon.TINID {
+				if err := wkbcommon.CheckTriangle(layout, d.flatCoords[start:], offset, ends); err != nil {
+					return nil, err
+				}
+			}
+			endss = append(endss, ends)
+		}
+		switch t {
+		case wkbcommon.PolyhedralSurfaceID:
+			return geom.NewPolyhedralSurfaceFlat(layout, d.flat(start), endss), nil
+		case wkbcommon.TINID:
+			return geom.NewTINFlat(layout, d.flat(start), endss), nil
+		default:
+			return geom.NewMultiPolygonFlat(layout, d.flat(start), endss), nil
+		}
+	case wkbcommon.GeometryCollectionID:
+		n, err := d.decodeParts(byteOrder, 0, 5)
+		if err != nil {
+			return nil, err
+		}
+		if err := d.counter.Enter(); err != nil {
+			return nil, err
+		}
+		defer d.counter.Leave()
+		gc := geom.NewGeometryCollection()
+		for range n {
+			g, err := d.decode()
+			if err != nil {
+				return nil, err
+			}
+			if err := gc.Push(g); err != nil {
+				return nil, err
+			}
+		}
+		// If EMPTY, mark the collection with a fixed layout to differentiate
+		// GEOMETRYCOLLECTION EMPTY between 2D/Z/M/ZM.
+		if gc.IsEmpty() && gc.NumGeoms() == 0 {
+			if err := gc.SetLayout(layout); err != nil {
+				return nil, err
+			}
+		}
+		return gc, nil
+	case wkbcommon.CompoundCurveID, wkbcommon.CurvePolygonID, wkbcommon.MultiCurveID, wkbcommon.MultiSurfaceID:
+		n, err := d.decodeParts(byteOrder, 2, 5)
+		if err != nil {
+			return nil, err
+		}
+		components := make([]geom.T, 0, n)
+		for range n {
+			g, err := d.decode()
+			if err != nil {
+				return nil, err
+			}
+			components = append(components, g)
+		}
+		return wkbcommon.NewComposite(t, layout, components)
+	default:
+		return nil, wkbcommon.ErrUnsupportedType(t)
+	}
+}
+
+// decodeMember decodes the header of a member of a multi-geometry, which must
+// have type t and the given layout, and returns its byte order. If the member
+// has a different type then it is decoded to report it in the error.
+func (d *Decoder) decodeMember(t wkbcommon.Type, want geom.T, layout geom.Layout) (binary.ByteOrder, error) {
+	offset := d.offset
+	byteOrder, memberType, memberLayout, _, err := d.decodeHeader()
+	if err != nil {
+		return nil, err
+	}
+	if memberType != t {
+		d.offset = offset
+		g, err := d.decode()
+		if err != nil {
+			return nil, err
+		}
+		return nil, wkbcommon.ErrUnexpectedType{Got: g, Want: want}
+	}
+	if memberLayout != layout {
+		return nil, geom.ErrLayoutMismatch{Got: memberLayout, Want: layout}
+	}
+	return byteOrder, nil
+}
+
+// decodeRings decodes a number of rings and returns their ends, relative to
+// start.
+func (d *Decoder) decodeRings(byteOrder binary.ByteOrder, stride, start int) ([]int, error) {
+	n, err := d.decodeParts(byteOrder, 2, 4)
+	if err != nil {
+		return nil, err
+	}
+	ends := make([]int, n)
+	for i := range n {
+		if err := d.decodeLine(byteOrder, stride); err != nil {
+			return nil, err
+		}
+		ends[i] = len(d.flatCoords) - start
+	}
+	return ends, nil
+}
+
+// decodeLine decodes a number of coordinates.
+func (d *Decoder) decodeLine(byteOrder binary.ByteOrder, stride int) error {
+	n, err := d.decodeCount(byteOrder, 1, 8*stride)
+	if err != nil {
+		return err
+	}
+	return d.decodeCoords(byteOrder, n, stride)
+}
+
+// decodeParts is like decodeCount, but also checks the number of parts against
+// the limits.
+func (d *Decoder) decodeParts(byteOrder binary.ByteOrder, level, size int) (int, error) {
+	n, err := d.decodeCount(byteOrder, level, size)
+	if err != nil {
+		return 0, err
+	}
+	if err := d.counter.CheckParts(n); err != nil {
+		return 0, err
+	}
+	return n, nil
+}
+
+// decodeCount decodes the number of elements at level, each of which is at
+// least size bytes long. Level zero is not limited by MaxGeometryElements.
+func (d *Decoder) decodeCount(byteOrder binary.ByteOrder, level, size int) (int, error) {
+	n, err := d.decodeUInt32(byteOrder)
+	if err != nil {
+		return 0, err
+	}
+	if limit := wkbcommon.MaxGeometryElements[level]; level > 0 && limit >= 0 && int(n) > limit {
+		return 0, wkbcommon.ErrGeometryTooLarge{Level: level, N: int(n), Limit: limit}
+	}
+	// Check that the data is long enough before allocating anything, so that
+	// corrupt counts cannot cause excessive allocations.
+	if uint64(n)*uint64(size) > uint64(len(d.data)-d.offset) {
+		return 0, io.ErrUnexpectedEOF
+	}
+	return int(n), nil
+}
+
+// decodeCoords appends n coordinates with the given stride to d.flatCoords.
+func (d *Decoder) decodeCoords(byteOrder binary.ByteOrder, n, stride int) error {
+	size := n * stride
+	if 8*size > len(d.data)-d.offset {
+		return io.ErrUnexpectedEOF
+	}
+	if err := d.counter.AddCoords(n); err != nil {
+		return err
+	}
+	d.flatCoords = slices.Grow(d.flatCoords, size)
+	data := d.data[d.offset : d.offset+8*size]
+	for i := 0; i < len(data); i += 8 {
+		d.flatCoords = append(d.flatCoords, math.Float64frombits(byteOrder.Uint64(data[i:])))
+	}
+	d.offset += 8 * size
+	return nil
+}
+
+// decodeUInt32 decodes a uint32.
+func (d *Decoder) decodeUInt32(byteOrder binary.ByteOrder) (uint32, error) {
+	if len(d.data)-d.offset < 4 {
+		return 0, io.ErrUnexpectedEOF
+	}
+	value := byteOrder.Uint32(d.data[d.offset:])
+	d.offset += 4
+	return value, nil
+}
+
+// flat returns the flat coordinates decoded since start. Its capacity is
+// limited so that appending to it does not overwrite the buffer.
+func (d *Decoder) flat(start int) []float64 {
+	end := len(d.flatCoords)
+	return d.flatCoords[start:end:end]
+}
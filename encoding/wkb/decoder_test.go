@@ -0,0 +1,138 @@
+package wkb
+
+import (
+	"encoding/binary"
+	"io"
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+
+	"github.com/don4get/go-geom"
+	"github.com/don4get/go-geom/encoding/wkbcommon"
+	"github.com/don4get/go-geom/internal/testdata"
+)
+
+func TestDecoder(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		g    geom.T
+		opts []wkbcommon.WKBOption
+	}{
+		{
+			name: "point",
+			g:    geom.NewPointFlat(geom.XYZ, []float64{1, 2, 3}),
+		},
+		{
+			name: "linestring",
+			g:    geom.NewLineStringFlat(geom.XY, []float64{1, 2, 3, 4, 5, 6}),
+		},
+		{
+			name: "polygon",
+			g:    geom.NewPolygonFlat(geom.XYM, []float64{0, 0, 1, 4, 0, 2, 0, 4, 3, 0, 0, 1, 1, 1, 1, 2, 1, 2, 1, 2, 3, 1, 1, 1}, []int{12, 24}),
+		},
+		{
+			name: "multipoint with empty points",
+			g:    geom.NewMultiPointFlat(geom.XY, []float64{1, 2, 3, 4}, geom.NewMultiPointFlatOptionWithEnds([]int{2, 2, 4})),
+			opts: []wkbcommon.WKBOption{wkbcommon.WKBOptionEmptyPointHandling(wkbcommon.EmptyPointHandlingNaN)},
+		},
+		{
+			name: "multilinestring",
+			g:    geom.NewMultiLineStringFlat(geom.XY, []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, []int{4, 10}),
+		},
+		{
+			name: "multipolygon",
+			g:    geom.NewMultiPolygonFlat(geom.XY, []float64{0, 0, 1, 0, 1, 1, 0, 0, 2, 2, 3, 2, 3, 3, 2, 2}, [][]int{{8}, {16}}),
+		},
+		{
+			name: "tin",
+			g:    geom.NewTINFlat(geom.XY, []float64{0, 0, 1, 0, 0, 1, 0, 0, 1, 0, 1, 1, 0, 1, 1, 0}, [][]int{{8}, {16}}),
+		},
+		{
+			name: "geometrycollection",
+			g: geom.NewGeometryCollection().MustPush(
+				geom.NewPointFlat(geom.XY, []float64{1, 2}),
+				geom.NewLineStringFlat(geom.XY, []float64{3, 4, 5, 6}),
+			),
+		},
+		{
+			name: "curvepolygon",
+			g: geom.NewCurvePolygon(geom.XY).MustPush(
+				geom.NewCircularString(geom.XY).MustSetCoords([]geom.Coord{{0, 0}, {4, 0}, {0, 0}}),
+				geom.NewLinearRing(geom.XY).MustSetCoords([]geom.Coord{{1, 1}, {2, 1}, {1, 2}, {1, 1}}),
+			),
+		},
+		{
+			name: "extended with srid",
+			g:    geom.NewMultiLineStringFlat(geom.XYZ, []float64{1, 2, 3, 4, 5, 6}, []int{6}).SetSRID(4326),
+			opts: []wkbcommon.WKBOption{wkbcommon.WKBOptionDialect(wkbcommon.DialectExtended)},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			d := NewDecoder(nil, tc.opts...)
+			for _, byteOrder := range []binary.ByteOrder{XDR, NDR} {
+				data, err := Marshal(tc.g, byteOrder, tc.opts...)
+				assert.NoError(t, err)
+				got, err := d.Decode(data)
+				assert.NoError(t, err)
+				assert.Equal(t, tc.g.GetSRID(), got.GetSRID())
+				gotData, err := Marshal(got, byteOrder, tc.opts...)
+				assert.NoError(t, err)
+				assert.Equal(t, data, gotData)
+			}
+		})
+	}
+}
+
+func TestDecoderRandom(t *testing.T) {
+	d := NewDecoder(nil)
+	for _, tc := range testdata.Random {
+		got, err := d.Decode(tc.WKB)
+		assert.NoError(t, err)
+		gotData, err := Marshal(got, NDR)
+		assert.NoError(t, err)
+		assert.Equal(t, tc.WKB, gotData)
+	}
+}
+
+func TestDecoderReusesBuffer(t *testing.T) {
+	buf := make([]float64, 0, 16)
+	d := NewDecoder(buf)
+	for _, g := range []*geom.LineString{
+		geom.NewLineStringFlat(geom.XY, []float64{1, 2, 3, 4}),
+		geom.NewLineStringFlat(geom.XY, []float64{5, 6, 7, 8, 9, 10}),
+	} {
+		data, err := Marshal(g, NDR)
+		assert.NoError(t, err)
+		got, err := d.Decode(data)
+		assert.NoError(t, err)
+		ls, ok := got.(*geom.LineString)
+		assert.True(t, ok)
+		assert.Equal(t, g.FlatCoords, ls.FlatCoords)
+		assert.True(t, &ls.FlatCoords[0] == &buf[:1][0])
+	}
+}
+
+func TestDecoderErrors(t *testing.T) {
+	data, err := Marshal(geom.NewMultiLineStringFlat(geom.XY, []float64{1, 2, 3, 4}, []int{4}), NDR)
+	assert.NoError(t, err)
+	d := NewDecoder(nil)
+	for i := range len(data) {
+		_, err := d.Decode(data[:i])
+		assert.Equal[error](t, io.ErrUnexpectedEOF, err)
+	}
+
+	// A count of 0xffffffff coordinates must not be allocated.
+	_, err = d.Decode([]byte{1, 2, 0, 0, 0, 0xff, 0xff, 0xff, 0xff})
+	assert.Equal[error](t, io.ErrUnexpectedEOF, err)
+
+	_, err = d.Decode([]byte{2})
+	assert.Equal[error](t, wkbcommon.ErrUnknownByteOrder(2), err)
+
+	gc := geom.NewGeometryCollection().MustPush(geom.NewLineStringFlat(geom.XY, []float64{1, 2, 3, 4}))
+	data, err = Marshal(gc, NDR)
+	assert.NoError(t, err)
+	data[1] = wkbcommon.MultiPointID
+	_, wantErr := Unmarshal(data)
+	_, err = d.Decode(data)
+	assert.Equal(t, wantErr, err)
+}
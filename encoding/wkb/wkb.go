@@ -1,5 +1,10 @@
 // Package wkb implements Well Known Binary encoding and decoding.
 //
+// By default, Z and M coordinates are encoded with ISO type codes. Use
+// wkbcommon.WKBOptionDialect to read or write OGC 1.1 or extended (PostGIS)
+// WKB instead, or to read WKB in any of these dialects with
+// wkbcommon.DialectAuto.
+//
 // If you are encoding geometries in WKB to send to PostgreSQL/PostGIS, then
 // you must specify binary_parameters=yes in the data source name that you pass
 // to sql.Open.
@@ -23,13 +28,6 @@ var (
 	NDR = wkbcommon.NDR
 )
 
-const (
-	wkbXYID   = 0
-	wkbXYZID  = 1000
-	wkbXYMID  = 2000
-	wkbXYZMID = 3000
-)
-
 // Read reads an arbitrary geometry from r.
 func Read(r io.Reader, opts ...wkbcommon.WKBOption) (geom.T, error) {
 	params := wkbcommon.InitWKBParams(
@@ -38,6 +36,9 @@ func Read(r io.Reader, opts ...wkbcommon.WKBOption) (geom.T, error) {
 		},
 		opts...,
 	)
+	if params.Limits != (geom.Limits{}) && wkbcommon.CounterOf(r) == nil {
+		r = wkbcommon.NewLimitedReader(r, params.Limits)
+	}
 
 	wkbByteOrder, err := wkbcommon.ReadByte(r)
 	if err != nil {
@@ -57,23 +58,29 @@ func Read(r io.Reader, opts ...wkbcommon.WKBOption) (geom.T, error) {
 	if err != nil {
 		return nil, err
 	}
-	t := wkbcommon.Type(wkbGeometryType)
+	t, layout, hasSRID, err := wkbcommon.ParseType(wkbGeometryType, params.Dialect)
+	if err != nil {
+		return nil, err
+	}
+	var srid uint32
+	if hasSRID {
+		srid, err = wkbcommon.ReadUInt32(r, byteOrder)
+		if err != nil {
+			return nil, err
+		}
+	}
 
-	var layout geom.Layout
-	switch 1000 * (t / 1000) {
-	case wkbXYID:
-		layout = geom.XY
-	case wkbXYZID:
-		layout = geom.XYZ
-	case wkbXYMID:
-		layout = geom.XYM
-	case wkbXYZMID:
-		layout = geom.XYZM
-	default:
-		return nil, wkbcommon.ErrUnknownType(t)
+	g, err := read(r, byteOrder, t, layout, params, opts)
+	if err != nil || srid == 0 {
+		return g, err
 	}
+	return geom.SetSRID(g, int(srid))
+}
 
-	switch t % 1000 {
+// read reads the rest of a geometry with type t and the given layout from r.
+func read(r io.Reader, byteOrder binary.ByteOrder, t wkbcommon.Type, layout geom.Layout, params wkbcommon.WKBParams, opts []wkbcommon.WKBOption) (geom.T, error) {
+	counter := wkbcommon.CounterOf(r)
+	switch t {
 	case wkbcommon.PointID:
 		flatCoords, err := wkbcommon.ReadFlatCoords0(r, byteOrder, layout.Stride())
 		if err != nil {
@@ -103,6 +110,9 @@ func Read(r io.Reader, opts ...wkbcommon.WKBOption) (geom.T, error) {
 		if limit := wkbcommon.MaxGeometryElements[1]; limit >= 0 && int(n) > limit {
 			return nil, wkbcommon.ErrGeometryTooLarge{Level: 1, N: int(n), Limit: limit}
 		}
+		if err := counter.CheckParts(int(n)); err != nil {
+			return nil, err
+		}
 		mp := geom.NewMultiPoint(layout)
 		for range n {
 			g, err := Read(r, opts...)
@@ -126,6 +136,9 @@ func Read(r io.Reader, opts ...wkbcommon.WKBOption) (geom.T, error) {
 		if limit := wkbcommon.MaxGeometryElements[2]; limit >= 0 && int(n) > limit {
 			return nil, wkbcommon.ErrGeometryTooLarge{Level: 2, N: int(n), Limit: limit}
 		}
+		if err := counter.CheckParts(int(n)); err != nil {
+			return nil, err
+		}
 		mls := geom.NewMultiLineString(layout)
 		for range n {
 			g, err := Read(r, opts...)
@@ -149,6 +162,9 @@ func Read(r io.Reader, opts ...wkbcommon.WKBOption) (geom.T, error) {
 		if limit := wkbcommon.MaxGeometryElements[3]; limit >= 0 && int(n) > limit {
 			return nil, wkbcommon.ErrGeometryTooLarge{Level: 3, N: int(n), Limit: limit}
 		}
+		if err := counter.CheckParts(int(n)); err != nil {
+			return nil, err
+		}
 		mp := geom.NewMultiPolygon(layout)
 		for range n {
 			g, err := Read(r, opts...)
@@ -169,6 +185,13 @@ func Read(r io.Reader, opts ...wkbcommon.WKBOption) (geom.T, error) {
 		if err != nil {
 			return nil, err
 		}
+		if err := counter.CheckParts(int(n)); err != nil {
+			return nil, err
+		}
+		if err := counter.Enter(); err != nil {
+			return nil, err
+		}
+		defer counter.Leave()
 		gc := geom.NewGeometryCollection()
 		for range n {
 			g, err := Read(r, opts...)
@@ -187,8 +210,95 @@ func Read(r io.Reader, opts ...wkbcommon.WKBOption) (geom.T, error) {
 			}
 		}
 		return gc, nil
+	case wkbcommon.TriangleID:
+		flatCoords, ends, err := wkbcommon.ReadFlatCoords2(r, byteOrder, layout.Stride())
+		if err != nil {
+			return nil, err
+		}
+		if err := wkbcommon.CheckTriangle(layout, flatCoords, 0, ends); err != nil {
+			return nil, err
+		}
+		return geom.NewTriangleFlat(layout, flatCoords, ends), nil
+	case wkbcommon.TINID:
+		n, err := wkbcommon.ReadUInt32(r, byteOrder)
+		if err != nil {
+			return nil, err
+		}
+		if limit := wkbcommon.MaxGeometryElements[3]; limit >= 0 && int(n) > limit {
+			return nil, wkbcommon.ErrGeometryTooLarge{Level: 3, N: int(n), Limit: limit}
+		}
+		if err := counter.CheckParts(int(n)); err != nil {
+			return nil, err
+		}
+		tin := geom.NewTIN(layout)
+		for range n {
+			g, err := Read(r, opts...)
+			if err != nil {
+				return nil, err
+			}
+			t, ok := g.(*geom.Triangle)
+			if !ok {
+				return nil, wkbcommon.ErrUnexpectedType{Got: g, Want: &geom.Triangle{}}
+			}
+			if err = tin.Push(t); err != nil {
+				return nil, err
+			}
+		}
+		return tin, nil
+	case wkbcommon.PolyhedralSurfaceID:
+		n, err := wkbcommon.ReadUInt32(r, byteOrder)
+		if err != nil {
+			return nil, err
+		}
+		if limit := wkbcommon.MaxGeometryElements[3]; limit >= 0 && int(n) > limit {
+			return nil, wkbcommon.ErrGeometryTooLarge{Level: 3, N: int(n), Limit: limit}
+		}
+		if err := counter.CheckParts(int(n)); err != nil {
+			return nil, err
+		}
+		ps := geom.NewPolyhedralSurface(layout)
+		for range n {
+			g, err := Read(r, opts...)
+			if err != nil {
+				return nil, err
+			}
+			p, ok := g.(*geom.Polygon)
+			if !ok {
+				return nil, wkbcommon.ErrUnexpectedType{Got: g, Want: &geom.Polygon{}}
+			}
+			if err = ps.Push(p); err != nil {
+				return nil, err
+			}
+		}
+		return ps, nil
+	case wkbcommon.CircularStringID:
+		flatCoords, err := wkbcommon.ReadFlatCoords1(r, byteOrder, layout.Stride())
+		if err != nil {
+			return nil, err
+		}
+		return geom.NewCircularStringFlat(layout, flatCoords), nil
+	case wkbcommon.CompoundCurveID, wkbcommon.CurvePolygonID, wkbcommon.MultiCurveID, wkbcommon.MultiSurfaceID:
+		n, err := wkbcommon.ReadUInt32(r, byteOrder)
+		if err != nil {
+			return nil, err
+		}
+		if limit := wkbcommon.MaxGeometryElements[2]; limit >= 0 && int(n) > limit {
+			return nil, wkbcommon.ErrGeometryTooLarge{Level: 2, N: int(n), Limit: limit}
+		}
+		if err := counter.CheckParts(int(n)); err != nil {
+			return nil, err
+		}
+		var components []geom.T
+		for range n {
+			g, err := Read(r, opts...)
+			if err != nil {
+				return nil, err
+			}
+			components = append(components, g)
+		}
+		return wkbcommon.NewComposite(t, layout, components)
 	default:
-		return nil, wkbcommon.ErrUnsupportedType(wkbGeometryType)
+		return nil, wkbcommon.ErrUnsupportedType(t)
 	}
 }
 
@@ -197,6 +307,13 @@ func Unmarshal(data []byte, opts ...wkbcommon.WKBOption) (geom.T, error) {
 	return Read(bytes.NewBuffer(data), opts...)
 }
 
+// Inspect returns information about the geometry in data, including its
+// bounds, without decoding it.
+func Inspect(data []byte, opts ...wkbcommon.WKBOption) (*wkbcommon.Info, error) {
+	params := wkbcommon.InitWKBParams(wkbcommon.WKBParams{}, opts...)
+	return wkbcommon.Inspect(data, params.Dialect, params.Limits)
+}
+
 // Write writes an arbitrary geometry to w.
 func Write(w io.Writer, byteOrder binary.ByteOrder, g geom.T, opts ...wkbcommon.WKBOption) error {
 	params := wkbcommon.InitWKBParams(
@@ -219,45 +336,59 @@ func Write(w io.Writer, byteOrder binary.ByteOrder, g geom.T, opts ...wkbcommon.
 		return err
 	}
 
-	var wkbGeometryType uint32
+	var t wkbcommon.Type
 	switch g.(type) {
 	case *geom.Point:
-		wkbGeometryType = wkbcommon.PointID
+		t = wkbcommon.PointID
 	case *geom.LineString:
-		wkbGeometryType = wkbcommon.LineStringID
+		t = wkbcommon.LineStringID
 	case *geom.Polygon:
-		wkbGeometryType = wkbcommon.PolygonID
+		t = wkbcommon.PolygonID
 	case *geom.MultiPoint:
-		wkbGeometryType = wkbcommon.MultiPointID
+		t = wkbcommon.MultiPointID
 	case *geom.MultiLineString:
-		wkbGeometryType = wkbcommon.MultiLineStringID
+		t = wkbcommon.MultiLineStringID
 	case *geom.MultiPolygon:
-		wkbGeometryType = wkbcommon.MultiPolygonID
+		t = wkbcommon.MultiPolygonID
 	case *geom.GeometryCollection:
-		wkbGeometryType = wkbcommon.GeometryCollectionID
+		t = wkbcommon.GeometryCollectionID
+	case *geom.Triangle:
+		t = wkbcommon.TriangleID
+	case *geom.TIN:
+		t = wkbcommon.TINID
+	case *geom.PolyhedralSurface:
+		t = wkbcommon.PolyhedralSurfaceID
+	case *geom.CircularString:
+		t = wkbcommon.CircularStringID
+	case *geom.CompoundCurve:
+		t = wkbcommon.CompoundCurveID
+	case *geom.CurvePolygon:
+		t = wkbcommon.CurvePolygonID
+	case *geom.MultiCurve:
+		t = wkbcommon.MultiCurveID
+	case *geom.MultiSurface:
+		t = wkbcommon.MultiSurfaceID
 	default:
 		return geom.ErrUnsupportedType{Value: g}
 	}
-	switch g.GetLayout() {
-	case geom.NoLayout:
+	if g.GetLayout() == geom.NoLayout {
 		// Special case for empty GeometryCollections
 		if _, ok := g.(*geom.GeometryCollection); !ok || !g.IsEmpty() {
 			return geom.ErrUnsupportedLayout(g.GetLayout())
 		}
-	case geom.XY:
-		wkbGeometryType += wkbXYID
-	case geom.XYZ:
-		wkbGeometryType += wkbXYZID
-	case geom.XYM:
-		wkbGeometryType += wkbXYMID
-	case geom.XYZM:
-		wkbGeometryType += wkbXYZMID
-	default:
-		return geom.ErrUnsupportedLayout(g.GetLayout())
+	}
+	wkbGeometryType, hasSRID, err := wkbcommon.TypeCode(t, g.GetLayout(), g.GetSRID(), params.Dialect)
+	if err != nil {
+		return err
 	}
 	if err := wkbcommon.WriteUInt32(w, byteOrder, wkbGeometryType); err != nil {
 		return err
 	}
+	if hasSRID {
+		if err := wkbcommon.WriteUInt32(w, byteOrder, uint32(g.GetSRID())); err != nil {
+			return err
+		}
+	}
 
 	switch g := g.(type) {
 	case *geom.Point:
@@ -320,6 +451,43 @@ func Write(w io.Writer, byteOrder binary.ByteOrder, g geom.T, opts ...wkbcommon.
 			}
 		}
 		return nil
+	case *geom.Triangle:
+		return wkbcommon.WriteFlatCoords2(w, byteOrder, g.GetFlatCoords(), g.GetEnds(), g.GetStride())
+	case *geom.TIN:
+		n := g.NumTriangles()
+		if err := wkbcommon.WriteUInt32(w, byteOrder, uint32(n)); err != nil {
+			return err
+		}
+		for i := range n {
+			if err := Write(w, byteOrder, g.Triangle(i), opts...); err != nil {
+				return err
+			}
+		}
+		return nil
+	case *geom.PolyhedralSurface:
+		n := g.NumPolygons()
+		if err := wkbcommon.WriteUInt32(w, byteOrder, uint32(n)); err != nil {
+			return err
+		}
+		for i := range n {
+			if err := Write(w, byteOrder, g.Polygon(i), opts...); err != nil {
+				return err
+			}
+		}
+		return nil
+	case *geom.CircularString:
+		return wkbcommon.WriteFlatCoords1(w, byteOrder, g.GetFlatCoords(), g.GetStride())
+	case *geom.CompoundCurve, *geom.CurvePolygon, *geom.MultiCurve, *geom.MultiSurface:
+		components, _ := wkbcommon.Components(g)
+		if err := wkbcommon.WriteUInt32(w, byteOrder, uint32(len(components))); err != nil {
+			return err
+		}
+		for _, component := range components {
+			if err := Write(w, byteOrder, component, opts...); err != nil {
+				return err
+			}
+		}
+		return nil
 	default:
 		return geom.ErrUnsupportedType{Value: g}
 	}
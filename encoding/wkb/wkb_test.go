@@ -3,6 +3,7 @@ package wkb
 import (
 	"encoding/binary"
 	"fmt"
+	"io"
 	"testing"
 
 	"github.com/alecthomas/assert/v2"
@@ -333,6 +334,349 @@ func Test(t *testing.T) {
 	})
 }
 
+func TestCurves(t *testing.T) {
+	for _, tc := range []struct {
+		g   geom.T
+		ndr []byte
+	}{
+		{
+			g:   geom.NewCircularString(geom.XY).MustSetCoords([]geom.Coord{{0, 0}, {1, 1}, {2, 0}}),
+			ndr: geomtest.MustHexDecode("01080000000300000000000000000000000000000000000000000000000000f03f000000000000f03f00000000000000400000000000000000"),
+		},
+		{
+			g: geom.NewCompoundCurve(geom.XY).MustPush(
+				geom.NewLineString(geom.XY).MustSetCoords([]geom.Coord{{0, 0}, {1, 1}}),
+				geom.NewCircularString(geom.XY).MustSetCoords([]geom.Coord{{1, 1}, {2, 0}, {3, 1}}),
+			),
+			ndr: geomtest.MustHexDecode("01090000000200000001020000000200000000000000000000000000000000000000000000000000f03f000000000000f03f010800000003000000000000000000f03f000000000000f03f000000000000004000000000000000000000000000000840000000000000f03f"),
+		},
+		{
+			g: geom.NewCurvePolygon(geom.XYZ).MustPush(
+				geom.NewCircularString(geom.XYZ).MustSetCoords([]geom.Coord{{0, 0, 1}, {4, 0, 2}, {0, 0, 1}}),
+				geom.NewLinearRing(geom.XYZ).MustSetCoords([]geom.Coord{{1, 1, 1}, {2, 1, 1}, {1, 2, 1}, {1, 1, 1}}),
+			),
+			ndr: geomtest.MustHexDecode("01f20300000200000001f00300000300000000000000000000000000000000000000000000000000f03f00000000000010400000000000000000000000000000004000000000000000000000000000000000000000000000f03f01ea03000004000000000000000000f03f000000000000f03f000000000000f03f0000000000000040000000000000f03f000000000000f03f000000000000f03f0000000000000040000000000000f03f000000000000f03f000000000000f03f000000000000f03f"),
+		},
+		{
+			g: geom.NewMultiCurve(geom.XY).MustPush(
+				geom.NewLineString(geom.XY).MustSetCoords([]geom.Coord{{0, 0}, {1, 1}}),
+				geom.NewCompoundCurve(geom.XY).MustPush(
+					geom.NewCircularString(geom.XY).MustSetCoords([]geom.Coord{{0, 0}, {1, 1}, {2, 0}}),
+				),
+			),
+			ndr: geomtest.MustHexDecode("010b0000000200000001020000000200000000000000000000000000000000000000000000000000f03f000000000000f03f01090000000100000001080000000300000000000000000000000000000000000000000000000000f03f000000000000f03f00000000000000400000000000000000"),
+		},
+		{
+			g: geom.NewMultiSurface(geom.XY).MustPush(
+				geom.NewCurvePolygon(geom.XY).MustPush(
+					geom.NewCircularString(geom.XY).MustSetCoords([]geom.Coord{{0, 0}, {4, 0}, {0, 0}}),
+				),
+				geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{{{0, 0}, {1, 0}, {1, 1}, {0, 0}}}),
+			),
+			ndr: geomtest.MustHexDecode("010c00000002000000010a000000010000000108000000030000000000000000000000000000000000000000000000000010400000000000000000000000000000000000000000000000000103000000010000000400000000000000000000000000000000000000000000000000f03f0000000000000000000000000000f03f000000000000f03f00000000000000000000000000000000"),
+		},
+	} {
+		t.Run(fmt.Sprintf("ndr:%x", tc.ndr), func(t *testing.T) {
+			test(t, tc.g, nil, tc.ndr)
+		})
+	}
+}
+
+func TestSurfaces(t *testing.T) {
+	for _, tc := range []struct {
+		g   geom.T
+		ndr []byte
+	}{
+		{
+			g:   geom.NewTriangle(geom.XY).MustSetCoords([][]geom.Coord{{{0, 0}, {1, 0}, {0, 1}, {0, 0}}}),
+			ndr: geomtest.MustHexDecode("0111000000010000000400000000000000000000000000000000000000000000000000f03f00000000000000000000000000000000000000000000f03f00000000000000000000000000000000"),
+		},
+		{
+			g:   geom.NewTIN(geom.XYZ).MustSetCoords([][][]geom.Coord{{{{0, 0, 1}, {1, 0, 1}, {0, 1, 1}, {0, 0, 1}}}}),
+			ndr: geomtest.MustHexDecode("01f80300000100000001f9030000010000000400000000000000000000000000000000000000000000000000f03f000000000000f03f0000000000000000000000000000f03f0000000000000000000000000000f03f000000000000f03f00000000000000000000000000000000000000000000f03f"),
+		},
+		{
+			g:   geom.NewPolyhedralSurface(geom.XY).MustSetCoords([][][]geom.Coord{{{{0, 0}, {1, 0}, {1, 1}, {0, 0}}}, {{{0, 0}, {1, 1}, {0, 1}, {0, 0}}}}),
+			ndr: geomtest.MustHexDecode("010f000000020000000103000000010000000400000000000000000000000000000000000000000000000000f03f0000000000000000000000000000f03f000000000000f03f000000000000000000000000000000000103000000010000000400000000000000000000000000000000000000000000000000f03f000000000000f03f0000000000000000000000000000f03f00000000000000000000000000000000"),
+		},
+	} {
+		t.Run(fmt.Sprintf("ndr:%x", tc.ndr), func(t *testing.T) {
+			test(t, tc.g, nil, tc.ndr)
+		})
+	}
+}
+
+func TestInvalidTriangles(t *testing.T) {
+	for _, g := range []geom.T{
+		geom.NewTriangleFlat(geom.XY, []float64{0, 0, 1, 0, 0, 1, 0, 0, 0, 0}, []int{10}),
+		geom.NewTriangleFlat(geom.XY, []float64{0, 0, 1, 0, 0, 1, 1, 1}, []int{8}),
+		geom.NewTriangleFlat(geom.XYZ, []float64{0, 0, 0, 1, 0, 0, 0, 1, 0, 0, 0, 1}, []int{12}),
+		geom.NewTriangleFlat(geom.XY, []float64{0, 0, 1, 0, 0, 1, 0, 0, 0, 0, 1, 0, 0, 1, 0, 0}, []int{8, 16}),
+		geom.NewTINFlat(geom.XY, []float64{0, 0, 1, 0, 0, 1, 0, 0, 0, 0, 1, 0, 0, 1}, [][]int{{8}, {14}}),
+	} {
+		data, err := Marshal(g, NDR)
+		assert.NoError(t, err)
+		_, err = Unmarshal(data)
+		assert.Equal[error](t, wkbcommon.ErrInvalidTriangle{}, err)
+		_, err = NewDecoder(nil).Decode(data)
+		assert.Equal[error](t, wkbcommon.ErrInvalidTriangle{}, err)
+	}
+}
+
+func TestDialects(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		g       geom.T
+		dialect wkbcommon.Dialect
+		ndr     []byte
+	}{
+		{
+			name:    "iso",
+			g:       geom.NewPointFlat(geom.XYZ, []float64{1, 2, 3}),
+			dialect: wkbcommon.DialectISO,
+			ndr:     geomtest.MustHexDecode("01e9030000000000000000f03f00000000000000400000000000000840"),
+		},
+		{
+			name:    "ogc",
+			g:       geom.NewPointFlat(geom.XY, []float64{1, 2}),
+			dialect: wkbcommon.DialectOGC,
+			ndr:     geomtest.MustHexDecode("0101000000000000000000f03f0000000000000040"),
+		},
+		{
+			name:    "extended",
+			g:       geom.NewPointFlat(geom.XYZ, []float64{1, 2, 3}),
+			dialect: wkbcommon.DialectExtended,
+			ndr:     geomtest.MustHexDecode("0101000080000000000000f03f00000000000000400000000000000840"),
+		},
+		{
+			name:    "extended with srid",
+			g:       geom.NewPointFlat(geom.XYZ, []float64{1, 2, 3}).SetSRID(4326),
+			dialect: wkbcommon.DialectExtended,
+			ndr:     geomtest.MustHexDecode("01010000a0e6100000000000000000f03f00000000000000400000000000000840"),
+		},
+		{
+			name:    "extended multipoint with srid",
+			g:       geom.NewMultiPointFlat(geom.XYM, []float64{1, 2, 3}).SetSRID(4326),
+			dialect: wkbcommon.DialectExtended,
+			ndr:     geomtest.MustHexDecode("0104000060e6100000010000000101000040000000000000f03f00000000000000400000000000000840"),
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Run("marshal", func(t *testing.T) {
+				got, err := Marshal(tc.g, NDR, wkbcommon.WKBOptionDialect(tc.dialect))
+				assert.NoError(t, err)
+				assert.Equal(t, tc.ndr, got)
+			})
+			t.Run("unmarshal", func(t *testing.T) {
+				got, err := Unmarshal(tc.ndr, wkbcommon.WKBOptionDialect(tc.dialect))
+				assert.NoError(t, err)
+				assert.Equal(t, tc.g, got)
+			})
+			t.Run("unmarshal auto", func(t *testing.T) {
+				got, err := Unmarshal(tc.ndr, wkbcommon.WKBOptionDialect(wkbcommon.DialectAuto))
+				assert.NoError(t, err)
+				assert.Equal(t, tc.g, got)
+			})
+		})
+	}
+}
+
+func TestDialectErrors(t *testing.T) {
+	xyz := geom.NewPointFlat(geom.XYZ, []float64{1, 2, 3})
+
+	_, err := Marshal(xyz, NDR, wkbcommon.WKBOptionDialect(wkbcommon.DialectOGC))
+	assert.Equal[error](t, geom.ErrUnsupportedLayout(geom.XYZ), err)
+
+	_, err = Marshal(xyz, NDR, wkbcommon.WKBOptionDialect(wkbcommon.DialectAuto))
+	assert.Equal[error](t, wkbcommon.ErrUnsupportedDialect(wkbcommon.DialectAuto), err)
+
+	iso := geomtest.MustHexDecode("01e9030000000000000000f03f00000000000000400000000000000840")
+	_, err = Unmarshal(iso, wkbcommon.WKBOptionDialect(wkbcommon.DialectOGC))
+	assert.Equal[error](t, wkbcommon.ErrUnknownType(1001), err)
+
+	extended := geomtest.MustHexDecode("0101000080000000000000f03f00000000000000400000000000000840")
+	_, err = Unmarshal(extended)
+	assert.Equal[error](t, wkbcommon.ErrUnknownType(0x80000001), err)
+}
+
+func TestInspect(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		g        geom.T
+		opts     []wkbcommon.WKBOption
+		expected *wkbcommon.Info
+	}{
+		{
+			name: "point",
+			g:    geom.NewPointFlat(geom.XYZ, []float64{1, 2, 3}),
+			expected: &wkbcommon.Info{
+				Type:      wkbcommon.PointID,
+				Layout:    geom.XYZ,
+				NumPoints: 1,
+				Bounds:    geom.NewBounds(geom.XYZ).Set(1, 2, 3, 1, 2, 3),
+			},
+		},
+		{
+			name: "empty linestring",
+			g:    geom.NewLineString(geom.XY),
+			expected: &wkbcommon.Info{
+				Type:   wkbcommon.LineStringID,
+				Layout: geom.XY,
+				Bounds: geom.NewBounds(geom.XY),
+			},
+		},
+		{
+			name: "multipoint with empty point",
+			g:    geom.NewMultiPointFlat(geom.XY, []float64{1, 4, 3, 2}, geom.NewMultiPointFlatOptionWithEnds([]int{2, 2, 4})),
+			opts: []wkbcommon.WKBOption{wkbcommon.WKBOptionEmptyPointHandling(wkbcommon.EmptyPointHandlingNaN)},
+			expected: &wkbcommon.Info{
+				Type:      wkbcommon.MultiPointID,
+				Layout:    geom.XY,
+				NumPoints: 2,
+				Bounds:    geom.NewBounds(geom.XY).Set(1, 2, 3, 4),
+			},
+		},
+		{
+			name: "multipolygon",
+			g:    geom.NewMultiPolygonFlat(geom.XYM, []float64{0, 0, 1, 1, 0, 2, 1, 1, 3, 0, 0, 1, 2, 2, 5, 3, 2, 5, 3, 3, 5, 2, 2, 5}, [][]int{{12}, {24}}),
+			expected: &wkbcommon.Info{
+				Type:      wkbcommon.MultiPolygonID,
+				Layout:    geom.XYM,
+				NumPoints: 8,
+				Bounds:    geom.NewBounds(geom.XYM).Set(0, 0, 1, 3, 3, 5),
+			},
+		},
+		{
+			name: "geometrycollection",
+			g: geom.NewGeometryCollection().MustPush(
+				geom.NewPointFlat(geom.XY, []float64{-1, 2}),
+				geom.NewCompoundCurve(geom.XY).MustPush(
+					geom.NewCircularString(geom.XY).MustSetCoords([]geom.Coord{{0, 0}, {1, 1}, {2, 0}}),
+				),
+			),
+			expected: &wkbcommon.Info{
+				Type:      wkbcommon.GeometryCollectionID,
+				Layout:    geom.XY,
+				NumPoints: 4,
+				Bounds:    geom.NewBounds(geom.XY).Set(-1, 0, 2, 2),
+			},
+		},
+		{
+			name: "geometrycollection with mixed layouts",
+			g: geom.NewGeometryCollection().MustPush(
+				geom.NewPointFlat(geom.XY, []float64{1, 2}),
+				geom.NewPointFlat(geom.XYZ, []float64{3, 4, 5}),
+			),
+			expected: &wkbcommon.Info{
+				Type:      wkbcommon.GeometryCollectionID,
+				Layout:    geom.XYZ,
+				NumPoints: 2,
+				Bounds:    geom.NewBounds(geom.XYZ).Set(1, 2, 5, 3, 4, 5),
+			},
+		},
+		{
+			name: "extended with srid",
+			g:    geom.NewLineStringFlat(geom.XY, []float64{1, 2, 3, 4}).SetSRID(4326),
+			opts: []wkbcommon.WKBOption{wkbcommon.WKBOptionDialect(wkbcommon.DialectExtended)},
+			expected: &wkbcommon.Info{
+				Type:      wkbcommon.LineStringID,
+				Layout:    geom.XY,
+				SRID:      4326,
+				NumPoints: 2,
+				Bounds:    geom.NewBounds(geom.XY).Set(1, 2, 3, 4),
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			data, err := Marshal(tc.g, NDR, tc.opts...)
+			assert.NoError(t, err)
+			actual, err := Inspect(data, tc.opts...)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestInspectRandom(t *testing.T) {
+	for _, tc := range testdata.Random {
+		info, err := Inspect(tc.WKB)
+		assert.NoError(t, err)
+		assert.Equal(t, tc.G.GetLayout(), info.Layout)
+		if _, ok := tc.G.(*geom.GeometryCollection); !ok {
+			assert.Equal(t, geom.NewBounds(tc.G.GetLayout()).Extend(tc.G), info.Bounds)
+		}
+	}
+}
+
+func TestInspectErrors(t *testing.T) {
+	data, err := Marshal(geom.NewPolygonFlat(geom.XY, []float64{0, 0, 1, 0, 1, 1, 0, 0}, []int{8}), NDR)
+	assert.NoError(t, err)
+	for i := range len(data) {
+		_, err := Inspect(data[:i])
+		assert.Equal[error](t, io.ErrUnexpectedEOF, err)
+	}
+
+	savedMaxGeometryElements := wkbcommon.MaxGeometryElements
+	defer func() {
+		wkbcommon.MaxGeometryElements = savedMaxGeometryElements
+	}()
+	wkbcommon.MaxGeometryElements[1] = 3
+	_, err = Inspect(data)
+	assert.Equal[error](t, wkbcommon.ErrGeometryTooLarge{Level: 1, N: 4, Limit: 3}, err)
+}
+
+func TestLimits(t *testing.T) {
+	g := geom.NewGeometryCollection().MustPush(
+		geom.NewGeometryCollection().MustPush(
+			geom.NewPointFlat(geom.XY, []float64{1, 2}),
+			geom.NewLineStringFlat(geom.XY, []float64{1, 2, 3, 4}),
+		),
+		geom.NewMultiPolygonFlat(geom.XY, []float64{0, 0, 1, 0, 1, 1, 0, 0, 0, 0, 1, 0, 1, 1, 0, 0}, [][]int{{8, 16}}),
+	)
+	data, err := Marshal(g, NDR)
+	assert.NoError(t, err)
+	for _, tc := range []struct {
+		name     string
+		limits   geom.Limits
+		expected error
+	}{
+		{
+			name:   "within limits",
+			limits: geom.Limits{MaxBytes: len(data), MaxCoords: 11, MaxParts: 2, MaxDepth: 2},
+		},
+		{
+			name:     "bytes",
+			limits:   geom.Limits{MaxBytes: len(data) - 1},
+			expected: geom.ErrLimitExceeded{Limit: geom.LimitBytes, N: len(data), Max: len(data) - 1},
+		},
+		{
+			name:     "coordinates",
+			limits:   geom.Limits{MaxCoords: 10},
+			expected: geom.ErrLimitExceeded{Limit: geom.LimitCoords, N: 11, Max: 10},
+		},
+		{
+			name:     "parts",
+			limits:   geom.Limits{MaxParts: 1},
+			expected: geom.ErrLimitExceeded{Limit: geom.LimitParts, N: 2, Max: 1},
+		},
+		{
+			name:     "depth",
+			limits:   geom.Limits{MaxDepth: 1},
+			expected: geom.ErrLimitExceeded{Limit: geom.LimitDepth, N: 2, Max: 1},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			opt := wkbcommon.WKBOptionLimits(tc.limits)
+			_, err := Unmarshal(data, opt)
+			assert.Equal(t, tc.expected, err)
+			_, err = NewDecoder(nil, opt).Decode(data)
+			assert.Equal(t, tc.expected, err)
+			_, err = Inspect(data, opt)
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+}
+
 func TestRandom(t *testing.T) {
 	for _, tc := range testdata.Random {
 		test(t, tc.G, nil, tc.WKB)
@@ -349,6 +693,17 @@ func BenchmarkUnmarshal(b *testing.B) {
 	}
 }
 
+func BenchmarkDecode(b *testing.B) {
+	d := NewDecoder(nil)
+	for range b.N {
+		for _, tc := range testdata.Random {
+			if _, err := d.Decode(tc.WKB); err != nil {
+				b.Errorf("decode error %v", err)
+			}
+		}
+	}
+}
+
 func BenchmarkMarshal(b *testing.B) {
 	for range b.N {
 		for _, tc := range testdata.Random {
@@ -0,0 +1,66 @@
+package wkbcommon
+
+import (
+	"github.com/don4get/go-geom"
+)
+
+// NewComposite returns a new CompoundCurve, CurvePolygon, MultiCurve, or
+// MultiSurface, depending on t, with the given layout and components. The
+// rings of CurvePolygons are encoded as LineStrings, which are converted to
+// LinearRings.
+func NewComposite(t Type, layout geom.Layout, components []geom.T) (geom.T, error) {
+	switch t {
+	case CompoundCurveID:
+		return push(geom.NewCompoundCurve(layout), components)
+	case CurvePolygonID:
+		for i, component := range components {
+			if ls, ok := component.(*geom.LineString); ok {
+				components[i] = geom.NewLinearRingFlat(ls.Layout, ls.FlatCoords)
+			}
+		}
+		return push(geom.NewCurvePolygon(layout), components)
+	case MultiCurveID:
+		return push(geom.NewMultiCurve(layout), components)
+	case MultiSurfaceID:
+		return push(geom.NewMultiSurface(layout), components)
+	default:
+		return nil, ErrUnsupportedType(t)
+	}
+}
+
+// Components returns the components of a CompoundCurve, CurvePolygon,
+// MultiCurve, or MultiSurface, and whether g is one of these types. The
+// LinearRings of CurvePolygons are converted to LineStrings, as they are
+// encoded.
+func Components(g geom.T) ([]geom.T, bool) {
+	switch g := g.(type) {
+	case *geom.CompoundCurve:
+		return g.Curves(), true
+	case *geom.CurvePolygon:
+		rings := make([]geom.T, g.NumRings())
+		for i, ring := range g.Rings() {
+			if lr, ok := ring.(*geom.LinearRing); ok {
+				rings[i] = geom.NewLineStringFlat(lr.Layout, lr.FlatCoords)
+			} else {
+				rings[i] = ring
+			}
+		}
+		return rings, true
+	case *geom.MultiCurve:
+		return g.Curves(), true
+	case *geom.MultiSurface:
+		return g.Surfaces(), true
+	default:
+		return nil, false
+	}
+}
+
+func push[G interface {
+	geom.T
+	Push(...geom.T) error
+}](g G, components []geom.T) (geom.T, error) {
+	if err := g.Push(components...); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
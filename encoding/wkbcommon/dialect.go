@@ -0,0 +1,152 @@
+package wkbcommon
+
+import (
+	"fmt"
+
+	"github.com/don4get/go-geom"
+)
+
+// A Dialect is a variant of WKB, which determines how the layout and the SRID
+// of a geometry are encoded in its type code.
+type Dialect uint8
+
+const (
+	// DialectISO is the ISO 13249-3 and OGC Simple Features 1.2 dialect, which
+	// adds 1000, 2000, or 3000 to the type code of geometries with Z, M, or ZM
+	// coordinates. It is used by GeoPackage, SpatiaLite, and SQL Server.
+	DialectISO Dialect = iota
+	// DialectOGC is the OGC Simple Features 1.1 dialect, which only supports
+	// geometries with XY coordinates.
+	DialectOGC
+	// DialectExtended is the extended dialect of PostGIS, which sets the
+	// 0x80000000, 0x40000000, and 0x20000000 bits of the type code of
+	// geometries with Z coordinates, M coordinates, or an SRID.
+	DialectExtended
+	// DialectAuto accepts any of the other dialects when reading, detecting
+	// the dialect from each type code. It cannot be used for writing.
+	DialectAuto
+)
+
+// Extended type code flags.
+const (
+	ExtendedZ    = 0x80000000
+	ExtendedM    = 0x40000000
+	ExtendedSRID = 0x20000000
+)
+
+func (d Dialect) String() string {
+	switch d {
+	case DialectISO:
+		return "ISO"
+	case DialectOGC:
+		return "OGC"
+	case DialectExtended:
+		return "extended"
+	case DialectAuto:
+		return "auto"
+	default:
+		return fmt.Sprintf("Dialect(%d)", uint8(d))
+	}
+}
+
+// An ErrUnsupportedDialect is returned when a dialect cannot be used.
+type ErrUnsupportedDialect Dialect
+
+func (e ErrUnsupportedDialect) Error() string {
+	return fmt.Sprintf("wkb: unsupported dialect: %s", Dialect(e))
+}
+
+// ParseType splits the type code t, in dialect d, into the geometry type ID
+// and the layout, and reports whether an SRID follows the type code.
+func ParseType(t uint32, d Dialect) (Type, geom.Layout, bool, error) {
+	if d == DialectAuto {
+		if t&(ExtendedZ|ExtendedM|ExtendedSRID) != 0 {
+			d = DialectExtended
+		} else {
+			d = DialectISO
+		}
+	}
+	switch d {
+	case DialectISO:
+		var layout geom.Layout
+		switch t / 1000 {
+		case 0:
+			layout = geom.XY
+		case 1:
+			layout = geom.XYZ
+		case 2:
+			layout = geom.XYM
+		case 3:
+			layout = geom.XYZM
+		default:
+			return 0, geom.NoLayout, false, ErrUnknownType(t)
+		}
+		return Type(t % 1000), layout, false, nil
+	case DialectOGC:
+		if t >= 1000 {
+			return 0, geom.NoLayout, false, ErrUnknownType(t)
+		}
+		return Type(t), geom.XY, false, nil
+	case DialectExtended:
+		var layout geom.Layout
+		switch t & (ExtendedZ | ExtendedM) {
+		case 0:
+			layout = geom.XY
+		case ExtendedZ:
+			layout = geom.XYZ
+		case ExtendedM:
+			layout = geom.XYM
+		default:
+			layout = geom.XYZM
+		}
+		return Type(t &^ (ExtendedZ | ExtendedM | ExtendedSRID)), layout, t&ExtendedSRID != 0, nil
+	default:
+		return 0, geom.NoLayout, false, ErrUnsupportedDialect(d)
+	}
+}
+
+// TypeCode returns the type code, in dialect d, of a geometry with type ID t,
+// the given layout, and the given SRID, and reports whether the SRID must
+// follow the type code. Only the extended dialect encodes SRIDs. Empty
+// GeometryCollections have no layout, which is encoded as XY.
+func TypeCode(t Type, layout geom.Layout, srid int, d Dialect) (uint32, bool, error) {
+	code := uint32(t)
+	switch d {
+	case DialectISO:
+		switch layout {
+		case geom.NoLayout, geom.XY:
+		case geom.XYZ:
+			code += 1000
+		case geom.XYM:
+			code += 2000
+		case geom.XYZM:
+			code += 3000
+		default:
+			return 0, false, geom.ErrUnsupportedLayout(layout)
+		}
+		return code, false, nil
+	case DialectOGC:
+		if layout != geom.NoLayout && layout != geom.XY {
+			return 0, false, geom.ErrUnsupportedLayout(layout)
+		}
+		return code, false, nil
+	case DialectExtended:
+		switch layout {
+		case geom.NoLayout, geom.XY:
+		case geom.XYZ:
+			code |= ExtendedZ
+		case geom.XYM:
+			code |= ExtendedM
+		case geom.XYZM:
+			code |= ExtendedZ | ExtendedM
+		default:
+			return 0, false, geom.ErrUnsupportedLayout(layout)
+		}
+		if srid != 0 {
+			code |= ExtendedSRID
+		}
+		return code, srid != 0, nil
+	default:
+		return 0, false, ErrUnsupportedDialect(d)
+	}
+}
@@ -0,0 +1,262 @@
+package wkbcommon
+
+import (
+	"encoding/binary"
+	"io"
+	"math"
+
+	"github.com/don4get/go-geom"
+)
+
+// An Info contains information about a WKB geometry.
+type Info struct {
+	// Type is the geometry type ID, without any layout or SRID flags.
+	Type Type
+	// Layout is the layout of the geometry.
+	Layout geom.Layout
+	// SRID is the SRID of the geometry, or zero if it has none.
+	SRID int
+	// NumPoints is the total number of points in the geometry, excluding empty
+	// points.
+	NumPoints int
+	// Bounds is the bounds of the points in the geometry. The bounds of
+	// curves are the bounds of their control points. The members of a
+	// GeometryCollection may have different layouts, so the layout of Bounds
+	// has every ordinate of the geometry and of its members.
+	Bounds *geom.Bounds
+}
+
+// Inspect returns information about the geometry encoded in data in dialect
+// d, without decoding the geometry. The numbers of elements are limited by
+// MaxGeometryElements and by limits, as when decoding.
+func Inspect(data []byte, d Dialect, limits geom.Limits) (*Info, error) {
+	if err := limits.CheckBytes(len(data)); err != nil {
+		return nil, err
+	}
+	byteOrder, t, layout, srid, offset, err := readHeader(data, 0, d)
+	if err != nil {
+		return nil, err
+	}
+	i := &inspector{
+		data:    data,
+		offset:  offset,
+		dialect: d,
+		counter: Counter{Limits: limits},
+	}
+	for j := range i.min {
+		i.min[j], i.max[j] = math.Inf(1), math.Inf(-1)
+	}
+	if err := i.inspectBody(byteOrder, t, layout); err != nil {
+		return nil, err
+	}
+	return &Info{
+		Type:      t,
+		Layout:    layout,
+		SRID:      int(srid),
+		NumPoints: i.numPoints,
+		Bounds:    i.bounds(),
+	}, nil
+}
+
+// An inspector walks the structure of a WKB geometry.
+type inspector struct {
+	data      []byte
+	offset    int
+	dialect   Dialect
+	counter   Counter
+	numPoints int
+	hasZ      bool
+	hasM      bool
+	// min and max are the bounds of the points in XYZM order.
+	min [4]float64
+	max [4]float64
+}
+
+// bounds returns the bounds of the points with every ordinate that i has seen.
+func (i *inspector) bounds() *geom.Bounds {
+	layout, indexes := geom.XY, []int{0, 1}
+	switch {
+	case i.hasZ && i.hasM:
+		layout, indexes = geom.XYZM, []int{0, 1, 2, 3}
+	case i.hasZ:
+		layout, indexes = geom.XYZ, []int{0, 1, 2}
+	case i.hasM:
+		layout, indexes = geom.XYM, []int{0, 1, 3}
+	}
+	args := make([]float64, 0, 2*len(indexes))
+	for _, j := range indexes {
+		args = append(args, i.min[j])
+	}
+	for _, j := range indexes {
+		args = append(args, i.max[j])
+	}
+	return geom.NewBounds(layout).Set(args...)
+}
+
+// inspectMember inspects a member of a collection with the given layout. The
+// members of homogeneous collections must have the collection's layout, but
+// the members of a GeometryCollection may have any layout.
+func (i *inspector) inspectMember(t Type, layout geom.Layout) error {
+	byteOrder, memberType, memberLayout, _, offset, err := readHeader(i.data, i.offset, i.dialect)
+	if err != nil {
+		return err
+	}
+	if t != GeometryCollectionID && memberLayout != layout {
+		return geom.ErrLayoutMismatch{Got: memberLayout, Want: layout}
+	}
+	i.offset = offset
+	return i.inspectBody(byteOrder, memberType, memberLayout)
+}
+
+// inspectBody inspects the rest of a geometry with type t and the given
+// layout.
+func (i *inspector) inspectBody(byteOrder binary.ByteOrder, t Type, layout geom.Layout) error {
+	i.hasZ = i.hasZ || layout.ZIndex() != -1
+	i.hasM = i.hasM || layout.MIndex() != -1
+	var level, size int
+	switch t {
+	case PointID:
+		return i.inspectCoords(byteOrder, 1, layout)
+	case LineStringID, CircularStringID:
+		return i.inspectLine(byteOrder, layout)
+	case PolygonID, TriangleID:
+		return i.inspectRings(byteOrder, layout)
+	case MultiPointID:
+		level, size = 1, 5
+	case MultiLineStringID, CompoundCurveID, CurvePolygonID, MultiCurveID, MultiSurfaceID:
+		level, size = 2, 5
+	case MultiPolygonID, PolyhedralSurfaceID, TINID:
+		level, size = 3, 5
+	case GeometryCollectionID:
+		if err := i.counter.Enter(); err != nil {
+			return err
+		}
+		defer i.counter.Leave()
+		level, size = 0, 5
+	default:
+		return ErrUnsupportedType(t)
+	}
+	n, err := i.readCount(byteOrder, level, size)
+	if err != nil {
+		return err
+	}
+	if err := i.counter.CheckParts(n); err != nil {
+		return err
+	}
+	for range n {
+		if err := i.inspectMember(t, layout); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// inspectRings inspects a number of rings.
+func (i *inspector) inspectRings(byteOrder binary.ByteOrder, layout geom.Layout) error {
+	n, err := i.readCount(byteOrder, 2, 4)
+	if err != nil {
+		return err
+	}
+	if err := i.counter.CheckParts(n); err != nil {
+		return err
+	}
+	for range n {
+		if err := i.inspectLine(byteOrder, layout); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// inspectLine inspects a number of coordinates.
+func (i *inspector) inspectLine(byteOrder binary.ByteOrder, layout geom.Layout) error {
+	n, err := i.readCount(byteOrder, 1, 8*layout.Stride())
+	if err != nil {
+		return err
+	}
+	return i.inspectCoords(byteOrder, n, layout)
+}
+
+// inspectCoords adds n coordinates with the given layout to the number of
+// points and the bounds. Empty points, whose ordinates are all NaN, are
+// skipped.
+func (i *inspector) inspectCoords(byteOrder binary.ByteOrder, n int, layout geom.Layout) error {
+	stride := layout.Stride()
+	if 8*n*stride > len(i.data)-i.offset {
+		return io.ErrUnexpectedEOF
+	}
+	if err := i.counter.AddCoords(n); err != nil {
+		return err
+	}
+	coord := make([]float64, stride)
+	for range n {
+		for j := range stride {
+			coord[j] = readFloat(i.data[i.offset+8*j:], byteOrder)
+		}
+		i.offset += 8 * stride
+		if IsEmptyPointCoord(coord) {
+			continue
+		}
+		i.numPoints++
+		for j, x := range coord {
+			switch j {
+			case layout.ZIndex():
+				j = 2
+			case layout.MIndex():
+				j = 3
+			}
+			if x < i.min[j] {
+				i.min[j] = x
+			}
+			if x > i.max[j] {
+				i.max[j] = x
+			}
+		}
+	}
+	return nil
+}
+
+// readCount reads the number of elements at level, each of which is at least
+// size bytes long. Level zero is not limited by MaxGeometryElements.
+func (i *inspector) readCount(byteOrder binary.ByteOrder, level, size int) (int, error) {
+	if len(i.data)-i.offset < 4 {
+		return 0, io.ErrUnexpectedEOF
+	}
+	n := byteOrder.Uint32(i.data[i.offset:])
+	i.offset += 4
+	if limit := MaxGeometryElements[level]; level > 0 && limit >= 0 && int(n) > limit {
+		return 0, ErrGeometryTooLarge{Level: level, N: int(n), Limit: limit}
+	}
+	if uint64(n)*uint64(size) > uint64(len(i.data)-i.offset) {
+		return 0, io.ErrUnexpectedEOF
+	}
+	return int(n), nil
+}
+
+// readHeader reads the byte order, the type, the layout, and the optional SRID
+// of the geometry at offset in data, and returns the offset after the header.
+func readHeader(data []byte, offset int, d Dialect) (binary.ByteOrder, Type, geom.Layout, uint32, int, error) {
+	if len(data)-offset < 5 {
+		return nil, 0, geom.NoLayout, 0, 0, io.ErrUnexpectedEOF
+	}
+	var byteOrder binary.ByteOrder
+	switch data[offset] {
+	case XDRID:
+		byteOrder = XDR
+	case NDRID:
+		byteOrder = NDR
+	default:
+		return nil, 0, geom.NoLayout, 0, 0, ErrUnknownByteOrder(data[offset])
+	}
+	t, layout, hasSRID, err := ParseType(byteOrder.Uint32(data[offset+1:]), d)
+	if err != nil {
+		return nil, 0, geom.NoLayout, 0, 0, err
+	}
+	if !hasSRID {
+		return byteOrder, t, layout, 0, offset + 5, nil
+	}
+	if len(data)-offset < 9 {
+		return nil, 0, geom.NoLayout, 0, 0, io.ErrUnexpectedEOF
+	}
+	return byteOrder, t, layout, byteOrder.Uint32(data[offset+5:]), offset + 9, nil
+}
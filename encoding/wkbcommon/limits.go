@@ -0,0 +1,88 @@
+package wkbcommon
+
+import (
+	"io"
+
+	"github.com/don4get/go-geom"
+)
+
+// A Counter counts the coordinates of a geometry and the nesting depth of its
+// GeometryCollections while it is decoded, and enforces Limits. A nil
+// *Counter enforces no limits.
+type Counter struct {
+	Limits geom.Limits
+	coords int
+	depth  int
+}
+
+// AddCoords adds n coordinates to the total number of coordinates.
+func (c *Counter) AddCoords(n int) error {
+	if c == nil {
+		return nil
+	}
+	c.coords += n
+	return c.Limits.CheckCoords(c.coords)
+}
+
+// CheckParts checks the number of parts of a geometry.
+func (c *Counter) CheckParts(n int) error {
+	if c == nil {
+		return nil
+	}
+	return c.Limits.CheckParts(n)
+}
+
+// Enter enters a GeometryCollection.
+func (c *Counter) Enter() error {
+	if c == nil {
+		return nil
+	}
+	c.depth++
+	return c.Limits.CheckDepth(c.depth)
+}
+
+// Leave leaves a GeometryCollection.
+func (c *Counter) Leave() {
+	if c == nil {
+		return
+	}
+	c.depth--
+}
+
+// A LimitedReader reads from an underlying io.Reader and enforces Limits on
+// the geometry that is read, including its size in bytes. The ReadFlatCoords
+// functions enforce the limits on coordinates and parts when they read from a
+// *LimitedReader.
+type LimitedReader struct {
+	Counter
+	r io.Reader
+	n int
+}
+
+// NewLimitedReader returns a new LimitedReader that reads from r.
+func NewLimitedReader(r io.Reader, limits geom.Limits) *LimitedReader {
+	return &LimitedReader{
+		Counter: Counter{Limits: limits},
+		r:       r,
+	}
+}
+
+// Read implements io.Reader. It fails without reading anything if reading p
+// would exceed the limit on the number of bytes.
+func (r *LimitedReader) Read(p []byte) (int, error) {
+	if err := r.Limits.CheckBytes(r.n + len(p)); err != nil {
+		return 0, err
+	}
+	n, err := r.r.Read(p)
+	r.n += n
+	return n, err
+}
+
+// CounterOf returns the Counter of r if r is a *LimitedReader, or nil
+// otherwise.
+func CounterOf(r io.Reader) *Counter {
+	if lr, ok := r.(*LimitedReader); ok {
+		return &lr.Counter
+	}
+	return nil
+}
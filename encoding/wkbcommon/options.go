@@ -1,5 +1,11 @@
 package wkbcommon
 
+import (
+	"math"
+
+	"github.com/don4get/go-geom"
+)
+
 // EmptyPointHandling is the mechanism to handle an empty point.
 type EmptyPointHandling uint8
 
@@ -11,9 +17,23 @@ const (
 	EmptyPointHandlingNaN
 )
 
+// IsEmptyPointCoord returns whether coord is the coordinate of an empty point,
+// whose ordinates are all the NaN geom.PointEmptyCoord, as recognized by
+// geom.NewPointFlatMaybeEmpty.
+func IsEmptyPointCoord(coord []float64) bool {
+	for _, x := range coord {
+		if math.Float64bits(x) != geom.PointEmptyCoordHex {
+			return false
+		}
+	}
+	return true
+}
+
 // WKBParams are parameters for encoding and decoding WKB items.
 type WKBParams struct {
 	EmptyPointHandling EmptyPointHandling
+	Dialect            Dialect
+	Limits             geom.Limits
 }
 
 // WKBOption is an option to set on WKBParams.
@@ -27,6 +47,22 @@ func WKBOptionEmptyPointHandling(h EmptyPointHandling) WKBOption {
 	}
 }
 
+// WKBOptionDialect sets the params to the specified Dialect.
+func WKBOptionDialect(d Dialect) WKBOption {
+	return func(p WKBParams) WKBParams {
+		p.Dialect = d
+		return p
+	}
+}
+
+// WKBOptionLimits sets the params to the specified Limits.
+func WKBOptionLimits(limits geom.Limits) WKBOption {
+	return func(p WKBParams) WKBParams {
+		p.Limits = limits
+		return p
+	}
+}
+
 // InitWKBParams initializes WKBParams from an initial parameter and some options.
 func InitWKBParams(params WKBParams, opts ...WKBOption) WKBParams {
 	for _, opt := range opts {
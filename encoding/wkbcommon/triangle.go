@@ -0,0 +1,34 @@
+package wkbcommon
+
+import "github.com/don4get/go-geom"
+
+// An ErrInvalidTriangle is returned when a Triangle is neither empty nor a
+// single closed ring of four points.
+type ErrInvalidTriangle struct{}
+
+func (e ErrInvalidTriangle) Error() string {
+	return "wkb: a triangle has a single closed ring of 4 points"
+}
+
+// CheckTriangle returns an error if the rings ending at ends in flatCoords,
+// starting at offset, are not a valid Triangle. Like in WKT, the first and
+// last points must have the same x and y, and z if layout has one.
+func CheckTriangle(layout geom.Layout, flatCoords []float64, offset int, ends []int) error {
+	if len(ends) == 0 {
+		return nil
+	}
+	stride := layout.Stride()
+	if len(ends) != 1 || ends[0]-offset != 4*stride {
+		return ErrInvalidTriangle{}
+	}
+	dimensions := 2
+	if layout.ZIndex() != -1 {
+		dimensions = 3
+	}
+	for i := range dimensions {
+		if flatCoords[offset+i] != flatCoords[ends[0]-stride+i] {
+			return ErrInvalidTriangle{}
+		}
+	}
+	return nil
+}
@@ -98,13 +98,22 @@ const (
 	MultiLineStringID    = 5
 	MultiPolygonID       = 6
 	GeometryCollectionID = 7
+	CircularStringID     = 8
+	CompoundCurveID      = 9
+	CurvePolygonID       = 10
+	MultiCurveID         = 11
+	MultiSurfaceID       = 12
 	PolyhedralSurfaceID  = 15
 	TINID                = 16
 	TriangleID           = 17
 )
 
-// ReadFlatCoords0 reads flat coordinates 0.
+// ReadFlatCoords0 reads flat coordinates 0. If r is a *LimitedReader then its
+// limits are enforced.
 func ReadFlatCoords0(r io.Reader, byteOrder binary.ByteOrder, stride int) ([]float64, error) {
+	if err := CounterOf(r).AddCoords(1); err != nil {
+		return nil, err
+	}
 	coord := make([]float64, stride)
 	if err := ReadFloatArray(r, byteOrder, coord); err != nil {
 		return nil, err
@@ -112,7 +121,8 @@ func ReadFlatCoords0(r io.Reader, byteOrder binary.ByteOrder, stride int) ([]flo
 	return coord, nil
 }
 
-// ReadFlatCoords1 reads flat coordinates 1.
+// ReadFlatCoords1 reads flat coordinates 1. If r is a *LimitedReader then its
+// limits are enforced.
 func ReadFlatCoords1(r io.Reader, byteOrder binary.ByteOrder, stride int) ([]float64, error) {
 	n, err := ReadUInt32(r, byteOrder)
 	if err != nil {
@@ -121,6 +131,9 @@ func ReadFlatCoords1(r io.Reader, byteOrder binary.ByteOrder, stride int) ([]flo
 	if limit := MaxGeometryElements[1]; limit >= 0 && int(n) > limit {
 		return nil, ErrGeometryTooLarge{Level: 1, N: int(n), Limit: limit}
 	}
+	if err := CounterOf(r).AddCoords(int(n)); err != nil {
+		return nil, err
+	}
 	flatCoords := make([]float64, int(n)*stride)
 	if err := ReadFloatArray(r, byteOrder, flatCoords); err != nil {
 		return nil, err
@@ -128,7 +141,8 @@ func ReadFlatCoords1(r io.Reader, byteOrder binary.ByteOrder, stride int) ([]flo
 	return flatCoords, nil
 }
 
-// ReadFlatCoords2 reads flat coordinates 2.
+// ReadFlatCoords2 reads flat coordinates 2. If r is a *LimitedReader then its
+// limits are enforced.
 func ReadFlatCoords2(r io.Reader, byteOrder binary.ByteOrder, stride int) ([]float64, []int, error) {
 	n, err := ReadUInt32(r, byteOrder)
 	if err != nil {
@@ -137,6 +151,9 @@ func ReadFlatCoords2(r io.Reader, byteOrder binary.ByteOrder, stride int) ([]flo
 	if limit := MaxGeometryElements[2]; limit >= 0 && int(n) > limit {
 		return nil, nil, ErrGeometryTooLarge{Level: 2, N: int(n), Limit: limit}
 	}
+	if err := CounterOf(r).CheckParts(int(n)); err != nil {
+		return nil, nil, err
+	}
 	var flatCoordss []float64
 	ends := make([]int, n)
 	for i := range n {
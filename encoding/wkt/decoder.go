@@ -0,0 +1,124 @@
+package wkt
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"unicode"
+
+	"github.com/don4get/go-geom"
+)
+
+// A Decoder decodes a stream of WKT geometries, for example one geometry per
+// line. Geometries are separated by newlines or semicolons, except for the
+// semicolon of an Extended WKT SRID=n; prefix, so each geometry must be on a
+// single line. Empty records are ignored.
+type Decoder struct {
+	r      *bufio.Reader
+	opts   decodeOptions
+	buf    []byte
+	offset int
+	line   int
+	err    error
+}
+
+// NewDecoder returns a new Decoder that reads from r.
+func NewDecoder(r io.Reader, opts ...DecodeOption) *Decoder {
+	d := &Decoder{
+		r:    bufio.NewReader(r),
+		line: 1,
+	}
+	for _, opt := range opts {
+		opt(&d.opts)
+	}
+	return d
+}
+
+// Decode returns the next geometry in the stream, or io.EOF if there are no
+// more geometries. If a record cannot be decoded, the error is returned and
+// the next call to Decode continues with the following record. The Offset and
+// Line of a *SyntaxError are positions in the whole stream. Errors reading
+// from the underlying reader are returned by all subsequent calls.
+func (d *Decoder) Decode() (geom.T, error) {
+	for {
+		offset, line, n, err := d.readRecord()
+		if err != nil {
+			return nil, err
+		}
+		g, err := d.decodeRecord(offset, line, n)
+		if err == nil {
+			return g, nil
+		}
+		if !d.opts.skipInvalid {
+			return nil, err
+		}
+		if d.opts.onInvalid != nil {
+			d.opts.onInvalid(err)
+		}
+	}
+}
+
+// decodeRecord decodes the record of n bytes at offset and line in the stream,
+// of which d.buf holds at most the limit on the number of bytes.
+func (d *Decoder) decodeRecord(offset, line, n int) (geom.T, error) {
+	if err := d.opts.limits.CheckBytes(n); err != nil {
+		return nil, fmt.Errorf("line %d: %w", line, err)
+	}
+	g, err := unmarshal(string(d.buf), &d.opts)
+	if err == nil {
+		return g, nil
+	}
+	var syntaxErr *SyntaxError
+	if errors.As(err, &syntaxErr) {
+		syntaxErr.Offset += offset
+		syntaxErr.Line += line - 1
+		return nil, syntaxErr
+	}
+	return nil, fmt.Errorf("line %d: %w", line, err)
+}
+
+// readRecord reads the next non-empty record into d.buf, and returns its
+// offset, its line, and its length. Leading whitespace is skipped. Only the
+// bytes within the limit on the number of bytes are kept, so that long records
+// do not use unbounded memory.
+func (d *Decoder) readRecord() (offset, line, n int, err error) {
+	if d.err != nil {
+		return 0, 0, 0, d.err
+	}
+	d.buf = d.buf[:0]
+	for {
+		c, err := d.r.ReadByte()
+		if err != nil {
+			d.err = err
+			if n > 0 && errors.Is(err, io.EOF) {
+				return offset, line, n, nil
+			}
+			return 0, 0, 0, err
+		}
+		d.offset++
+		if c == '\n' {
+			d.line++
+		}
+		if n == 0 {
+			if c == ';' || unicode.IsSpace(rune(c)) {
+				continue
+			}
+			offset, line = d.offset-1, d.line
+		}
+		if c == '\n' || c == ';' && !d.inSRIDPrefix() {
+			return offset, line, n, nil
+		}
+		n++
+		if maxBytes := d.opts.limits.MaxBytes; maxBytes <= 0 || n <= maxBytes {
+			d.buf = append(d.buf, c)
+		}
+	}
+}
+
+// inSRIDPrefix returns whether the current record is an Extended WKT SRID=n;
+// prefix whose semicolon has not been read yet.
+func (d *Decoder) inSRIDPrefix() bool {
+	return len(d.buf) >= 4 && bytes.EqualFold(d.buf[:4], []byte("SRID")) && bytes.IndexByte(d.buf, ';') == -1
+}
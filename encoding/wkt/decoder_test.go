@@ -0,0 +1,179 @@
+package wkt
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/alecthomas/assert/v2"
+
+	"github.com/don4get/go-geom"
+)
+
+func TestDecoder(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		s        string
+		expected []geom.T
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name: "blank_lines",
+			s:    "\n  \n;\r\n",
+		},
+		{
+			name: "newlines",
+			s:    "POINT (1 2)\nLINESTRING (1 2, 3 4)\n",
+			expected: []geom.T{
+				geom.NewPointFlat(geom.XY, []float64{1, 2}),
+				geom.NewLineStringFlat(geom.XY, []float64{1, 2, 3, 4}),
+			},
+		},
+		{
+			name: "crlf_without_final_newline",
+			s:    "POINT (1 2)\r\n\r\nPOINT Z (3 4 5)",
+			expected: []geom.T{
+				geom.NewPointFlat(geom.XY, []float64{1, 2}),
+				geom.NewPointFlat(geom.XYZ, []float64{3, 4, 5}),
+			},
+		},
+		{
+			name: "semicolons",
+			s:    "POINT (1 2); POINT EMPTY;POINT (3 4);",
+			expected: []geom.T{
+				geom.NewPointFlat(geom.XY, []float64{1, 2}),
+				geom.NewPointEmpty(geom.XY),
+				geom.NewPointFlat(geom.XY, []float64{3, 4}),
+			},
+		},
+		{
+			name: "ewkt",
+			s:    "SRID=4326;POINT (1 2);srid=3857; POINT (3 4)\nPOINT (5 6)",
+			expected: []geom.T{
+				geom.NewPointFlat(geom.XY, []float64{1, 2}).SetSRID(4326),
+				geom.NewPointFlat(geom.XY, []float64{3, 4}).SetSRID(3857),
+				geom.NewPointFlat(geom.XY, []float64{5, 6}),
+			},
+		},
+		{
+			name: "geometry_collection",
+			s:    "GEOMETRYCOLLECTION (POINT (1 2), POINT (3 4))\nPOINT (5 6)",
+			expected: []geom.T{
+				geom.NewGeometryCollection().MustPush(
+					geom.NewPointFlat(geom.XY, []float64{1, 2}),
+					geom.NewPointFlat(geom.XY, []float64{3, 4}),
+				).MustSetLayout(geom.XY),
+				geom.NewPointFlat(geom.XY, []float64{5, 6}),
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			d := NewDecoder(iotest.OneByteReader(strings.NewReader(tc.s)))
+			var got []geom.T
+			for {
+				g, err := d.Decode()
+				if errors.Is(err, io.EOF) {
+					break
+				}
+				assert.NoError(t, err)
+				got = append(got, g)
+			}
+			assert.Equal(t, tc.expected, got)
+			_, err := d.Decode()
+			assert.Equal(t, io.EOF, err)
+		})
+	}
+}
+
+func TestDecoderErrors(t *testing.T) {
+	s := "POINT (1 2)\nPOINT (1 2\nLINESTRING (1 2)\n  POINT (3 4) ; POINT (1 2, 3)\nPOINT (5 6)"
+	d := NewDecoder(strings.NewReader(s))
+	for _, expected := range []struct {
+		g      geom.T
+		offset int
+		line   int
+	}{
+		{g: geom.NewPointFlat(geom.XY, []float64{1, 2})},
+		{offset: 22, line: 2},
+		{offset: 38, line: 3},
+		{g: geom.NewPointFlat(geom.XY, []float64{3, 4})},
+		{offset: 66, line: 4},
+		{g: geom.NewPointFlat(geom.XY, []float64{5, 6})},
+	} {
+		g, err := d.Decode()
+		if expected.g != nil {
+			assert.NoError(t, err)
+			assert.Equal(t, expected.g, g)
+			continue
+		}
+		var syntaxErr *SyntaxError
+		assert.True(t, errors.As(err, &syntaxErr))
+		assert.Equal(t, expected.offset, syntaxErr.Offset)
+		assert.Equal(t, expected.line, syntaxErr.Line)
+	}
+	_, err := d.Decode()
+	assert.Equal(t, io.EOF, err)
+}
+
+func TestDecoderSkipInvalid(t *testing.T) {
+	s := "POINT (1 2)\nPOINT (\nPOINT (3 4)\nLINESTRING (1 2, 3 4, 5 6)\nPOINT (5 6)"
+	var errs []error
+	d := NewDecoder(
+		strings.NewReader(s),
+		DecodeOptionWithLimits(geom.Limits{MaxCoords: 2}),
+		DecodeOptionWithSkipInvalid(func(err error) {
+			errs = append(errs, err)
+		}),
+	)
+	var got []geom.T
+	for {
+		g, err := d.Decode()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		assert.NoError(t, err)
+		got = append(got, g)
+	}
+	assert.Equal(t, []geom.T{
+		geom.NewPointFlat(geom.XY, []float64{1, 2}),
+		geom.NewPointFlat(geom.XY, []float64{3, 4}),
+		geom.NewPointFlat(geom.XY, []float64{5, 6}),
+	}, got)
+	assert.Equal(t, 2, len(errs))
+	var syntaxErr *SyntaxError
+	assert.True(t, errors.As(errs[0], &syntaxErr))
+	assert.Equal(t, 2, syntaxErr.Line)
+	var limitErr geom.ErrLimitExceeded
+	assert.True(t, errors.As(errs[1], &limitErr))
+	assert.Equal(t, "line 4: geom: coordinates limit exceeded, 3 > 2", errs[1].Error())
+}
+
+func TestDecoderLimits(t *testing.T) {
+	s := "POINT (1 2)\nLINESTRING (1 2, 3 4, 5 6, 7 8)\nPOINT (3 4)"
+	d := NewDecoder(strings.NewReader(s), DecodeOptionWithLimits(geom.Limits{MaxBytes: 16}))
+
+	_, err := d.Decode()
+	assert.NoError(t, err)
+	_, err = d.Decode()
+	assert.Equal(t, "line 2: geom: bytes limit exceeded, 31 > 16", err.Error())
+	assert.True(t, len(d.buf) <= 16)
+	g, err := d.Decode()
+	assert.NoError(t, err)
+	assert.Equal[geom.T](t, geom.NewPointFlat(geom.XY, []float64{3, 4}), g)
+}
+
+func TestDecoderReadError(t *testing.T) {
+	errRead := errors.New("read error")
+	d := NewDecoder(io.MultiReader(strings.NewReader("POINT (1 2)\nPOINT (3"), iotest.ErrReader(errRead)))
+
+	_, err := d.Decode()
+	assert.NoError(t, err)
+	for range 2 {
+		_, err = d.Decode()
+		assert.Equal(t, errRead, err)
+	}
+}
@@ -10,33 +10,56 @@ import (
 // Encode translates a geometry to the corresponding WKT.
 func (e *Encoder) Encode(g geom.T) (string, error) {
 	sb := &strings.Builder{}
-	if err := e.write(sb, g); err != nil {
+	if srid := g.GetSRID(); e.ewkt && srid != 0 {
+		sb.WriteString("SRID=" + strconv.Itoa(srid) + ";")
+	}
+	if err := e.write(sb, g, 0); err != nil {
 		return "", err
 	}
 	return sb.String(), nil
 }
 
-func (e *Encoder) write(sb *strings.Builder, g geom.T) error {
+func (e *Encoder) write(sb *strings.Builder, g geom.T, depth int) error {
 	var typeString string
+	var empty bool
 	switch g := g.(type) {
 	case *geom.Point:
-		typeString = tPoint
-	case *geom.LineString, *geom.LinearRing:
-		typeString = tLineString
+		typeString, empty = tPoint, g.IsEmpty()
+	case *geom.LineString:
+		typeString, empty = tLineString, g.IsEmpty()
+	case *geom.LinearRing:
+		typeString, empty = tLineString, g.IsEmpty()
 	case *geom.Polygon:
-		typeString = tPolygon
+		typeString, empty = tPolygon, g.IsEmpty()
 	case *geom.MultiPoint:
-		typeString = tMultiPoint
+		typeString, empty = tMultiPoint, g.NumPoints() == 0
 	case *geom.MultiLineString:
-		typeString = tMultiLineString
+		typeString, empty = tMultiLineString, g.NumLineStrings() == 0
 	case *geom.MultiPolygon:
-		typeString = tMultiPolygon
+		typeString, empty = tMultiPolygon, g.NumPolygons() == 0
 	case *geom.GeometryCollection:
-		typeString = tGeometryCollection
+		typeString, empty = tGeometryCollection, g.NumGeoms() == 0
+	case *geom.CircularString:
+		typeString, empty = tCircularString, g.IsEmpty()
+	case *geom.CompoundCurve:
+		typeString, empty = tCompoundCurve, g.NumCurves() == 0
+	case *geom.CurvePolygon:
+		typeString, empty = tCurvePolygon, g.NumRings() == 0
+	case *geom.MultiCurve:
+		typeString, empty = tMultiCurve, g.NumCurves() == 0
+	case *geom.MultiSurface:
+		typeString, empty = tMultiSurface, g.NumSurfaces() == 0
+	case *geom.Triangle:
+		typeString, empty = tTriangle, g.IsEmpty()
+	case *geom.TIN:
+		typeString, empty = tTIN, g.NumTriangles() == 0
+	case *geom.PolyhedralSurface:
+		typeString, empty = tPolyhedralSurface, g.NumPolygons() == 0
 	default:
 		return geom.ErrUnsupportedType{Value: g}
 	}
 	layout := g.GetLayout()
+	var dimString string
 	switch layout {
 	case geom.NoLayout:
 		// Special case for empty GeometryCollections
@@ -45,74 +68,122 @@ func (e *Encoder) write(sb *strings.Builder, g geom.T) error {
 		}
 	case geom.XY:
 	case geom.XYZ:
-		typeString += tZ
+		dimString = tZ
 	case geom.XYM:
-		typeString += tM
+		dimString = tM
 	case geom.XYZM:
-		typeString += tZm
+		dimString = tZm
 	default:
 		return geom.ErrUnsupportedLayout(layout)
 	}
+	if dimString != "" {
+		switch e.dimensionStyle {
+		case DimensionStyleKeyword:
+			typeString += " " + dimString
+		case DimensionStyleSuffix:
+			typeString += dimString
+		}
+	}
+	if empty || e.dimensionStyle != DimensionStyleSuffix {
+		typeString += " "
+	}
 	if _, err := sb.WriteString(typeString); err != nil {
 		return err
 	}
+	if empty {
+		return e.writeEMPTY(sb)
+	}
 	switch g := g.(type) {
 	case *geom.Point:
-		if g.IsEmpty() {
-			return e.writeEMPTY(sb)
-		}
 		return e.writeFlatCoords0(sb, g.GetFlatCoords(), layout.Stride())
 	case *geom.LineString:
-		if g.IsEmpty() {
-			return e.writeEMPTY(sb)
-		}
 		return e.writeFlatCoords1(sb, g.GetFlatCoords(), layout.Stride())
 	case *geom.LinearRing:
-		if g.IsEmpty() {
-			return e.writeEMPTY(sb)
-		}
 		return e.writeFlatCoords1(sb, g.GetFlatCoords(), layout.Stride())
 	case *geom.Polygon:
-		if g.IsEmpty() {
-			return e.writeEMPTY(sb)
-		}
 		return e.writeFlatCoords2(sb, g.GetFlatCoords(), 0, g.GetEnds(), layout.Stride())
 	case *geom.MultiPoint:
-		if g.NumPoints() == 0 {
-			return e.writeEMPTY(sb)
-		}
 		return e.writeFlatCoords1Ends(sb, g.GetFlatCoords(), 0, g.GetEnds())
 	case *geom.MultiLineString:
-		if g.NumLineStrings() == 0 {
-			return e.writeEMPTY(sb)
-		}
 		return e.writeFlatCoords2(sb, g.GetFlatCoords(), 0, g.GetEnds(), layout.Stride())
 	case *geom.MultiPolygon:
-		if g.NumPolygons() == 0 {
-			return e.writeEMPTY(sb)
-		}
+		return e.writeFlatCoords3(sb, g.GetFlatCoords(), g.GetEndss(), layout.Stride())
+	case *geom.Triangle:
+		return e.writeFlatCoords2(sb, g.GetFlatCoords(), 0, g.GetEnds(), layout.Stride())
+	case *geom.TIN:
+		return e.writeFlatCoords3(sb, g.GetFlatCoords(), g.GetEndss(), layout.Stride())
+	case *geom.PolyhedralSurface:
 		return e.writeFlatCoords3(sb, g.GetFlatCoords(), g.GetEndss(), layout.Stride())
 	case *geom.GeometryCollection:
-		if g.NumGeoms() == 0 {
-			return e.writeEMPTY(sb)
+		return e.writeGeometryCollection(sb, g, depth)
+	case *geom.CircularString:
+		return e.writeFlatCoords1(sb, g.GetFlatCoords(), layout.Stride())
+	case *geom.CompoundCurve:
+		return e.writeComponents(sb, g.Curves(), layout.Stride(), depth)
+	case *geom.CurvePolygon:
+		return e.writeComponents(sb, g.Rings(), layout.Stride(), depth)
+	case *geom.MultiCurve:
+		return e.writeComponents(sb, g.Curves(), layout.Stride(), depth)
+	case *geom.MultiSurface:
+		return e.writeComponents(sb, g.Surfaces(), layout.Stride(), depth)
+	}
+	return nil
+}
+
+// writeGeometryCollection writes the members of a non-empty
+// GeometryCollection at the given nesting depth. If an indent is set, each
+// member is written on its own line.
+func (e *Encoder) writeGeometryCollection(sb *strings.Builder, g *geom.GeometryCollection, depth int) error {
+	open, sep, closing := "(", ", ", ")"
+	if e.indent != "" {
+		newline := "\n" + strings.Repeat(e.indent, depth+1)
+		open, sep, closing = "("+newline, ","+newline, "\n"+strings.Repeat(e.indent, depth)+")"
+	}
+	if _, err := sb.WriteString(open); err != nil {
+		return err
+	}
+	for i, g := range g.Geoms() {
+		if i != 0 {
+			if _, err := sb.WriteString(sep); err != nil {
+				return err
+			}
 		}
-		if _, err := sb.WriteRune('('); err != nil {
+		if err := e.write(sb, g, depth+1); err != nil {
 			return err
 		}
-		for i, g := range g.Geoms() {
-			if i != 0 {
-				if _, err := sb.WriteString(", "); err != nil {
-					return err
-				}
-			}
-			if err := e.write(sb, g); err != nil {
+	}
+	_, err := sb.WriteString(closing)
+	return err
+}
+
+// writeComponents writes the components of a curved geometry. Linear
+// components are written without a type keyword, and curved components with
+// one.
+func (e *Encoder) writeComponents(sb *strings.Builder, gs []geom.T, stride, depth int) error {
+	if _, err := sb.WriteRune('('); err != nil {
+		return err
+	}
+	for i, g := range gs {
+		if i != 0 {
+			if _, err := sb.WriteString(", "); err != nil {
 				return err
 			}
 		}
-		_, err := sb.WriteRune(')')
-		return err
+		var err error
+		switch g := g.(type) {
+		case *geom.LineString, *geom.LinearRing:
+			err = e.writeFlatCoords1(sb, g.GetFlatCoords(), stride)
+		case *geom.Polygon:
+			err = e.writeFlatCoords2(sb, g.GetFlatCoords(), 0, g.GetEnds(), stride)
+		default:
+			err = e.write(sb, g, depth)
+		}
+		if err != nil {
+			return err
+		}
 	}
-	return nil
+	_, err := sb.WriteRune(')')
+	return err
 }
 
 func (e *Encoder) writeCoord(sb *strings.Builder, coord []float64) error {
@@ -123,7 +194,7 @@ func (e *Encoder) writeCoord(sb *strings.Builder, coord []float64) error {
 			}
 		}
 		coordStr := strconv.FormatFloat(x, 'f', e.maxDecimalDigits, 64)
-		if e.maxDecimalDigits > 0 {
+		if e.maxDecimalDigits > 0 && !e.fixedDecimalDigits {
 			coordStr = strings.TrimRight(strings.TrimRight(coordStr, "0"), ".")
 		}
 		if _, err := sb.WriteString(coordStr); err != nil {
@@ -184,6 +255,10 @@ func (e *Encoder) writeFlatCoords1Ends(
 			if err := e.writeEMPTY(sb); err != nil {
 				return err
 			}
+		} else if e.multiPointParentheses {
+			if err := e.writeFlatCoords0(sb, flatCoords[start:end], end-start); err != nil {
+				return err
+			}
 		} else {
 			if err := e.writeCoord(sb, flatCoords[start:end]); err != nil {
 				return err
@@ -2,6 +2,7 @@ package wkt
 
 import (
 	"fmt"
+	"math"
 	"strconv"
 	"strings"
 	"unicode"
@@ -75,7 +76,7 @@ func (l *wktLex) Lex(yylval *wktSymType) int {
 	switch c := l.peek(); c {
 	case eof:
 		return eof
-	case '(', ')', ',':
+	case '(', ')', ',', '=', ';':
 		return int(l.next())
 	default:
 		switch {
@@ -105,7 +106,7 @@ func (l *wktLex) keyword() int {
 	}
 
 	// Check for extra dimensions for geometry types.
-	if b.String() != "EMPTY" {
+	if b.String() != "EMPTY" && b.String() != "SRID" {
 		l.trimLeft()
 		if unicode.ToUpper(l.peek()) == 'Z' {
 			l.next()
@@ -318,6 +319,41 @@ func (l *wktLex) isValidLineString(flatCoords []float64) bool {
 	return true
 }
 
+func (l *wktLex) isValidCircularString(flatCoords []float64) bool {
+	stride := l.curLayout().Stride()
+	if n := len(flatCoords) / stride; n < 3 || n%2 == 0 {
+		l.setParseError("circularstring has the wrong number of points", "number of points must be odd and at least 3")
+		return false
+	}
+	return true
+}
+
+func (l *wktLex) isValidTriangle(ends []int) bool {
+	if len(ends) != 1 || ends[0] != 4*l.curLayout().Stride() {
+		l.setParseError("triangle has the wrong number of points", "a triangle has a single ring of 4 points")
+		return false
+	}
+	return true
+}
+
+func (l *wktLex) isValidTIN(endss [][]int) bool {
+	offset := 0
+	for _, ends := range endss {
+		if len(ends) == 0 {
+			continue
+		}
+		if len(ends) != 1 {
+			l.setParseError("triangle has the wrong number of rings", "a triangle has a single ring")
+			return false
+		}
+		if !l.isValidTriangle([]int{ends[0] - offset}) {
+			return false
+		}
+		offset = ends[0]
+	}
+	return true
+}
+
 func (l *wktLex) isValidPolygonRing(flatCoords []float64) bool {
 	stride := l.curLayout().Stride()
 	if len(flatCoords) < 4*stride {
@@ -337,6 +373,15 @@ func (l *wktLex) isValidPolygonRing(flatCoords []float64) bool {
 	return true
 }
 
+// isValidSRID returns whether srid, parsed from an EWKT SRID=n; prefix, is a valid SRID.
+func (l *wktLex) isValidSRID(srid float64) bool {
+	if srid != math.Trunc(srid) || srid < 0 || math.MaxInt32 < srid {
+		l.setParseError(fmt.Sprintf("invalid SRID %v", srid), "the SRID must be a non-negative integer")
+		return false
+	}
+	return true
+}
+
 // setLayoutIfNoLayout sets the parsed layout if no layout has been determined yet.
 func (l *wktLex) setLayoutIfNoLayout(layout geom.Layout) {
 	if l.curLayout() == geom.NoLayout {
@@ -401,9 +446,10 @@ func (l *wktLex) Error(s string) {
 // setSyntaxError is called when a syntax error occurs.
 func (l *wktLex) setSyntaxError(problem, hint string) {
 	l.setError(&SyntaxError{
+		Offset:    l.lastPos.wktPos,
+		Line:      l.lastPos.lineNum + 1,
 		wkt:       l.wkt,
 		problem:   problem,
-		lineNum:   l.lastPos.lineNum + 1,
 		lineStart: l.lastPos.lineStart,
 		linePos:   l.lastPos.linePos,
 		hint:      hint,
@@ -446,6 +492,7 @@ func isNumRune(r rune) bool {
 // keywordsMap defines a map from strings to tokens.
 var keywordsMap = map[string]int{
 	"EMPTY": EMPTY,
+	"SRID":  SRID,
 	"POINT": POINT, "POINTM": POINTM, "POINTZ": POINTZ, "POINTZM": POINTZM,
 	"LINESTRING": LINESTRING, "LINESTRINGM": LINESTRINGM, "LINESTRINGZ": LINESTRINGZ, "LINESTRINGZM": LINESTRINGZM,
 	"POLYGON": POLYGON, "POLYGONM": POLYGONM, "POLYGONZ": POLYGONZ, "POLYGONZM": POLYGONZM,
@@ -456,6 +503,19 @@ var keywordsMap = map[string]int{
 	"MULTIPOLYGONZ": MULTIPOLYGONZ, "MULTIPOLYGONZM": MULTIPOLYGONZM,
 	"GEOMETRYCOLLECTION": GEOMETRYCOLLECTION, "GEOMETRYCOLLECTIONM": GEOMETRYCOLLECTIONM,
 	"GEOMETRYCOLLECTIONZ": GEOMETRYCOLLECTIONZ, "GEOMETRYCOLLECTIONZM": GEOMETRYCOLLECTIONZM,
+	"CIRCULARSTRING": CIRCULARSTRING, "CIRCULARSTRINGM": CIRCULARSTRINGM,
+	"CIRCULARSTRINGZ": CIRCULARSTRINGZ, "CIRCULARSTRINGZM": CIRCULARSTRINGZM,
+	"COMPOUNDCURVE": COMPOUNDCURVE, "COMPOUNDCURVEM": COMPOUNDCURVEM,
+	"COMPOUNDCURVEZ": COMPOUNDCURVEZ, "COMPOUNDCURVEZM": COMPOUNDCURVEZM,
+	"CURVEPOLYGON": CURVEPOLYGON, "CURVEPOLYGONM": CURVEPOLYGONM,
+	"CURVEPOLYGONZ": CURVEPOLYGONZ, "CURVEPOLYGONZM": CURVEPOLYGONZM,
+	"MULTICURVE": MULTICURVE, "MULTICURVEM": MULTICURVEM, "MULTICURVEZ": MULTICURVEZ, "MULTICURVEZM": MULTICURVEZM,
+	"MULTISURFACE": MULTISURFACE, "MULTISURFACEM": MULTISURFACEM,
+	"MULTISURFACEZ": MULTISURFACEZ, "MULTISURFACEZM": MULTISURFACEZM,
+	"TRIANGLE": TRIANGLE, "TRIANGLEM": TRIANGLEM, "TRIANGLEZ": TRIANGLEZ, "TRIANGLEZM": TRIANGLEZM,
+	"TIN": TIN, "TINM": TINM, "TINZ": TINZ, "TINZM": TINZM,
+	"POLYHEDRALSURFACE": POLYHEDRALSURFACE, "POLYHEDRALSURFACEM": POLYHEDRALSURFACEM,
+	"POLYHEDRALSURFACEZ": POLYHEDRALSURFACEZ, "POLYHEDRALSURFACEZM": POLYHEDRALSURFACEZM,
 }
 
 // keywordToken returns the yacc token for a WKT keyword.
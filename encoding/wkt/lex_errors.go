@@ -7,9 +7,13 @@ import (
 
 // SyntaxError is an error that occurs during parsing of a WKT string.
 type SyntaxError struct {
+	// Offset is the byte offset of the problem in the input.
+	Offset int
+	// Line is the line number of the problem in the input, starting at 1.
+	Line int
+
 	wkt       string
 	problem   string
-	lineNum   int
 	lineStart int
 	linePos   int
 	hint      string
@@ -25,7 +29,7 @@ func (e *SyntaxError) Error() string {
 	)
 
 	// Print the problem along with line and pos number.
-	err := fmt.Sprintf("syntax error: %s at line %d, pos %d\n", e.problem, e.lineNum, e.linePos)
+	err := fmt.Sprintf("syntax error: %s at line %d, pos %d\n", e.problem, e.Line, e.linePos)
 
 	// Find the position of the end of the line.
 	lineEnd := strings.IndexRune(e.wkt[e.lineStart:], '\n')
@@ -36,7 +40,7 @@ func (e *SyntaxError) Error() string {
 	}
 
 	// Prepend the line with the line number.
-	strLinePrefix := fmt.Sprintf("LINE %d: ", e.lineNum)
+	strLinePrefix := fmt.Sprintf("LINE %d: ", e.Line)
 	strLineSuffix := "\n"
 
 	// Trim the start and end of the line as needed.
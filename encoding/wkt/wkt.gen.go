@@ -47,8 +47,41 @@ const (
 	GEOMETRYCOLLECTIONM  = 57371
 	GEOMETRYCOLLECTIONZ  = 57372
 	GEOMETRYCOLLECTIONZM = 57373
-	EMPTY                = 57374
-	NUM                  = 57375
+	CIRCULARSTRING       = 57374
+	CIRCULARSTRINGM      = 57375
+	CIRCULARSTRINGZ      = 57376
+	CIRCULARSTRINGZM     = 57377
+	COMPOUNDCURVE        = 57378
+	COMPOUNDCURVEM       = 57379
+	COMPOUNDCURVEZ       = 57380
+	COMPOUNDCURVEZM      = 57381
+	CURVEPOLYGON         = 57382
+	CURVEPOLYGONM        = 57383
+	CURVEPOLYGONZ        = 57384
+	CURVEPOLYGONZM       = 57385
+	MULTICURVE           = 57386
+	MULTICURVEM          = 57387
+	MULTICURVEZ          = 57388
+	MULTICURVEZM         = 57389
+	MULTISURFACE         = 57390
+	MULTISURFACEM        = 57391
+	MULTISURFACEZ        = 57392
+	MULTISURFACEZM       = 57393
+	TRIANGLE             = 57394
+	TRIANGLEM            = 57395
+	TRIANGLEZ            = 57396
+	TRIANGLEZM           = 57397
+	TIN                  = 57398
+	TINM                 = 57399
+	TINZ                 = 57400
+	TINZM                = 57401
+	POLYHEDRALSURFACE    = 57402
+	POLYHEDRALSURFACEM   = 57403
+	POLYHEDRALSURFACEZ   = 57404
+	POLYHEDRALSURFACEZM  = 57405
+	EMPTY                = 57406
+	SRID                 = 57407
+	NUM                  = 57408
 )
 
 var wktToknames = [...]string{
@@ -83,10 +116,45 @@ var wktToknames = [...]string{
 	"GEOMETRYCOLLECTIONM",
 	"GEOMETRYCOLLECTIONZ",
 	"GEOMETRYCOLLECTIONZM",
+	"CIRCULARSTRING",
+	"CIRCULARSTRINGM",
+	"CIRCULARSTRINGZ",
+	"CIRCULARSTRINGZM",
+	"COMPOUNDCURVE",
+	"COMPOUNDCURVEM",
+	"COMPOUNDCURVEZ",
+	"COMPOUNDCURVEZM",
+	"CURVEPOLYGON",
+	"CURVEPOLYGONM",
+	"CURVEPOLYGONZ",
+	"CURVEPOLYGONZM",
+	"MULTICURVE",
+	"MULTICURVEM",
+	"MULTICURVEZ",
+	"MULTICURVEZM",
+	"MULTISURFACE",
+	"MULTISURFACEM",
+	"MULTISURFACEZ",
+	"MULTISURFACEZM",
+	"TRIANGLE",
+	"TRIANGLEM",
+	"TRIANGLEZ",
+	"TRIANGLEZM",
+	"TIN",
+	"TINM",
+	"TINZ",
+	"TINZM",
+	"POLYHEDRALSURFACE",
+	"POLYHEDRALSURFACEM",
+	"POLYHEDRALSURFACEZ",
+	"POLYHEDRALSURFACEZM",
 	"EMPTY",
+	"SRID",
 	"NUM",
-	"'('",
+	"'='",
+	"';'",
 	"')'",
+	"'('",
 	"','",
 }
 
@@ -106,133 +174,230 @@ var wktExca = [...]int8{
 
 const wktPrivate = 57344
 
-const wktLast = 218
-
-var wktAct = [...]uint8{
-	66, 2, 116, 126, 131, 106, 65, 111, 128, 121,
-	60, 57, 137, 118, 155, 156, 61, 70, 58, 101,
-	75, 114, 81, 63, 87, 69, 63, 64, 104, 63,
-	61, 63, 90, 63, 62, 63, 107, 68, 63, 61,
-	72, 84, 77, 61, 83, 78, 89, 108, 59, 94,
-	58, 67, 153, 154, 71, 92, 74, 97, 80, 61,
-	86, 151, 152, 93, 149, 150, 147, 148, 145, 146,
-	143, 144, 141, 142, 139, 140, 57, 102, 138, 57,
-	61, 97, 58, 27, 113, 109, 26, 25, 70, 63,
-	24, 70, 23, 22, 136, 63, 56, 21, 123, 133,
-	20, 63, 19, 18, 17, 95, 124, 16, 15, 14,
-	13, 12, 134, 11, 99, 10, 1, 91, 119, 135,
-	88, 85, 130, 125, 129, 132, 127, 82, 79, 120,
-	115, 122, 117, 76, 73, 110, 103, 112, 105, 100,
-	98, 96, 9, 8, 7, 57, 6, 57, 5, 102,
-	4, 161, 113, 70, 160, 70, 163, 63, 165, 164,
-	162, 63, 158, 133, 123, 63, 3, 0, 0, 0,
-	0, 0, 124, 159, 0, 0, 134, 0, 0, 0,
-	0, 0, 0, 0, 0, 119, 0, 0, 157, 129,
-	28, 29, 30, 31, 32, 33, 34, 35, 36, 37,
-	38, 39, 40, 41, 42, 43, 44, 45, 46, 47,
-	48, 49, 50, 51, 52, 53, 54, 55,
+const wktLast = 494
+
+var wktAct = [...]int16{
+	128, 127, 244, 39, 235, 37, 39, 247, 37, 237,
+	2, 225, 232, 215, 116, 198, 253, 41, 220, 238,
+	210, 119, 239, 153, 229, 241, 252, 132, 122, 246,
+	137, 230, 143, 314, 149, 286, 120, 154, 231, 157,
+	313, 161, 282, 165, 205, 169, 296, 132, 297, 254,
+	149, 125, 149, 200, 125, 294, 295, 125, 293, 125,
+	208, 125, 126, 125, 287, 123, 125, 191, 125, 185,
+	125, 120, 125, 292, 125, 293, 131, 125, 263, 125,
+	202, 125, 262, 124, 125, 291, 130, 290, 289, 134,
+	290, 139, 201, 145, 117, 151, 173, 288, 156, 286,
+	160, 285, 164, 286, 168, 284, 172, 282, 281, 175,
+	282, 179, 123, 183, 121, 191, 187, 129, 120, 279,
+	133, 280, 136, 277, 142, 278, 148, 264, 275, 155,
+	276, 158, 188, 162, 196, 166, 123, 170, 119, 55,
+	174, 119, 177, 273, 181, 274, 271, 186, 272, 269,
+	132, 270, 267, 132, 268, 195, 257, 89, 90, 91,
+	265, 123, 266, 196, 54, 196, 53, 152, 52, 125,
+	132, 123, 132, 245, 240, 125, 240, 171, 249, 147,
+	249, 125, 212, 51, 236, 217, 120, 258, 39, 258,
+	37, 250, 150, 250, 207, 123, 261, 251, 259, 222,
+	189, 167, 227, 50, 248, 123, 248, 218, 49, 193,
+	48, 163, 47, 228, 234, 81, 82, 83, 243, 85,
+	86, 87, 234, 81, 82, 83, 123, 123, 123, 176,
+	203, 180, 159, 146, 140, 154, 213, 46, 256, 242,
+	233, 45, 223, 178, 44, 182, 43, 42, 40, 38,
+	36, 118, 120, 35, 34, 33, 32, 283, 31, 30,
+	120, 29, 28, 27, 26, 25, 24, 23, 22, 21,
+	196, 119, 20, 119, 1, 255, 184, 260, 224, 132,
+	219, 132, 226, 221, 144, 141, 300, 214, 196, 209,
+	303, 299, 305, 216, 132, 302, 196, 304, 309, 240,
+	39, 125, 37, 249, 311, 125, 307, 240, 312, 125,
+	310, 258, 308, 212, 306, 217, 250, 301, 211, 138,
+	135, 204, 197, 206, 199, 194, 207, 192, 222, 248,
+	227, 190, 17, 16, 15, 14, 13, 218, 12, 11,
+	10, 228, 18, 9, 8, 7, 6, 298, 5, 4,
+	3, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 213, 0, 0,
+	0, 223, 56, 57, 58, 59, 60, 61, 62, 63,
+	64, 65, 66, 67, 68, 69, 70, 71, 72, 73,
+	74, 75, 76, 77, 78, 79, 112, 113, 114, 115,
+	80, 81, 82, 83, 84, 85, 86, 87, 88, 89,
+	90, 91, 92, 93, 94, 95, 96, 97, 98, 99,
+	100, 101, 102, 103, 104, 105, 106, 107, 108, 109,
+	110, 111, 0, 19, 56, 57, 58, 59, 60, 61,
+	62, 63, 64, 65, 66, 67, 68, 69, 70, 71,
+	72, 73, 74, 75, 76, 77, 78, 79, 112, 113,
+	114, 115, 80, 81, 82, 83, 84, 85, 86, 87,
+	88, 89, 90, 91, 92, 93, 94, 95, 96, 97,
+	98, 99, 100, 101, 102, 103, 104, 105, 106, 107,
+	108, 109, 110, 111,
 }
 
 var wktPact = [...]int16{
-	186, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000,
-	16, 27, 27, 16, 27, 27, 16, 27, 27, -16,
-	11, -16, 7, -16, -2, 21, 27, 27, -1000, -1000,
-	-1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000,
-	-1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000,
-	-1000, -1000, -1000, -1000, -1000, -1000, -1000, 24, -1000, -1000,
-	-1000, -1000, -1000, -1000, -1000, -1000, 24, -1000, -1000, -1000,
-	16, -1000, -1000, -1000, -1000, 48, -1000, -1000, 48, -1000,
-	-1000, -16, -1000, -1000, -16, -1000, -1000, -16, -1000, -1000,
-	-16, -1000, 186, -1000, -1000, -23, 45, -1000, 39, -1000,
-	37, -1000, -1000, 35, -1000, -1000, -1000, -1000, -1000, -1000,
-	33, -1000, -1000, -1000, -1000, 31, -1000, -1000, -1000, -1000,
-	29, -1000, -1000, -1000, -1000, 26, -1000, -1000, -1000, -1000,
-	17, -1000, -1000, -1000, -1000, -21, -1000, -1000, -1000, -1000,
-	24, -1000, 16, -1000, 48, -1000, 48, -1000, -16, -1000,
-	-16, -1000, -16, -1000, -16, -1000, 186, -1000, -1000, -1000,
-	-1000, -1000, -1000, -1000, -1000, -1000,
+	368, -32768, -32768, 430, -32768, -32768, -32768, -32768, -32768, -32768,
+	-32768, -32768, -32768, -32768, -32768, -32768, -32768, -32768, -32768, 27,
+	-34, 72, 72, -34, 72, 72, -34, 72, 72, 48,
+	164, 48, 163, 48, 97, -34, 72, 72, 48, 162,
+	48, 141, 48, 131, 48, 107, -34, 72, 72, 48,
+	97, 48, 97, -1, 72, 72, -32768, -32768, -32768, -32768,
+	-32768, -32768, -32768, -32768, -32768, -32768, -32768, -32768, -32768, -32768,
+	-32768, -32768, -32768, -32768, -32768, -32768, -32768, -32768, -32768, -32768,
+	-32768, -32768, -32768, -32768, -32768, -32768, -32768, -32768, -32768, -32768,
+	-32768, -32768, -32768, -32768, -32768, -32768, -32768, -32768, -32768, -32768,
+	-32768, -32768, -32768, -32768, -32768, -32768, -32768, -32768, -32768, -32768,
+	-32768, -32768, -32768, -32768, -32768, -32768, -32768, 66, -32768, 49,
+	-32768, -32768, -32768, -32768, -32768, -32768, -32768, -32768, 49, -32768,
+	-32768, -32768, -34, -32768, -32768, -32768, -32768, 1, -32768, -32768,
+	1, -32768, -32768, 48, -32768, -32768, 48, -32768, -32768, 48,
+	-32768, -32768, 48, -32768, -32768, -32768, -32768, 190, -32768, 190,
+	-32768, 182, -32768, 182, -32768, 182, -32768, 182, -32768, 116,
+	-32768, 116, -32768, -32768, -32768, -32768, -32768, -32768, -32768, -32768,
+	-32768, -32768, -32768, -32768, -32768, 430, -32768, -32768, 14, 9,
+	61, -32768, 91, -32768, 83, -32768, -32768, 80, -32768, -32768,
+	-32768, -32768, -32768, -32768, 77, -32768, -32768, -32768, -32768, 74,
+	-32768, -32768, -32768, -32768, 59, -32768, -32768, -32768, -32768, 54,
+	-32768, -32768, -32768, -32768, 50, -32768, -32768, -32768, -32768, 39,
+	-32768, -32768, -32768, -34, -32768, -32768, 36, 32, -32768, -32768,
+	-32768, -32768, -6, -32768, -32768, 28, 19, -32768, -32768, -32768,
+	-32768, 16, 4, -32768, -32768, -32768, -15, -32768, -32768, -13,
+	-23, -32768, -32768, -32768, -32768, -32768, 49, -32768, -34, -32768,
+	1, -32768, 1, -32768, 48, -32768, 48, -32768, 48, -32768,
+	48, -32768, 190, -32768, -32768, -32768, 182, 190, -32768, -32768,
+	182, -32768, -32768, 116, 182, -32768, -32768, 430, -32768, -32768,
+	-32768, -32768, -32768, -32768, -32768, -32768, -32768, -32768, -29, -32768,
+	-32768, -36, -32768, -32768, -32768,
 }
 
-var wktPgo = [...]uint8{
-	0, 1, 166, 150, 148, 146, 144, 143, 142, 36,
-	21, 10, 141, 47, 85, 140, 6, 13, 19, 139,
-	8, 5, 138, 137, 28, 7, 136, 135, 134, 133,
-	132, 131, 2, 9, 130, 129, 128, 127, 126, 125,
-	3, 4, 123, 122, 121, 120, 119, 117, 116, 115,
-	113, 111, 110, 109, 108, 107, 104, 103, 102, 100,
-	97, 93, 92, 90, 87, 86, 83, 0,
+var wktPgo = [...]int16{
+	0, 10, 350, 349, 348, 346, 345, 344, 343, 342,
+	340, 339, 338, 336, 335, 334, 333, 332, 92, 60,
+	28, 331, 80, 230, 327, 1, 38, 22, 325, 49,
+	53, 324, 323, 15, 44, 322, 321, 320, 319, 318,
+	293, 20, 13, 289, 287, 285, 284, 283, 282, 18,
+	11, 280, 278, 179, 192, 277, 276, 23, 12, 25,
+	275, 31, 19, 7, 16, 24, 9, 29, 26, 274,
+	272, 269, 268, 267, 266, 265, 264, 263, 262, 261,
+	259, 258, 256, 255, 254, 253, 250, 4, 249, 0,
+	2, 248, 17, 247, 246, 244, 241, 240, 239, 238,
+	237, 212, 210, 208, 203, 183, 168, 166, 164, 139,
 }
 
 var wktR1 = [...]int8{
-	0, 48, 1, 1, 1, 1, 1, 1, 1, 2,
-	2, 2, 49, 49, 50, 51, 51, 51, 3, 3,
-	3, 52, 52, 53, 54, 54, 54, 4, 4, 4,
-	55, 55, 56, 57, 57, 57, 5, 5, 5, 5,
-	58, 59, 59, 59, 6, 6, 6, 6, 60, 61,
-	61, 61, 7, 7, 7, 7, 62, 63, 63, 63,
-	8, 8, 8, 47, 46, 46, 64, 64, 65, 66,
-	66, 66, 67, 44, 45, 43, 43, 42, 42, 40,
-	41, 38, 38, 39, 39, 36, 37, 34, 34, 35,
-	35, 32, 33, 30, 30, 31, 31, 28, 29, 26,
-	26, 27, 27, 24, 25, 22, 22, 23, 23, 21,
-	21, 20, 19, 19, 18, 17, 16, 15, 15, 14,
-	13, 12, 12, 9, 10, 11,
+	0, 69, 69, 2, 1, 1, 1, 1, 1, 1,
+	1, 1, 1, 1, 1, 1, 1, 1, 1, 3,
+	3, 3, 70, 70, 71, 72, 72, 72, 4, 4,
+	4, 73, 73, 74, 75, 75, 75, 5, 5, 5,
+	76, 76, 77, 78, 78, 78, 6, 6, 6, 6,
+	79, 80, 80, 80, 7, 7, 7, 7, 81, 82,
+	82, 82, 8, 8, 8, 8, 83, 84, 84, 84,
+	10, 10, 10, 85, 85, 86, 87, 87, 87, 11,
+	11, 11, 11, 88, 90, 90, 90, 12, 12, 12,
+	12, 91, 92, 92, 92, 13, 13, 13, 13, 93,
+	94, 94, 94, 14, 14, 14, 14, 95, 96, 96,
+	96, 65, 65, 61, 61, 66, 66, 62, 62, 62,
+	67, 67, 63, 63, 63, 68, 68, 64, 64, 58,
+	97, 97, 59, 98, 98, 60, 99, 99, 15, 15,
+	15, 100, 100, 101, 102, 102, 102, 16, 16, 16,
+	16, 103, 104, 104, 104, 17, 17, 17, 17, 105,
+	106, 106, 106, 9, 9, 9, 56, 55, 55, 107,
+	107, 108, 109, 109, 109, 89, 53, 54, 52, 52,
+	51, 51, 49, 50, 47, 47, 48, 48, 45, 46,
+	43, 43, 44, 44, 41, 42, 39, 39, 40, 40,
+	37, 38, 35, 35, 36, 36, 33, 34, 31, 31,
+	32, 32, 30, 30, 29, 28, 28, 27, 26, 57,
+	25, 24, 24, 23, 22, 21, 21, 18, 19, 20,
 }
 
 var wktR2 = [...]int8{
-	0, 1, 1, 1, 1, 1, 1, 1, 1, 2,
+	0, 1, 2, 4, 1, 1, 1, 1, 1, 1,
+	1, 1, 1, 1, 1, 1, 1, 1, 1, 2,
 	2, 2, 1, 1, 1, 1, 1, 1, 2, 2,
 	2, 1, 1, 1, 1, 1, 1, 2, 2, 2,
 	1, 1, 1, 1, 1, 1, 2, 2, 2, 2,
 	1, 1, 1, 1, 2, 2, 2, 2, 1, 1,
 	1, 1, 2, 2, 2, 2, 1, 1, 1, 1,
-	2, 2, 2, 3, 3, 1, 1, 1, 1, 1,
-	1, 1, 1, 3, 3, 3, 1, 3, 1, 1,
-	1, 1, 1, 1, 1, 3, 3, 3, 1, 3,
-	1, 1, 1, 1, 1, 1, 1, 3, 3, 3,
-	1, 3, 1, 1, 1, 1, 1, 1, 1, 1,
-	1, 3, 3, 1, 1, 1, 3, 3, 1, 3,
-	1, 2, 1, 1, 1, 1,
+	2, 2, 2, 1, 1, 1, 1, 1, 1, 4,
+	4, 2, 2, 1, 1, 1, 1, 4, 4, 2,
+	2, 1, 1, 1, 1, 4, 4, 2, 2, 1,
+	1, 1, 1, 4, 4, 2, 2, 1, 1, 1,
+	1, 3, 1, 1, 1, 3, 1, 1, 1, 1,
+	3, 1, 1, 1, 1, 3, 1, 1, 1, 2,
+	1, 1, 4, 1, 1, 4, 1, 1, 2, 2,
+	2, 1, 1, 1, 1, 1, 1, 2, 2, 2,
+	2, 1, 1, 1, 1, 2, 2, 2, 2, 1,
+	1, 1, 1, 2, 2, 2, 3, 3, 1, 1,
+	1, 1, 1, 1, 1, 1, 3, 3, 3, 1,
+	3, 1, 1, 1, 1, 1, 1, 1, 3, 3,
+	3, 1, 3, 1, 1, 1, 1, 1, 1, 1,
+	3, 3, 3, 1, 3, 1, 1, 1, 1, 1,
+	1, 1, 1, 1, 3, 3, 1, 1, 1, 1,
+	3, 3, 1, 3, 1, 2, 1, 1, 1, 1,
 }
 
 var wktChk = [...]int16{
-	-1000, -48, -1, -2, -3, -4, -5, -6, -7, -8,
-	-49, -50, -51, -52, -53, -54, -55, -56, -57, -58,
-	-59, -60, -61, -62, -63, -64, -65, -66, 4, 5,
-	6, 7, 8, 9, 10, 11, 12, 13, 14, 15,
-	16, 17, 18, 19, 20, 21, 22, 23, 24, 25,
-	26, 27, 28, 29, 30, 31, -14, -67, 34, -9,
-	-11, 32, -10, -11, -17, -16, -67, -9, -10, -20,
-	-67, -9, -10, -28, -9, -67, -29, -10, 34, -36,
-	-9, -67, -37, -10, 34, -44, -9, -67, -45, -10,
-	34, -47, 34, -9, -10, -13, -12, 33, -15, -13,
-	-19, -18, -16, -26, -24, -22, -21, -9, -13, -14,
-	-27, -25, -23, -21, -10, -34, -32, -30, -17, -9,
-	-35, -33, -31, -17, -10, -42, -40, -38, -20, -9,
-	-43, -41, -39, -20, -10, -46, -1, 35, 33, 35,
-	36, 35, 36, 35, 36, 35, 36, 35, 36, 35,
-	36, 35, 36, 35, 36, 35, 36, -13, -18, -24,
-	-25, -32, -33, -40, -41, -1,
+	-32768, -69, -1, -2, -3, -4, -5, -6, -7, -8,
+	-10, -11, -12, -13, -14, -15, -16, -17, -9, 65,
+	-70, -71, -72, -73, -74, -75, -76, -77, -78, -79,
+	-80, -81, -82, -83, -84, -85, -86, -87, -88, -90,
+	-91, -92, -93, -94, -95, -96, -100, -101, -102, -103,
+	-104, -105, -106, -107, -108, -109, 4, 5, 6, 7,
+	8, 9, 10, 11, 12, 13, 14, 15, 16, 17,
+	18, 19, 20, 21, 22, 23, 24, 25, 26, 27,
+	32, 33, 34, 35, 36, 37, 38, 39, 40, 41,
+	42, 43, 44, 45, 46, 47, 48, 49, 50, 51,
+	52, 53, 54, 55, 56, 57, 58, 59, 60, 61,
+	62, 63, 28, 29, 30, 31, -1, 67, -23, -89,
+	70, -18, -20, 64, -19, -20, -26, -25, -89, -18,
+	-19, -29, -89, -18, -19, -37, -18, -89, -38, -19,
+	70, -45, -18, -89, -46, -19, 70, -53, -18, -89,
+	-54, -19, 70, -57, -25, -18, -19, -89, -18, 70,
+	-19, -89, -18, 70, -19, -89, -18, 70, -19, -89,
+	-18, 70, -19, -29, -18, -19, -53, -18, -54, -19,
+	-53, -18, -54, -19, -56, 70, -18, -19, 66, -22,
+	-21, 66, -24, -22, -28, -27, -25, -35, -33, -31,
+	-30, -18, -22, -23, -36, -34, -32, -30, -19, -43,
+	-41, -39, -26, -18, -44, -42, -40, -26, -19, -51,
+	-49, -47, -29, -18, -52, -50, -48, -29, -19, -65,
+	-61, -26, -58, -97, 32, -87, -65, -66, -62, -27,
+	-58, -59, -98, 36, -90, -66, -67, -63, -26, -58,
+	-59, -67, -68, -64, -29, -60, -99, 40, -92, -68,
+	-55, -1, 68, 69, 66, 69, 71, 69, 71, 69,
+	71, 69, 71, 69, 71, 69, 71, 69, 71, 69,
+	71, 69, 71, -57, 69, 69, 71, 70, 69, 69,
+	71, 69, 69, 71, 70, 69, 69, 71, -22, -27,
+	-33, -34, -41, -42, -49, -50, -61, -62, -65, -63,
+	-64, -66, -1, 69, 69,
 }
 
-var wktDef = [...]int8{
-	0, -2, 1, 2, 3, 4, 5, 6, 7, 8,
-	0, 12, 13, 0, 21, 22, 0, 30, 31, 0,
-	0, 0, 0, 0, 0, 0, 66, 67, 14, 15,
-	16, 17, 23, 24, 25, 26, 32, 33, 34, 35,
-	40, 41, 42, 43, 48, 49, 50, 51, 56, 57,
-	58, 59, 68, 69, 70, 71, 9, 0, 72, 10,
-	123, 125, 11, 124, 18, 115, 0, 19, 20, 27,
-	0, 28, 29, 36, 38, 0, 37, 39, 0, 44,
-	46, 0, 45, 47, 0, 52, 54, 0, 53, 55,
-	0, 60, 0, 61, 62, 0, 120, 122, 0, 118,
-	0, 113, 114, 0, 100, 103, 105, 106, 109, 110,
-	0, 102, 104, 107, 108, 0, 88, 91, 93, 94,
-	0, 90, 92, 95, 96, 0, 78, 79, 81, 82,
-	0, 76, 80, 83, 84, 0, 65, 119, 121, 116,
-	0, 111, 0, 97, 0, 98, 0, 85, 0, 86,
-	0, 73, 0, 74, 0, 63, 0, 117, 112, 99,
-	101, 87, 89, 77, 75, 64,
+var wktDef = [...]int16{
+	0, -2, 1, 0, 4, 5, 6, 7, 8, 9,
+	10, 11, 12, 13, 14, 15, 16, 17, 18, 0,
+	0, 22, 23, 0, 31, 32, 0, 40, 41, 0,
+	0, 0, 0, 0, 0, 0, 73, 74, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 141, 142, 0,
+	0, 0, 0, 0, 169, 170, 24, 25, 26, 27,
+	33, 34, 35, 36, 42, 43, 44, 45, 50, 51,
+	52, 53, 58, 59, 60, 61, 66, 67, 68, 69,
+	75, 76, 77, 78, 83, 84, 85, 86, 91, 92,
+	93, 94, 99, 100, 101, 102, 107, 108, 109, 110,
+	143, 144, 145, 146, 151, 152, 153, 154, 159, 160,
+	161, 162, 171, 172, 173, 174, 2, 0, 19, 0,
+	175, 20, 227, 229, 21, 228, 28, 218, 0, 29,
+	30, 37, 0, 38, 39, 46, 48, 0, 47, 49,
+	0, 54, 56, 0, 55, 57, 0, 62, 64, 0,
+	63, 65, 0, 70, 219, 71, 72, 0, 81, 0,
+	82, 0, 89, 0, 90, 0, 97, 0, 98, 0,
+	105, 0, 106, 138, 139, 140, 147, 149, 148, 150,
+	155, 157, 156, 158, 163, 0, 164, 165, 0, 0,
+	224, 226, 0, 222, 0, 216, 217, 0, 203, 206,
+	208, 209, 212, 213, 0, 205, 207, 210, 211, 0,
+	191, 194, 196, 197, 0, 193, 195, 198, 199, 0,
+	181, 182, 184, 185, 0, 179, 183, 186, 187, 0,
+	112, 113, 114, 0, 130, 131, 0, 0, 116, 117,
+	118, 119, 0, 133, 134, 0, 0, 121, 122, 123,
+	124, 0, 0, 126, 127, 128, 0, 136, 137, 0,
+	0, 168, 3, 223, 225, 220, 0, 214, 0, 200,
+	0, 201, 0, 188, 0, 189, 0, 176, 0, 177,
+	0, 79, 0, 129, 80, 87, 0, 0, 88, 95,
+	0, 96, 103, 0, 0, 104, 166, 0, 221, 215,
+	202, 204, 190, 192, 180, 178, 111, 115, 0, 120,
+	125, 0, 167, 132, 135,
 }
 
 var wktTok1 = [...]int8{
@@ -240,14 +405,19 @@ var wktTok1 = [...]int8{
 	3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
 	3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
 	3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
-	34, 35, 3, 3, 36,
+	70, 69, 3, 3, 71, 3, 3, 3, 3, 3,
+	3, 3, 3, 3, 3, 3, 3, 3, 3, 68,
+	3, 67,
 }
 
 var wktTok2 = [...]int8{
 	2, 3, 4, 5, 6, 7, 8, 9, 10, 11,
 	12, 13, 14, 15, 16, 17, 18, 19, 20, 21,
 	22, 23, 24, 25, 26, 27, 28, 29, 30, 31,
-	32, 33,
+	32, 33, 34, 35, 36, 37, 38, 39, 40, 41,
+	42, 43, 44, 45, 46, 47, 48, 49, 50, 51,
+	52, 53, 54, 55, 56, 57, 58, 59, 60, 61,
+	62, 63, 64, 65, 66,
 }
 
 var wktTok3 = [...]int8{
@@ -291,7 +461,7 @@ func wktNewParser() wktParser {
 	return &wktParserImpl{}
 }
 
-const wktFlag = -1000
+const wktFlag = -32768
 
 func wktTokname(c int) string {
 	if c >= 1 && c-1 < len(wktToknames) {
@@ -598,7 +768,29 @@ wktdefault:
 			}
 			wktlex.(*wktLex).ret = wktDollar[1].geom
 		}
-	case 8:
+	case 2:
+		wktDollar = wktS[wktpt-2 : wktpt+1]
+		{
+			ok := wktlex.(*wktLex).validateLayoutStackAtEnd()
+			if !ok {
+				return 1
+			}
+			g, err := geom.SetSRID(wktDollar[2].geom, int(wktDollar[1].coord))
+			if err != nil {
+				wktlex.(*wktLex).setError(err)
+				return 1
+			}
+			wktlex.(*wktLex).ret = g
+		}
+	case 3:
+		wktDollar = wktS[wktpt-4 : wktpt+1]
+		{
+			if !wktlex.(*wktLex).isValidSRID(wktDollar[3].coord) {
+				return 1
+			}
+			wktVAL.coord = wktDollar[3].coord
+		}
+	case 18:
 		wktDollar = wktS[wktpt-1 : wktpt+1]
 		{
 			ok := wktlex.(*wktLex).validateAndPopLayoutStackFrame()
@@ -612,22 +804,22 @@ wktdefault:
 			}
 			wktVAL.geom = wktDollar[1].geomCollect
 		}
-	case 9:
+	case 19:
 		wktDollar = wktS[wktpt-2 : wktpt+1]
 		{
 			wktVAL.geom = geom.NewPointFlat(wktlex.(*wktLex).curLayout(), wktDollar[2].coordList)
 		}
-	case 10:
+	case 20:
 		wktDollar = wktS[wktpt-2 : wktpt+1]
 		{
 			wktVAL.geom = geom.NewPointEmpty(wktlex.(*wktLex).curLayout())
 		}
-	case 11:
+	case 21:
 		wktDollar = wktS[wktpt-2 : wktpt+1]
 		{
 			wktVAL.geom = geom.NewPointEmpty(wktlex.(*wktLex).curLayout())
 		}
-	case 14:
+	case 24:
 		wktDollar = wktS[wktpt-1 : wktpt+1]
 		{
 			ok := wktlex.(*wktLex).validateBaseGeometryTypeAllowed()
@@ -635,7 +827,7 @@ wktdefault:
 				return 1
 			}
 		}
-	case 15:
+	case 25:
 		wktDollar = wktS[wktpt-1 : wktpt+1]
 		{
 			ok := wktlex.(*wktLex).validateAndSetLayoutIfNoLayout(geom.XYM)
@@ -643,7 +835,7 @@ wktdefault:
 				return 1
 			}
 		}
-	case 16:
+	case 26:
 		wktDollar = wktS[wktpt-1 : wktpt+1]
 		{
 			ok := wktlex.(*wktLex).validateAndSetLayoutIfNoLayout(geom.XYZ)
@@ -651,7 +843,7 @@ wktdefault:
 				return 1
 			}
 		}
-	case 17:
+	case 27:
 		wktDollar = wktS[wktpt-1 : wktpt+1]
 		{
 			ok := wktlex.(*wktLex).validateAndSetLayoutIfNoLayout(geom.XYZM)
@@ -659,22 +851,22 @@ wktdefault:
 				return 1
 			}
 		}
-	case 18:
+	case 28:
 		wktDollar = wktS[wktpt-2 : wktpt+1]
 		{
 			wktVAL.geom = geom.NewLineStringFlat(wktlex.(*wktLex).curLayout(), wktDollar[2].coordList)
 		}
-	case 19:
+	case 29:
 		wktDollar = wktS[wktpt-2 : wktpt+1]
 		{
 			wktVAL.geom = geom.NewLineString(wktlex.(*wktLex).curLayout())
 		}
-	case 20:
+	case 30:
 		wktDollar = wktS[wktpt-2 : wktpt+1]
 		{
 			wktVAL.geom = geom.NewLineString(wktlex.(*wktLex).curLayout())
 		}
-	case 23:
+	case 33:
 		wktDollar = wktS[wktpt-1 : wktpt+1]
 		{
 			ok := wktlex.(*wktLex).validateBaseGeometryTypeAllowed()
@@ -682,7 +874,7 @@ wktdefault:
 				return 1
 			}
 		}
-	case 24:
+	case 34:
 		wktDollar = wktS[wktpt-1 : wktpt+1]
 		{
 			ok := wktlex.(*wktLex).validateAndSetLayoutIfNoLayout(geom.XYM)
@@ -690,7 +882,7 @@ wktdefault:
 				return 1
 			}
 		}
-	case 25:
+	case 35:
 		wktDollar = wktS[wktpt-1 : wktpt+1]
 		{
 			ok := wktlex.(*wktLex).validateAndSetLayoutIfNoLayout(geom.XYZ)
@@ -698,7 +890,7 @@ wktdefault:
 				return 1
 			}
 		}
-	case 26:
+	case 36:
 		wktDollar = wktS[wktpt-1 : wktpt+1]
 		{
 			ok := wktlex.(*wktLex).validateAndSetLayoutIfNoLayout(geom.XYZM)
@@ -706,22 +898,22 @@ wktdefault:
 				return 1
 			}
 		}
-	case 27:
+	case 37:
 		wktDollar = wktS[wktpt-2 : wktpt+1]
 		{
 			wktVAL.geom = geom.NewPolygonFlat(wktlex.(*wktLex).curLayout(), wktDollar[2].flatRepr.flatCoords, wktDollar[2].flatRepr.ends)
 		}
-	case 28:
+	case 38:
 		wktDollar = wktS[wktpt-2 : wktpt+1]
 		{
 			wktVAL.geom = geom.NewPolygon(wktlex.(*wktLex).curLayout())
 		}
-	case 29:
+	case 39:
 		wktDollar = wktS[wktpt-2 : wktpt+1]
 		{
 			wktVAL.geom = geom.NewPolygon(wktlex.(*wktLex).curLayout())
 		}
-	case 32:
+	case 42:
 		wktDollar = wktS[wktpt-1 : wktpt+1]
 		{
 			ok := wktlex.(*wktLex).validateBaseGeometryTypeAllowed()
@@ -729,7 +921,7 @@ wktdefault:
 				return 1
 			}
 		}
-	case 33:
+	case 43:
 		wktDollar = wktS[wktpt-1 : wktpt+1]
 		{
 			ok := wktlex.(*wktLex).validateAndSetLayoutIfNoLayout(geom.XYM)
@@ -737,7 +929,7 @@ wktdefault:
 				return 1
 			}
 		}
-	case 34:
+	case 44:
 		wktDollar = wktS[wktpt-1 : wktpt+1]
 		{
 			ok := wktlex.(*wktLex).validateAndSetLayoutIfNoLayout(geom.XYZ)
@@ -745,7 +937,7 @@ wktdefault:
 				return 1
 			}
 		}
-	case 35:
+	case 45:
 		wktDollar = wktS[wktpt-1 : wktpt+1]
 		{
 			ok := wktlex.(*wktLex).validateAndSetLayoutIfNoLayout(geom.XYZM)
@@ -753,31 +945,31 @@ wktdefault:
 				return 1
 			}
 		}
-	case 36:
+	case 46:
 		wktDollar = wktS[wktpt-2 : wktpt+1]
 		{
 			wktVAL.geom = geom.NewMultiPointFlat(
 				wktlex.(*wktLex).curLayout(), wktDollar[2].flatRepr.flatCoords, geom.NewMultiPointFlatOptionWithEnds(wktDollar[2].flatRepr.ends),
 			)
 		}
-	case 37:
+	case 47:
 		wktDollar = wktS[wktpt-2 : wktpt+1]
 		{
 			wktVAL.geom = geom.NewMultiPointFlat(
 				wktlex.(*wktLex).curLayout(), wktDollar[2].flatRepr.flatCoords, geom.NewMultiPointFlatOptionWithEnds(wktDollar[2].flatRepr.ends),
 			)
 		}
-	case 38:
+	case 48:
 		wktDollar = wktS[wktpt-2 : wktpt+1]
 		{
 			wktVAL.geom = geom.NewMultiPoint(wktlex.(*wktLex).curLayout())
 		}
-	case 39:
+	case 49:
 		wktDollar = wktS[wktpt-2 : wktpt+1]
 		{
 			wktVAL.geom = geom.NewMultiPoint(wktlex.(*wktLex).curLayout())
 		}
-	case 40:
+	case 50:
 		wktDollar = wktS[wktpt-1 : wktpt+1]
 		{
 			ok := wktlex.(*wktLex).validateBaseGeometryTypeAllowed()
@@ -785,7 +977,7 @@ wktdefault:
 				return 1
 			}
 		}
-	case 41:
+	case 51:
 		wktDollar = wktS[wktpt-1 : wktpt+1]
 		{
 			ok := wktlex.(*wktLex).validateAndSetLayoutIfNoLayout(geom.XYM)
@@ -793,7 +985,7 @@ wktdefault:
 				return 1
 			}
 		}
-	case 42:
+	case 52:
 		wktDollar = wktS[wktpt-1 : wktpt+1]
 		{
 			ok := wktlex.(*wktLex).validateAndSetLayoutIfNoLayout(geom.XYZ)
@@ -801,7 +993,7 @@ wktdefault:
 				return 1
 			}
 		}
-	case 43:
+	case 53:
 		wktDollar = wktS[wktpt-1 : wktpt+1]
 		{
 			ok := wktlex.(*wktLex).validateAndSetLayoutIfNoLayout(geom.XYZM)
@@ -809,27 +1001,27 @@ wktdefault:
 				return 1
 			}
 		}
-	case 44:
+	case 54:
 		wktDollar = wktS[wktpt-2 : wktpt+1]
 		{
 			wktVAL.geom = geom.NewMultiLineStringFlat(wktlex.(*wktLex).curLayout(), wktDollar[2].flatRepr.flatCoords, wktDollar[2].flatRepr.ends)
 		}
-	case 45:
+	case 55:
 		wktDollar = wktS[wktpt-2 : wktpt+1]
 		{
 			wktVAL.geom = geom.NewMultiLineStringFlat(wktlex.(*wktLex).curLayout(), wktDollar[2].flatRepr.flatCoords, wktDollar[2].flatRepr.ends)
 		}
-	case 46:
+	case 56:
 		wktDollar = wktS[wktpt-2 : wktpt+1]
 		{
 			wktVAL.geom = geom.NewMultiLineString(wktlex.(*wktLex).curLayout())
 		}
-	case 47:
+	case 57:
 		wktDollar = wktS[wktpt-2 : wktpt+1]
 		{
 			wktVAL.geom = geom.NewMultiLineString(wktlex.(*wktLex).curLayout())
 		}
-	case 48:
+	case 58:
 		wktDollar = wktS[wktpt-1 : wktpt+1]
 		{
 			ok := wktlex.(*wktLex).validateBaseGeometryTypeAllowed()
@@ -837,7 +1029,7 @@ wktdefault:
 				return 1
 			}
 		}
-	case 49:
+	case 59:
 		wktDollar = wktS[wktpt-1 : wktpt+1]
 		{
 			ok := wktlex.(*wktLex).validateAndSetLayoutIfNoLayout(geom.XYM)
@@ -845,7 +1037,7 @@ wktdefault:
 				return 1
 			}
 		}
-	case 50:
+	case 60:
 		wktDollar = wktS[wktpt-1 : wktpt+1]
 		{
 			ok := wktlex.(*wktLex).validateAndSetLayoutIfNoLayout(geom.XYZ)
@@ -853,7 +1045,7 @@ wktdefault:
 				return 1
 			}
 		}
-	case 51:
+	case 61:
 		wktDollar = wktS[wktpt-1 : wktpt+1]
 		{
 			ok := wktlex.(*wktLex).validateAndSetLayoutIfNoLayout(geom.XYZM)
@@ -861,27 +1053,27 @@ wktdefault:
 				return 1
 			}
 		}
-	case 52:
+	case 62:
 		wktDollar = wktS[wktpt-2 : wktpt+1]
 		{
 			wktVAL.geom = geom.NewMultiPolygonFlat(wktlex.(*wktLex).curLayout(), wktDollar[2].multiPolyFlatRepr.flatCoords, wktDollar[2].multiPolyFlatRepr.endss)
 		}
-	case 53:
+	case 63:
 		wktDollar = wktS[wktpt-2 : wktpt+1]
 		{
 			wktVAL.geom = geom.NewMultiPolygonFlat(wktlex.(*wktLex).curLayout(), wktDollar[2].multiPolyFlatRepr.flatCoords, wktDollar[2].multiPolyFlatRepr.endss)
 		}
-	case 54:
+	case 64:
 		wktDollar = wktS[wktpt-2 : wktpt+1]
 		{
 			wktVAL.geom = geom.NewMultiPolygon(wktlex.(*wktLex).curLayout())
 		}
-	case 55:
+	case 65:
 		wktDollar = wktS[wktpt-2 : wktpt+1]
 		{
 			wktVAL.geom = geom.NewMultiPolygon(wktlex.(*wktLex).curLayout())
 		}
-	case 56:
+	case 66:
 		wktDollar = wktS[wktpt-1 : wktpt+1]
 		{
 			ok := wktlex.(*wktLex).validateBaseGeometryTypeAllowed()
@@ -889,7 +1081,7 @@ wktdefault:
 				return 1
 			}
 		}
-	case 57:
+	case 67:
 		wktDollar = wktS[wktpt-1 : wktpt+1]
 		{
 			ok := wktlex.(*wktLex).validateAndSetLayoutIfNoLayout(geom.XYM)
@@ -897,7 +1089,7 @@ wktdefault:
 				return 1
 			}
 		}
-	case 58:
+	case 68:
 		wktDollar = wktS[wktpt-1 : wktpt+1]
 		{
 			ok := wktlex.(*wktLex).validateAndSetLayoutIfNoLayout(geom.XYZ)
@@ -905,7 +1097,7 @@ wktdefault:
 				return 1
 			}
 		}
-	case 59:
+	case 69:
 		wktDollar = wktS[wktpt-1 : wktpt+1]
 		{
 			ok := wktlex.(*wktLex).validateAndSetLayoutIfNoLayout(geom.XYZM)
@@ -913,193 +1105,733 @@ wktdefault:
 				return 1
 			}
 		}
-	case 60:
+	case 70:
 		wktDollar = wktS[wktpt-2 : wktpt+1]
 		{
-			newCollection := geom.NewGeometryCollection()
-			err := newCollection.Push(wktDollar[2].geomList...)
-			if err != nil {
-				wktlex.(*wktLex).setError(err)
-				return 1
-			}
-			wktVAL.geomCollect = newCollection
+			wktVAL.geom = geom.NewCircularStringFlat(wktlex.(*wktLex).curLayout(), wktDollar[2].coordList)
 		}
-	case 61:
+	case 71:
 		wktDollar = wktS[wktpt-2 : wktpt+1]
 		{
-			wktVAL.geomCollect = geom.NewGeometryCollection()
+			wktVAL.geom = geom.NewCircularString(wktlex.(*wktLex).curLayout())
 		}
-	case 62:
+	case 72:
 		wktDollar = wktS[wktpt-2 : wktpt+1]
 		{
-			wktVAL.geomCollect = geom.NewGeometryCollection()
-		}
-	case 63:
-		wktDollar = wktS[wktpt-3 : wktpt+1]
-		{
-			wktVAL.geomList = wktDollar[2].geomList
-		}
-	case 64:
-		wktDollar = wktS[wktpt-3 : wktpt+1]
-		{
-			wktVAL.geomList = append(wktDollar[1].geomList, wktDollar[3].geom)
-		}
-	case 65:
-		wktDollar = wktS[wktpt-1 : wktpt+1]
-		{
-			wktVAL.geomList = []geom.T{wktDollar[1].geom}
+			wktVAL.geom = geom.NewCircularString(wktlex.(*wktLex).curLayout())
 		}
-	case 68:
+	case 75:
 		wktDollar = wktS[wktpt-1 : wktpt+1]
 		{
-			ok := wktlex.(*wktLex).validateAndPushLayoutStackFrame(geom.NoLayout)
+			ok := wktlex.(*wktLex).validateBaseGeometryTypeAllowed()
 			if !ok {
 				return 1
 			}
 		}
-	case 69:
+	case 76:
 		wktDollar = wktS[wktpt-1 : wktpt+1]
 		{
-			ok := wktlex.(*wktLex).validateAndPushLayoutStackFrame(geom.XYM)
+			ok := wktlex.(*wktLex).validateAndSetLayoutIfNoLayout(geom.XYM)
 			if !ok {
 				return 1
 			}
 		}
-	case 70:
+	case 77:
 		wktDollar = wktS[wktpt-1 : wktpt+1]
 		{
-			ok := wktlex.(*wktLex).validateAndPushLayoutStackFrame(geom.XYZ)
+			ok := wktlex.(*wktLex).validateAndSetLayoutIfNoLayout(geom.XYZ)
 			if !ok {
 				return 1
 			}
 		}
-	case 71:
+	case 78:
 		wktDollar = wktS[wktpt-1 : wktpt+1]
 		{
-			ok := wktlex.(*wktLex).validateAndPushLayoutStackFrame(geom.XYZM)
+			ok := wktlex.(*wktLex).validateAndSetLayoutIfNoLayout(geom.XYZM)
 			if !ok {
 				return 1
 			}
 		}
-	case 72:
-		wktDollar = wktS[wktpt-1 : wktpt+1]
+	case 79:
+		wktDollar = wktS[wktpt-4 : wktpt+1]
 		{
-			ok := wktlex.(*wktLex).validateNonEmptyGeometryAllowed()
-			if !ok {
+			g := geom.NewCompoundCurve(wktlex.(*wktLex).curLayout())
+			if err := g.Push(wktDollar[3].geomList...); err != nil {
+				wktlex.(*wktLex).setError(err)
 				return 1
 			}
+			wktVAL.geom = g
 		}
-	case 73:
-		wktDollar = wktS[wktpt-3 : wktpt+1]
-		{
-			wktVAL.multiPolyFlatRepr = wktDollar[2].multiPolyFlatRepr
-		}
-	case 74:
-		wktDollar = wktS[wktpt-3 : wktpt+1]
+	case 80:
+		wktDollar = wktS[wktpt-4 : wktpt+1]
 		{
-			wktVAL.multiPolyFlatRepr = wktDollar[2].multiPolyFlatRepr
+			g := geom.NewCompoundCurve(wktlex.(*wktLex).curLayout())
+			if err := g.Push(wktDollar[3].geomList...); err != nil {
+				wktlex.(*wktLex).setError(err)
+				return 1
+			}
+			wktVAL.geom = g
 		}
-	case 75:
-		wktDollar = wktS[wktpt-3 : wktpt+1]
+	case 81:
+		wktDollar = wktS[wktpt-2 : wktpt+1]
 		{
-			wktVAL.multiPolyFlatRepr = appendMultiPolygonFlatCoordsRepr(wktDollar[1].multiPolyFlatRepr, wktDollar[3].multiPolyFlatRepr)
+			wktVAL.geom = geom.NewCompoundCurve(wktlex.(*wktLex).curLayout())
 		}
-	case 77:
-		wktDollar = wktS[wktpt-3 : wktpt+1]
+	case 82:
+		wktDollar = wktS[wktpt-2 : wktpt+1]
 		{
-			wktVAL.multiPolyFlatRepr = appendMultiPolygonFlatCoordsRepr(wktDollar[1].multiPolyFlatRepr, wktDollar[3].multiPolyFlatRepr)
+			wktVAL.geom = geom.NewCompoundCurve(wktlex.(*wktLex).curLayout())
 		}
-	case 79:
+	case 83:
 		wktDollar = wktS[wktpt-1 : wktpt+1]
 		{
-			wktVAL.multiPolyFlatRepr = makeMultiPolygonFlatCoordsRepr(wktDollar[1].flatRepr)
+			ok := wktlex.(*wktLex).validateBaseGeometryTypeAllowed()
+			if !ok {
+				return 1
+			}
 		}
-	case 80:
+	case 84:
 		wktDollar = wktS[wktpt-1 : wktpt+1]
 		{
-			wktVAL.multiPolyFlatRepr = makeMultiPolygonFlatCoordsRepr(wktDollar[1].flatRepr)
+			ok := wktlex.(*wktLex).validateAndSetLayoutIfNoLayout(geom.XYM)
+			if !ok {
+				return 1
+			}
 		}
-	case 82:
+	case 85:
 		wktDollar = wktS[wktpt-1 : wktpt+1]
 		{
-			wktVAL.flatRepr = makeGeomFlatCoordsRepr(wktDollar[1].coordList)
+			ok := wktlex.(*wktLex).validateAndSetLayoutIfNoLayout(geom.XYZ)
+			if !ok {
+				return 1
+			}
 		}
-	case 84:
+	case 86:
 		wktDollar = wktS[wktpt-1 : wktpt+1]
 		{
-			wktVAL.flatRepr = makeGeomFlatCoordsRepr(wktDollar[1].coordList)
+			ok := wktlex.(*wktLex).validateAndSetLayoutIfNoLayout(geom.XYZM)
+			if !ok {
+				return 1
+			}
 		}
-	case 85:
-		wktDollar = wktS[wktpt-3 : wktpt+1]
+	case 87:
+		wktDollar = wktS[wktpt-4 : wktpt+1]
 		{
-			wktVAL.flatRepr = wktDollar[2].flatRepr
+			g := geom.NewCurvePolygon(wktlex.(*wktLex).curLayout())
+			if err := g.Push(wktDollar[3].geomList...); err != nil {
+				wktlex.(*wktLex).setError(err)
+				return 1
+			}
+			wktVAL.geom = g
 		}
-	case 86:
-		wktDollar = wktS[wktpt-3 : wktpt+1]
+	case 88:
+		wktDollar = wktS[wktpt-4 : wktpt+1]
 		{
-			wktVAL.flatRepr = wktDollar[2].flatRepr
+			g := geom.NewCurvePolygon(wktlex.(*wktLex).curLayout())
+			if err := g.Push(wktDollar[3].geomList...); err != nil {
+				wktlex.(*wktLex).setError(err)
+				return 1
+			}
+			wktVAL.geom = g
 		}
-	case 87:
-		wktDollar = wktS[wktpt-3 : wktpt+1]
+	case 89:
+		wktDollar = wktS[wktpt-2 : wktpt+1]
 		{
-			wktVAL.flatRepr = appendGeomFlatCoordsReprs(wktDollar[1].flatRepr, wktDollar[3].flatRepr)
+			wktVAL.geom = geom.NewCurvePolygon(wktlex.(*wktLex).curLayout())
 		}
-	case 89:
-		wktDollar = wktS[wktpt-3 : wktpt+1]
+	case 90:
+		wktDollar = wktS[wktpt-2 : wktpt+1]
 		{
-			wktVAL.flatRepr = appendGeomFlatCoordsReprs(wktDollar[1].flatRepr, wktDollar[3].flatRepr)
+			wktVAL.geom = geom.NewCurvePolygon(wktlex.(*wktLex).curLayout())
 		}
 	case 91:
 		wktDollar = wktS[wktpt-1 : wktpt+1]
 		{
-			wktVAL.flatRepr = makeGeomFlatCoordsRepr(wktDollar[1].coordList)
+			ok := wktlex.(*wktLex).validateBaseGeometryTypeAllowed()
+			if !ok {
+				return 1
+			}
 		}
 	case 92:
 		wktDollar = wktS[wktpt-1 : wktpt+1]
 		{
-			wktVAL.flatRepr = makeGeomFlatCoordsRepr(wktDollar[1].coordList)
+			ok := wktlex.(*wktLex).validateAndSetLayoutIfNoLayout(geom.XYM)
+			if !ok {
+				return 1
+			}
 		}
-	case 97:
-		wktDollar = wktS[wktpt-3 : wktpt+1]
+	case 93:
+		wktDollar = wktS[wktpt-1 : wktpt+1]
 		{
-			wktVAL.flatRepr = wktDollar[2].flatRepr
+			ok := wktlex.(*wktLex).validateAndSetLayoutIfNoLayout(geom.XYZ)
+			if !ok {
+				return 1
+			}
 		}
-	case 98:
-		wktDollar = wktS[wktpt-3 : wktpt+1]
+	case 94:
+		wktDollar = wktS[wktpt-1 : wktpt+1]
 		{
-			wktVAL.flatRepr = wktDollar[2].flatRepr
+			ok := wktlex.(*wktLex).validateAndSetLayoutIfNoLayout(geom.XYZM)
+			if !ok {
+				return 1
+			}
 		}
-	case 99:
-		wktDollar = wktS[wktpt-3 : wktpt+1]
+	case 95:
+		wktDollar = wktS[wktpt-4 : wktpt+1]
 		{
-			wktVAL.flatRepr = appendGeomFlatCoordsReprs(wktDollar[1].flatRepr, wktDollar[3].flatRepr)
+			g := geom.NewMultiCurve(wktlex.(*wktLex).curLayout())
+			if err := g.Push(wktDollar[3].geomList...); err != nil {
+				wktlex.(*wktLex).setError(err)
+				return 1
+			}
+			wktVAL.geom = g
 		}
-	case 101:
-		wktDollar = wktS[wktpt-3 : wktpt+1]
+	case 96:
+		wktDollar = wktS[wktpt-4 : wktpt+1]
 		{
-			wktVAL.flatRepr = appendGeomFlatCoordsReprs(wktDollar[1].flatRepr, wktDollar[3].flatRepr)
+			g := geom.NewMultiCurve(wktlex.(*wktLex).curLayout())
+			if err := g.Push(wktDollar[3].geomList...); err != nil {
+				wktlex.(*wktLex).setError(err)
+				return 1
+			}
+			wktVAL.geom = g
 		}
-	case 103:
-		wktDollar = wktS[wktpt-1 : wktpt+1]
+	case 97:
+		wktDollar = wktS[wktpt-2 : wktpt+1]
 		{
-			wktVAL.flatRepr = makeGeomFlatCoordsRepr(wktDollar[1].coordList)
+			wktVAL.geom = geom.NewMultiCurve(wktlex.(*wktLex).curLayout())
 		}
-	case 104:
+	case 98:
+		wktDollar = wktS[wktpt-2 : wktpt+1]
+		{
+			wktVAL.geom = geom.NewMultiCurve(wktlex.(*wktLex).curLayout())
+		}
+	case 99:
 		wktDollar = wktS[wktpt-1 : wktpt+1]
 		{
-			wktVAL.flatRepr = makeGeomFlatCoordsRepr(wktDollar[1].coordList)
+			ok := wktlex.(*wktLex).validateBaseGeometryTypeAllowed()
+			if !ok {
+				return 1
+			}
+		}
+	case 100:
+		wktDollar = wktS[wktpt-1 : wktpt+1]
+		{
+			ok := wktlex.(*wktLex).validateAndSetLayoutIfNoLayout(geom.XYM)
+			if !ok {
+				return 1
+			}
+		}
+	case 101:
+		wktDollar = wktS[wktpt-1 : wktpt+1]
+		{
+			ok := wktlex.(*wktLex).validateAndSetLayoutIfNoLayout(geom.XYZ)
+			if !ok {
+				return 1
+			}
+		}
+	case 102:
+		wktDollar = wktS[wktpt-1 : wktpt+1]
+		{
+			ok := wktlex.(*wktLex).validateAndSetLayoutIfNoLayout(geom.XYZM)
+			if !ok {
+				return 1
+			}
+		}
+	case 103:
+		wktDollar = wktS[wktpt-4 : wktpt+1]
+		{
+			g := geom.NewMultiSurface(wktlex.(*wktLex).curLayout())
+			if err := g.Push(wktDollar[3].geomList...); err != nil {
+				wktlex.(*wktLex).setError(err)
+				return 1
+			}
+			wktVAL.geom = g
+		}
+	case 104:
+		wktDollar = wktS[wktpt-4 : wktpt+1]
+		{
+			g := geom.NewMultiSurface(wktlex.(*wktLex).curLayout())
+			if err := g.Push(wktDollar[3].geomList...); err != nil {
+				wktlex.(*wktLex).setError(err)
+				return 1
+			}
+			wktVAL.geom = g
+		}
+	case 105:
+		wktDollar = wktS[wktpt-2 : wktpt+1]
+		{
+			wktVAL.geom = geom.NewMultiSurface(wktlex.(*wktLex).curLayout())
+		}
+	case 106:
+		wktDollar = wktS[wktpt-2 : wktpt+1]
+		{
+			wktVAL.geom = geom.NewMultiSurface(wktlex.(*wktLex).curLayout())
+		}
+	case 107:
+		wktDollar = wktS[wktpt-1 : wktpt+1]
+		{
+			ok := wktlex.(*wktLex).validateBaseGeometryTypeAllowed()
+			if !ok {
+				return 1
+			}
+		}
+	case 108:
+		wktDollar = wktS[wktpt-1 : wktpt+1]
+		{
+			ok := wktlex.(*wktLex).validateAndSetLayoutIfNoLayout(geom.XYM)
+			if !ok {
+				return 1
+			}
+		}
+	case 109:
+		wktDollar = wktS[wktpt-1 : wktpt+1]
+		{
+			ok := wktlex.(*wktLex).validateAndSetLayoutIfNoLayout(geom.XYZ)
+			if !ok {
+				return 1
+			}
+		}
+	case 110:
+		wktDollar = wktS[wktpt-1 : wktpt+1]
+		{
+			ok := wktlex.(*wktLex).validateAndSetLayoutIfNoLayout(geom.XYZM)
+			if !ok {
+				return 1
+			}
 		}
 	case 111:
 		wktDollar = wktS[wktpt-3 : wktpt+1]
 		{
-			wktVAL.flatRepr = wktDollar[2].flatRepr
+			wktVAL.geomList = append(wktDollar[1].geomList, wktDollar[3].geom)
 		}
 	case 112:
+		wktDollar = wktS[wktpt-1 : wktpt+1]
+		{
+			wktVAL.geomList = []geom.T{wktDollar[1].geom}
+		}
+	case 113:
+		wktDollar = wktS[wktpt-1 : wktpt+1]
+		{
+			wktVAL.geom = geom.NewLineStringFlat(wktlex.(*wktLex).curLayout(), wktDollar[1].coordList)
+		}
+	case 115:
+		wktDollar = wktS[wktpt-3 : wktpt+1]
+		{
+			wktVAL.geomList = append(wktDollar[1].geomList, wktDollar[3].geom)
+		}
+	case 116:
+		wktDollar = wktS[wktpt-1 : wktpt+1]
+		{
+			wktVAL.geomList = []geom.T{wktDollar[1].geom}
+		}
+	case 117:
+		wktDollar = wktS[wktpt-1 : wktpt+1]
+		{
+			wktVAL.geom = geom.NewLinearRingFlat(wktlex.(*wktLex).curLayout(), wktDollar[1].flatRepr.flatCoords)
+		}
+	case 120:
+		wktDollar = wktS[wktpt-3 : wktpt+1]
+		{
+			wktVAL.geomList = append(wktDollar[1].geomList, wktDollar[3].geom)
+		}
+	case 121:
+		wktDollar = wktS[wktpt-1 : wktpt+1]
+		{
+			wktVAL.geomList = []geom.T{wktDollar[1].geom}
+		}
+	case 122:
+		wktDollar = wktS[wktpt-1 : wktpt+1]
+		{
+			wktVAL.geom = geom.NewLineStringFlat(wktlex.(*wktLex).curLayout(), wktDollar[1].coordList)
+		}
+	case 125:
+		wktDollar = wktS[wktpt-3 : wktpt+1]
+		{
+			wktVAL.geomList = append(wktDollar[1].geomList, wktDollar[3].geom)
+		}
+	case 126:
+		wktDollar = wktS[wktpt-1 : wktpt+1]
+		{
+			wktVAL.geomList = []geom.T{wktDollar[1].geom}
+		}
+	case 127:
+		wktDollar = wktS[wktpt-1 : wktpt+1]
+		{
+			wktVAL.geom = geom.NewPolygonFlat(wktlex.(*wktLex).curLayout(), wktDollar[1].flatRepr.flatCoords, wktDollar[1].flatRepr.ends)
+		}
+	case 129:
+		wktDollar = wktS[wktpt-2 : wktpt+1]
+		{
+			wktVAL.geom = geom.NewCircularStringFlat(wktlex.(*wktLex).curLayout(), wktDollar[2].coordList)
+		}
+	case 132:
+		wktDollar = wktS[wktpt-4 : wktpt+1]
+		{
+			g := geom.NewCompoundCurve(wktlex.(*wktLex).curLayout())
+			if err := g.Push(wktDollar[3].geomList...); err != nil {
+				wktlex.(*wktLex).setError(err)
+				return 1
+			}
+			wktVAL.geom = g
+		}
+	case 135:
+		wktDollar = wktS[wktpt-4 : wktpt+1]
+		{
+			g := geom.NewCurvePolygon(wktlex.(*wktLex).curLayout())
+			if err := g.Push(wktDollar[3].geomList...); err != nil {
+				wktlex.(*wktLex).setError(err)
+				return 1
+			}
+			wktVAL.geom = g
+		}
+	case 138:
+		wktDollar = wktS[wktpt-2 : wktpt+1]
+		{
+			if !wktlex.(*wktLex).isValidTriangle(wktDollar[2].flatRepr.ends) {
+				return 1
+			}
+			wktVAL.geom = geom.NewTriangleFlat(wktlex.(*wktLex).curLayout(), wktDollar[2].flatRepr.flatCoords, wktDollar[2].flatRepr.ends)
+		}
+	case 139:
+		wktDollar = wktS[wktpt-2 : wktpt+1]
+		{
+			wktVAL.geom = geom.NewTriangle(wktlex.(*wktLex).curLayout())
+		}
+	case 140:
+		wktDollar = wktS[wktpt-2 : wktpt+1]
+		{
+			wktVAL.geom = geom.NewTriangle(wktlex.(*wktLex).curLayout())
+		}
+	case 143:
+		wktDollar = wktS[wktpt-1 : wktpt+1]
+		{
+			ok := wktlex.(*wktLex).validateBaseGeometryTypeAllowed()
+			if !ok {
+				return 1
+			}
+		}
+	case 144:
+		wktDollar = wktS[wktpt-1 : wktpt+1]
+		{
+			ok := wktlex.(*wktLex).validateAndSetLayoutIfNoLayout(geom.XYM)
+			if !ok {
+				return 1
+			}
+		}
+	case 145:
+		wktDollar = wktS[wktpt-1 : wktpt+1]
+		{
+			ok := wktlex.(*wktLex).validateAndSetLayoutIfNoLayout(geom.XYZ)
+			if !ok {
+				return 1
+			}
+		}
+	case 146:
+		wktDollar = wktS[wktpt-1 : wktpt+1]
+		{
+			ok := wktlex.(*wktLex).validateAndSetLayoutIfNoLayout(geom.XYZM)
+			if !ok {
+				return 1
+			}
+		}
+	case 147:
+		wktDollar = wktS[wktpt-2 : wktpt+1]
+		{
+			if !wktlex.(*wktLex).isValidTIN(wktDollar[2].multiPolyFlatRepr.endss) {
+				return 1
+			}
+			wktVAL.geom = geom.NewTINFlat(wktlex.(*wktLex).curLayout(), wktDollar[2].multiPolyFlatRepr.flatCoords, wktDollar[2].multiPolyFlatRepr.endss)
+		}
+	case 148:
+		wktDollar = wktS[wktpt-2 : wktpt+1]
+		{
+			if !wktlex.(*wktLex).isValidTIN(wktDollar[2].multiPolyFlatRepr.endss) {
+				return 1
+			}
+			wktVAL.geom = geom.NewTINFlat(wktlex.(*wktLex).curLayout(), wktDollar[2].multiPolyFlatRepr.flatCoords, wktDollar[2].multiPolyFlatRepr.endss)
+		}
+	case 149:
+		wktDollar = wktS[wktpt-2 : wktpt+1]
+		{
+			wktVAL.geom = geom.NewTIN(wktlex.(*wktLex).curLayout())
+		}
+	case 150:
+		wktDollar = wktS[wktpt-2 : wktpt+1]
+		{
+			wktVAL.geom = geom.NewTIN(wktlex.(*wktLex).curLayout())
+		}
+	case 151:
+		wktDollar = wktS[wktpt-1 : wktpt+1]
+		{
+			ok := wktlex.(*wktLex).validateBaseGeometryTypeAllowed()
+			if !ok {
+				return 1
+			}
+		}
+	case 152:
+		wktDollar = wktS[wktpt-1 : wktpt+1]
+		{
+			ok := wktlex.(*wktLex).validateAndSetLayoutIfNoLayout(geom.XYM)
+			if !ok {
+				return 1
+			}
+		}
+	case 153:
+		wktDollar = wktS[wktpt-1 : wktpt+1]
+		{
+			ok := wktlex.(*wktLex).validateAndSetLayoutIfNoLayout(geom.XYZ)
+			if !ok {
+				return 1
+			}
+		}
+	case 154:
+		wktDollar = wktS[wktpt-1 : wktpt+1]
+		{
+			ok := wktlex.(*wktLex).validateAndSetLayoutIfNoLayout(geom.XYZM)
+			if !ok {
+				return 1
+			}
+		}
+	case 155:
+		wktDollar = wktS[wktpt-2 : wktpt+1]
+		{
+			wktVAL.geom = geom.NewPolyhedralSurfaceFlat(wktlex.(*wktLex).curLayout(), wktDollar[2].multiPolyFlatRepr.flatCoords, wktDollar[2].multiPolyFlatRepr.endss)
+		}
+	case 156:
+		wktDollar = wktS[wktpt-2 : wktpt+1]
+		{
+			wktVAL.geom = geom.NewPolyhedralSurfaceFlat(wktlex.(*wktLex).curLayout(), wktDollar[2].multiPolyFlatRepr.flatCoords, wktDollar[2].multiPolyFlatRepr.endss)
+		}
+	case 157:
+		wktDollar = wktS[wktpt-2 : wktpt+1]
+		{
+			wktVAL.geom = geom.NewPolyhedralSurface(wktlex.(*wktLex).curLayout())
+		}
+	case 158:
+		wktDollar = wktS[wktpt-2 : wktpt+1]
+		{
+			wktVAL.geom = geom.NewPolyhedralSurface(wktlex.(*wktLex).curLayout())
+		}
+	case 159:
+		wktDollar = wktS[wktpt-1 : wktpt+1]
+		{
+			ok := wktlex.(*wktLex).validateBaseGeometryTypeAllowed()
+			if !ok {
+				return 1
+			}
+		}
+	case 160:
+		wktDollar = wktS[wktpt-1 : wktpt+1]
+		{
+			ok := wktlex.(*wktLex).validateAndSetLayoutIfNoLayout(geom.XYM)
+			if !ok {
+				return 1
+			}
+		}
+	case 161:
+		wktDollar = wktS[wktpt-1 : wktpt+1]
+		{
+			ok := wktlex.(*wktLex).validateAndSetLayoutIfNoLayout(geom.XYZ)
+			if !ok {
+				return 1
+			}
+		}
+	case 162:
+		wktDollar = wktS[wktpt-1 : wktpt+1]
+		{
+			ok := wktlex.(*wktLex).validateAndSetLayoutIfNoLayout(geom.XYZM)
+			if !ok {
+				return 1
+			}
+		}
+	case 163:
+		wktDollar = wktS[wktpt-2 : wktpt+1]
+		{
+			newCollection := geom.NewGeometryCollection()
+			err := newCollection.Push(wktDollar[2].geomList...)
+			if err != nil {
+				wktlex.(*wktLex).setError(err)
+				return 1
+			}
+			wktVAL.geomCollect = newCollection
+		}
+	case 164:
+		wktDollar = wktS[wktpt-2 : wktpt+1]
+		{
+			wktVAL.geomCollect = geom.NewGeometryCollection()
+		}
+	case 165:
+		wktDollar = wktS[wktpt-2 : wktpt+1]
+		{
+			wktVAL.geomCollect = geom.NewGeometryCollection()
+		}
+	case 166:
+		wktDollar = wktS[wktpt-3 : wktpt+1]
+		{
+			wktVAL.geomList = wktDollar[2].geomList
+		}
+	case 167:
+		wktDollar = wktS[wktpt-3 : wktpt+1]
+		{
+			wktVAL.geomList = append(wktDollar[1].geomList, wktDollar[3].geom)
+		}
+	case 168:
+		wktDollar = wktS[wktpt-1 : wktpt+1]
+		{
+			wktVAL.geomList = []geom.T{wktDollar[1].geom}
+		}
+	case 171:
+		wktDollar = wktS[wktpt-1 : wktpt+1]
+		{
+			ok := wktlex.(*wktLex).validateAndPushLayoutStackFrame(geom.NoLayout)
+			if !ok {
+				return 1
+			}
+		}
+	case 172:
+		wktDollar = wktS[wktpt-1 : wktpt+1]
+		{
+			ok := wktlex.(*wktLex).validateAndPushLayoutStackFrame(geom.XYM)
+			if !ok {
+				return 1
+			}
+		}
+	case 173:
+		wktDollar = wktS[wktpt-1 : wktpt+1]
+		{
+			ok := wktlex.(*wktLex).validateAndPushLayoutStackFrame(geom.XYZ)
+			if !ok {
+				return 1
+			}
+		}
+	case 174:
+		wktDollar = wktS[wktpt-1 : wktpt+1]
+		{
+			ok := wktlex.(*wktLex).validateAndPushLayoutStackFrame(geom.XYZM)
+			if !ok {
+				return 1
+			}
+		}
+	case 175:
+		wktDollar = wktS[wktpt-1 : wktpt+1]
+		{
+			ok := wktlex.(*wktLex).validateNonEmptyGeometryAllowed()
+			if !ok {
+				return 1
+			}
+		}
+	case 176:
+		wktDollar = wktS[wktpt-3 : wktpt+1]
+		{
+			wktVAL.multiPolyFlatRepr = wktDollar[2].multiPolyFlatRepr
+		}
+	case 177:
+		wktDollar = wktS[wktpt-3 : wktpt+1]
+		{
+			wktVAL.multiPolyFlatRepr = wktDollar[2].multiPolyFlatRepr
+		}
+	case 178:
+		wktDollar = wktS[wktpt-3 : wktpt+1]
+		{
+			wktVAL.multiPolyFlatRepr = appendMultiPolygonFlatCoordsRepr(wktDollar[1].multiPolyFlatRepr, wktDollar[3].multiPolyFlatRepr)
+		}
+	case 180:
+		wktDollar = wktS[wktpt-3 : wktpt+1]
+		{
+			wktVAL.multiPolyFlatRepr = appendMultiPolygonFlatCoordsRepr(wktDollar[1].multiPolyFlatRepr, wktDollar[3].multiPolyFlatRepr)
+		}
+	case 182:
+		wktDollar = wktS[wktpt-1 : wktpt+1]
+		{
+			wktVAL.multiPolyFlatRepr = makeMultiPolygonFlatCoordsRepr(wktDollar[1].flatRepr)
+		}
+	case 183:
+		wktDollar = wktS[wktpt-1 : wktpt+1]
+		{
+			wktVAL.multiPolyFlatRepr = makeMultiPolygonFlatCoordsRepr(wktDollar[1].flatRepr)
+		}
+	case 185:
+		wktDollar = wktS[wktpt-1 : wktpt+1]
+		{
+			wktVAL.flatRepr = makeGeomFlatCoordsRepr(wktDollar[1].coordList)
+		}
+	case 187:
+		wktDollar = wktS[wktpt-1 : wktpt+1]
+		{
+			wktVAL.flatRepr = makeGeomFlatCoordsRepr(wktDollar[1].coordList)
+		}
+	case 188:
+		wktDollar = wktS[wktpt-3 : wktpt+1]
+		{
+			wktVAL.flatRepr = wktDollar[2].flatRepr
+		}
+	case 189:
+		wktDollar = wktS[wktpt-3 : wktpt+1]
+		{
+			wktVAL.flatRepr = wktDollar[2].flatRepr
+		}
+	case 190:
+		wktDollar = wktS[wktpt-3 : wktpt+1]
+		{
+			wktVAL.flatRepr = appendGeomFlatCoordsReprs(wktDollar[1].flatRepr, wktDollar[3].flatRepr)
+		}
+	case 192:
 		wktDollar = wktS[wktpt-3 : wktpt+1]
 		{
 			wktVAL.flatRepr = appendGeomFlatCoordsReprs(wktDollar[1].flatRepr, wktDollar[3].flatRepr)
 		}
-	case 114:
+	case 194:
+		wktDollar = wktS[wktpt-1 : wktpt+1]
+		{
+			wktVAL.flatRepr = makeGeomFlatCoordsRepr(wktDollar[1].coordList)
+		}
+	case 195:
+		wktDollar = wktS[wktpt-1 : wktpt+1]
+		{
+			wktVAL.flatRepr = makeGeomFlatCoordsRepr(wktDollar[1].coordList)
+		}
+	case 200:
+		wktDollar = wktS[wktpt-3 : wktpt+1]
+		{
+			wktVAL.flatRepr = wktDollar[2].flatRepr
+		}
+	case 201:
+		wktDollar = wktS[wktpt-3 : wktpt+1]
+		{
+			wktVAL.flatRepr = wktDollar[2].flatRepr
+		}
+	case 202:
+		wktDollar = wktS[wktpt-3 : wktpt+1]
+		{
+			wktVAL.flatRepr = appendGeomFlatCoordsReprs(wktDollar[1].flatRepr, wktDollar[3].flatRepr)
+		}
+	case 204:
+		wktDollar = wktS[wktpt-3 : wktpt+1]
+		{
+			wktVAL.flatRepr = appendGeomFlatCoordsReprs(wktDollar[1].flatRepr, wktDollar[3].flatRepr)
+		}
+	case 206:
+		wktDollar = wktS[wktpt-1 : wktpt+1]
+		{
+			wktVAL.flatRepr = makeGeomFlatCoordsRepr(wktDollar[1].coordList)
+		}
+	case 207:
+		wktDollar = wktS[wktpt-1 : wktpt+1]
+		{
+			wktVAL.flatRepr = makeGeomFlatCoordsRepr(wktDollar[1].coordList)
+		}
+	case 214:
+		wktDollar = wktS[wktpt-3 : wktpt+1]
+		{
+			wktVAL.flatRepr = wktDollar[2].flatRepr
+		}
+	case 215:
+		wktDollar = wktS[wktpt-3 : wktpt+1]
+		{
+			wktVAL.flatRepr = appendGeomFlatCoordsReprs(wktDollar[1].flatRepr, wktDollar[3].flatRepr)
+		}
+	case 217:
 		wktDollar = wktS[wktpt-1 : wktpt+1]
 		{
 			if !wktlex.(*wktLex).isValidPolygonRing(wktDollar[1].coordList) {
@@ -1107,46 +1839,53 @@ wktdefault:
 			}
 			wktVAL.flatRepr = makeGeomFlatCoordsRepr(wktDollar[1].coordList)
 		}
-	case 115:
+	case 218:
 		wktDollar = wktS[wktpt-1 : wktpt+1]
 		{
 			if !wktlex.(*wktLex).isValidLineString(wktDollar[1].coordList) {
 				return 1
 			}
 		}
-	case 116:
+	case 219:
+		wktDollar = wktS[wktpt-1 : wktpt+1]
+		{
+			if !wktlex.(*wktLex).isValidCircularString(wktDollar[1].coordList) {
+				return 1
+			}
+		}
+	case 220:
 		wktDollar = wktS[wktpt-3 : wktpt+1]
 		{
 			wktVAL.coordList = wktDollar[2].coordList
 		}
-	case 117:
+	case 221:
 		wktDollar = wktS[wktpt-3 : wktpt+1]
 		{
 			wktVAL.coordList = append(wktDollar[1].coordList, wktDollar[3].coordList...)
 		}
-	case 119:
+	case 223:
 		wktDollar = wktS[wktpt-3 : wktpt+1]
 		{
 			wktVAL.coordList = wktDollar[2].coordList
 		}
-	case 120:
+	case 224:
 		wktDollar = wktS[wktpt-1 : wktpt+1]
 		{
 			if !wktlex.(*wktLex).isValidPoint(wktDollar[1].coordList) {
 				return 1
 			}
 		}
-	case 121:
+	case 225:
 		wktDollar = wktS[wktpt-2 : wktpt+1]
 		{
 			wktVAL.coordList = append(wktDollar[1].coordList, wktDollar[2].coord)
 		}
-	case 122:
+	case 226:
 		wktDollar = wktS[wktpt-1 : wktpt+1]
 		{
 			wktVAL.coordList = []float64{wktDollar[1].coord}
 		}
-	case 123:
+	case 227:
 		wktDollar = wktS[wktpt-1 : wktpt+1]
 		{
 			ok := wktlex.(*wktLex).validateBaseTypeEmptyAllowed()
@@ -1154,7 +1893,7 @@ wktdefault:
 				return 1
 			}
 		}
-	case 125:
+	case 229:
 		wktDollar = wktS[wktpt-1 : wktpt+1]
 		{
 			wktVAL.coordList = []float64(nil)
@@ -13,16 +13,24 @@ import (
 )
 
 const (
-	tPoint              = "POINT "
-	tMultiPoint         = "MULTIPOINT "
-	tLineString         = "LINESTRING "
-	tMultiLineString    = "MULTILINESTRING "
-	tPolygon            = "POLYGON "
-	tMultiPolygon       = "MULTIPOLYGON "
-	tGeometryCollection = "GEOMETRYCOLLECTION "
-	tZ                  = "Z "
-	tM                  = "M "
-	tZm                 = "ZM "
+	tPoint              = "POINT"
+	tMultiPoint         = "MULTIPOINT"
+	tLineString         = "LINESTRING"
+	tMultiLineString    = "MULTILINESTRING"
+	tPolygon            = "POLYGON"
+	tMultiPolygon       = "MULTIPOLYGON"
+	tGeometryCollection = "GEOMETRYCOLLECTION"
+	tCircularString     = "CIRCULARSTRING"
+	tCompoundCurve      = "COMPOUNDCURVE"
+	tCurvePolygon       = "CURVEPOLYGON"
+	tMultiCurve         = "MULTICURVE"
+	tMultiSurface       = "MULTISURFACE"
+	tTriangle           = "TRIANGLE"
+	tTIN                = "TIN"
+	tPolyhedralSurface  = "POLYHEDRALSURFACE"
+	tZ                  = "Z"
+	tM                  = "M"
+	tZm                 = "ZM"
 	tEmpty              = "EMPTY"
 )
 
@@ -31,9 +39,32 @@ var ErrBraceMismatch = errors.New("wkt: brace mismatch")
 
 // Encoder encodes WKT based on specified parameters.
 type Encoder struct {
-	maxDecimalDigits int
+	maxDecimalDigits      int
+	fixedDecimalDigits    bool
+	ewkt                  bool
+	dimensionStyle        DimensionStyle
+	multiPointParentheses bool
+	indent                string
 }
 
+// A DimensionStyle is a way of writing the dimension of a geometry with Z or M
+// ordinates.
+type DimensionStyle int
+
+// Dimension styles.
+const (
+	// DimensionStyleKeyword writes the dimension as a separate keyword, as in
+	// POINT Z (1 2 3). This is the default.
+	DimensionStyleKeyword DimensionStyle = iota
+	// DimensionStyleSuffix appends the dimension to the geometry type, as in
+	// POINTZ(1 2 3).
+	DimensionStyleSuffix
+	// DimensionStyleNone omits the dimension, as in Simple Features 1.1, for
+	// example POINT (1 2 3). The layout is then inferred from the number of
+	// ordinates when decoding, so XYM geometries are decoded as XYZ.
+	DimensionStyleNone
+)
+
 // NewEncoder returns a new encoder with the given options set.
 func NewEncoder(applyOptFns ...EncodeOption) *Encoder {
 	encoder := &Encoder{
@@ -49,9 +80,55 @@ func NewEncoder(applyOptFns ...EncodeOption) *Encoder {
 type EncodeOption func(*Encoder)
 
 // EncodeOptionWithMaxDecimalDigits sets the maximum decimal digits to encode.
+// Trailing zeros are removed. By default, the shortest representation that
+// decodes to the same value is used.
 func EncodeOptionWithMaxDecimalDigits(maxDecimalDigits int) EncodeOption {
 	return func(e *Encoder) {
 		e.maxDecimalDigits = maxDecimalDigits
+		e.fixedDecimalDigits = false
+	}
+}
+
+// EncodeOptionWithFixedDecimalDigits sets the exact number of decimal digits
+// to encode, keeping trailing zeros, for example 1.500 with three digits.
+func EncodeOptionWithFixedDecimalDigits(decimalDigits int) EncodeOption {
+	return func(e *Encoder) {
+		e.maxDecimalDigits = decimalDigits
+		e.fixedDecimalDigits = true
+	}
+}
+
+// EncodeOptionWithDimensionStyle sets the way in which the dimension of
+// geometries with Z or M ordinates is written.
+func EncodeOptionWithDimensionStyle(dimensionStyle DimensionStyle) EncodeOption {
+	return func(e *Encoder) {
+		e.dimensionStyle = dimensionStyle
+	}
+}
+
+// EncodeOptionWithMultiPointParentheses encloses each point of a MultiPoint in
+// parentheses, as in MULTIPOINT ((1 2), (3 4)), as required by the OGC
+// specification. By default the points are written as MULTIPOINT (1 2, 3 4).
+func EncodeOptionWithMultiPointParentheses() EncodeOption {
+	return func(e *Encoder) {
+		e.multiPointParentheses = true
+	}
+}
+
+// EncodeOptionWithIndent writes each member of a GeometryCollection on its own
+// line, indented by indent for each level of nesting. By default,
+// GeometryCollections are written on a single line.
+func EncodeOptionWithIndent(indent string) EncodeOption {
+	return func(e *Encoder) {
+		e.indent = indent
+	}
+}
+
+// EncodeOptionWithEWKT enables Extended WKT, as used by PostGIS, in which
+// geometries with a non-zero SRID are prefixed with SRID=n;.
+func EncodeOptionWithEWKT() EncodeOption {
+	return func(e *Encoder) {
+		e.ewkt = true
 	}
 }
 
@@ -60,12 +137,57 @@ func Marshal(g geom.T, applyOptFns ...EncodeOption) (string, error) {
 	return NewEncoder(applyOptFns...).Encode(g)
 }
 
-// Unmarshal translates a WKT to the corresponding geometry.
-func Unmarshal(wkt string) (geom.T, error) {
+// A DecodeOption is a decoder option.
+type DecodeOption func(*decodeOptions)
+
+// decodeOptions are the options for decoding WKT.
+type decodeOptions struct {
+	limits      geom.Limits
+	skipInvalid bool
+	onInvalid   func(error)
+}
+
+// DecodeOptionWithLimits sets the limits on the size of the input and of the
+// decoded geometry. When decoding a stream with a Decoder, the limits apply to
+// each record.
+func DecodeOptionWithLimits(limits geom.Limits) DecodeOption {
+	return func(o *decodeOptions) {
+		o.limits = limits
+	}
+}
+
+// DecodeOptionWithSkipInvalid makes a Decoder skip records that cannot be
+// decoded instead of returning an error. If onInvalid is not nil, it is called
+// with the error for each skipped record. It has no effect on Unmarshal.
+func DecodeOptionWithSkipInvalid(onInvalid func(error)) DecodeOption {
+	return func(o *decodeOptions) {
+		o.skipInvalid = true
+		o.onInvalid = onInvalid
+	}
+}
+
+// Unmarshal translates a WKT to the corresponding geometry. Extended WKT with a
+// SRID=n; prefix is also accepted, in which case the geometry's SRID is set.
+func Unmarshal(wkt string, opts ...DecodeOption) (geom.T, error) {
+	var o decodeOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return unmarshal(wkt, &o)
+}
+
+// unmarshal translates a WKT to the corresponding geometry with options o.
+func unmarshal(wkt string, o *decodeOptions) (geom.T, error) {
+	if err := o.limits.CheckBytes(len(wkt)); err != nil {
+		return nil, err
+	}
 	wktlex := newWKTLex(wkt)
 	wktParse(wktlex)
 	if wktlex.lastErr != nil {
 		return nil, wktlex.lastErr
 	}
+	if err := o.limits.Check(wktlex.ret); err != nil {
+		return nil, err
+	}
 	return wktlex.ret, nil
 }
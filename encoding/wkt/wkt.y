@@ -25,13 +25,24 @@ import "github.com/don4get/go-geom"
 %token <str> MULTILINESTRING MULTILINESTRINGM MULTILINESTRINGZ MULTILINESTRINGZM
 %token <str> MULTIPOLYGON MULTIPOLYGONM MULTIPOLYGONZ MULTIPOLYGONZM
 %token <str> GEOMETRYCOLLECTION GEOMETRYCOLLECTIONM GEOMETRYCOLLECTIONZ GEOMETRYCOLLECTIONZM
-%token <str> EMPTY
+%token <str> CIRCULARSTRING CIRCULARSTRINGM CIRCULARSTRINGZ CIRCULARSTRINGZM
+%token <str> COMPOUNDCURVE COMPOUNDCURVEM COMPOUNDCURVEZ COMPOUNDCURVEZM
+%token <str> CURVEPOLYGON CURVEPOLYGONM CURVEPOLYGONZ CURVEPOLYGONZM
+%token <str> MULTICURVE MULTICURVEM MULTICURVEZ MULTICURVEZM
+%token <str> MULTISURFACE MULTISURFACEM MULTISURFACEZ MULTISURFACEZM
+%token <str> TRIANGLE TRIANGLEM TRIANGLEZ TRIANGLEZM
+%token <str> TIN TINM TINZ TINZM
+%token <str> POLYHEDRALSURFACE POLYHEDRALSURFACEM POLYHEDRALSURFACEZ POLYHEDRALSURFACEZM
+%token <str> EMPTY SRID
 %token <coord> NUM
 
 // Geometries
 %type <geom> geometry
+%type <coord> srid
 %type <geom> point linestring polygon multipoint multilinestring multipolygon
 %type <geomCollect> geometry_collection
+%type <geom> circularstring compoundcurve curvepolygon multicurve multisurface
+%type <geom> triangle tin polyhedralsurface
 
 // Empty representations
 %type <coordList> empty_in_base_type
@@ -91,6 +102,15 @@ import "github.com/don4get/go-geom"
 %type <geomList> geometry_list
 %type <geomList> geometry_list_with_parens
 
+// Curves
+%type <coordList> flat_coords_circularstring
+%type <geom> inner_circularstring inner_compoundcurve inner_curvepolygon
+%type <geom> compoundcurve_component curvepolygon_ring multicurve_curve multisurface_surface
+%type <geomList> compoundcurve_component_list
+%type <geomList> curvepolygon_ring_list
+%type <geomList> multicurve_curve_list
+%type <geomList> multisurface_surface_list
+
 %%
 
 start:
@@ -102,6 +122,28 @@ start:
 		}
 		wktlex.(*wktLex).ret = $1
 	}
+|	srid geometry
+	{
+		ok := wktlex.(*wktLex).validateLayoutStackAtEnd()
+		if !ok {
+			return 1
+		}
+		g, err := geom.SetSRID($2, int($1))
+		if err != nil {
+			wktlex.(*wktLex).setError(err)
+			return 1
+		}
+		wktlex.(*wktLex).ret = g
+	}
+
+srid:
+	SRID '=' NUM ';'
+	{
+		if !wktlex.(*wktLex).isValidSRID($3) {
+			return 1
+		}
+		$$ = $3
+	}
 
 geometry:
 	point
@@ -110,6 +152,14 @@ geometry:
 |	multipoint
 |	multilinestring
 |	multipolygon
+|	circularstring
+|	compoundcurve
+|	curvepolygon
+|	multicurve
+|	multisurface
+|	triangle
+|	tin
+|	polyhedralsurface
 |	geometry_collection
 	{
 		ok := wktlex.(*wktLex).validateAndPopLayoutStackFrame()
@@ -428,6 +478,565 @@ multipolygon_non_base_type:
 		}
 	}
 
+circularstring:
+	circularstring_type flat_coords_circularstring
+	{
+		$$ = geom.NewCircularStringFlat(wktlex.(*wktLex).curLayout(), $2)
+	}
+|	circularstring_base_type empty_in_base_type
+	{
+		$$ = geom.NewCircularString(wktlex.(*wktLex).curLayout())
+	}
+|	circularstring_non_base_type empty_in_non_base_type
+	{
+		$$ = geom.NewCircularString(wktlex.(*wktLex).curLayout())
+	}
+
+circularstring_type:
+	circularstring_base_type
+|	circularstring_non_base_type
+
+circularstring_base_type:
+	CIRCULARSTRING
+	{
+		ok := wktlex.(*wktLex).validateBaseGeometryTypeAllowed()
+		if !ok {
+			return 1
+		}
+	}
+
+circularstring_non_base_type:
+	CIRCULARSTRINGM
+	{
+		ok := wktlex.(*wktLex).validateAndSetLayoutIfNoLayout(geom.XYM)
+		if !ok {
+			return 1
+		}
+	}
+|	CIRCULARSTRINGZ
+	{
+		ok := wktlex.(*wktLex).validateAndSetLayoutIfNoLayout(geom.XYZ)
+		if !ok {
+			return 1
+		}
+	}
+|	CIRCULARSTRINGZM
+	{
+		ok := wktlex.(*wktLex).validateAndSetLayoutIfNoLayout(geom.XYZM)
+		if !ok {
+			return 1
+		}
+	}
+
+compoundcurve:
+	compoundcurve_base_type geometry_opening_lparen compoundcurve_component_list ')'
+	{
+		g := geom.NewCompoundCurve(wktlex.(*wktLex).curLayout())
+		if err := g.Push($3...); err != nil {
+			wktlex.(*wktLex).setError(err)
+			return 1
+		}
+		$$ = g
+	}
+|	compoundcurve_non_base_type '(' compoundcurve_component_list ')'
+	{
+		g := geom.NewCompoundCurve(wktlex.(*wktLex).curLayout())
+		if err := g.Push($3...); err != nil {
+			wktlex.(*wktLex).setError(err)
+			return 1
+		}
+		$$ = g
+	}
+|	compoundcurve_base_type empty_in_base_type
+	{
+		$$ = geom.NewCompoundCurve(wktlex.(*wktLex).curLayout())
+	}
+|	compoundcurve_non_base_type empty_in_non_base_type
+	{
+		$$ = geom.NewCompoundCurve(wktlex.(*wktLex).curLayout())
+	}
+
+compoundcurve_base_type:
+	COMPOUNDCURVE
+	{
+		ok := wktlex.(*wktLex).validateBaseGeometryTypeAllowed()
+		if !ok {
+			return 1
+		}
+	}
+
+compoundcurve_non_base_type:
+	COMPOUNDCURVEM
+	{
+		ok := wktlex.(*wktLex).validateAndSetLayoutIfNoLayout(geom.XYM)
+		if !ok {
+			return 1
+		}
+	}
+|	COMPOUNDCURVEZ
+	{
+		ok := wktlex.(*wktLex).validateAndSetLayoutIfNoLayout(geom.XYZ)
+		if !ok {
+			return 1
+		}
+	}
+|	COMPOUNDCURVEZM
+	{
+		ok := wktlex.(*wktLex).validateAndSetLayoutIfNoLayout(geom.XYZM)
+		if !ok {
+			return 1
+		}
+	}
+
+curvepolygon:
+	curvepolygon_base_type geometry_opening_lparen curvepolygon_ring_list ')'
+	{
+		g := geom.NewCurvePolygon(wktlex.(*wktLex).curLayout())
+		if err := g.Push($3...); err != nil {
+			wktlex.(*wktLex).setError(err)
+			return 1
+		}
+		$$ = g
+	}
+|	curvepolygon_non_base_type '(' curvepolygon_ring_list ')'
+	{
+		g := geom.NewCurvePolygon(wktlex.(*wktLex).curLayout())
+		if err := g.Push($3...); err != nil {
+			wktlex.(*wktLex).setError(err)
+			return 1
+		}
+		$$ = g
+	}
+|	curvepolygon_base_type empty_in_base_type
+	{
+		$$ = geom.NewCurvePolygon(wktlex.(*wktLex).curLayout())
+	}
+|	curvepolygon_non_base_type empty_in_non_base_type
+	{
+		$$ = geom.NewCurvePolygon(wktlex.(*wktLex).curLayout())
+	}
+
+curvepolygon_base_type:
+	CURVEPOLYGON
+	{
+		ok := wktlex.(*wktLex).validateBaseGeometryTypeAllowed()
+		if !ok {
+			return 1
+		}
+	}
+
+curvepolygon_non_base_type:
+	CURVEPOLYGONM
+	{
+		ok := wktlex.(*wktLex).validateAndSetLayoutIfNoLayout(geom.XYM)
+		if !ok {
+			return 1
+		}
+	}
+|	CURVEPOLYGONZ
+	{
+		ok := wktlex.(*wktLex).validateAndSetLayoutIfNoLayout(geom.XYZ)
+		if !ok {
+			return 1
+		}
+	}
+|	CURVEPOLYGONZM
+	{
+		ok := wktlex.(*wktLex).validateAndSetLayoutIfNoLayout(geom.XYZM)
+		if !ok {
+			return 1
+		}
+	}
+
+multicurve:
+	multicurve_base_type geometry_opening_lparen multicurve_curve_list ')'
+	{
+		g := geom.NewMultiCurve(wktlex.(*wktLex).curLayout())
+		if err := g.Push($3...); err != nil {
+			wktlex.(*wktLex).setError(err)
+			return 1
+		}
+		$$ = g
+	}
+|	multicurve_non_base_type '(' multicurve_curve_list ')'
+	{
+		g := geom.NewMultiCurve(wktlex.(*wktLex).curLayout())
+		if err := g.Push($3...); err != nil {
+			wktlex.(*wktLex).setError(err)
+			return 1
+		}
+		$$ = g
+	}
+|	multicurve_base_type empty_in_base_type
+	{
+		$$ = geom.NewMultiCurve(wktlex.(*wktLex).curLayout())
+	}
+|	multicurve_non_base_type empty_in_non_base_type
+	{
+		$$ = geom.NewMultiCurve(wktlex.(*wktLex).curLayout())
+	}
+
+multicurve_base_type:
+	MULTICURVE
+	{
+		ok := wktlex.(*wktLex).validateBaseGeometryTypeAllowed()
+		if !ok {
+			return 1
+		}
+	}
+
+multicurve_non_base_type:
+	MULTICURVEM
+	{
+		ok := wktlex.(*wktLex).validateAndSetLayoutIfNoLayout(geom.XYM)
+		if !ok {
+			return 1
+		}
+	}
+|	MULTICURVEZ
+	{
+		ok := wktlex.(*wktLex).validateAndSetLayoutIfNoLayout(geom.XYZ)
+		if !ok {
+			return 1
+		}
+	}
+|	MULTICURVEZM
+	{
+		ok := wktlex.(*wktLex).validateAndSetLayoutIfNoLayout(geom.XYZM)
+		if !ok {
+			return 1
+		}
+	}
+
+multisurface:
+	multisurface_base_type geometry_opening_lparen multisurface_surface_list ')'
+	{
+		g := geom.NewMultiSurface(wktlex.(*wktLex).curLayout())
+		if err := g.Push($3...); err != nil {
+			wktlex.(*wktLex).setError(err)
+			return 1
+		}
+		$$ = g
+	}
+|	multisurface_non_base_type '(' multisurface_surface_list ')'
+	{
+		g := geom.NewMultiSurface(wktlex.(*wktLex).curLayout())
+		if err := g.Push($3...); err != nil {
+			wktlex.(*wktLex).setError(err)
+			return 1
+		}
+		$$ = g
+	}
+|	multisurface_base_type empty_in_base_type
+	{
+		$$ = geom.NewMultiSurface(wktlex.(*wktLex).curLayout())
+	}
+|	multisurface_non_base_type empty_in_non_base_type
+	{
+		$$ = geom.NewMultiSurface(wktlex.(*wktLex).curLayout())
+	}
+
+multisurface_base_type:
+	MULTISURFACE
+	{
+		ok := wktlex.(*wktLex).validateBaseGeometryTypeAllowed()
+		if !ok {
+			return 1
+		}
+	}
+
+multisurface_non_base_type:
+	MULTISURFACEM
+	{
+		ok := wktlex.(*wktLex).validateAndSetLayoutIfNoLayout(geom.XYM)
+		if !ok {
+			return 1
+		}
+	}
+|	MULTISURFACEZ
+	{
+		ok := wktlex.(*wktLex).validateAndSetLayoutIfNoLayout(geom.XYZ)
+		if !ok {
+			return 1
+		}
+	}
+|	MULTISURFACEZM
+	{
+		ok := wktlex.(*wktLex).validateAndSetLayoutIfNoLayout(geom.XYZM)
+		if !ok {
+			return 1
+		}
+	}
+
+compoundcurve_component_list:
+	compoundcurve_component_list ',' compoundcurve_component
+	{
+		$$ = append($1, $3)
+	}
+|	compoundcurve_component
+	{
+		$$ = []geom.T{$1}
+	}
+
+compoundcurve_component:
+	flat_coords_linestring
+	{
+		$$ = geom.NewLineStringFlat(wktlex.(*wktLex).curLayout(), $1)
+	}
+|	inner_circularstring
+
+curvepolygon_ring_list:
+	curvepolygon_ring_list ',' curvepolygon_ring
+	{
+		$$ = append($1, $3)
+	}
+|	curvepolygon_ring
+	{
+		$$ = []geom.T{$1}
+	}
+
+curvepolygon_ring:
+	flat_coords_polygon_ring
+	{
+		$$ = geom.NewLinearRingFlat(wktlex.(*wktLex).curLayout(), $1.flatCoords)
+	}
+|	inner_circularstring
+|	inner_compoundcurve
+
+multicurve_curve_list:
+	multicurve_curve_list ',' multicurve_curve
+	{
+		$$ = append($1, $3)
+	}
+|	multicurve_curve
+	{
+		$$ = []geom.T{$1}
+	}
+
+multicurve_curve:
+	flat_coords_linestring
+	{
+		$$ = geom.NewLineStringFlat(wktlex.(*wktLex).curLayout(), $1)
+	}
+|	inner_circularstring
+|	inner_compoundcurve
+
+multisurface_surface_list:
+	multisurface_surface_list ',' multisurface_surface
+	{
+		$$ = append($1, $3)
+	}
+|	multisurface_surface
+	{
+		$$ = []geom.T{$1}
+	}
+
+multisurface_surface:
+	flat_coords_polygon_ring_list_with_parens
+	{
+		$$ = geom.NewPolygonFlat(wktlex.(*wktLex).curLayout(), $1.flatCoords, $1.ends)
+	}
+|	inner_curvepolygon
+
+inner_circularstring:
+	inner_circularstring_type flat_coords_circularstring
+	{
+		$$ = geom.NewCircularStringFlat(wktlex.(*wktLex).curLayout(), $2)
+	}
+
+inner_circularstring_type:
+	CIRCULARSTRING
+|	circularstring_non_base_type
+
+inner_compoundcurve:
+	inner_compoundcurve_type '(' compoundcurve_component_list ')'
+	{
+		g := geom.NewCompoundCurve(wktlex.(*wktLex).curLayout())
+		if err := g.Push($3...); err != nil {
+			wktlex.(*wktLex).setError(err)
+			return 1
+		}
+		$$ = g
+	}
+
+inner_compoundcurve_type:
+	COMPOUNDCURVE
+|	compoundcurve_non_base_type
+
+inner_curvepolygon:
+	inner_curvepolygon_type '(' curvepolygon_ring_list ')'
+	{
+		g := geom.NewCurvePolygon(wktlex.(*wktLex).curLayout())
+		if err := g.Push($3...); err != nil {
+			wktlex.(*wktLex).setError(err)
+			return 1
+		}
+		$$ = g
+	}
+
+inner_curvepolygon_type:
+	CURVEPOLYGON
+|	curvepolygon_non_base_type
+
+triangle:
+	triangle_type flat_coords_polygon_ring_list_with_parens
+	{
+		if !wktlex.(*wktLex).isValidTriangle($2.ends) {
+			return 1
+		}
+		$$ = geom.NewTriangleFlat(wktlex.(*wktLex).curLayout(), $2.flatCoords, $2.ends)
+	}
+|	triangle_base_type empty_in_base_type
+	{
+		$$ = geom.NewTriangle(wktlex.(*wktLex).curLayout())
+	}
+|	triangle_non_base_type empty_in_non_base_type
+	{
+		$$ = geom.NewTriangle(wktlex.(*wktLex).curLayout())
+	}
+
+triangle_type:
+	triangle_base_type
+|	triangle_non_base_type
+
+triangle_base_type:
+	TRIANGLE
+	{
+		ok := wktlex.(*wktLex).validateBaseGeometryTypeAllowed()
+		if !ok {
+			return 1
+		}
+	}
+
+triangle_non_base_type:
+	TRIANGLEM
+	{
+		ok := wktlex.(*wktLex).validateAndSetLayoutIfNoLayout(geom.XYM)
+		if !ok {
+			return 1
+		}
+	}
+|	TRIANGLEZ
+	{
+		ok := wktlex.(*wktLex).validateAndSetLayoutIfNoLayout(geom.XYZ)
+		if !ok {
+			return 1
+		}
+	}
+|	TRIANGLEZM
+	{
+		ok := wktlex.(*wktLex).validateAndSetLayoutIfNoLayout(geom.XYZM)
+		if !ok {
+			return 1
+		}
+	}
+
+tin:
+	tin_base_type multipolygon_base_type_polygon_list_with_parens
+	{
+		if !wktlex.(*wktLex).isValidTIN($2.endss) {
+			return 1
+		}
+		$$ = geom.NewTINFlat(wktlex.(*wktLex).curLayout(), $2.flatCoords, $2.endss)
+	}
+|	tin_non_base_type multipolygon_non_base_type_polygon_list_with_parens
+	{
+		if !wktlex.(*wktLex).isValidTIN($2.endss) {
+			return 1
+		}
+		$$ = geom.NewTINFlat(wktlex.(*wktLex).curLayout(), $2.flatCoords, $2.endss)
+	}
+|	tin_base_type empty_in_base_type
+	{
+		$$ = geom.NewTIN(wktlex.(*wktLex).curLayout())
+	}
+|	tin_non_base_type empty_in_non_base_type
+	{
+		$$ = geom.NewTIN(wktlex.(*wktLex).curLayout())
+	}
+
+tin_base_type:
+	TIN
+	{
+		ok := wktlex.(*wktLex).validateBaseGeometryTypeAllowed()
+		if !ok {
+			return 1
+		}
+	}
+
+tin_non_base_type:
+	TINM
+	{
+		ok := wktlex.(*wktLex).validateAndSetLayoutIfNoLayout(geom.XYM)
+		if !ok {
+			return 1
+		}
+	}
+|	TINZ
+	{
+		ok := wktlex.(*wktLex).validateAndSetLayoutIfNoLayout(geom.XYZ)
+		if !ok {
+			return 1
+		}
+	}
+|	TINZM
+	{
+		ok := wktlex.(*wktLex).validateAndSetLayoutIfNoLayout(geom.XYZM)
+		if !ok {
+			return 1
+		}
+	}
+
+polyhedralsurface:
+	polyhedralsurface_base_type multipolygon_base_type_polygon_list_with_parens
+	{
+		$$ = geom.NewPolyhedralSurfaceFlat(wktlex.(*wktLex).curLayout(), $2.flatCoords, $2.endss)
+	}
+|	polyhedralsurface_non_base_type multipolygon_non_base_type_polygon_list_with_parens
+	{
+		$$ = geom.NewPolyhedralSurfaceFlat(wktlex.(*wktLex).curLayout(), $2.flatCoords, $2.endss)
+	}
+|	polyhedralsurface_base_type empty_in_base_type
+	{
+		$$ = geom.NewPolyhedralSurface(wktlex.(*wktLex).curLayout())
+	}
+|	polyhedralsurface_non_base_type empty_in_non_base_type
+	{
+		$$ = geom.NewPolyhedralSurface(wktlex.(*wktLex).curLayout())
+	}
+
+polyhedralsurface_base_type:
+	POLYHEDRALSURFACE
+	{
+		ok := wktlex.(*wktLex).validateBaseGeometryTypeAllowed()
+		if !ok {
+			return 1
+		}
+	}
+
+polyhedralsurface_non_base_type:
+	POLYHEDRALSURFACEM
+	{
+		ok := wktlex.(*wktLex).validateAndSetLayoutIfNoLayout(geom.XYM)
+		if !ok {
+			return 1
+		}
+	}
+|	POLYHEDRALSURFACEZ
+	{
+		ok := wktlex.(*wktLex).validateAndSetLayoutIfNoLayout(geom.XYZ)
+		if !ok {
+			return 1
+		}
+	}
+|	POLYHEDRALSURFACEZM
+	{
+		ok := wktlex.(*wktLex).validateAndSetLayoutIfNoLayout(geom.XYZM)
+		if !ok {
+			return 1
+		}
+	}
+
 geometry_collection:
 	geometry_collection_type geometry_list_with_parens
 	{
@@ -686,6 +1295,14 @@ flat_coords_linestring:
 		}
 	}
 
+flat_coords_circularstring:
+	flat_coords_point_list_with_parens
+	{
+		if !wktlex.(*wktLex).isValidCircularString($1) {
+			return 1
+		}
+	}
+
 flat_coords_point_list_with_parens:
 	geometry_opening_lparen flat_coords_point_list ')'
 	{
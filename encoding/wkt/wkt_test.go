@@ -165,6 +165,97 @@ func TestMarshalAndUnmarshal(t *testing.T) {
 			).MustSetLayout(geom.XY),
 			s: "GEOMETRYCOLLECTION (POINT (1 2), LINESTRING (3 4, 5 6))",
 		},
+		{
+			g: geom.NewCircularString(geom.XY),
+			s: "CIRCULARSTRING EMPTY",
+		},
+		{
+			g: geom.NewCircularString(geom.XY).MustSetCoords([]geom.Coord{{0, 0}, {1, 1}, {2, 0}}),
+			s: "CIRCULARSTRING (0 0, 1 1, 2 0)",
+		},
+		{
+			g: geom.NewCircularString(geom.XYZ).MustSetCoords([]geom.Coord{{0, 0, 1}, {1, 1, 2}, {2, 0, 3}}),
+			s: "CIRCULARSTRING Z (0 0 1, 1 1 2, 2 0 3)",
+		},
+		{
+			g: geom.NewCompoundCurve(geom.XY).MustPush(
+				geom.NewLineString(geom.XY).MustSetCoords([]geom.Coord{{0, 0}, {1, 1}}),
+				geom.NewCircularString(geom.XY).MustSetCoords([]geom.Coord{{1, 1}, {2, 0}, {3, 1}}),
+			),
+			s: "COMPOUNDCURVE ((0 0, 1 1), CIRCULARSTRING (1 1, 2 0, 3 1))",
+		},
+		{
+			g: geom.NewCompoundCurve(geom.XYM).MustPush(
+				geom.NewCircularString(geom.XYM).MustSetCoords([]geom.Coord{{0, 0, 1}, {1, 1, 1}, {2, 0, 1}}),
+			),
+			s: "COMPOUNDCURVE M (CIRCULARSTRING M (0 0 1, 1 1 1, 2 0 1))",
+		},
+		{
+			g: geom.NewCurvePolygon(geom.XY).MustPush(
+				geom.NewCompoundCurve(geom.XY).MustPush(
+					geom.NewCircularString(geom.XY).MustSetCoords([]geom.Coord{{0, 0}, {2, 0}, {2, 2}}),
+					geom.NewLineString(geom.XY).MustSetCoords([]geom.Coord{{2, 2}, {0, 0}}),
+				),
+				geom.NewLinearRing(geom.XY).MustSetCoords([]geom.Coord{{1, 0.5}, {1.5, 0.5}, {1.5, 1}, {1, 0.5}}),
+			),
+			s: "CURVEPOLYGON (COMPOUNDCURVE (CIRCULARSTRING (0 0, 2 0, 2 2), (2 2, 0 0)), (1 0.5, 1.5 0.5, 1.5 1, 1 0.5))",
+		},
+		{
+			g: geom.NewCurvePolygon(geom.XYZ),
+			s: "CURVEPOLYGON Z EMPTY",
+		},
+		{
+			g: geom.NewMultiCurve(geom.XY).MustPush(
+				geom.NewLineString(geom.XY).MustSetCoords([]geom.Coord{{0, 0}, {1, 1}}),
+				geom.NewCircularString(geom.XY).MustSetCoords([]geom.Coord{{0, 0}, {1, 1}, {2, 0}}),
+			),
+			s: "MULTICURVE ((0 0, 1 1), CIRCULARSTRING (0 0, 1 1, 2 0))",
+		},
+		{
+			g: geom.NewMultiSurface(geom.XY).MustPush(
+				geom.NewCurvePolygon(geom.XY).MustPush(
+					geom.NewCircularString(geom.XY).MustSetCoords([]geom.Coord{{0, 0}, {4, 0}, {0, 0}}),
+				),
+				geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{{{0, 0}, {1, 0}, {1, 1}, {0, 0}}}),
+			),
+			s: "MULTISURFACE (CURVEPOLYGON (CIRCULARSTRING (0 0, 4 0, 0 0)), ((0 0, 1 0, 1 1, 0 0)))",
+		},
+		{
+			g: geom.NewGeometryCollection().MustPush(
+				geom.NewCircularString(geom.XY).MustSetCoords([]geom.Coord{{0, 0}, {1, 1}, {2, 0}}),
+			).MustSetLayout(geom.XY),
+			s: "GEOMETRYCOLLECTION (CIRCULARSTRING (0 0, 1 1, 2 0))",
+		},
+		{
+			g: geom.NewTriangle(geom.XYZ).MustSetCoords([][]geom.Coord{{{0, 0, 0}, {1, 0, 0}, {0, 1, 1}, {0, 0, 0}}}),
+			s: "TRIANGLE Z ((0 0 0, 1 0 0, 0 1 1, 0 0 0))",
+		},
+		{
+			g: geom.NewTriangle(geom.XY),
+			s: "TRIANGLE EMPTY",
+		},
+		{
+			g: geom.NewTIN(geom.XY).MustSetCoords([][][]geom.Coord{
+				{{{0, 0}, {1, 0}, {0, 1}, {0, 0}}},
+				{{{1, 0}, {1, 1}, {0, 1}, {1, 0}}},
+			}),
+			s: "TIN (((0 0, 1 0, 0 1, 0 0)), ((1 0, 1 1, 0 1, 1 0)))",
+		},
+		{
+			g: geom.NewTIN(geom.XYM),
+			s: "TIN M EMPTY",
+		},
+		{
+			g: geom.NewPolyhedralSurface(geom.XYZ).MustSetCoords([][][]geom.Coord{
+				{{{0, 0, 0}, {0, 1, 0}, {1, 1, 0}, {1, 0, 0}, {0, 0, 0}}},
+				{{{0, 0, 0}, {1, 0, 0}, {1, 0, 1}, {0, 0, 1}, {0, 0, 0}}},
+			}),
+			s: "POLYHEDRALSURFACE Z (((0 0 0, 0 1 0, 1 1 0, 1 0 0, 0 0 0)), ((0 0 0, 1 0 0, 1 0 1, 0 0 1, 0 0 0)))",
+		},
+		{
+			g: geom.NewPolyhedralSurface(geom.XY),
+			s: "POLYHEDRALSURFACE EMPTY",
+		},
 	} {
 		t.Run(tc.s, func(t *testing.T) {
 			t.Run("marshal", func(t *testing.T) {
@@ -223,6 +314,90 @@ func TestEncoder(t *testing.T) {
 			g:       geom.NewPointFlat(geom.XY, []float64{1.001, 1.066}),
 			s:       "POINT (1.001 1.066)",
 		},
+		{
+			encoder: NewEncoder(EncodeOptionWithFixedDecimalDigits(3)),
+			g:       geom.NewPointFlat(geom.XY, []float64{1.5, 1.06666}),
+			s:       "POINT (1.500 1.067)",
+		},
+		{
+			encoder: NewEncoder(EncodeOptionWithFixedDecimalDigits(0)),
+			g:       geom.NewPointFlat(geom.XY, []float64{1.5, 2}),
+			s:       "POINT (2 2)",
+		},
+		{
+			encoder: NewEncoder(EncodeOptionWithFixedDecimalDigits(2), EncodeOptionWithMaxDecimalDigits(2)),
+			g:       geom.NewPointFlat(geom.XY, []float64{1.5, 2}),
+			s:       "POINT (1.5 2)",
+		},
+		{
+			encoder: NewEncoder(EncodeOptionWithDimensionStyle(DimensionStyleSuffix)),
+			g:       geom.NewPointFlat(geom.XYZ, []float64{1, 2, 3}),
+			s:       "POINTZ(1 2 3)",
+		},
+		{
+			encoder: NewEncoder(EncodeOptionWithDimensionStyle(DimensionStyleSuffix)),
+			g:       geom.NewPointFlat(geom.XY, []float64{1, 2}),
+			s:       "POINT(1 2)",
+		},
+		{
+			encoder: NewEncoder(EncodeOptionWithDimensionStyle(DimensionStyleSuffix)),
+			g:       geom.NewPointEmpty(geom.XYM),
+			s:       "POINTM EMPTY",
+		},
+		{
+			encoder: NewEncoder(EncodeOptionWithDimensionStyle(DimensionStyleSuffix)),
+			g: geom.NewGeometryCollection().MustPush(
+				geom.NewLineStringFlat(geom.XYZM, []float64{1, 2, 3, 4, 5, 6, 7, 8}),
+			),
+			s: "GEOMETRYCOLLECTIONZM(LINESTRINGZM(1 2 3 4, 5 6 7 8))",
+		},
+		{
+			encoder: NewEncoder(EncodeOptionWithDimensionStyle(DimensionStyleNone)),
+			g:       geom.NewPointFlat(geom.XYZ, []float64{1, 2, 3}),
+			s:       "POINT (1 2 3)",
+		},
+		{
+			encoder: NewEncoder(EncodeOptionWithDimensionStyle(DimensionStyleNone)),
+			g:       geom.NewPolygon(geom.XYZM),
+			s:       "POLYGON EMPTY",
+		},
+		{
+			encoder: NewEncoder(EncodeOptionWithMultiPointParentheses()),
+			g: geom.NewMultiPointFlat(
+				geom.XY, []float64{1, 2, 3, 4},
+				geom.NewMultiPointFlatOptionWithEnds([]int{2, 2, 4}),
+			),
+			s: "MULTIPOINT ((1 2), EMPTY, (3 4))",
+		},
+		{
+			encoder: NewEncoder(EncodeOptionWithMultiPointParentheses(), EncodeOptionWithDimensionStyle(DimensionStyleSuffix)),
+			g:       geom.NewMultiPointFlat(geom.XYZ, []float64{1, 2, 3}),
+			s:       "MULTIPOINTZ((1 2 3))",
+		},
+		{
+			encoder: NewEncoder(EncodeOptionWithIndent("  ")),
+			g: geom.NewGeometryCollection().MustPush(
+				geom.NewPointFlat(geom.XY, []float64{1, 2}),
+				geom.NewGeometryCollection().MustPush(
+					geom.NewLineStringFlat(geom.XY, []float64{1, 2, 3, 4}),
+					geom.NewPointEmpty(geom.XY),
+				),
+				geom.NewGeometryCollection(),
+			),
+			s: "GEOMETRYCOLLECTION (\n" +
+				"  POINT (1 2),\n" +
+				"  GEOMETRYCOLLECTION (\n" +
+				"    LINESTRING (1 2, 3 4),\n" +
+				"    POINT EMPTY\n" +
+				"  ),\n" +
+				"  GEOMETRYCOLLECTION EMPTY\n" +
+				")",
+		},
+		{
+			encoder: NewEncoder(EncodeOptionWithIndent("\t")),
+			g:       geom.NewGeometryCollection(),
+			s:       "GEOMETRYCOLLECTION EMPTY",
+		},
 	} {
 		t.Run(fmt.Sprintf("%s(encoder=%#v)", tc.s, tc.encoder), func(t *testing.T) {
 			got, err := tc.encoder.Encode(tc.g)
@@ -232,6 +407,153 @@ func TestEncoder(t *testing.T) {
 	}
 }
 
+func TestEncoderRoundTrip(t *testing.T) {
+	for _, g := range []geom.T{
+		geom.NewPointFlat(geom.XYZ, []float64{1, 2, 3}),
+		geom.NewMultiPointFlat(geom.XYZM, []float64{1, 2, 3, 4, 5, 6, 7, 8}),
+		geom.NewPolygonFlat(geom.XY, []float64{0, 0, 1, 0, 1, 1, 0, 0}, []int{8}),
+		geom.NewGeometryCollection().MustPush(
+			geom.NewPointFlat(geom.XYZ, []float64{1, 2, 3}),
+			geom.NewGeometryCollection().MustPush(
+				geom.NewMultiPointFlat(geom.XYZ, []float64{4, 5, 6}),
+			).MustSetLayout(geom.XYZ),
+		).MustSetLayout(geom.XYZ),
+	} {
+		for _, encoder := range []*Encoder{
+			NewEncoder(EncodeOptionWithDimensionStyle(DimensionStyleSuffix)),
+			NewEncoder(EncodeOptionWithDimensionStyle(DimensionStyleNone)),
+			NewEncoder(EncodeOptionWithMultiPointParentheses()),
+			NewEncoder(EncodeOptionWithFixedDecimalDigits(2), EncodeOptionWithIndent("\t")),
+		} {
+			s, err := encoder.Encode(g)
+			assert.NoError(t, err)
+			t.Run(s, func(t *testing.T) {
+				got, err := Unmarshal(s)
+				assert.NoError(t, err)
+				assert.Equal(t, g, got)
+			})
+		}
+	}
+}
+
+func TestEWKT(t *testing.T) {
+	for _, tc := range []struct {
+		g geom.T
+		s string
+	}{
+		{
+			g: geom.NewPointFlat(geom.XY, []float64{1, 2}).SetSRID(4326),
+			s: "SRID=4326;POINT (1 2)",
+		},
+		{
+			g: geom.NewPointFlat(geom.XY, []float64{1, 2}),
+			s: "POINT (1 2)",
+		},
+		{
+			g: geom.NewPointEmpty(geom.XYZ).SetSRID(3857),
+			s: "SRID=3857;POINT Z EMPTY",
+		},
+		{
+			g: geom.NewLineStringFlat(geom.XYM, []float64{1, 2, 3, 4, 5, 6}).SetSRID(4326),
+			s: "SRID=4326;LINESTRING M (1 2 3, 4 5 6)",
+		},
+		{
+			g: geom.NewPolygonFlat(geom.XY, []float64{0, 0, 1, 0, 1, 1, 0, 0}, []int{8}).SetSRID(2154),
+			s: "SRID=2154;POLYGON ((0 0, 1 0, 1 1, 0 0))",
+		},
+		{
+			g: geom.NewMultiPointFlat(geom.XY, []float64{1, 2, 3, 4}).SetSRID(4326),
+			s: "SRID=4326;MULTIPOINT (1 2, 3 4)",
+		},
+		{
+			g: geom.NewMultiLineStringFlat(geom.XY, []float64{1, 2, 3, 4}, []int{4}).SetSRID(4326),
+			s: "SRID=4326;MULTILINESTRING ((1 2, 3 4))",
+		},
+		{
+			g: geom.NewMultiPolygonFlat(geom.XY, []float64{0, 0, 1, 0, 1, 1, 0, 0}, [][]int{{8}}).SetSRID(4326),
+			s: "SRID=4326;MULTIPOLYGON (((0 0, 1 0, 1 1, 0 0)))",
+		},
+		{
+			g: geom.NewGeometryCollection().MustPush(
+				geom.NewPointFlat(geom.XY, []float64{1, 2}),
+				geom.NewLineStringFlat(geom.XY, []float64{1, 2, 3, 4}),
+			).MustSetLayout(geom.XY).SetSRID(4326),
+			s: "SRID=4326;GEOMETRYCOLLECTION (POINT (1 2), LINESTRING (1 2, 3 4))",
+		},
+		{
+			g: geom.NewGeometryCollection().MustSetLayout(geom.XY).SetSRID(4326),
+			s: "SRID=4326;GEOMETRYCOLLECTION EMPTY",
+		},
+		{
+			g: geom.NewCircularStringFlat(geom.XY, []float64{0, 0, 1, 1, 2, 0}).SetSRID(4326),
+			s: "SRID=4326;CIRCULARSTRING (0 0, 1 1, 2 0)",
+		},
+		{
+			g: geom.NewCompoundCurve(geom.XY).MustPush(
+				geom.NewCircularStringFlat(geom.XY, []float64{0, 0, 1, 1, 2, 0}),
+			).SetSRID(4326),
+			s: "SRID=4326;COMPOUNDCURVE (CIRCULARSTRING (0 0, 1 1, 2 0))",
+		},
+		{
+			g: geom.NewCurvePolygon(geom.XY).MustPush(
+				geom.NewCircularStringFlat(geom.XY, []float64{0, 0, 1, 1, 0, 0}),
+			).SetSRID(4326),
+			s: "SRID=4326;CURVEPOLYGON (CIRCULARSTRING (0 0, 1 1, 0 0))",
+		},
+		{
+			g: geom.NewMultiCurve(geom.XY).MustPush(
+				geom.NewLineStringFlat(geom.XY, []float64{0, 0, 1, 1}),
+			).SetSRID(4326),
+			s: "SRID=4326;MULTICURVE ((0 0, 1 1))",
+		},
+		{
+			g: geom.NewMultiSurface(geom.XY).MustPush(
+				geom.NewPolygonFlat(geom.XY, []float64{0, 0, 1, 0, 1, 1, 0, 0}, []int{8}),
+			).SetSRID(4326),
+			s: "SRID=4326;MULTISURFACE (((0 0, 1 0, 1 1, 0 0)))",
+		},
+		{
+			g: geom.NewTriangleFlat(geom.XYZ, []float64{0, 0, 0, 1, 0, 0, 1, 1, 0, 0, 0, 0}, []int{12}).SetSRID(4979),
+			s: "SRID=4979;TRIANGLE Z ((0 0 0, 1 0 0, 1 1 0, 0 0 0))",
+		},
+		{
+			g: geom.NewTINFlat(geom.XY, []float64{0, 0, 1, 0, 1, 1, 0, 0}, [][]int{{8}}).SetSRID(4326),
+			s: "SRID=4326;TIN (((0 0, 1 0, 1 1, 0 0)))",
+		},
+		{
+			g: geom.NewPolyhedralSurfaceFlat(geom.XY, []float64{0, 0, 1, 0, 1, 1, 0, 0}, [][]int{{8}}).SetSRID(4326),
+			s: "SRID=4326;POLYHEDRALSURFACE (((0 0, 1 0, 1 1, 0 0)))",
+		},
+	} {
+		t.Run(tc.s, func(t *testing.T) {
+			t.Run("marshal", func(t *testing.T) {
+				got, err := Marshal(tc.g, EncodeOptionWithEWKT())
+				assert.NoError(t, err)
+				assert.Equal(t, tc.s, got)
+			})
+
+			t.Run("unmarshal", func(t *testing.T) {
+				got, err := Unmarshal(tc.s)
+				assert.NoError(t, err)
+				assert.Equal(t, tc.g, got)
+				assert.Equal(t, tc.g.GetSRID(), got.GetSRID())
+			})
+		})
+	}
+
+	t.Run("without EWKT", func(t *testing.T) {
+		got, err := Marshal(geom.NewPointFlat(geom.XY, []float64{1, 2}).SetSRID(4326))
+		assert.NoError(t, err)
+		assert.Equal(t, "POINT (1 2)", got)
+	})
+
+	t.Run("lowercase and spaces", func(t *testing.T) {
+		got, err := Unmarshal("srid = 4326 ; point(1 2)")
+		assert.NoError(t, err)
+		assert.Equal[geom.T](t, geom.NewPointFlat(geom.XY, []float64{1, 2}).SetSRID(4326), got)
+	})
+}
+
 func TestUnmarshalEmptyGeomWithArbitrarySpaces(t *testing.T) {
 	for _, tc := range []struct {
 		g geom.T
@@ -1308,6 +1630,59 @@ LINE 6:  MULTIPOINT(-1 5 -16, 0.23 7.0 0),
                    ^
 HINT: the M variant is required for non-empty XYM geometries in GEOMETRYCOLLECTIONs`,
 		},
+		{
+			desc:  "triangle with too many points",
+			input: "TRIANGLE((0 0, 1 0, 1 1, 0 1, 0 0))",
+			expectedErrStr: `syntax error: triangle has the wrong number of points at line 1, pos 34
+LINE 1: ...NGLE((0 0, 1 0, 1 1, 0 1, 0 0))
+                                         ^
+HINT: a triangle has a single ring of 4 points`,
+		},
+		{
+			desc:  "negative srid",
+			input: "SRID=-1;POINT(1 2)",
+			expectedErrStr: `syntax error: invalid SRID -1 at line 1, pos 7
+LINE 1: SRID=-1;POINT(1 2)
+               ^
+HINT: the SRID must be a non-negative integer`,
+		},
+		{
+			desc:  "non-integer srid",
+			input: "SRID=4326.5;POINT(1 2)",
+			expectedErrStr: `syntax error: invalid SRID 4326.5 at line 1, pos 11
+LINE 1: SRID=4326.5;POINT(1 2)
+                   ^
+HINT: the SRID must be a non-negative integer`,
+		},
+		{
+			desc:  "srid without semicolon",
+			input: "SRID=4326 POINT(1 2)",
+			expectedErrStr: `syntax error: unexpected POINT, expecting ';' at line 1, pos 10
+LINE 1: SRID=4326 POINT(1 2)
+                  ^`,
+		},
+		{
+			desc:  "srid in geometrycollection",
+			input: "GEOMETRYCOLLECTION(SRID=4326;POINT(1 2))",
+			expectedErrStr: `syntax error: unexpected SRID at line 1, pos 19
+LINE 1: GEOMETRYCOLLECTION(SRID=4326;POINT(1 2))
+                           ^`,
+		},
+		{
+			desc:  "circularstring with an even number of points",
+			input: "CIRCULARSTRING(0 0, 1 1)",
+			expectedErrStr: `syntax error: circularstring has the wrong number of points at line 1, pos 23
+LINE 1: CIRCULARSTRING(0 0, 1 1)
+                               ^
+HINT: number of points must be odd and at least 3`,
+		},
+		{
+			desc:  "compoundcurve with mixed dimensionality",
+			input: "COMPOUNDCURVE Z ((0 0, 1 1))",
+			expectedErrStr: `syntax error: mixed dimensionality, parsed layout is XYZ so expecting 3 coords but got 2 coords at line 1, pos 21
+LINE 1: COMPOUNDCURVE Z ((0 0, 1 1))
+                             ^`,
+		},
 	}
 
 	for _, tc := range errorTestCases {
@@ -1317,3 +1692,42 @@ HINT: the M variant is required for non-empty XYM geometries in GEOMETRYCOLLECTI
 		})
 	}
 }
+
+func TestUnmarshalLimits(t *testing.T) {
+	const wkt = "GEOMETRYCOLLECTION (GEOMETRYCOLLECTION (POINT (1 2)), POLYGON ((0 0, 1 0, 1 1, 0 0), (0 0, 1 0, 1 1, 0 0)))"
+	for _, tc := range []struct {
+		name     string
+		limits   geom.Limits
+		expected error
+	}{
+		{
+			name:   "within limits",
+			limits: geom.Limits{MaxBytes: len(wkt), MaxCoords: 9, MaxParts: 2, MaxDepth: 2},
+		},
+		{
+			name:     "bytes",
+			limits:   geom.Limits{MaxBytes: 16},
+			expected: geom.ErrLimitExceeded{Limit: geom.LimitBytes, N: len(wkt), Max: 16},
+		},
+		{
+			name:     "coordinates",
+			limits:   geom.Limits{MaxCoords: 8},
+			expected: geom.ErrLimitExceeded{Limit: geom.LimitCoords, N: 9, Max: 8},
+		},
+		{
+			name:     "parts",
+			limits:   geom.Limits{MaxParts: 1},
+			expected: geom.ErrLimitExceeded{Limit: geom.LimitParts, N: 2, Max: 1},
+		},
+		{
+			name:     "depth",
+			limits:   geom.Limits{MaxDepth: 1},
+			expected: geom.ErrLimitExceeded{Limit: geom.LimitDepth, N: 2, Max: 1},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := Unmarshal(wkt, DecodeOptionWithLimits(tc.limits))
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+}
@@ -215,6 +215,53 @@ func (g *geom3) Reverse() {
 	reverse3(g.FlatCoords, 0, g.Endss, g.Stride)
 }
 
+// element returns the flat coordinates and ends of the ith sub-structure of
+// g. The flat coordinates alias g.
+func (g *geom3) element(i int) ([]float64, []int) {
+	if len(g.Endss[i]) == 0 {
+		return nil, nil
+	}
+	// Find the offset from the previous non-empty element.
+	offset := 0
+	lastNonEmptyIdx := i - 1
+	for lastNonEmptyIdx >= 0 {
+		ends := g.Endss[lastNonEmptyIdx]
+		if len(ends) > 0 {
+			offset = ends[len(ends)-1]
+			break
+		}
+		lastNonEmptyIdx--
+	}
+	ends := make([]int, len(g.Endss[i]))
+	if offset == 0 {
+		copy(ends, g.Endss[i])
+	} else {
+		for j, end := range g.Endss[i] {
+			ends[j] = end - offset
+		}
+	}
+	return g.FlatCoords[offset:g.Endss[i][len(g.Endss[i])-1]], ends
+}
+
+// pushElement appends a sub-structure with flat coordinates flatCoords and
+// ends ends to g.
+func (g *geom3) pushElement(flatCoords []float64, ends []int) {
+	offset := len(g.FlatCoords)
+	var newEnds []int
+	if len(ends) > 0 {
+		newEnds = make([]int, len(ends))
+		if offset == 0 {
+			copy(newEnds, ends)
+		} else {
+			for i, end := range ends {
+				newEnds[i] = end + offset
+			}
+		}
+	}
+	g.FlatCoords = append(g.FlatCoords, flatCoords...)
+	g.Endss = append(g.Endss, newEnds)
+}
+
 func (g *geom3) setCoords(coords3 [][][]Coord) error {
 	var err error
 	g.FlatCoords, g.Endss, err = deflate3(nil, nil, coords3, g.Stride)
@@ -275,6 +322,9 @@ func doubleArea2(flatCoords []float64, offset int, ends []int, stride int) float
 func doubleArea3(flatCoords []float64, offset int, endss [][]int, stride int) float64 {
 	var doubleArea float64
 	for _, ends := range endss {
+		if len(ends) == 0 {
+			continue
+		}
 		doubleArea += doubleArea2(flatCoords, offset, ends, stride)
 		offset = ends[len(ends)-1]
 	}
@@ -391,6 +441,9 @@ func length2(flatCoords []float64, offset int, ends []int, stride int) float64 {
 func length3(flatCoords []float64, offset int, endss [][]int, stride int) float64 {
 	var length float64
 	for _, ends := range endss {
+		if len(ends) == 0 {
+			continue
+		}
 		length += length2(flatCoords, offset, ends, stride)
 		offset = ends[len(ends)-1]
 	}
@@ -212,6 +212,61 @@ func SetSRID(g T, srid int) (T, error) {
 		return g.SetSRID(srid), nil
 	case *GeometryCollection:
 		return g.SetSRID(srid), nil
+	case *CircularString:
+		return g.SetSRID(srid), nil
+	case *CompoundCurve:
+		return g.SetSRID(srid), nil
+	case *CurvePolygon:
+		return g.SetSRID(srid), nil
+	case *MultiCurve:
+		return g.SetSRID(srid), nil
+	case *MultiSurface:
+		return g.SetSRID(srid), nil
+	case *Triangle:
+		return g.SetSRID(srid), nil
+	case *TIN:
+		return g.SetSRID(srid), nil
+	case *PolyhedralSurface:
+		return g.SetSRID(srid), nil
+	default:
+		return g, &ErrUnsupportedType{
+			Value: g,
+		}
+	}
+}
+
+// Linearize returns a linear approximation of an arbitrary geometry, with
+// segmentsPerQuadrant segments for each quarter circle of each arc. Curved
+// geometries are replaced by their linear equivalents: CircularStrings and
+// CompoundCurves by LineStrings, CurvePolygons by Polygons, MultiCurves by
+// MultiLineStrings, and MultiSurfaces by MultiPolygons. GeometryCollections
+// are linearized recursively and other geometries are returned unchanged.
+func Linearize(g T, segmentsPerQuadrant int) (T, error) {
+	switch g := g.(type) {
+	case *Point, *LineString, *LinearRing, *Polygon, *MultiPoint, *MultiLineString, *MultiPolygon,
+		*Triangle, *TIN, *PolyhedralSurface:
+		return g, nil
+	case *CircularString:
+		return g.Linearize(segmentsPerQuadrant), nil
+	case *CompoundCurve:
+		return g.Linearize(segmentsPerQuadrant), nil
+	case *CurvePolygon:
+		return g.Linearize(segmentsPerQuadrant), nil
+	case *MultiCurve:
+		return g.Linearize(segmentsPerQuadrant), nil
+	case *MultiSurface:
+		return g.Linearize(segmentsPerQuadrant), nil
+	case *GeometryCollection:
+		gc := NewGeometryCollection().SetSRID(g.srid)
+		gc.layout = g.layout
+		for _, geom := range g.geoms {
+			linearGeom, err := Linearize(geom, segmentsPerQuadrant)
+			if err != nil {
+				return nil, err
+			}
+			gc.geoms = append(gc.geoms, linearGeom)
+		}
+		return gc, nil
 	default:
 		return g, &ErrUnsupportedType{
 			Value: g,
@@ -0,0 +1,111 @@
+// Package cover contains helpers shared by the packages that cover polygons
+// with grid cells, such as cell and tile.
+package cover
+
+import (
+	"github.com/don4get/go-geom"
+	"github.com/don4get/go-geom/xy"
+)
+
+// A Rect is an axis-aligned rectangle.
+type Rect struct {
+	MinX, MinY, MaxX, MaxY float64
+}
+
+// Polygons returns the polygons of g, which must be a *geom.Polygon or a
+// *geom.MultiPolygon.
+func Polygons(g geom.T) ([]*geom.Polygon, error) {
+	switch g := g.(type) {
+	case *geom.Polygon:
+		return []*geom.Polygon{g}, nil
+	case *geom.MultiPolygon:
+		polygons := make([]*geom.Polygon, 0, g.NumPolygons())
+		for i := range g.NumPolygons() {
+			polygons = append(polygons, g.Polygon(i))
+		}
+		return polygons, nil
+	default:
+		return nil, geom.ErrUnsupportedType{Value: g}
+	}
+}
+
+// IntersectsPolygon returns whether r intersects the polygon p. r intersects
+// p if any of p's edges crosses r or, failing that, if r's center is inside
+// p.
+func (r Rect) IntersectsPolygon(p *geom.Polygon) bool {
+	if p.IsEmpty() {
+		return false
+	}
+	stride := p.Stride
+	flatCoords := p.FlatCoords
+	offset := 0
+	for _, end := range p.Ends {
+		if r.IntersectsLine(flatCoords[offset:end], stride) {
+			return true
+		}
+		offset = end
+	}
+	center := geom.Coord{(r.MinX + r.MaxX) / 2, (r.MinY + r.MaxY) / 2}
+	offset = 0
+	for i, end := range p.Ends {
+		inRing := xy.IsPointInRing(p.Layout, center, flatCoords[offset:end])
+		if i == 0 && !inRing || i != 0 && inRing {
+			return false
+		}
+		offset = end
+	}
+	return true
+}
+
+// IntersectsLine returns whether any segment of the line with flatCoords
+// intersects r.
+func (r Rect) IntersectsLine(flatCoords []float64, stride int) bool {
+	if len(flatCoords) == stride {
+		return r.ContainsPoint(flatCoords[0], flatCoords[1])
+	}
+	for i := stride; i < len(flatCoords); i += stride {
+		if r.intersectsSegment(flatCoords[i-stride], flatCoords[i-stride+1], flatCoords[i], flatCoords[i+1]) {
+			return true
+		}
+	}
+	return false
+}
+
+// ContainsPoint returns whether x, y is inside r or on its boundary.
+func (r Rect) ContainsPoint(x, y float64) bool {
+	return r.MinX <= x && x <= r.MaxX && r.MinY <= y && y <= r.MaxY
+}
+
+// intersectsSegment returns whether the segment from x0, y0 to x1, y1
+// intersects r, using Liang-Barsky clipping.
+func (r Rect) intersectsSegment(x0, y0, x1, y1 float64) bool {
+	t0, t1 := 0.0, 1.0
+	dx, dy := x1-x0, y1-y0
+	for _, pq := range [4][2]float64{
+		{-dx, x0 - r.MinX},
+		{dx, r.MaxX - x0},
+		{-dy, y0 - r.MinY},
+		{dy, r.MaxY - y0},
+	} {
+		p, q := pq[0], pq[1]
+		switch {
+		case p == 0:
+			if q < 0 {
+				return false
+			}
+		case p < 0:
+			if t := q / p; t > t1 {
+				return false
+			} else if t > t0 {
+				t0 = t
+			}
+		default:
+			if t := q / p; t < t0 {
+				return false
+			} else if t < t1 {
+				t1 = t
+			}
+		}
+	}
+	return true
+}
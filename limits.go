@@ -0,0 +1,157 @@
+package geom
+
+import "fmt"
+
+// A Limit identifies one of the limits in Limits.
+type Limit int
+
+// Limits.
+const (
+	LimitBytes Limit = iota
+	LimitCoords
+	LimitParts
+	LimitDepth
+)
+
+func (l Limit) String() string {
+	switch l {
+	case LimitBytes:
+		return "bytes"
+	case LimitCoords:
+		return "coordinates"
+	case LimitParts:
+		return "parts"
+	case LimitDepth:
+		return "depth"
+	default:
+		return fmt.Sprintf("Limit(%d)", int(l))
+	}
+}
+
+// Limits limit the size of the input and of the geometries that decoders
+// accept, which protects them against hostile input. A zero limit means no
+// limit.
+type Limits struct {
+	// MaxBytes is the maximum size of the input, in bytes.
+	MaxBytes int
+	// MaxCoords is the maximum total number of coordinates in a geometry.
+	MaxCoords int
+	// MaxParts is the maximum number of rings in a polygon, and of parts in
+	// a multi-geometry, a GeometryCollection, or a composite geometry.
+	MaxParts int
+	// MaxDepth is the maximum nesting depth of GeometryCollections. A
+	// GeometryCollection that does not contain any GeometryCollections has
+	// depth 1.
+	MaxDepth int
+}
+
+// An ErrLimitExceeded is returned when a decoder's input exceeds its Limits.
+type ErrLimitExceeded struct {
+	Limit Limit
+	N     int
+	Max   int
+}
+
+func (e ErrLimitExceeded) Error() string {
+	return fmt.Sprintf("geom: %s limit exceeded, %d > %d", e.Limit, e.N, e.Max)
+}
+
+// CheckBytes returns an error if n exceeds l.MaxBytes.
+func (l Limits) CheckBytes(n int) error {
+	return checkLimit(LimitBytes, n, l.MaxBytes)
+}
+
+// CheckCoords returns an error if n exceeds l.MaxCoords.
+func (l Limits) CheckCoords(n int) error {
+	return checkLimit(LimitCoords, n, l.MaxCoords)
+}
+
+// CheckParts returns an error if n exceeds l.MaxParts.
+func (l Limits) CheckParts(n int) error {
+	return checkLimit(LimitParts, n, l.MaxParts)
+}
+
+// CheckDepth returns an error if n exceeds l.MaxDepth.
+func (l Limits) CheckDepth(n int) error {
+	return checkLimit(LimitDepth, n, l.MaxDepth)
+}
+
+// Check returns an error if the total number of coordinates, the number of
+// parts, or the nesting depth of g exceed l.
+func (l Limits) Check(g T) error {
+	coords := 0
+	return l.check(g, &coords, 0)
+}
+
+// check checks g, which is nested depth GeometryCollections deep, and adds
+// its coordinates to coords.
+func (l Limits) check(g T, coords *int, depth int) error {
+	var geoms []T
+	switch g := g.(type) {
+	case *GeometryCollection:
+		depth++
+		if err := l.CheckDepth(depth); err != nil {
+			return err
+		}
+		geoms = g.geoms
+	case *CompoundCurve:
+		geoms = g.Curves()
+	case *CurvePolygon:
+		geoms = g.Rings()
+	case *MultiCurve:
+		geoms = g.Curves()
+	case *MultiSurface:
+		geoms = g.Surfaces()
+	case *Polygon:
+		return l.checkFlat(coords, g.FlatCoords, g.Stride, g.Ends)
+	case *Triangle:
+		return l.checkFlat(coords, g.FlatCoords, g.Stride, g.Ends)
+	case *MultiPoint:
+		return l.checkFlat(coords, g.FlatCoords, g.Stride, g.Ends)
+	case *MultiLineString:
+		return l.checkFlat(coords, g.FlatCoords, g.Stride, g.Ends)
+	case *MultiPolygon:
+		return l.checkFlat(coords, g.FlatCoords, g.Stride, nil, g.Endss...)
+	case *PolyhedralSurface:
+		return l.checkFlat(coords, g.FlatCoords, g.Stride, nil, g.Endss...)
+	case *TIN:
+		return l.checkFlat(coords, g.FlatCoords, g.Stride, nil, g.Endss...)
+	default:
+		return l.checkFlat(coords, g.GetFlatCoords(), g.GetStride(), nil)
+	}
+	if err := l.CheckParts(len(geoms)); err != nil {
+		return err
+	}
+	for _, g := range geoms {
+		if err := l.check(g, coords, depth); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkFlat checks a geometry with the given flat coordinates, stride, ends,
+// and endss, and adds its coordinates to coords.
+func (l Limits) checkFlat(coords *int, flatCoords []float64, stride int, ends []int, endss ...[]int) error {
+	if err := l.CheckParts(max(len(ends), len(endss))); err != nil {
+		return err
+	}
+	for _, ends := range endss {
+		if err := l.CheckParts(len(ends)); err != nil {
+			return err
+		}
+	}
+	if stride > 0 {
+		*coords += len(flatCoords) / stride
+	}
+	return l.CheckCoords(*coords)
+}
+
+// checkLimit returns an error if n exceeds the limit maxN. A zero limit means
+// no limit.
+func checkLimit(limit Limit, n, maxN int) error {
+	if maxN > 0 && n > maxN {
+		return ErrLimitExceeded{Limit: limit, N: n, Max: maxN}
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package geom
+
+import (
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+)
+
+func TestLimitsCheck(t *testing.T) {
+	nested := NewGeometryCollection().MustPush(
+		NewGeometryCollection().MustPush(
+			NewPointFlat(XY, []float64{1, 2}),
+			NewLineStringFlat(XY, []float64{1, 2, 3, 4}),
+		),
+		NewMultiPolygonFlat(XY, []float64{0, 0, 1, 0, 1, 1, 0, 0, 0, 0, 1, 0, 1, 1, 0, 0}, [][]int{{8, 16}}),
+	)
+	for _, tc := range []struct {
+		name     string
+		limits   Limits
+		g        T
+		expected error
+	}{
+		{
+			name: "no limits",
+			g:    nested,
+		},
+		{
+			name:   "within limits",
+			limits: Limits{MaxCoords: 11, MaxParts: 2, MaxDepth: 2},
+			g:      nested,
+		},
+		{
+			name:     "coordinates",
+			limits:   Limits{MaxCoords: 10},
+			g:        nested,
+			expected: ErrLimitExceeded{Limit: LimitCoords, N: 11, Max: 10},
+		},
+		{
+			name:     "rings",
+			limits:   Limits{MaxParts: 1},
+			g:        NewPolygonFlat(XY, []float64{0, 0, 1, 0, 1, 1, 0, 0, 0, 0, 1, 0, 1, 1, 0, 0}, []int{8, 16}),
+			expected: ErrLimitExceeded{Limit: LimitParts, N: 2, Max: 1},
+		},
+		{
+			name:     "rings in multipolygon",
+			limits:   Limits{MaxParts: 1},
+			g:        NewMultiPolygonFlat(XY, []float64{0, 0, 1, 0, 1, 1, 0, 0, 0, 0, 1, 0, 1, 1, 0, 0}, [][]int{{8, 16}}),
+			expected: ErrLimitExceeded{Limit: LimitParts, N: 2, Max: 1},
+		},
+		{
+			name:     "components",
+			limits:   Limits{MaxParts: 1},
+			g:        NewCompoundCurve(XY).MustPush(NewLineStringFlat(XY, []float64{0, 0, 1, 1}), NewLineStringFlat(XY, []float64{1, 1, 2, 0})),
+			expected: ErrLimitExceeded{Limit: LimitParts, N: 2, Max: 1},
+		},
+		{
+			name:     "depth",
+			limits:   Limits{MaxDepth: 1},
+			g:        nested,
+			expected: ErrLimitExceeded{Limit: LimitDepth, N: 2, Max: 1},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.limits.Check(tc.g))
+		})
+	}
+}
+
+func TestErrLimitExceeded(t *testing.T) {
+	assert.EqualError(t, Limits{MaxBytes: 10}.CheckBytes(11), "geom: bytes limit exceeded, 11 > 10")
+	assert.NoError(t, Limits{MaxBytes: 10}.CheckBytes(10))
+}
@@ -0,0 +1,82 @@
+package geom
+
+// A MultiCurve is a collection of curves. Each curve is a *LineString, a
+// *CircularString, or a *CompoundCurve.
+type MultiCurve struct {
+	composite
+}
+
+// NewMultiCurve returns a new empty MultiCurve with layout l.
+func NewMultiCurve(l Layout) *MultiCurve {
+	return &MultiCurve{newComposite(l)}
+}
+
+// Clone returns a deep copy of g.
+func (g *MultiCurve) Clone() *MultiCurve {
+	return &MultiCurve{g.clone()}
+}
+
+// Curve returns the ith curve of g. The flat coordinates alias g.
+func (g *MultiCurve) Curve(i int) T {
+	return g.curve(i, false)
+}
+
+// Curves returns the curves of g.
+func (g *MultiCurve) Curves() []T {
+	return g.curves(0, g.NumCurves(), false)
+}
+
+// GetEnds returns the end of each curve of g in its flat coordinates.
+func (g *MultiCurve) GetEnds() []int {
+	return g.curveFlatEnds(0, g.NumCurves())
+}
+
+// GetEndss returns nil.
+func (g *MultiCurve) GetEndss() [][]int {
+	return nil
+}
+
+// Linearize returns a MultiLineString approximating g, with
+// segmentsPerQuadrant segments for each quarter circle of each arc.
+func (g *MultiCurve) Linearize(segmentsPerQuadrant int) *MultiLineString {
+	flatCoords, ends := g.linearizeCurves(0, g.NumCurves(), segmentsPerQuadrant)
+	return NewMultiLineStringFlat(g.layout, flatCoords, ends).SetSRID(g.srid)
+}
+
+// MustPush pushes curves to g. It panics on any error.
+func (g *MultiCurve) MustPush(curves ...T) *MultiCurve {
+	if err := g.Push(curves...); err != nil {
+		panic(err)
+	}
+	return g
+}
+
+// NumCurves returns the number of curves in g.
+func (g *MultiCurve) NumCurves() int {
+	return len(g.curveTypes)
+}
+
+// Push appends curves to g. Each curve must be a *LineString, a
+// *CircularString, or a *CompoundCurve with g's layout.
+func (g *MultiCurve) Push(curves ...T) error {
+	if err := g.checkPush(func(curve T) bool {
+		switch curve.(type) {
+		case *LineString, *CircularString, *CompoundCurve:
+			return true
+		default:
+			return false
+		}
+	}, curves); err != nil {
+		return err
+	}
+	for _, curve := range curves {
+		g.pushCurve(curve)
+	}
+	return nil
+}
+
+// SetSRID sets the SRID of g.
+func (g *MultiCurve) SetSRID(srid int) *MultiCurve {
+	g.srid = srid
+	return g
+}
@@ -48,29 +48,8 @@ func (g *MultiPolygon) NumPolygons() int {
 
 // Polygon returns the ith Polygon.
 func (g *MultiPolygon) Polygon(i int) *Polygon {
-	if len(g.Endss[i]) == 0 {
-		return NewPolygon(g.Layout)
-	}
-	// Find the offset from the previous non-empty polygon element.
-	offset := 0
-	lastNonEmptyIdx := i - 1
-	for lastNonEmptyIdx >= 0 {
-		ends := g.Endss[lastNonEmptyIdx]
-		if len(ends) > 0 {
-			offset = ends[len(ends)-1]
-			break
-		}
-		lastNonEmptyIdx--
-	}
-	ends := make([]int, len(g.Endss[i]))
-	if offset == 0 {
-		copy(ends, g.Endss[i])
-	} else {
-		for j, end := range g.Endss[i] {
-			ends[j] = end - offset
-		}
-	}
-	return NewPolygonFlat(g.Layout, g.FlatCoords[offset:g.Endss[i][len(g.Endss[i])-1]], ends)
+	flatCoords, ends := g.element(i)
+	return NewPolygonFlat(g.Layout, flatCoords, ends)
 }
 
 // Push appends a Polygon.
@@ -78,20 +57,7 @@ func (g *MultiPolygon) Push(p *Polygon) error {
 	if p.Layout != g.Layout {
 		return ErrLayoutMismatch{Got: p.Layout, Want: g.Layout}
 	}
-	offset := len(g.FlatCoords)
-	var ends []int
-	if len(p.Ends) > 0 {
-		ends = make([]int, len(p.Ends))
-		if offset == 0 {
-			copy(ends, p.Ends)
-		} else {
-			for i, end := range p.Ends {
-				ends[i] = end + offset
-			}
-		}
-	}
-	g.FlatCoords = append(g.FlatCoords, p.FlatCoords...)
-	g.Endss = append(g.Endss, ends)
+	g.pushElement(p.FlatCoords, p.Ends)
 	return nil
 }
 
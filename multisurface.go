@@ -0,0 +1,111 @@
+package geom
+
+// A MultiSurface is a collection of surfaces. Each surface is a *Polygon or
+// a *CurvePolygon.
+type MultiSurface struct {
+	composite
+}
+
+// NewMultiSurface returns a new empty MultiSurface with layout l.
+func NewMultiSurface(l Layout) *MultiSurface {
+	return &MultiSurface{newComposite(l)}
+}
+
+// Clone returns a deep copy of g.
+func (g *MultiSurface) Clone() *MultiSurface {
+	return &MultiSurface{g.clone()}
+}
+
+// GetEnds returns nil.
+func (g *MultiSurface) GetEnds() []int {
+	return nil
+}
+
+// GetEndss returns the ends of the rings of each surface of g in its flat
+// coordinates.
+func (g *MultiSurface) GetEndss() [][]int {
+	endss := make([][]int, 0, g.NumSurfaces())
+	for i := range g.NumSurfaces() {
+		endss = append(endss, g.curveFlatEnds(g.surfaceOffset(i), g.surfaceEnds[i]))
+	}
+	return endss
+}
+
+// Linearize returns a MultiPolygon approximating g, with segmentsPerQuadrant
+// segments for each quarter circle of each arc.
+func (g *MultiSurface) Linearize(segmentsPerQuadrant int) *MultiPolygon {
+	mp := NewMultiPolygon(g.layout).SetSRID(g.srid)
+	for i := range g.NumSurfaces() {
+		flatCoords, ends := g.linearizeCurves(g.surfaceOffset(i), g.surfaceEnds[i], segmentsPerQuadrant)
+		mp.pushElement(flatCoords, ends)
+	}
+	return mp
+}
+
+// MustPush pushes surfaces to g. It panics on any error.
+func (g *MultiSurface) MustPush(surfaces ...T) *MultiSurface {
+	if err := g.Push(surfaces...); err != nil {
+		panic(err)
+	}
+	return g
+}
+
+// NumSurfaces returns the number of surfaces in g.
+func (g *MultiSurface) NumSurfaces() int {
+	return len(g.surfaceTypes)
+}
+
+// Push appends surfaces to g. Each surface must be a *Polygon or a
+// *CurvePolygon with g's layout.
+func (g *MultiSurface) Push(surfaces ...T) error {
+	if err := g.checkPush(func(surface T) bool {
+		switch surface.(type) {
+		case *Polygon, *CurvePolygon:
+			return true
+		default:
+			return false
+		}
+	}, surfaces); err != nil {
+		return err
+	}
+	for _, surface := range surfaces {
+		switch surface := surface.(type) {
+		case *Polygon:
+			for i := range surface.NumLinearRings() {
+				g.pushCurve(surface.LinearRing(i))
+			}
+			g.surfaceTypes = append(g.surfaceTypes, false)
+		case *CurvePolygon:
+			g.pushCurves(&surface.composite)
+			g.surfaceTypes = append(g.surfaceTypes, true)
+		}
+		g.surfaceEnds = append(g.surfaceEnds, len(g.curveEnds))
+	}
+	return nil
+}
+
+// SetSRID sets the SRID of g.
+func (g *MultiSurface) SetSRID(srid int) *MultiSurface {
+	g.srid = srid
+	return g
+}
+
+// Surface returns the ith surface of g, either a *Polygon or a
+// *CurvePolygon. The flat coordinates alias g.
+func (g *MultiSurface) Surface(i int) T {
+	first, last := g.surfaceOffset(i), g.surfaceEnds[i]
+	if g.surfaceTypes[i] {
+		return &CurvePolygon{g.curveRange(first, last)}
+	}
+	c := g.curveRange(first, last)
+	return NewPolygonFlat(g.layout, c.flatCoords, c.curveFlatEnds(0, last-first))
+}
+
+// Surfaces returns the surfaces of g.
+func (g *MultiSurface) Surfaces() []T {
+	surfaces := make([]T, 0, g.NumSurfaces())
+	for i := range g.NumSurfaces() {
+		surfaces = append(surfaces, g.Surface(i))
+	}
+	return surfaces
+}
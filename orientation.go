@@ -0,0 +1,156 @@
+package geom
+
+import (
+	"slices"
+)
+
+// ForceCCW orients g's exterior ring counter-clockwise and its interior rings
+// clockwise, in place, and returns g. This is the orientation used by GeoJSON.
+func (g *Polygon) ForceCCW() *Polygon {
+	forceOrientation2(g.FlatCoords, 0, g.Ends, g.Stride, true)
+	return g
+}
+
+// ForceCW orients g's exterior ring clockwise and its interior rings
+// counter-clockwise, in place, and returns g. This is the orientation used by
+// shapefiles.
+func (g *Polygon) ForceCW() *Polygon {
+	forceOrientation2(g.FlatCoords, 0, g.Ends, g.Stride, false)
+	return g
+}
+
+// ForceRHR orients g's rings so that its interior is on the right when walking
+// along each ring (the right-hand rule), in place, and returns g. It is
+// equivalent to ForceCW.
+func (g *Polygon) ForceRHR() *Polygon {
+	return g.ForceCW()
+}
+
+// Normalize puts g into a canonical form, in place, and returns g. The
+// exterior ring is oriented counter-clockwise, the interior rings are oriented
+// clockwise, every closed ring starts at its smallest coordinate, and the
+// interior rings are sorted. Two polygons with the same rings have equal
+// coordinates after normalization, whatever their original representation.
+func (g *Polygon) Normalize() *Polygon {
+	g.ForceCCW()
+	rings := normalizeRings(g.FlatCoords, 0, g.Ends, g.Stride)
+	if len(rings) > 1 {
+		slices.SortStableFunc(rings[1:], slices.Compare[[]float64])
+	}
+	g.FlatCoords, g.Ends = g.FlatCoords[:0], g.Ends[:0]
+	for _, ring := range rings {
+		g.FlatCoords = append(g.FlatCoords, ring...)
+		g.Ends = append(g.Ends, len(g.FlatCoords))
+	}
+	return g
+}
+
+// ForceCCW orients the exterior rings of g's Polygons counter-clockwise and
+// their interior rings clockwise, in place, and returns g.
+func (g *MultiPolygon) ForceCCW() *MultiPolygon {
+	forceOrientation3(g.FlatCoords, 0, g.Endss, g.Stride, true)
+	return g
+}
+
+// ForceCW orients the exterior rings of g's Polygons clockwise and their
+// interior rings counter-clockwise, in place, and returns g.
+func (g *MultiPolygon) ForceCW() *MultiPolygon {
+	forceOrientation3(g.FlatCoords, 0, g.Endss, g.Stride, false)
+	return g
+}
+
+// ForceRHR orients the rings of g's Polygons so that their interiors are on
+// the right when walking along each ring (the right-hand rule), in place, and
+// returns g. It is equivalent to ForceCW.
+func (g *MultiPolygon) ForceRHR() *MultiPolygon {
+	return g.ForceCW()
+}
+
+// Normalize puts g into a canonical form, in place, and returns g. Each
+// Polygon is normalized as by Polygon.Normalize and the Polygons are sorted by
+// their exterior rings, then by their interior rings. Empty Polygons are sorted
+// first.
+func (g *MultiPolygon) Normalize() *MultiPolygon {
+	polygons := make([][][]float64, 0, len(g.Endss))
+	offset := 0
+	for _, ends := range g.Endss {
+		forceOrientation2(g.FlatCoords, offset, ends, g.Stride, true)
+		rings := normalizeRings(g.FlatCoords, offset, ends, g.Stride)
+		if len(rings) > 1 {
+			slices.SortStableFunc(rings[1:], slices.Compare[[]float64])
+		}
+		polygons = append(polygons, rings)
+		if len(ends) > 0 {
+			offset = ends[len(ends)-1]
+		}
+	}
+	slices.SortStableFunc(polygons, func(a, b [][]float64) int {
+		return slices.CompareFunc(a, b, slices.Compare[[]float64])
+	})
+	g.FlatCoords = g.FlatCoords[:0]
+	for i, rings := range polygons {
+		ends := make([]int, 0, len(rings))
+		for _, ring := range rings {
+			g.FlatCoords = append(g.FlatCoords, ring...)
+			ends = append(ends, len(g.FlatCoords))
+		}
+		g.Endss[i] = ends
+	}
+	return g
+}
+
+// forceOrientation2 orients the first ring in flatCoords counter-clockwise if
+// ccw is true, or clockwise otherwise, and the remaining rings the opposite
+// way. Rings with zero area are left unchanged.
+func forceOrientation2(flatCoords []float64, offset int, ends []int, stride int, ccw bool) {
+	for i, end := range ends {
+		doubleArea := doubleArea1(flatCoords, offset, end, stride)
+		if doubleArea != 0 && (doubleArea > 0) != (ccw == (i == 0)) {
+			reverse1(flatCoords, offset, end, stride)
+		}
+		offset = end
+	}
+}
+
+func forceOrientation3(flatCoords []float64, offset int, endss [][]int, stride int, ccw bool) {
+	for _, ends := range endss {
+		if len(ends) == 0 {
+			continue
+		}
+		forceOrientation2(flatCoords, offset, ends, stride, ccw)
+		offset = ends[len(ends)-1]
+	}
+}
+
+// normalizeRings returns copies of the rings in flatCoords, with each closed
+// ring rotated to start at its smallest coordinate.
+func normalizeRings(flatCoords []float64, offset int, ends []int, stride int) [][]float64 {
+	rings := make([][]float64, 0, len(ends))
+	for _, end := range ends {
+		ring := slices.Clone(flatCoords[offset:end])
+		n := len(ring)
+		if n > stride && slices.Equal(ring[:stride], ring[n-stride:]) {
+			rotateToMin(ring[:n-stride], stride)
+			copy(ring[n-stride:], ring[:stride])
+		}
+		rings = append(rings, ring)
+		offset = end
+	}
+	return rings
+}
+
+// rotateToMin rotates the coordinates in flatCoords so that the smallest
+// coordinate is first.
+func rotateToMin(flatCoords []float64, stride int) {
+	minIndex := 0
+	for i := stride; i < len(flatCoords); i += stride {
+		if slices.Compare(flatCoords[i:i+stride], flatCoords[minIndex:minIndex+stride]) < 0 {
+			minIndex = i
+		}
+	}
+	if minIndex == 0 {
+		return
+	}
+	rotated := append(slices.Clone(flatCoords[minIndex:]), flatCoords[:minIndex]...)
+	copy(flatCoords, rotated)
+}
@@ -0,0 +1,129 @@
+package geom
+
+import (
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+)
+
+func TestPolygonForceOrientation(t *testing.T) {
+	ccwShell := []Coord{{0, 0}, {4, 0}, {4, 4}, {0, 4}, {0, 0}}
+	cwShell := []Coord{{0, 0}, {0, 4}, {4, 4}, {4, 0}, {0, 0}}
+	ccwHole := []Coord{{1, 1}, {2, 1}, {2, 2}, {1, 2}, {1, 1}}
+	cwHole := []Coord{{1, 1}, {1, 2}, {2, 2}, {2, 1}, {1, 1}}
+	for _, tc := range []struct {
+		name        string
+		coords      [][]Coord
+		expectedCCW [][]Coord
+		expectedCW  [][]Coord
+	}{
+		{
+			name:        "empty",
+			coords:      [][]Coord{},
+			expectedCCW: [][]Coord{},
+			expectedCW:  [][]Coord{},
+		},
+		{
+			name:        "ccw",
+			coords:      [][]Coord{ccwShell, cwHole},
+			expectedCCW: [][]Coord{ccwShell, cwHole},
+			expectedCW:  [][]Coord{cwShell, ccwHole},
+		},
+		{
+			name:        "cw",
+			coords:      [][]Coord{cwShell, ccwHole},
+			expectedCCW: [][]Coord{ccwShell, cwHole},
+			expectedCW:  [][]Coord{cwShell, ccwHole},
+		},
+		{
+			name:        "mixed",
+			coords:      [][]Coord{cwShell, cwHole, ccwHole},
+			expectedCCW: [][]Coord{ccwShell, cwHole, cwHole},
+			expectedCW:  [][]Coord{cwShell, ccwHole, ccwHole},
+		},
+		{
+			name:        "degenerate",
+			coords:      [][]Coord{{{0, 0}, {1, 1}, {0, 0}}},
+			expectedCCW: [][]Coord{{{0, 0}, {1, 1}, {0, 0}}},
+			expectedCW:  [][]Coord{{{0, 0}, {1, 1}, {0, 0}}},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			p := NewPolygon(XY).MustSetCoords(tc.coords)
+			assert.Equal(t, tc.expectedCCW, p.Clone().ForceCCW().Coords())
+			assert.Equal(t, tc.expectedCW, p.Clone().ForceCW().Coords())
+			assert.Equal(t, tc.expectedCW, p.Clone().ForceRHR().Coords())
+		})
+	}
+}
+
+func TestMultiPolygonForceOrientation(t *testing.T) {
+	mp := NewMultiPolygon(XYZ).MustSetCoords([][][]Coord{
+		{{{0, 0, 1}, {0, 1, 2}, {1, 0, 3}, {0, 0, 1}}},
+		{},
+		{{{2, 0, 4}, {3, 0, 5}, {2, 1, 6}, {2, 0, 4}}},
+	})
+	assert.Equal(t, [][][]Coord{
+		{{{0, 0, 1}, {1, 0, 3}, {0, 1, 2}, {0, 0, 1}}},
+		{},
+		{{{2, 0, 4}, {3, 0, 5}, {2, 1, 6}, {2, 0, 4}}},
+	}, mp.Clone().ForceCCW().Coords())
+	assert.Equal(t, [][][]Coord{
+		{{{0, 0, 1}, {0, 1, 2}, {1, 0, 3}, {0, 0, 1}}},
+		{},
+		{{{2, 0, 4}, {2, 1, 6}, {3, 0, 5}, {2, 0, 4}}},
+	}, mp.Clone().ForceRHR().Coords())
+}
+
+func TestPolygonNormalize(t *testing.T) {
+	expected := NewPolygon(XY).MustSetCoords([][]Coord{
+		{{0, 0}, {4, 0}, {4, 4}, {0, 4}, {0, 0}},
+		{{1, 1}, {1, 2}, {2, 2}, {2, 1}, {1, 1}},
+		{{3, 1}, {3, 2}, {3.5, 2}, {3, 1}},
+	})
+	for _, tc := range []struct {
+		name string
+		p    *Polygon
+	}{
+		{
+			name: "normalized",
+			p:    expected.Clone(),
+		},
+		{
+			name: "rotated_and_reordered",
+			p: NewPolygon(XY).MustSetCoords([][]Coord{
+				{{4, 4}, {0, 4}, {0, 0}, {4, 0}, {4, 4}},
+				{{3.5, 2}, {3, 1}, {3, 2}, {3.5, 2}},
+				{{2, 2}, {2, 1}, {1, 1}, {1, 2}, {2, 2}},
+			}),
+		},
+		{
+			name: "reversed",
+			p: NewPolygon(XY).MustSetCoords([][]Coord{
+				{{0, 4}, {4, 4}, {4, 0}, {0, 0}, {0, 4}},
+				{{3, 2}, {3, 1}, {3.5, 2}, {3, 2}},
+				{{2, 1}, {2, 2}, {1, 2}, {1, 1}, {2, 1}},
+			}),
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, expected, tc.p.Normalize())
+		})
+	}
+}
+
+func TestMultiPolygonNormalize(t *testing.T) {
+	mp := NewMultiPolygon(XY).MustSetCoords([][][]Coord{
+		{{{5, 5}, {5, 6}, {6, 5}, {5, 5}}},
+		{},
+		{{{1, 0}, {0, 1}, {0, 0}, {1, 0}}},
+	}).SetSRID(4326)
+	expected := NewMultiPolygon(XY).MustSetCoords([][][]Coord{
+		{},
+		{{{0, 0}, {1, 0}, {0, 1}, {0, 0}}},
+		{{{5, 5}, {6, 5}, {5, 6}, {5, 5}}},
+	}).SetSRID(4326)
+	assert.Equal(t, expected.Coords(), mp.Normalize().Coords())
+	assert.Equal(t, 4326, mp.GetSRID())
+	assert.NoError(t, mp.verify())
+}
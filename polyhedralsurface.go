@@ -0,0 +1,85 @@
+package geom
+
+// A PolyhedralSurface is a contiguous collection of Polygons, its faces,
+// which share common boundary segments. Unlike a MultiPolygon, the faces of a
+// PolyhedralSurface are usually not coplanar and may enclose a volume.
+type PolyhedralSurface struct {
+	geom3
+}
+
+// NewPolyhedralSurface returns a new PolyhedralSurface with no faces.
+func NewPolyhedralSurface(layout Layout) *PolyhedralSurface {
+	return NewPolyhedralSurfaceFlat(layout, nil, nil)
+}
+
+// NewPolyhedralSurfaceFlat returns a new PolyhedralSurface with the given flat
+// coordinates.
+func NewPolyhedralSurfaceFlat(layout Layout, flatCoords []float64, endss [][]int) *PolyhedralSurface {
+	g := new(PolyhedralSurface)
+	g.Layout = layout
+	g.Stride = layout.Stride()
+	g.FlatCoords = flatCoords
+	g.Endss = endss
+	return g
+}
+
+// Area returns the sum of the areas of the faces projected onto the xy plane.
+// See xyz.SurfaceArea for the area in three dimensions.
+func (g *PolyhedralSurface) Area() float64 {
+	return doubleArea3(g.FlatCoords, 0, g.Endss, g.Stride) / 2
+}
+
+// Clone returns a deep copy.
+func (g *PolyhedralSurface) Clone() *PolyhedralSurface {
+	return deriveClonePolyhedralSurface(g)
+}
+
+// Length returns the sum of the perimeters of the faces.
+func (g *PolyhedralSurface) Length() float64 {
+	return length3(g.FlatCoords, 0, g.Endss, g.Stride)
+}
+
+// MustSetCoords sets the coordinates and panics on any error.
+func (g *PolyhedralSurface) MustSetCoords(coords [][][]Coord) *PolyhedralSurface {
+	Must(g.SetCoords(coords))
+	return g
+}
+
+// NumPolygons returns the number of faces.
+func (g *PolyhedralSurface) NumPolygons() int {
+	return len(g.Endss)
+}
+
+// Polygon returns the ith face.
+func (g *PolyhedralSurface) Polygon(i int) *Polygon {
+	flatCoords, ends := g.element(i)
+	return NewPolygonFlat(g.Layout, flatCoords, ends)
+}
+
+// Push appends a face.
+func (g *PolyhedralSurface) Push(p *Polygon) error {
+	if p.Layout != g.Layout {
+		return ErrLayoutMismatch{Got: p.Layout, Want: g.Layout}
+	}
+	g.pushElement(p.FlatCoords, p.Ends)
+	return nil
+}
+
+// SetCoords sets the coordinates.
+func (g *PolyhedralSurface) SetCoords(coords [][][]Coord) (*PolyhedralSurface, error) {
+	if err := g.setCoords(coords); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+// SetSRID sets the SRID of g.
+func (g *PolyhedralSurface) SetSRID(srid int) *PolyhedralSurface {
+	g.Srid = srid
+	return g
+}
+
+// Swap swaps the values of g and g2.
+func (g *PolyhedralSurface) Swap(g2 *PolyhedralSurface) {
+	*g, *g2 = *g2, *g
+}
@@ -0,0 +1,37 @@
+package geom
+
+import (
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+)
+
+// PolyhedralSurface implements interface T.
+var _ T = &PolyhedralSurface{}
+
+func TestPolyhedralSurface(t *testing.T) {
+	coords := [][][]Coord{
+		{{{0, 0, 0}, {0, 1, 0}, {1, 1, 0}, {1, 0, 0}, {0, 0, 0}}},
+		{{{0, 0, 0}, {1, 0, 0}, {1, 0, 1}, {0, 0, 1}, {0, 0, 0}}},
+	}
+	g := NewPolyhedralSurface(XYZ).MustSetCoords(coords)
+	assert.NoError(t, g.verify())
+	assert.Equal(t, coords, g.Coords())
+	assert.Equal(t, 2, g.NumPolygons())
+	assert.Equal(t, [][]int{{15}, {30}}, g.GetEndss())
+	assert.Equal(t, NewBounds(XYZ).Set(0, 0, 0, 1, 1, 1), g.GetBounds())
+	assert.Equal(t, NewPolygon(XYZ).MustSetCoords(coords[1]), g.Polygon(1))
+	assert.Equal(t, g, g.Clone())
+
+	g2 := NewPolyhedralSurface(XYZ)
+	for i := range g.NumPolygons() {
+		assert.NoError(t, g2.Push(g.Polygon(i)))
+	}
+	assert.Equal(t, g, g2)
+	assert.Equal[error](t, ErrLayoutMismatch{Got: XY, Want: XYZ}, g2.Push(NewPolygon(XY)))
+}
+
+func TestPolyhedralSurfaceSetSRID(t *testing.T) {
+	assert.Equal(t, 4326, NewPolyhedralSurface(NoLayout).SetSRID(4326).GetSRID())
+	assert.Equal(t, 4326, Must(SetSRID(NewPolyhedralSurface(NoLayout), 4326)).GetSRID())
+}
@@ -0,0 +1,159 @@
+package sorting
+
+import "github.com/don4get/go-geom"
+
+// CurveOrder is the number of bits per axis of the grid used to compute
+// space-filling curve keys. Keys therefore fit in 2*CurveOrder bits.
+const CurveOrder = 16
+
+// KeyFunc computes the space-filling curve key of the point x, y within bounds
+type KeyFunc func(bounds *geom.Bounds, x, y float64) uint32
+
+// HilbertKey computes the position of x, y along a Hilbert curve covering bounds.
+//
+// Points outside bounds are clamped to its edges.  Points that are close
+// together tend to have close keys.
+func HilbertKey(bounds *geom.Bounds, x, y float64) uint32 {
+	gx, gy := gridCoords(bounds, x, y)
+	var key uint32
+	for s := uint32(1) << (CurveOrder - 1); s > 0; s >>= 1 {
+		var rx, ry uint32
+		if gx&s != 0 {
+			rx = 1
+		}
+		if gy&s != 0 {
+			ry = 1
+		}
+		key += s * s * ((3 * rx) ^ ry)
+		// rotate the quadrant so that the curve is continuous
+		if ry == 0 {
+			if rx == 1 {
+				gx = s - 1 - gx
+				gy = s - 1 - gy
+			}
+			gx, gy = gy, gx
+		}
+	}
+	return key
+}
+
+// MortonKey computes the position of x, y along a Z-order (Morton) curve
+// covering bounds by interleaving the bits of the grid coordinates, x first.
+//
+// Points outside bounds are clamped to its edges.
+func MortonKey(bounds *geom.Bounds, x, y float64) uint32 {
+	gx, gy := gridCoords(bounds, x, y)
+	return spreadBits(gx) | spreadBits(gy)<<1
+}
+
+// NewFlatCoordSortingHilbert creates a sort.Interface implementation that
+// sorts coordinates by their HilbertKey within bounds
+func NewFlatCoordSortingHilbert(layout geom.Layout, coordData []float64, bounds *geom.Bounds) FlatCoord {
+	return NewFlatCoordSorting(layout, coordData, IsLessKey(bounds, HilbertKey))
+}
+
+// NewFlatCoordSortingMorton creates a sort.Interface implementation that
+// sorts coordinates by their MortonKey within bounds
+func NewFlatCoordSortingMorton(layout geom.Layout, coordData []float64, bounds *geom.Bounds) FlatCoord {
+	return NewFlatCoordSorting(layout, coordData, IsLessKey(bounds, MortonKey))
+}
+
+// IsLessKey creates a comparator that compares coordinates by their key within
+// bounds.  Ties are broken with IsLess2D so that the order is deterministic.
+func IsLessKey(bounds *geom.Bounds, key KeyFunc) IsLess {
+	return func(v1, v2 []float64) bool {
+		k1, k2 := key(bounds, v1[0], v1[1]), key(bounds, v2[0], v2[1])
+		if k1 != k2 {
+			return k1 < k2
+		}
+		return IsLess2D(v1, v2)
+	}
+}
+
+// Geoms is a sort.Interface implementation that sorts geometries by the key of
+// the center of their bounds.
+//
+// Note: this data struct cannot be used with its 0 values.  it must be
+// constructed using NewGeomSorting
+type Geoms struct {
+	geoms []geom.T
+	keys  []uint32
+	empty []bool
+}
+
+// NewGeomSorting creates a sort.Interface implementation that sorts geoms by
+// the key of the center of their bounds within bounds.  The keys are computed
+// once, up front.  Empty geometries sort first, before any geometry whose key
+// is zero.
+func NewGeomSorting(geoms []geom.T, bounds *geom.Bounds, key KeyFunc) Geoms {
+	keys := make([]uint32, len(geoms))
+	empty := make([]bool, len(geoms))
+	for i, g := range geoms {
+		b := g.GetBounds()
+		if b.IsEmpty() {
+			empty[i] = true
+			continue
+		}
+		keys[i] = key(bounds, (b.Min(0)+b.Max(0))/2, (b.Min(1)+b.Max(1))/2)
+	}
+	return Geoms{
+		geoms: geoms,
+		keys:  keys,
+		empty: empty,
+	}
+}
+
+// NewGeomSortingHilbert creates a sort.Interface implementation that sorts
+// geoms by HilbertKey within bounds
+func NewGeomSortingHilbert(geoms []geom.T, bounds *geom.Bounds) Geoms {
+	return NewGeomSorting(geoms, bounds, HilbertKey)
+}
+
+// NewGeomSortingMorton creates a sort.Interface implementation that sorts
+// geoms by MortonKey within bounds
+func NewGeomSortingMorton(geoms []geom.T, bounds *geom.Bounds) Geoms {
+	return NewGeomSorting(geoms, bounds, MortonKey)
+}
+
+func (s Geoms) Len() int {
+	return len(s.geoms)
+}
+
+func (s Geoms) Swap(i, j int) {
+	s.geoms[i], s.geoms[j] = s.geoms[j], s.geoms[i]
+	s.keys[i], s.keys[j] = s.keys[j], s.keys[i]
+	s.empty[i], s.empty[j] = s.empty[j], s.empty[i]
+}
+
+func (s Geoms) Less(i, j int) bool {
+	if s.empty[i] != s.empty[j] {
+		return s.empty[i]
+	}
+	return s.keys[i] < s.keys[j]
+}
+
+// gridCoords maps x, y to a grid of 2^CurveOrder cells along each axis of bounds
+func gridCoords(bounds *geom.Bounds, x, y float64) (uint32, uint32) {
+	return gridCoord(x, bounds.Min(0), bounds.Max(0)), gridCoord(y, bounds.Min(1), bounds.Max(1))
+}
+
+func gridCoord(v, minV, maxV float64) uint32 {
+	const maxCell = 1<<CurveOrder - 1
+	if !(maxV > minV) || v <= minV {
+		return 0
+	}
+	if v >= maxV {
+		return maxCell
+	}
+	return uint32((v - minV) / (maxV - minV) * maxCell)
+}
+
+// spreadBits inserts a zero bit between each of the low 16 bits of v
+func spreadBits(v uint32) uint32 {
+	v &= 0x0000ffff
+	v = (v | v<<8) & 0x00ff00ff
+	v = (v | v<<4) & 0x0f0f0f0f
+	v = (v | v<<2) & 0x33333333
+	v = (v | v<<1) & 0x55555555
+	return v
+}
@@ -0,0 +1,125 @@
+package sorting_test
+
+import (
+	"sort"
+	"strconv"
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+
+	"github.com/don4get/go-geom"
+	"github.com/don4get/go-geom/sorting"
+)
+
+var unitBounds = geom.NewBounds(geom.XY).Set(0, 0, 1, 1)
+
+func TestHilbertKey(t *testing.T) {
+	for i, tc := range []struct {
+		x, y     float64
+		quadrant uint32
+	}{
+		{x: 0.25, y: 0.25, quadrant: 0},
+		{x: 0.25, y: 0.75, quadrant: 1},
+		{x: 0.75, y: 0.75, quadrant: 2},
+		{x: 0.75, y: 0.25, quadrant: 3},
+	} {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			assert.Equal(t, tc.quadrant, sorting.HilbertKey(unitBounds, tc.x, tc.y)>>30)
+		})
+	}
+	assert.Equal(t, 0, sorting.HilbertKey(unitBounds, 0, 0))
+	assert.Equal(t, 1<<32-1, sorting.HilbertKey(unitBounds, 1, 0))
+	assert.Equal(t, 0, sorting.HilbertKey(unitBounds, -5, -5))
+	assert.Equal(t, 1<<32-1, sorting.HilbertKey(unitBounds, 5, -5))
+}
+
+func TestHilbertKeyContinuity(t *testing.T) {
+	// Consecutive keys on a Hilbert curve are always adjacent cells, so
+	// walking a small grid in key order must only ever take unit steps.
+	bounds := geom.NewBounds(geom.XY).Set(0, 0, 1<<sorting.CurveOrder-1, 1<<sorting.CurveOrder-1)
+	const n = 16
+	coords := make([]float64, 0, 2*n*n)
+	for x := range n {
+		for y := range n {
+			coords = append(coords, float64(x), float64(y))
+		}
+	}
+	sort.Sort(sorting.NewFlatCoordSortingHilbert(geom.XY, coords, bounds))
+	for i := 2; i < len(coords); i += 2 {
+		dx, dy := coords[i]-coords[i-2], coords[i+1]-coords[i-1]
+		assert.Equal(t, 1.0, dx*dx+dy*dy)
+	}
+}
+
+func TestMortonKey(t *testing.T) {
+	for i, tc := range []struct {
+		x, y   float64
+		result uint32
+	}{
+		{x: 0, y: 0, result: 0},
+		{x: 1, y: 0, result: 0x55555555},
+		{x: 0, y: 1, result: 0xaaaaaaaa},
+		{x: 1, y: 1, result: 0xffffffff},
+		{x: 2, y: -1, result: 0x55555555},
+	} {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			assert.Equal(t, tc.result, sorting.MortonKey(unitBounds, tc.x, tc.y))
+		})
+	}
+}
+
+func TestNewFlatCoordSortingByCurve(t *testing.T) {
+	for i, tc := range []struct {
+		newSorting func(geom.Layout, []float64, *geom.Bounds) sorting.FlatCoord
+		c, result  []float64
+		layout     geom.Layout
+	}{
+		{
+			newSorting: sorting.NewFlatCoordSortingHilbert,
+			c:          []float64{0.75, 0.25, 0.25, 0.75, 0.75, 0.75, 0.25, 0.25},
+			result:     []float64{0.25, 0.25, 0.25, 0.75, 0.75, 0.75, 0.75, 0.25},
+			layout:     geom.XY,
+		},
+		{
+			newSorting: sorting.NewFlatCoordSortingMorton,
+			c:          []float64{0.75, 0.25, 0.25, 0.75, 0.75, 0.75, 0.25, 0.25},
+			result:     []float64{0.25, 0.25, 0.75, 0.25, 0.25, 0.75, 0.75, 0.75},
+			layout:     geom.XY,
+		},
+		{
+			newSorting: sorting.NewFlatCoordSortingHilbert,
+			c:          []float64{0.75, 0.25, 1, 0.25, 0.25, 2, 0.25, 0.25, 3},
+			result:     []float64{0.25, 0.25, 2, 0.25, 0.25, 3, 0.75, 0.25, 1},
+			layout:     geom.XYZ,
+		},
+	} {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			sort.Stable(tc.newSorting(tc.layout, tc.c, unitBounds))
+			assert.Equal(t, tc.result, tc.c)
+		})
+	}
+}
+
+func TestNewGeomSorting(t *testing.T) {
+	p := func(x, y float64) geom.T {
+		return geom.NewPointFlat(geom.XY, []float64{x, y})
+	}
+	empty := geom.NewLineString(geom.XY)
+	square := geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{{{0.5, 0.5}, {1, 0.5}, {1, 1}, {0.5, 1}, {0.5, 0.5}}})
+	geoms := []geom.T{p(0.75, 0.25), square, p(0.25, 0.75), empty, p(0.25, 0.25)}
+	sort.Sort(sorting.NewGeomSortingHilbert(geoms, unitBounds))
+	assert.Equal(t, []geom.T{empty, p(0.25, 0.25), p(0.25, 0.75), square, p(0.75, 0.25)}, geoms)
+
+	sort.Sort(sorting.NewGeomSortingMorton(geoms, unitBounds))
+	assert.Equal(t, []geom.T{empty, p(0.25, 0.25), p(0.75, 0.25), p(0.25, 0.75), square}, geoms)
+
+	// Empty geometries sort before geometries at the min corner of the bounds,
+	// which also have key zero, whatever the input order.
+	for _, geoms := range [][]geom.T{
+		{p(0, 0), empty, p(1, 1)},
+		{p(1, 1), p(0, 0), empty},
+	} {
+		sort.Sort(sorting.NewGeomSortingHilbert(geoms, unitBounds))
+		assert.Equal(t, []geom.T{empty, p(0, 0), p(1, 1)}, geoms)
+	}
+}
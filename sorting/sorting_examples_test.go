@@ -25,3 +25,19 @@ func ExampleNewFlatCoordSorting() {
 	fmt.Println(coords)
 	// Output: [-1 0 0 1 1 0 2 2]
 }
+
+func ExampleNewFlatCoordSortingHilbert() {
+	coords := []float64{3, 1, 1, 3, 3, 3, 1, 1}
+	bounds := geom.NewBounds(geom.XY).Set(0, 0, 4, 4)
+	sort.Sort(sorting.NewFlatCoordSortingHilbert(geom.XY, coords, bounds))
+	fmt.Println(coords)
+	// Output: [1 1 1 3 3 3 3 1]
+}
+
+func ExampleNewFlatCoordSortingMorton() {
+	coords := []float64{3, 1, 1, 3, 3, 3, 1, 1}
+	bounds := geom.NewBounds(geom.XY).Set(0, 0, 4, 4)
+	sort.Sort(sorting.NewFlatCoordSortingMorton(geom.XY, coords, bounds))
+	fmt.Println(coords)
+	// Output: [1 1 3 1 1 3 3 3]
+}
@@ -0,0 +1,293 @@
+// Package tile implements Web Mercator (EPSG:3857) tile math: conversions
+// between longitudes and latitudes, EPSG:3857 meters and z/x/y tiles, tile
+// bounds, tile coverings of geometries, and Bing Maps quadkeys.
+//
+// Geometries and bounds in longitudes and latitudes use x for the longitude
+// and y for the latitude, in degrees.
+package tile
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+
+	"github.com/don4get/go-geom"
+	"github.com/don4get/go-geom/internal/cover"
+)
+
+// EarthRadius is the radius of the sphere used by Web Mercator, in meters.
+const EarthRadius = 6378137
+
+// MaxLatitude is the latitude of the top edge of tile 0/0/0, in degrees.
+// Latitudes beyond ±MaxLatitude are clamped.
+const MaxLatitude = 85.05112877980659
+
+// MaxZoom is the maximum zoom level.
+const MaxZoom = 30
+
+// DefaultMaxCoverTiles is the default maximum number of tiles that Cover will
+// consider for each component of a geometry.
+const DefaultMaxCoverTiles = 1 << 20
+
+// ErrInvalidZoom is returned when a zoom level is out of range.
+type ErrInvalidZoom int
+
+func (e ErrInvalidZoom) Error() string {
+	return fmt.Sprintf("tile: invalid zoom %d", int(e))
+}
+
+// ErrInvalidQuadkey is returned when a quadkey cannot be decoded.
+type ErrInvalidQuadkey string
+
+func (e ErrInvalidQuadkey) Error() string {
+	return fmt.Sprintf("tile: invalid quadkey %q", string(e))
+}
+
+// ErrTooManyTiles is returned by Cover when a covering would need more than
+// the maximum number of tiles to be considered.
+var ErrTooManyTiles = errors.New("tile: too many tiles")
+
+// A Tile is a z/x/y tile. X increases eastwards from the antimeridian and Y
+// increases southwards from MaxLatitude.
+type Tile struct {
+	Z, X, Y int
+}
+
+// LonLatToMeters converts lon and lat, in degrees, to EPSG:3857 meters.
+func LonLatToMeters(lon, lat float64) (float64, float64) {
+	lat = max(-MaxLatitude, min(MaxLatitude, lat))
+	x := EarthRadius * lon * math.Pi / 180
+	y := EarthRadius * math.Log(math.Tan(math.Pi/4+lat*math.Pi/360))
+	return x, y
+}
+
+// MetersToLonLat converts x and y, in EPSG:3857 meters, to a longitude and a
+// latitude in degrees.
+func MetersToLonLat(x, y float64) (float64, float64) {
+	lon := x / EarthRadius * 180 / math.Pi
+	lat := (2*math.Atan(math.Exp(y/EarthRadius)) - math.Pi/2) * 180 / math.Pi
+	return lon, lat
+}
+
+// FromLonLat returns the tile at zoom z containing lon and lat.
+func FromLonLat(lon, lat float64, z int) (Tile, error) {
+	if z < 0 || MaxZoom < z {
+		return Tile{}, ErrInvalidZoom(z)
+	}
+	return Tile{Z: z, X: lonToX(lon, z), Y: latToY(lat, z)}, nil
+}
+
+// FromMeters returns the tile at zoom z containing x and y, in EPSG:3857
+// meters.
+func FromMeters(x, y float64, z int) (Tile, error) {
+	lon, lat := MetersToLonLat(x, y)
+	return FromLonLat(lon, lat, z)
+}
+
+// FromQuadkey returns the tile with Bing Maps quadkey q. The empty quadkey is
+// tile 0/0/0.
+func FromQuadkey(q string) (Tile, error) {
+	if len(q) > MaxZoom {
+		return Tile{}, ErrInvalidQuadkey(q)
+	}
+	t := Tile{Z: len(q)}
+	for _, c := range q {
+		if c < '0' || '3' < c {
+			return Tile{}, ErrInvalidQuadkey(q)
+		}
+		digit := int(c - '0')
+		t.X = t.X<<1 | digit&1
+		t.Y = t.Y<<1 | digit>>1
+	}
+	return t, nil
+}
+
+// Bounds returns the bounds of t in longitudes and latitudes.
+func (t Tile) Bounds() *geom.Bounds {
+	r := t.rect()
+	return geom.NewBounds(geom.XY).Set(r.MinX, r.MinY, r.MaxX, r.MaxY)
+}
+
+// MercatorBounds returns the bounds of t in EPSG:3857 meters.
+func (t Tile) MercatorBounds() *geom.Bounds {
+	size := 2 * math.Pi * EarthRadius / float64(int64(1)<<t.Z)
+	minX := -math.Pi*EarthRadius + float64(t.X)*size
+	maxY := math.Pi*EarthRadius - float64(t.Y)*size
+	return geom.NewBounds(geom.XY).Set(minX, maxY-size, minX+size, maxY)
+}
+
+// Quadkey returns the Bing Maps quadkey of t.
+func (t Tile) Quadkey() string {
+	var sb strings.Builder
+	sb.Grow(t.Z)
+	for i := t.Z - 1; i >= 0; i-- {
+		digit := byte('0')
+		if t.X>>i&1 != 0 {
+			digit++
+		}
+		if t.Y>>i&1 != 0 {
+			digit += 2
+		}
+		sb.WriteByte(digit)
+	}
+	return sb.String()
+}
+
+// String returns t in z/x/y form.
+func (t Tile) String() string {
+	return fmt.Sprintf("%d/%d/%d", t.Z, t.X, t.Y)
+}
+
+// A CoverOption is an option for Cover.
+type CoverOption func(*coverOptions)
+
+// coverOptions are the options for Cover.
+type coverOptions struct {
+	maxTiles int
+}
+
+// CoverOptionWithMaxTiles sets the maximum number of tiles that Cover will
+// consider for each component of a geometry. Zero means no limit. The default
+// is DefaultMaxCoverTiles.
+func CoverOptionWithMaxTiles(maxTiles int) CoverOption {
+	return func(o *coverOptions) {
+		o.maxTiles = maxTiles
+	}
+}
+
+// Cover returns the tiles at zoom z that intersect g, sorted by y and then by
+// x. g must be in longitudes and latitudes. Lines and polygons are tested
+// against each tile, so only the tiles that they actually cross are returned,
+// not every tile in their bounds. Tiles that only touch g are included.
+func Cover(g geom.T, z int, opts ...CoverOption) ([]Tile, error) {
+	if z < 0 || MaxZoom < z {
+		return nil, ErrInvalidZoom(z)
+	}
+	o := coverOptions{
+		maxTiles: DefaultMaxCoverTiles,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	tiles, err := o.appendCover(nil, g, z)
+	if err != nil {
+		return nil, err
+	}
+	slices.SortFunc(tiles, func(a, b Tile) int {
+		if c := cmp.Compare(a.Y, b.Y); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.X, b.X)
+	})
+	return slices.Compact(tiles), nil
+}
+
+// appendCover appends the tiles at zoom z that intersect g to tiles.
+func (o *coverOptions) appendCover(tiles []Tile, g geom.T, z int) ([]Tile, error) {
+	switch g := g.(type) {
+	case *geom.Point, *geom.MultiPoint:
+		flatCoords, stride := g.GetFlatCoords(), g.GetStride()
+		for i := 0; i < len(flatCoords); i += stride {
+			tiles = append(tiles, Tile{Z: z, X: lonToX(flatCoords[i], z), Y: latToY(flatCoords[i+1], z)})
+		}
+		return tiles, nil
+	case *geom.LineString, *geom.LinearRing:
+		return o.appendLineCover(tiles, g, z)
+	case *geom.MultiLineString:
+		var err error
+		for i := range g.NumLineStrings() {
+			if tiles, err = o.appendLineCover(tiles, g.LineString(i), z); err != nil {
+				return nil, err
+			}
+		}
+		return tiles, nil
+	case *geom.Polygon, *geom.MultiPolygon:
+		polygons, err := cover.Polygons(g)
+		if err != nil {
+			return nil, err
+		}
+		for _, polygon := range polygons {
+			tiles, err = o.appendTiles(tiles, polygon.GetBounds(), z, func(r cover.Rect) bool {
+				return r.IntersectsPolygon(polygon)
+			})
+			if err != nil {
+				return nil, err
+			}
+		}
+		return tiles, nil
+	case *geom.GeometryCollection:
+		var err error
+		for _, g := range g.Geoms() {
+			if tiles, err = o.appendCover(tiles, g, z); err != nil {
+				return nil, err
+			}
+		}
+		return tiles, nil
+	default:
+		return nil, geom.ErrUnsupportedType{Value: g}
+	}
+}
+
+// appendLineCover appends the tiles at zoom z that intersect line to tiles.
+func (o *coverOptions) appendLineCover(tiles []Tile, line geom.T, z int) ([]Tile, error) {
+	return o.appendTiles(tiles, line.GetBounds(), z, func(r cover.Rect) bool {
+		return r.IntersectsLine(line.GetFlatCoords(), line.GetStride())
+	})
+}
+
+// appendTiles appends the tiles at zoom z within b for which intersects
+// returns true to tiles.
+func (o *coverOptions) appendTiles(tiles []Tile, b *geom.Bounds, z int, intersects func(cover.Rect) bool) ([]Tile, error) {
+	if b.IsEmpty() {
+		return tiles, nil
+	}
+	minX, maxX := lonToX(b.Min(0), z), lonToX(b.Max(0), z)
+	minY, maxY := latToY(b.Max(1), z), latToY(b.Min(1), z)
+	if n := int64(maxX-minX+1) * int64(maxY-minY+1); o.maxTiles > 0 && n > int64(o.maxTiles) {
+		return nil, ErrTooManyTiles
+	}
+	for y := minY; y <= maxY; y++ {
+		for x := minX; x <= maxX; x++ {
+			t := Tile{Z: z, X: x, Y: y}
+			if intersects(t.rect()) {
+				tiles = append(tiles, t)
+			}
+		}
+	}
+	return tiles, nil
+}
+
+// rect returns the bounds of t in longitudes and latitudes.
+func (t Tile) rect() cover.Rect {
+	n := float64(int64(1) << t.Z)
+	return cover.Rect{
+		MinX: float64(t.X)/n*360 - 180,
+		MinY: yToLat(float64(t.Y+1) / n),
+		MaxX: float64(t.X+1)/n*360 - 180,
+		MaxY: yToLat(float64(t.Y) / n),
+	}
+}
+
+// lonToX returns the x index of the tile at zoom z containing lon.
+func lonToX(lon float64, z int) int {
+	n := int64(1) << z
+	x := int64(math.Floor((lon + 180) / 360 * float64(n)))
+	return int(max(0, min(n-1, x)))
+}
+
+// latToY returns the y index of the tile at zoom z containing lat.
+func latToY(lat float64, z int) int {
+	n := int64(1) << z
+	lat = max(-MaxLatitude, min(MaxLatitude, lat)) * math.Pi / 180
+	y := int64(math.Floor((1 - math.Log(math.Tan(lat)+1/math.Cos(lat))/math.Pi) / 2 * float64(n)))
+	return int(max(0, min(n-1, y)))
+}
+
+// yToLat returns the latitude of the fraction y of the height of the map,
+// counted from the top.
+func yToLat(y float64) float64 {
+	return math.Atan(math.Sinh(math.Pi*(1-2*y))) * 180 / math.Pi
+}
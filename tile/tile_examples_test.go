@@ -0,0 +1,16 @@
+package tile_test
+
+import (
+	"fmt"
+
+	"github.com/don4get/go-geom/tile"
+)
+
+func ExampleFromLonLat() {
+	t, err := tile.FromLonLat(-122.4194, 37.7749, 12)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(t, t.Quadkey())
+	// Output: 12/655/1583 023010203333
+}
@@ -0,0 +1,194 @@
+package tile_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+
+	"github.com/don4get/go-geom"
+	"github.com/don4get/go-geom/tile"
+)
+
+func TestLonLatToMeters(t *testing.T) {
+	for _, tc := range []struct {
+		lon, lat float64
+		x, y     float64
+	}{
+		{lon: 0, lat: 0, x: 0, y: 0},
+		{lon: 180, lat: 0, x: 20037508.342789244, y: 0},
+		{lon: -180, lat: tile.MaxLatitude, x: -20037508.342789244, y: 20037508.342789244},
+		{lon: 2.3522, lat: 48.8566, x: 261845.706244, y: 6250564.349543},
+	} {
+		x, y := tile.LonLatToMeters(tc.lon, tc.lat)
+		assert.True(t, math.Abs(tc.x-x) < 1e-3)
+		assert.True(t, math.Abs(tc.y-y) < 1e-3)
+
+		lon, lat := tile.MetersToLonLat(x, y)
+		assert.True(t, math.Abs(tc.lon-lon) < 1e-9)
+		assert.True(t, math.Abs(tc.lat-lat) < 1e-9)
+	}
+}
+
+func TestFromLonLat(t *testing.T) {
+	for _, tc := range []struct {
+		lon, lat float64
+		z        int
+		expected tile.Tile
+	}{
+		{lon: 0, lat: 0, z: 0, expected: tile.Tile{Z: 0, X: 0, Y: 0}},
+		{lon: -122.4194, lat: 37.7749, z: 12, expected: tile.Tile{Z: 12, X: 655, Y: 1583}},
+		{lon: 2.3522, lat: 48.8566, z: 10, expected: tile.Tile{Z: 10, X: 518, Y: 352}},
+		{lon: 180, lat: -90, z: 3, expected: tile.Tile{Z: 3, X: 7, Y: 7}},
+		{lon: -180, lat: 90, z: 3, expected: tile.Tile{Z: 3, X: 0, Y: 0}},
+	} {
+		t.Run(tc.expected.String(), func(t *testing.T) {
+			actual, err := tile.FromLonLat(tc.lon, tc.lat, tc.z)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, actual)
+
+			x, y := tile.LonLatToMeters(tc.lon, tc.lat)
+			actual, err = tile.FromMeters(x, y, tc.z)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+
+	_, err := tile.FromLonLat(0, 0, 31)
+	assert.Equal[error](t, tile.ErrInvalidZoom(31), err)
+}
+
+func TestBounds(t *testing.T) {
+	b := tile.Tile{Z: 1, X: 0, Y: 0}.Bounds()
+	assert.True(t, math.Abs(b.Min(0)-(-180)) < 1e-9)
+	assert.True(t, math.Abs(b.Min(1)) < 1e-9)
+	assert.True(t, math.Abs(b.Max(0)) < 1e-9)
+	assert.True(t, math.Abs(b.Max(1)-tile.MaxLatitude) < 1e-9)
+
+	mb := tile.Tile{Z: 0, X: 0, Y: 0}.MercatorBounds()
+	assert.Equal(t, geom.NewBounds(geom.XY).Set(-20037508.342789244, -20037508.342789244, 20037508.342789244, 20037508.342789244), mb)
+
+	mb = tile.Tile{Z: 1, X: 1, Y: 1}.MercatorBounds()
+	assert.Equal(t, geom.NewBounds(geom.XY).Set(0, -20037508.342789244, 20037508.342789244, 0), mb)
+}
+
+func TestQuadkey(t *testing.T) {
+	for _, tc := range []struct {
+		tile    tile.Tile
+		quadkey string
+	}{
+		{tile: tile.Tile{Z: 0, X: 0, Y: 0}, quadkey: ""},
+		{tile: tile.Tile{Z: 1, X: 1, Y: 0}, quadkey: "1"},
+		{tile: tile.Tile{Z: 3, X: 3, Y: 5}, quadkey: "213"},
+		{tile: tile.Tile{Z: 12, X: 655, Y: 1583}, quadkey: "023010203333"},
+	} {
+		t.Run(tc.quadkey, func(t *testing.T) {
+			assert.Equal(t, tc.quadkey, tc.tile.Quadkey())
+			actual, err := tile.FromQuadkey(tc.quadkey)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.tile, actual)
+		})
+	}
+
+	_, err := tile.FromQuadkey("124")
+	assert.Equal[error](t, tile.ErrInvalidQuadkey("124"), err)
+}
+
+func TestCover(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		g        geom.T
+		z        int
+		expected []tile.Tile
+	}{
+		{
+			name: "point",
+			g:    geom.NewPointFlat(geom.XY, []float64{-122.4194, 37.7749}),
+			z:    12,
+			expected: []tile.Tile{
+				{Z: 12, X: 655, Y: 1583},
+			},
+		},
+		{
+			name: "line",
+			g:    geom.NewLineString(geom.XY).MustSetCoords([]geom.Coord{{-135, 75}, {135, -60}}),
+			z:    2,
+			expected: []tile.Tile{
+				{Z: 2, X: 0, Y: 0},
+				{Z: 2, X: 0, Y: 1},
+				{Z: 2, X: 1, Y: 1},
+				{Z: 2, X: 2, Y: 1},
+				{Z: 2, X: 2, Y: 2},
+				{Z: 2, X: 3, Y: 2},
+			},
+		},
+		{
+			name: "triangle",
+			g:    geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{{{-170, 80}, {170, -70}, {-170, -80}, {-170, 80}}}),
+			z:    2,
+			expected: []tile.Tile{
+				{Z: 2, X: 0, Y: 0},
+				{Z: 2, X: 0, Y: 1},
+				{Z: 2, X: 1, Y: 1},
+				{Z: 2, X: 2, Y: 1},
+				{Z: 2, X: 0, Y: 2},
+				{Z: 2, X: 1, Y: 2},
+				{Z: 2, X: 2, Y: 2},
+				{Z: 2, X: 3, Y: 2},
+				{Z: 2, X: 0, Y: 3},
+				{Z: 2, X: 1, Y: 3},
+				{Z: 2, X: 2, Y: 3},
+				{Z: 2, X: 3, Y: 3},
+			},
+		},
+		{
+			name: "polygon with hole",
+			g: geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{
+				{{-170, -80}, {170, -80}, {170, 80}, {-170, 80}, {-170, -80}},
+				{{-95, -70}, {95, -70}, {95, 70}, {-95, 70}, {-95, -70}},
+			}),
+			z: 2,
+			expected: []tile.Tile{
+				{Z: 2, X: 0, Y: 0},
+				{Z: 2, X: 1, Y: 0},
+				{Z: 2, X: 2, Y: 0},
+				{Z: 2, X: 3, Y: 0},
+				{Z: 2, X: 0, Y: 1},
+				{Z: 2, X: 3, Y: 1},
+				{Z: 2, X: 0, Y: 2},
+				{Z: 2, X: 3, Y: 2},
+				{Z: 2, X: 0, Y: 3},
+				{Z: 2, X: 1, Y: 3},
+				{Z: 2, X: 2, Y: 3},
+				{Z: 2, X: 3, Y: 3},
+			},
+		},
+		{
+			name: "collection",
+			g: geom.NewGeometryCollection().MustPush(
+				geom.NewPointFlat(geom.XY, []float64{-100, 10}),
+				geom.NewMultiLineString(geom.XY).MustSetCoords([][]geom.Coord{{{10, -10}, {20, -20}}}),
+			),
+			z: 1,
+			expected: []tile.Tile{
+				{Z: 1, X: 0, Y: 0},
+				{Z: 1, X: 1, Y: 1},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := tile.Cover(tc.g, tc.z)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+
+	_, err := tile.Cover(geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{{{-170, 80}, {170, -80}, {-170, -80}, {-170, 80}}}), 20)
+	assert.IsError(t, err, tile.ErrTooManyTiles)
+	line := geom.NewLineStringFlat(geom.XY, []float64{-170, 80, 170, -80})
+	_, err = tile.Cover(line, 4, tile.CoverOptionWithMaxTiles(16))
+	assert.IsError(t, err, tile.ErrTooManyTiles)
+	tiles, err := tile.Cover(line, 4, tile.CoverOptionWithMaxTiles(0))
+	assert.NoError(t, err)
+	assert.NotZero(t, len(tiles))
+}
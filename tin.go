@@ -0,0 +1,83 @@
+package geom
+
+// A TIN is a triangulated irregular network, a PolyhedralSurface made only of
+// Triangles.
+type TIN struct {
+	geom3
+}
+
+// NewTIN returns a new TIN with no Triangles.
+func NewTIN(layout Layout) *TIN {
+	return NewTINFlat(layout, nil, nil)
+}
+
+// NewTINFlat returns a new TIN with the given flat coordinates.
+func NewTINFlat(layout Layout, flatCoords []float64, endss [][]int) *TIN {
+	g := new(TIN)
+	g.Layout = layout
+	g.Stride = layout.Stride()
+	g.FlatCoords = flatCoords
+	g.Endss = endss
+	return g
+}
+
+// Area returns the sum of the areas of the Triangles projected onto the xy
+// plane.
+func (g *TIN) Area() float64 {
+	return doubleArea3(g.FlatCoords, 0, g.Endss, g.Stride) / 2
+}
+
+// Clone returns a deep copy.
+func (g *TIN) Clone() *TIN {
+	return deriveCloneTIN(g)
+}
+
+// Length returns the sum of the perimeters of the Triangles.
+func (g *TIN) Length() float64 {
+	return length3(g.FlatCoords, 0, g.Endss, g.Stride)
+}
+
+// MustSetCoords sets the coordinates and panics on any error.
+func (g *TIN) MustSetCoords(coords [][][]Coord) *TIN {
+	Must(g.SetCoords(coords))
+	return g
+}
+
+// NumTriangles returns the number of Triangles.
+func (g *TIN) NumTriangles() int {
+	return len(g.Endss)
+}
+
+// Push appends a Triangle.
+func (g *TIN) Push(t *Triangle) error {
+	if t.Layout != g.Layout {
+		return ErrLayoutMismatch{Got: t.Layout, Want: g.Layout}
+	}
+	g.pushElement(t.FlatCoords, t.Ends)
+	return nil
+}
+
+// SetCoords sets the coordinates.
+func (g *TIN) SetCoords(coords [][][]Coord) (*TIN, error) {
+	if err := g.setCoords(coords); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+// SetSRID sets the SRID of g.
+func (g *TIN) SetSRID(srid int) *TIN {
+	g.Srid = srid
+	return g
+}
+
+// Swap swaps the values of g and g2.
+func (g *TIN) Swap(g2 *TIN) {
+	*g, *g2 = *g2, *g
+}
+
+// Triangle returns the ith Triangle.
+func (g *TIN) Triangle(i int) *Triangle {
+	flatCoords, ends := g.element(i)
+	return NewTriangleFlat(g.Layout, flatCoords, ends)
+}
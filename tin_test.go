@@ -0,0 +1,32 @@
+package geom
+
+import (
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+)
+
+// TIN implements interface T.
+var _ T = &TIN{}
+
+func TestTIN(t *testing.T) {
+	g := NewTIN(XY)
+	assert.True(t, g.IsEmpty())
+	assert.NoError(t, g.Push(NewTriangle(XY).MustSetCoords([][]Coord{{{0, 0}, {1, 0}, {0, 1}, {0, 0}}})))
+	assert.NoError(t, g.Push(NewTriangle(XY)))
+	assert.NoError(t, g.Push(NewTriangle(XY).MustSetCoords([][]Coord{{{1, 0}, {1, 1}, {0, 1}, {1, 0}}})))
+	assert.NoError(t, g.verify())
+	assert.Equal(t, 3, g.NumTriangles())
+	assert.Equal(t, [][]int{{8}, nil, {16}}, g.GetEndss())
+	assert.Equal(t, NewBounds(XY).Set(0, 0, 1, 1), g.GetBounds())
+	assert.Equal(t, 1.0, g.Area())
+	assert.Equal(t, NewTriangle(XY).MustSetCoords([][]Coord{{{1, 0}, {1, 1}, {0, 1}, {1, 0}}}), g.Triangle(2))
+	assert.Equal(t, NewTriangle(XY), g.Triangle(1))
+	assert.Equal(t, g, g.Clone())
+	assert.Equal[error](t, ErrLayoutMismatch{Got: XYZ, Want: XY}, g.Push(NewTriangle(XYZ)))
+}
+
+func TestTINSetSRID(t *testing.T) {
+	assert.Equal(t, 4326, NewTIN(NoLayout).SetSRID(4326).GetSRID())
+	assert.Equal(t, 4326, Must(SetSRID(NewTIN(NoLayout), 4326)).GetSRID())
+}
@@ -0,0 +1,67 @@
+package geom
+
+// A Triangle is a Polygon with a single closed ring of exactly four
+// coordinates, the last equal to the first, and no holes.
+type Triangle struct {
+	Geom2
+}
+
+// NewTriangle returns a new, empty, Triangle.
+func NewTriangle(layout Layout) *Triangle {
+	return NewTriangleFlat(layout, nil, nil)
+}
+
+// NewTriangleFlat returns a new Triangle with the given flat coordinates.
+func NewTriangleFlat(layout Layout, flatCoords []float64, ends []int) *Triangle {
+	g := new(Triangle)
+	g.Layout = layout
+	g.Stride = layout.Stride()
+	g.FlatCoords = flatCoords
+	g.Ends = ends
+	return g
+}
+
+// Area returns the area of g projected onto the xy plane.
+func (g *Triangle) Area() float64 {
+	return doubleArea2(g.FlatCoords, 0, g.Ends, g.Stride) / 2
+}
+
+// Clone returns a deep copy.
+func (g *Triangle) Clone() *Triangle {
+	return deriveCloneTriangle(g)
+}
+
+// Length returns the perimeter.
+func (g *Triangle) Length() float64 {
+	return length2(g.FlatCoords, 0, g.Ends, g.Stride)
+}
+
+// MustSetCoords sets the coordinates and panics on any error.
+func (g *Triangle) MustSetCoords(coords [][]Coord) *Triangle {
+	Must(g.SetCoords(coords))
+	return g
+}
+
+// Polygon returns g as a Polygon. The returned Polygon aliases g.
+func (g *Triangle) Polygon() *Polygon {
+	return NewPolygonFlat(g.Layout, g.FlatCoords, g.Ends).SetSRID(g.Srid)
+}
+
+// SetCoords sets the coordinates.
+func (g *Triangle) SetCoords(coords [][]Coord) (*Triangle, error) {
+	if err := g.setCoords(coords); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+// SetSRID sets the SRID of g.
+func (g *Triangle) SetSRID(srid int) *Triangle {
+	g.Srid = srid
+	return g
+}
+
+// Swap swaps the values of g and g2.
+func (g *Triangle) Swap(g2 *Triangle) {
+	*g, *g2 = *g2, *g
+}
@@ -0,0 +1,28 @@
+package geom
+
+import (
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+)
+
+// Triangle implements interface T.
+var _ T = &Triangle{}
+
+func TestTriangle(t *testing.T) {
+	g := NewTriangle(XYZ).MustSetCoords([][]Coord{{{0, 0, 1}, {2, 0, 2}, {0, 2, 3}, {0, 0, 1}}})
+	assert.NoError(t, g.verify())
+	assert.Equal(t, []float64{0, 0, 1, 2, 0, 2, 0, 2, 3, 0, 0, 1}, g.GetFlatCoords())
+	assert.Equal(t, []int{12}, g.GetEnds())
+	assert.Equal(t, NewBounds(XYZ).Set(0, 0, 1, 2, 2, 3), g.GetBounds())
+	assert.Equal(t, 2.0, g.Area())
+	assert.False(t, g.IsEmpty())
+	assert.Equal(t, NewPolygon(XYZ).MustSetCoords(g.Coords()), g.Polygon())
+	assert.Equal(t, g, g.Clone())
+	assert.True(t, NewTriangle(XY).IsEmpty())
+}
+
+func TestTriangleSetSRID(t *testing.T) {
+	assert.Equal(t, 4326, NewTriangle(NoLayout).SetSRID(4326).GetSRID())
+	assert.Equal(t, 4326, Must(SetSRID(NewTriangle(NoLayout), 4326)).GetSRID())
+}
@@ -0,0 +1,320 @@
+package triangulate
+
+import (
+	"container/heap"
+	"math"
+
+	"github.com/don4get/go-geom"
+	"github.com/don4get/go-geom/xy"
+)
+
+// ConcaveHull returns a concave hull of the vertices of g, computed by eroding
+// the Delaunay triangulation of g from its boundary (the chi-shape algorithm).
+// Boundary edges longer than the threshold
+//
+//	minEdgeLength + maxEdgeLengthRatio * (maxEdgeLength - minEdgeLength)
+//
+// are removed for as long as the hull remains a single polygon containing every
+// vertex, where minEdgeLength and maxEdgeLength are the lengths of the shortest
+// and longest edges of the triangulation. A maxEdgeLengthRatio of 1 returns the
+// convex hull and a maxEdgeLengthRatio of 0 returns the most concave hull. If
+// allowHoles is true then long interior edges are also removed, creating holes.
+//
+// If g has at least three vertices that are not collinear then the result is a
+// *geom.Polygon with a counter-clockwise shell and clockwise holes, otherwise it
+// is the result of xy.ConvexHull.
+func ConcaveHull(g geom.T, maxEdgeLengthRatio float64, allowHoles bool) (geom.T, error) {
+	m, err := newMeshFromGeom(g)
+	if err != nil {
+		return nil, err
+	}
+	m.build()
+	if len(m.tris) == 0 {
+		return xy.ConvexHullFlat(m.layout, m.coords), nil
+	}
+
+	minLength, maxLength := math.Inf(1), 0.0
+	for t := range m.tris {
+		if m.tris[t].dead || m.isGhost(t) {
+			continue
+		}
+		for k := range 3 {
+			length := m.edgeLength(t, k)
+			minLength, maxLength = math.Min(minLength, length), math.Max(maxLength, length)
+		}
+	}
+	threshold := minLength + maxEdgeLengthRatio*(maxLength-minLength)
+
+	e := newEroder(m, allowHoles)
+	for t := range m.tris {
+		if e.in[t] {
+			e.push(t, threshold)
+		}
+	}
+	for e.candidates.Len() > 0 {
+		c := heap.Pop(&e.candidates).(candidate) //nolint:forcetypeassert
+		if !e.in[c.t] {
+			continue
+		}
+		length, ok := e.removable(c.t)
+		switch {
+		case !ok || length <= threshold:
+			continue
+		case length < c.length:
+			heap.Push(&e.candidates, candidate{t: c.t, length: length})
+			continue
+		}
+		e.remove(c.t)
+		for _, nb := range m.tris[c.t].n {
+			if e.in[nb] {
+				e.push(nb, threshold)
+			}
+		}
+	}
+
+	shells, holes := m.boundaryRings(e.in)
+	polygon := geom.NewPolygon(m.layout)
+	if err := polygon.Push(shells[0]); err != nil {
+		return nil, err
+	}
+	for _, hole := range holes {
+		if err := polygon.Push(hole.ring); err != nil {
+			return nil, err
+		}
+	}
+	return polygon, nil
+}
+
+// AlphaShape returns the alpha shape of the vertices of g: the union of the
+// triangles of the Delaunay triangulation of g whose circumradius is at most
+// alpha. Vertices that are not part of any such triangle are omitted, so the
+// result may contain several polygons. If allowHoles is false then any holes
+// are filled. The shell of each polygon is counter-clockwise and its holes are
+// clockwise.
+func AlphaShape(g geom.T, alpha float64, allowHoles bool) (*geom.MultiPolygon, error) {
+	m, err := newMeshFromGeom(g)
+	if err != nil {
+		return nil, err
+	}
+	m.build()
+	multiPolygon := geom.NewMultiPolygon(m.layout)
+
+	in := make([]bool, len(m.tris))
+	for t := range m.tris {
+		if m.tris[t].dead || m.isGhost(t) {
+			continue
+		}
+		a, b, c := m.edgeLength(t, 0), m.edgeLength(t, 1), m.edgeLength(t, 2)
+		v := m.tris[t].v
+		in[t] = a*b*c <= 4*alpha*triangleArea(m.xy(v[0]), m.xy(v[1]), m.xy(v[2]))
+	}
+
+	shells, holes := m.boundaryRings(in)
+	polygons := make([]*geom.Polygon, len(shells))
+	for i, shell := range shells {
+		polygons[i] = geom.NewPolygon(m.layout)
+		if err := polygons[i].Push(shell); err != nil {
+			return nil, err
+		}
+	}
+	if allowHoles {
+		for _, hole := range holes {
+			if hole.shell < 0 {
+				continue
+			}
+			if err := polygons[hole.shell].Push(hole.ring); err != nil {
+				return nil, err
+			}
+		}
+	}
+	for _, polygon := range polygons {
+		if err := multiPolygon.Push(polygon); err != nil {
+			return nil, err
+		}
+	}
+	return multiPolygon, nil
+}
+
+// edgeLength returns the length of the edge of triangle t opposite its kth
+// vertex.
+func (m *mesh) edgeLength(t, k int) float64 {
+	tri := &m.tris[t]
+	a, b := m.xy(tri.v[(k+1)%3]), m.xy(tri.v[(k+2)%3])
+	return math.Hypot(b[0]-a[0], b[1]-a[1])
+}
+
+// triangleArea returns the area of the counter-clockwise triangle a, b, c.
+func triangleArea(a, b, c geom.Coord) float64 {
+	return ((b[0]-a[0])*(c[1]-a[1]) - (c[0]-a[0])*(b[1]-a[1])) / 2
+}
+
+// A candidate is a triangle that might be removed by an eroder, prioritized by
+// the length of the edge that would be removed.
+type candidate struct {
+	t      int
+	length float64
+}
+
+// candidates is a max-heap of candidates.
+type candidates []candidate
+
+func (c candidates) Len() int           { return len(c) }
+func (c candidates) Less(i, j int) bool { return c[i].length > c[j].length }
+func (c candidates) Swap(i, j int)      { c[i], c[j] = c[j], c[i] }
+func (c *candidates) Push(x any)        { *c = append(*c, x.(candidate)) } //nolint:forcetypeassert
+
+func (c *candidates) Pop() any {
+	n := len(*c)
+	x := (*c)[n-1]
+	*c = (*c)[:n-1]
+	return x
+}
+
+// An eroder removes triangles from a mesh while keeping the remaining
+// triangles a single polygon that contains every vertex.
+type eroder struct {
+	m          *mesh
+	allowHoles bool
+	in         []bool
+	onBoundary []bool
+	candidates candidates
+}
+
+func newEroder(m *mesh, allowHoles bool) *eroder {
+	e := &eroder{
+		m:          m,
+		allowHoles: allowHoles,
+		in:         make([]bool, len(m.tris)),
+		onBoundary: make([]bool, m.numVertices()),
+	}
+	for t := range m.tris {
+		switch {
+		case m.tris[t].dead:
+		case m.isGhost(t):
+			e.onBoundary[m.tris[t].v[0]] = true
+			e.onBoundary[m.tris[t].v[1]] = true
+		default:
+			e.in[t] = true
+		}
+	}
+	return e
+}
+
+// removable returns the length of the edge that would be removed with triangle
+// t, and whether t can be removed. A triangle with one boundary edge can be
+// removed if its opposite vertex is not on the boundary. A triangle with no
+// boundary edges can be removed, creating a hole, if holes are allowed and none
+// of its vertices are on the boundary.
+func (e *eroder) removable(t int) (float64, bool) {
+	tri := &e.m.tris[t]
+	boundaryEdge, numBoundaryEdges := -1, 0
+	for k := range 3 {
+		if !e.in[tri.n[k]] {
+			boundaryEdge = k
+			numBoundaryEdges++
+		}
+	}
+	switch numBoundaryEdges {
+	case 0:
+		if !e.allowHoles || e.onBoundary[tri.v[0]] || e.onBoundary[tri.v[1]] || e.onBoundary[tri.v[2]] {
+			return 0, false
+		}
+		return math.Max(e.m.edgeLength(t, 0), math.Max(e.m.edgeLength(t, 1), e.m.edgeLength(t, 2))), true
+	case 1:
+		if e.onBoundary[tri.v[boundaryEdge]] {
+			return 0, false
+		}
+		return e.m.edgeLength(t, boundaryEdge), true
+	default:
+		return 0, false
+	}
+}
+
+// push adds t to the candidates if it can be removed.
+func (e *eroder) push(t int, threshold float64) {
+	if length, ok := e.removable(t); ok && length > threshold {
+		heap.Push(&e.candidates, candidate{t: t, length: length})
+	}
+}
+
+// remove removes triangle t.
+func (e *eroder) remove(t int) {
+	e.in[t] = false
+	for _, v := range e.m.tris[t].v {
+		e.onBoundary[v] = true
+	}
+}
+
+// A hole is a clockwise boundary ring and the index of the shell that
+// contains it, or -1 if there is no such shell.
+type hole struct {
+	ring  *geom.LinearRing
+	shell int
+}
+
+// boundaryRings returns the rings that bound the triangles in m for which in is
+// true. Shells are counter-clockwise and holes are clockwise.
+func (m *mesh) boundaryRings(in []bool) ([]*geom.LinearRing, []hole) {
+	visited := make(map[[2]int]bool)
+	var shells []*geom.LinearRing
+	var holes []hole
+	var holePoints []geom.Coord
+	for t := range m.tris {
+		if !in[t] {
+			continue
+		}
+		for k := range 3 {
+			if in[m.tris[t].n[k]] || visited[[2]int{t, k}] {
+				continue
+			}
+			ring := m.traceBoundary(in, visited, t, k)
+			if xy.IsRingCounterClockwise(m.layout, ring.FlatCoords) {
+				shells = append(shells, ring)
+			} else {
+				// The centroid of the triangle on the other side of the first
+				// edge of the ring lies strictly inside the hole.
+				v := m.tris[m.tris[t].n[k]].v
+				a, b, c := m.xy(v[0]), m.xy(v[1]), m.xy(v[2])
+				holes = append(holes, hole{ring: ring, shell: -1})
+				holePoints = append(holePoints, geom.Coord{(a[0] + b[0] + c[0]) / 3, (a[1] + b[1] + c[1]) / 3})
+			}
+		}
+	}
+	for i := range holes {
+		minArea := math.Inf(1)
+		for j, shell := range shells {
+			area := shell.Area()
+			if area < minArea && xy.IsPointInRing(m.layout, holePoints[i], shell.FlatCoords) {
+				holes[i].shell, minArea = j, area
+			}
+		}
+	}
+	return shells, holes
+}
+
+// traceBoundary returns the ring that starts with the edge of triangle t
+// opposite its kth vertex, keeping the triangles for which in is true on its
+// left.
+func (m *mesh) traceBoundary(in []bool, visited map[[2]int]bool, t, k int) *geom.LinearRing {
+	var flatCoords []float64
+	startT, startK := t, k
+	for {
+		visited[[2]int{t, k}] = true
+		tri := &m.tris[t]
+		flatCoords = append(flatCoords, m.coords[tri.v[(k+1)%3]*m.stride:(tri.v[(k+1)%3]+1)*m.stride]...)
+
+		// Rotate clockwise around the end vertex of the edge until the next
+		// boundary edge is found.
+		b := tri.v[(k+2)%3]
+		k = (k + 1) % 3
+		for in[m.tris[t].n[k]] {
+			t = m.tris[t].n[k]
+			k = (vertexIndex(&m.tris[t], b) + 2) % 3
+		}
+		if t == startT && k == startK {
+			break
+		}
+	}
+	flatCoords = append(flatCoords, flatCoords[:m.stride]...)
+	return geom.NewLinearRingFlat(m.layout, flatCoords)
+}
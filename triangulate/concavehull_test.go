@@ -0,0 +1,140 @@
+package triangulate
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+
+	"github.com/don4get/go-geom"
+	"github.com/don4get/go-geom/xy"
+)
+
+// cShape returns the points of a 7x7 grid with the middle of its right side
+// removed.
+func cShape() *geom.MultiPoint {
+	var flatCoords []float64
+	for y := range 7 {
+		for x := range 7 {
+			if x >= 2 && y >= 2 && y <= 4 {
+				continue
+			}
+			flatCoords = append(flatCoords, float64(x), float64(y))
+		}
+	}
+	return geom.NewMultiPointFlat(geom.XY, flatCoords)
+}
+
+func TestConcaveHull(t *testing.T) {
+	for _, tc := range []struct {
+		name               string
+		g                  geom.T
+		maxEdgeLengthRatio float64
+		expectedType       geom.T
+		expectedArea       float64
+		expectedNumRings   int
+	}{
+		{
+			name:               "collinear",
+			g:                  geom.NewMultiPointFlat(geom.XY, []float64{0, 0, 1, 1, 2, 2}),
+			maxEdgeLengthRatio: 0,
+			expectedType:       &geom.LineString{},
+		},
+		{
+			name:               "convex",
+			g:                  cShape(),
+			maxEdgeLengthRatio: 1,
+			expectedType:       &geom.Polygon{},
+			expectedArea:       36,
+			expectedNumRings:   1,
+		},
+		{
+			name:               "concave",
+			g:                  cShape(),
+			maxEdgeLengthRatio: 0.2,
+			expectedType:       &geom.Polygon{},
+			expectedArea:       17,
+			expectedNumRings:   1,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			hull, err := ConcaveHull(tc.g, tc.maxEdgeLengthRatio, false)
+			assert.NoError(t, err)
+			assert.Equal(t, fmt.Sprintf("%T", tc.expectedType), fmt.Sprintf("%T", hull))
+			if polygon, ok := hull.(*geom.Polygon); ok {
+				assert.Equal(t, tc.expectedNumRings, polygon.NumLinearRings())
+				assert.True(t, math.Abs(tc.expectedArea-polygon.Area()) < 1e-9)
+				assertContainsVertices(t, polygon, tc.g)
+			}
+		})
+	}
+}
+
+func TestConcaveHullHoles(t *testing.T) {
+	// A 9x9 grid with the 3x3 points in the middle removed.
+	var flatCoords []float64
+	for y := range 9 {
+		for x := range 9 {
+			if x >= 3 && x <= 5 && y >= 3 && y <= 5 {
+				continue
+			}
+			flatCoords = append(flatCoords, float64(x), float64(y))
+		}
+	}
+	mp := geom.NewMultiPointFlat(geom.XY, flatCoords)
+
+	hull, err := ConcaveHull(mp, 0.2, false)
+	assert.NoError(t, err)
+	polygon, ok := hull.(*geom.Polygon)
+	assert.True(t, ok)
+	assert.Equal(t, 1, polygon.NumLinearRings())
+	assert.Equal(t, 64.0, polygon.Area())
+
+	hull, err = ConcaveHull(mp, 0.2, true)
+	assert.NoError(t, err)
+	polygon, ok = hull.(*geom.Polygon)
+	assert.True(t, ok)
+	assert.Equal(t, 2, polygon.NumLinearRings())
+	assert.False(t, xy.IsRingCounterClockwise(geom.XY, polygon.LinearRing(1).FlatCoords))
+	assert.Equal(t, 50.0, polygon.Area())
+	assertContainsVertices(t, polygon, mp)
+}
+
+func TestAlphaShape(t *testing.T) {
+	// Two unit grids, far apart.
+	var flatCoords []float64
+	for _, offset := range []float64{0, 10} {
+		for y := range 3 {
+			for x := range 3 {
+				flatCoords = append(flatCoords, offset+float64(x), float64(y))
+			}
+		}
+	}
+	mp := geom.NewMultiPointFlat(geom.XY, flatCoords)
+
+	shape, err := AlphaShape(mp, 1, false)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, shape.NumPolygons())
+	assert.Equal(t, 8.0, shape.Area())
+
+	shape, err = AlphaShape(mp, 0.1, false)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, shape.NumPolygons())
+
+	shape, err = AlphaShape(mp, 100, false)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, shape.NumPolygons())
+	assert.Equal(t, 24.0, shape.Area())
+}
+
+// assertContainsVertices asserts that every vertex of g is inside or on the
+// boundary of polygon.
+func assertContainsVertices(t *testing.T, polygon *geom.Polygon, g geom.T) {
+	t.Helper()
+	flatCoords, stride := g.GetFlatCoords(), g.GetStride()
+	for i := 0; i < len(flatCoords); i += stride {
+		c := geom.Coord(flatCoords[i : i+stride])
+		assert.True(t, xy.IsPointInRing(geom.XY, c, polygon.LinearRing(0).FlatCoords) || xy.IsOnLine(geom.XY, c, polygon.LinearRing(0).FlatCoords))
+	}
+}
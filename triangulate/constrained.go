@@ -0,0 +1,340 @@
+package triangulate
+
+import (
+	"errors"
+
+	"github.com/don4get/go-geom"
+	"github.com/don4get/go-geom/bigxy"
+	"github.com/don4get/go-geom/xy"
+	"github.com/don4get/go-geom/xy/orientation"
+)
+
+// ErrIntersectingConstraints is returned when two segments of a constrained
+// Delaunay triangulation cross each other other than at a shared vertex.
+var ErrIntersectingConstraints = errors.New("triangulate: constraints intersect")
+
+// ConstrainedDelaunay returns the constrained Delaunay triangulation of the
+// vertices of g. Every segment of g's linear and polygonal components is an
+// edge of the triangulation (split at any vertex lying on it) and, apart from
+// these constraints, the triangulation is Delaunay. Segments must only meet at
+// shared vertices, otherwise ErrIntersectingConstraints is returned.
+//
+// If g is a Polygon or a MultiPolygon then only the triangles inside g are
+// returned, otherwise all the triangles covering the convex hull of g are
+// returned.
+func ConstrainedDelaunay(g geom.T) (*Triangulation, error) {
+	m, err := newMeshFromGeom(g)
+	if err != nil {
+		return nil, err
+	}
+	m.build()
+	if len(m.tris) == 0 {
+		return m.triangulation(), nil
+	}
+
+	index := make(map[[2]float64]int, m.numVertices())
+	for i := range m.numVertices() {
+		c := m.xy(i)
+		index[[2]float64{c[0], c[1]}] = i
+	}
+	forEachSegment(g, func(a, b []float64) {
+		u, v := index[[2]float64{a[0], a[1]}], index[[2]float64{b[0], b[1]}]
+		if err == nil && u != v {
+			err = m.insertConstraint(u, v)
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	t := m.triangulation()
+	switch g := g.(type) {
+	case *geom.Polygon:
+		t.filter(func(c geom.Coord) bool {
+			return polygonContains(g, c)
+		})
+	case *geom.MultiPolygon:
+		t.filter(func(c geom.Coord) bool {
+			for i := range g.NumPolygons() {
+				if polygonContains(g.Polygon(i), c) {
+					return true
+				}
+			}
+			return false
+		})
+	}
+	return t, nil
+}
+
+// filter removes all triangles whose centroid does not satisfy keep.
+func (t *Triangulation) filter(keep func(geom.Coord) bool) {
+	triangles := t.triangles[:0]
+	for i := 0; i < len(t.triangles); i += 3 {
+		a, b, c := t.Site(t.triangles[i]), t.Site(t.triangles[i+1]), t.Site(t.triangles[i+2])
+		centroid := geom.Coord{(a[0] + b[0] + c[0]) / 3, (a[1] + b[1] + c[1]) / 3}
+		if keep(centroid) {
+			triangles = append(triangles, t.triangles[i:i+3]...)
+		}
+	}
+	t.triangles = triangles
+}
+
+// polygonContains returns true if c lies inside the shell of p and outside all
+// of its holes.
+func polygonContains(p *geom.Polygon, c geom.Coord) bool {
+	for i := range p.NumLinearRings() {
+		ring := p.LinearRing(i)
+		if ring.IsEmpty() {
+			continue
+		}
+		if xy.IsPointInRing(p.GetLayout(), c, ring.GetFlatCoords()) != (i == 0) {
+			return false
+		}
+	}
+	return !p.IsEmpty()
+}
+
+// forEachSegment calls f for every segment of g's linear and polygonal
+// components.
+func forEachSegment(g geom.T, f func(a, b []float64)) {
+	var ends []int
+	switch g := g.(type) {
+	case *geom.LineString, *geom.LinearRing:
+		ends = []int{len(g.GetFlatCoords())}
+	case *geom.Polygon, *geom.MultiLineString:
+		ends = g.GetEnds()
+	case *geom.MultiPolygon:
+		for _, polygonEnds := range g.GetEndss() {
+			ends = append(ends, polygonEnds...)
+		}
+	case *geom.GeometryCollection:
+		for _, child := range g.Geoms() {
+			forEachSegment(child, f)
+		}
+		return
+	default:
+		return
+	}
+	flatCoords, stride := g.GetFlatCoords(), g.GetStride()
+	offset := 0
+	for _, end := range ends {
+		for i := offset + stride; i < end; i += stride {
+			f(flatCoords[i-stride:i], flatCoords[i:i+stride])
+		}
+		offset = end
+	}
+}
+
+// A crossing is an edge that crosses a constraint, with l to the left of the
+// constraint and r to its right.
+type crossing struct {
+	l, r int
+}
+
+// insertConstraint forces the segment between vertices u and v to be edges of
+// m. It returns ErrIntersectingConstraints if the segment crosses a constraint
+// that is already an edge of m.
+func (m *mesh) insertConstraint(u, v int) error {
+	for u != v {
+		w, crossings := m.findCrossings(u, v)
+		for _, c := range crossings {
+			if m.constrained[makeEdgeKey(c.l, c.r)] {
+				return ErrIntersectingConstraints
+			}
+		}
+		if len(crossings) > 0 {
+			m.removeCrossings(u, w, crossings)
+		}
+		m.constrained[makeEdgeKey(u, w)] = true
+		u = w
+	}
+	return nil
+}
+
+// findCrossings walks from vertex u towards vertex v. It returns the first
+// vertex on the segment from u to v and the edges crossed on the way.
+func (m *mesh) findCrossings(u, v int) (int, []crossing) {
+	start := m.vt[u]
+	t := start
+	for {
+		tri := &m.tris[t]
+		i := vertexIndex(tri, u)
+		a, b := tri.v[(i+1)%3], tri.v[(i+2)%3]
+		if a == v || b == v {
+			return v, nil
+		}
+		if a != ghost && m.orient(u, v, a) == orientation.Collinear && m.between(u, v, a) {
+			return a, nil
+		}
+		if a != ghost && b != ghost &&
+			m.orient(u, a, v) == orientation.CounterClockwise && m.orient(u, b, v) == orientation.Clockwise {
+			break
+		}
+		t = tri.n[(i+1)%3]
+		if t == start {
+			panic("triangulate: constraint not found")
+		}
+	}
+
+	tri := &m.tris[t]
+	i := vertexIndex(tri, u)
+	c := crossing{l: tri.v[(i+2)%3], r: tri.v[(i+1)%3]}
+	crossings := []crossing{c}
+	t = tri.n[i]
+	for {
+		w := m.oppositeVertex(t, c.l, c.r)
+		if w == v {
+			return v, crossings
+		}
+		switch m.orient(u, v, w) {
+		case orientation.Collinear:
+			return w, crossings
+		case orientation.CounterClockwise:
+			t = m.across(t, w, c.r)
+			c = crossing{l: w, r: c.r}
+		default:
+			t = m.across(t, c.l, w)
+			c = crossing{l: c.l, r: w}
+		}
+		crossings = append(crossings, c)
+	}
+}
+
+// removeCrossings flips edges until none of crossings crosses the segment from
+// u to w, then restores the Delaunay property around the new edges.
+func (m *mesh) removeCrossings(u, w int, crossings []crossing) {
+	queue := make([][2]int, 0, len(crossings))
+	for _, c := range crossings {
+		queue = append(queue, [2]int{c.l, c.r})
+	}
+	var newEdges [][2]int
+	for len(queue) > 0 {
+		e := queue[0]
+		queue = queue[1:]
+		t, k := m.findEdge(e[0], e[1])
+		p := m.tris[t].v[k]
+		q := m.oppositeVertex(m.tris[t].n[k], e[0], e[1])
+		if !m.isConvex(e[0], e[1], p, q) {
+			queue = append(queue, e)
+			continue
+		}
+		m.flip(t, k)
+		if m.properlyCrosses(p, q, u, w) {
+			queue = append(queue, [2]int{p, q})
+		} else {
+			newEdges = append(newEdges, [2]int{p, q})
+		}
+	}
+
+	for swapped := true; swapped; {
+		swapped = false
+		for i, e := range newEdges {
+			if m.constrained[makeEdgeKey(e[0], e[1])] || makeEdgeKey(e[0], e[1]) == makeEdgeKey(u, w) {
+				continue
+			}
+			t, k := m.findEdge(e[0], e[1])
+			nb := m.tris[t].n[k]
+			if m.isGhost(nb) {
+				continue
+			}
+			v := m.tris[t].v
+			q := m.oppositeVertex(nb, e[0], e[1])
+			if bigxy.IsInCircle(m.xy(v[0]), m.xy(v[1]), m.xy(v[2]), m.xy(q)) {
+				m.flip(t, k)
+				newEdges[i] = [2]int{v[k], q}
+				swapped = true
+			}
+		}
+	}
+}
+
+// isConvex returns true if the quadrilateral formed by the two triangles
+// sharing the edge from x to y, with opposite vertices p and q, is strictly
+// convex.
+func (m *mesh) isConvex(x, y, p, q int) bool {
+	if p == ghost || q == ghost {
+		return false
+	}
+	ox, oy := m.orient(p, q, x), m.orient(p, q, y)
+	return ox != orientation.Collinear && oy != orientation.Collinear && ox != oy
+}
+
+// properlyCrosses returns true if the segments a-b and c-d cross at a point
+// interior to both.
+func (m *mesh) properlyCrosses(a, b, c, d int) bool {
+	if a == c || a == d || b == c || b == d {
+		return false
+	}
+	oa, ob := m.orient(c, d, a), m.orient(c, d, b)
+	if oa == orientation.Collinear || ob == orientation.Collinear || oa == ob {
+		return false
+	}
+	oc, od := m.orient(a, b, c), m.orient(a, b, d)
+	return oc != orientation.Collinear && od != orientation.Collinear && oc != od
+}
+
+// flip replaces the edge opposite vertex k of triangle t1, shared with triangle
+// t2, with the other diagonal of the quadrilateral formed by t1 and t2.
+func (m *mesh) flip(t1, k1 int) {
+	tri1 := m.tris[t1]
+	p, x, y := tri1.v[k1], tri1.v[(k1+1)%3], tri1.v[(k1+2)%3]
+	t2 := tri1.n[k1]
+	tri2 := m.tris[t2]
+	k2 := vertexIndex(&tri2, m.oppositeVertex(t2, x, y))
+	q := tri2.v[k2]
+	a, b := tri2.n[(k2+1)%3], tri2.n[(k2+2)%3]
+	c, d := tri1.n[(k1+1)%3], tri1.n[(k1+2)%3]
+	m.tris[t1] = triangle{v: [3]int{p, x, q}, n: [3]int{a, t2, d}}
+	m.tris[t2] = triangle{v: [3]int{q, y, p}, n: [3]int{c, t1, b}}
+	m.setNeighbor(a, q, x, t1)
+	m.setNeighbor(c, p, y, t2)
+	m.vt[p], m.vt[x], m.vt[y], m.vt[q] = t1, t1, t2, t1
+}
+
+// findEdge returns the triangle containing the directed edge from x to y and
+// the index of the vertex opposite it.
+func (m *mesh) findEdge(x, y int) (int, int) {
+	start := m.vt[x]
+	t := start
+	for {
+		tri := &m.tris[t]
+		i := vertexIndex(tri, x)
+		if tri.v[(i+1)%3] == y {
+			return t, (i + 2) % 3
+		}
+		t = tri.n[(i+1)%3]
+		if t == start {
+			panic("triangulate: edge not found")
+		}
+	}
+}
+
+// across returns the neighbor of triangle t across its edge between a and b.
+func (m *mesh) across(t, a, b int) int {
+	tri := &m.tris[t]
+	for k := range 3 {
+		if tri.v[k] != a && tri.v[k] != b {
+			return tri.n[k]
+		}
+	}
+	panic("triangulate: edge not found")
+}
+
+// oppositeVertex returns the vertex of triangle t that is neither a nor b.
+func (m *mesh) oppositeVertex(t, a, b int) int {
+	for _, v := range m.tris[t].v {
+		if v != a && v != b {
+			return v
+		}
+	}
+	panic("triangulate: degenerate triangle")
+}
+
+func vertexIndex(tri *triangle, v int) int {
+	for i := range 3 {
+		if tri.v[i] == v {
+			return i
+		}
+	}
+	panic("triangulate: vertex not found")
+}
@@ -0,0 +1,112 @@
+package triangulate
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+
+	"github.com/don4get/go-geom"
+)
+
+func TestConstrainedDelaunay(t *testing.T) {
+	for _, tc := range []struct {
+		name              string
+		g                 geom.T
+		expectedTriangles int
+		expectedArea      float64
+	}{
+		{
+			name: "u_shape",
+			g: geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{
+				{{0, 0}, {3, 0}, {3, 3}, {2, 3}, {2, 1}, {1, 1}, {1, 3}, {0, 3}, {0, 0}},
+			}),
+			expectedTriangles: 6,
+			expectedArea:      7,
+		},
+		{
+			name: "square_with_hole",
+			g: geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{
+				{{0, 0}, {4, 0}, {4, 4}, {0, 4}, {0, 0}},
+				{{1, 1}, {1, 3}, {3, 3}, {3, 1}, {1, 1}},
+			}),
+			expectedTriangles: 8,
+			expectedArea:      12,
+		},
+		{
+			name: "multipolygon",
+			g: geom.NewMultiPolygon(geom.XY).MustSetCoords([][][]geom.Coord{
+				{{{0, 0}, {1, 0}, {1, 1}, {0, 1}, {0, 0}}},
+				{{{2, 0}, {3, 0}, {3, 1}, {2, 1}, {2, 0}}},
+			}),
+			expectedTriangles: 4,
+			expectedArea:      2,
+		},
+		{
+			name:              "split_constraint",
+			g:                 geom.NewLineStringFlat(geom.XY, []float64{0, 0, 2, 0, 1, 0, 1, 2, 1, -2}),
+			expectedTriangles: 4,
+			expectedArea:      4,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			tri, err := ConstrainedDelaunay(tc.g)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedTriangles, tri.NumTriangles())
+			assert.True(t, math.Abs(tc.expectedArea-tri.MultiPolygon().Area()) < 1e-9)
+			assertHasEdges(t, tri, tc.g)
+		})
+	}
+}
+
+func TestConstrainedDelaunayIntersectingConstraints(t *testing.T) {
+	for _, g := range []geom.T{
+		geom.NewLineStringFlat(geom.XY, []float64{0, 0, 2, 2, 2, 0, 0, 2}),
+		geom.NewMultiLineStringFlat(geom.XY, []float64{0, 0, 2, 2, 2, 0, 0, 2}, []int{4, 8}),
+	} {
+		_, err := ConstrainedDelaunay(g)
+		assert.Equal(t, ErrIntersectingConstraints, err)
+	}
+}
+
+func TestConstrainedDelaunayZigzag(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	flatCoords := []float64{0, 0}
+	for i := 1; i < 50; i++ {
+		flatCoords = append(flatCoords, float64(i), 10*r.Float64())
+	}
+	flatCoords = append(flatCoords, 50, 20, 0, 20, 0, 0)
+	polygon := geom.NewPolygonFlat(geom.XY, flatCoords, []int{len(flatCoords)})
+	tri, err := ConstrainedDelaunay(polygon)
+	assert.NoError(t, err)
+	assert.Equal(t, polygon.NumCoords()-2-1, tri.NumTriangles())
+	assert.True(t, math.Abs(polygon.Area()-tri.MultiPolygon().Area()) < 1e-9)
+	assertHasEdges(t, tri, polygon)
+}
+
+func assertHasEdges(t *testing.T, tri *Triangulation, g geom.T) {
+	t.Helper()
+	edges := make(map[[4]float64]bool)
+	triangles := tri.Triangles()
+	for i := 0; i < len(triangles); i += 3 {
+		for k := range 3 {
+			a, b := tri.Site(triangles[i+k]), tri.Site(triangles[i+(k+1)%3])
+			edges[[4]float64{a[0], a[1], b[0], b[1]}] = true
+			edges[[4]float64{b[0], b[1], a[0], a[1]}] = true
+		}
+	}
+	forEachSegment(g, func(a, b []float64) {
+		if edges[[4]float64{a[0], a[1], b[0], b[1]}] {
+			return
+		}
+		// The segment may have been split at intermediate vertices.
+		for i := range tri.NumSites() {
+			c := tri.Site(i)
+			if edges[[4]float64{a[0], a[1], c[0], c[1]}] && edges[[4]float64{c[0], c[1], b[0], b[1]}] {
+				return
+			}
+		}
+		t.Errorf("missing edge %v-%v", a, b)
+	})
+}
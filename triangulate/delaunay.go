@@ -0,0 +1,23 @@
+package triangulate
+
+import "github.com/don4get/go-geom"
+
+// Delaunay returns the Delaunay triangulation of the vertices of g. Duplicate
+// vertices are removed. If all the vertices are collinear then the
+// triangulation contains no triangles.
+func Delaunay(g geom.T) (*Triangulation, error) {
+	m, err := newMeshFromGeom(g)
+	if err != nil {
+		return nil, err
+	}
+	m.build()
+	return m.triangulation(), nil
+}
+
+// DelaunayFlat returns the Delaunay triangulation of the coordinates in
+// flatCoords.
+func DelaunayFlat(layout geom.Layout, flatCoords []float64) *Triangulation {
+	m := newMesh(layout, flatCoords)
+	m.build()
+	return m.triangulation()
+}
@@ -0,0 +1,156 @@
+package triangulate
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+
+	"github.com/don4get/go-geom"
+	"github.com/don4get/go-geom/bigxy"
+	"github.com/don4get/go-geom/xy"
+)
+
+func TestDelaunay(t *testing.T) {
+	for _, tc := range []struct {
+		name              string
+		g                 geom.T
+		expectedSites     int
+		expectedTriangles int
+		expectedArea      float64
+	}{
+		{
+			name: "empty",
+			g:    geom.NewMultiPoint(geom.XY),
+		},
+		{
+			name:          "collinear",
+			g:             geom.NewLineStringFlat(geom.XY, []float64{0, 0, 1, 1, 2, 2, 3, 3}),
+			expectedSites: 4,
+		},
+		{
+			name:              "triangle",
+			g:                 geom.NewMultiPointFlat(geom.XY, []float64{0, 0, 1, 0, 0, 1}),
+			expectedSites:     3,
+			expectedTriangles: 1,
+			expectedArea:      0.5,
+		},
+		{
+			name:              "square_with_duplicates",
+			g:                 geom.NewPolygonFlat(geom.XY, []float64{0, 0, 2, 0, 2, 2, 0, 2, 0, 0}, []int{10}),
+			expectedSites:     4,
+			expectedTriangles: 2,
+			expectedArea:      4,
+		},
+		{
+			name:              "grid",
+			g:                 grid(5, 5),
+			expectedSites:     25,
+			expectedTriangles: 32,
+			expectedArea:      16,
+		},
+		{
+			name:              "collinear_then_apex",
+			g:                 geom.NewMultiPointFlat(geom.XY, []float64{0, 0, 1, 0, 2, 0, 3, 0, 4, 0, 2, 1}),
+			expectedSites:     6,
+			expectedTriangles: 4,
+			expectedArea:      2,
+		},
+		{
+			name: "collection",
+			g: geom.NewGeometryCollection().MustPush(
+				geom.NewPointFlat(geom.XY, []float64{0, 0}),
+				geom.NewLineStringFlat(geom.XY, []float64{1, 0, 1, 1}),
+			),
+			expectedSites:     3,
+			expectedTriangles: 1,
+			expectedArea:      0.5,
+		},
+		{
+			name: "empty_collection",
+			g:    geom.NewGeometryCollection(),
+		},
+		{
+			name: "collection_with_mixed_layouts",
+			g: geom.NewGeometryCollection().MustPush(
+				geom.NewPointFlat(geom.XY, []float64{0, 0}),
+				geom.NewPointFlat(geom.XYZ, []float64{1, 0, 5}),
+				geom.NewPointFlat(geom.XYM, []float64{0, 1, 6}),
+			),
+			expectedSites:     3,
+			expectedTriangles: 1,
+			expectedArea:      0.5,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			tri, err := Delaunay(tc.g)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedSites, tri.NumSites())
+			assert.Equal(t, tc.expectedTriangles, tri.NumTriangles())
+			assert.True(t, math.Abs(tc.expectedArea-tri.MultiPolygon().Area()) < 1e-9)
+			assertDelaunay(t, tri)
+		})
+	}
+}
+
+func TestDelaunayRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for _, n := range []int{10, 100, 1000} {
+		flatCoords := make([]float64, 0, 3*n)
+		for range n {
+			flatCoords = append(flatCoords, r.Float64(), r.Float64(), r.Float64())
+		}
+		tri := DelaunayFlat(geom.XYZ, flatCoords)
+		assert.Equal(t, n, tri.NumSites())
+		assertDelaunay(t, tri)
+
+		hull := xy.ConvexHullFlat(geom.XYZ, flatCoords)
+		numHullVertices := len(hull.GetFlatCoords())/3 - 1
+		assert.Equal(t, 2*n-2-numHullVertices, tri.NumTriangles())
+		assert.True(t, math.Abs(math.Abs(hull.(*geom.Polygon).Area())-tri.MultiPolygon().Area()) < 1e-9)
+	}
+}
+
+func TestEmptyGeometryCollection(t *testing.T) {
+	g := geom.NewGeometryCollection()
+	tri, err := ConstrainedDelaunay(g)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, tri.NumSites())
+	cells, err := Voronoi(g, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, cells.NumPolygons())
+	_, err = ConcaveHull(g, 0.5, false)
+	assert.NoError(t, err)
+	alphaShape, err := AlphaShape(g, 1, false)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, alphaShape.NumPolygons())
+}
+
+func TestDelaunayEdges(t *testing.T) {
+	tri, err := Delaunay(grid(2, 2))
+	assert.NoError(t, err)
+	assert.Equal(t, 5, tri.Edges().NumLineStrings())
+}
+
+func assertDelaunay(t *testing.T, tri *Triangulation) {
+	t.Helper()
+	triangles := tri.Triangles()
+	for i := 0; i < len(triangles); i += 3 {
+		a, b, c := tri.Site(triangles[i]), tri.Site(triangles[i+1]), tri.Site(triangles[i+2])
+		assert.True(t, xy.OrientationIndex(a, b, c) > 0)
+		for j := range tri.NumSites() {
+			assert.False(t, bigxy.IsInCircle(a, b, c, tri.Site(j)))
+		}
+	}
+}
+
+func grid(nx, ny int) *geom.MultiPoint {
+	flatCoords := make([]float64, 0, 2*nx*ny)
+	for i := range nx {
+		for j := range ny {
+			flatCoords = append(flatCoords, float64(i), float64(j))
+		}
+	}
+	return geom.NewMultiPointFlat(geom.XY, flatCoords)
+}
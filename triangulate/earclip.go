@@ -0,0 +1,452 @@
+package triangulate
+
+import (
+	"math"
+	"sort"
+
+	"github.com/don4get/go-geom"
+)
+
+// EarClip triangulates p by ear clipping. It returns the indexes of the
+// vertices of each triangle, three per triangle, where the index of a vertex is
+// its offset in p's flat coordinates divided by p's stride. The result can
+// therefore be used directly as an index buffer alongside p.GetFlatCoords().
+// The vertices of each triangle are in counter-clockwise order. The closing
+// vertex of each ring is never referenced.
+func EarClip(p *geom.Polygon) []int {
+	return EarClipFlat(p.GetLayout(), p.GetFlatCoords(), p.GetEnds())
+}
+
+// EarClipFlat triangulates the polygon with the given flat coordinates and ends
+// by ear clipping. See EarClip.
+func EarClipFlat(layout geom.Layout, flatCoords []float64, ends []int) []int {
+	stride := layout.Stride()
+	if len(ends) == 0 || stride < 2 {
+		return nil
+	}
+	outer := newEarList(flatCoords, 0, ends[0], stride, true)
+	if outer == nil || outer.next == outer.prev {
+		return nil
+	}
+	if len(ends) > 1 {
+		outer = eliminateHoles(flatCoords, ends, stride, outer)
+	}
+	var triangles []int
+	earClipLinked(outer, &triangles, stride, 0)
+	return triangles
+}
+
+// An earNode is a vertex in a circular doubly-linked list of polygon vertices.
+type earNode struct {
+	i          int
+	x, y       float64
+	prev, next *earNode
+	steiner    bool
+}
+
+// newEarList returns a circular doubly-linked list of the vertices in
+// flatCoords[start:end] in the requested winding order. The closing vertex of
+// the ring is omitted.
+func newEarList(flatCoords []float64, start, end, stride int, counterClockwise bool) *earNode {
+	if end-start > stride && flatCoords[start] == flatCoords[end-stride] && flatCoords[start+1] == flatCoords[end-stride+1] {
+		end -= stride
+	}
+	var last *earNode
+	if counterClockwise == (earSignedArea(flatCoords, start, end, stride) > 0) {
+		for i := start; i < end; i += stride {
+			last = insertEarNode(i, flatCoords[i], flatCoords[i+1], last)
+		}
+	} else {
+		for i := end - stride; i >= start; i -= stride {
+			last = insertEarNode(i, flatCoords[i], flatCoords[i+1], last)
+		}
+	}
+	return last
+}
+
+// filterEarPoints removes duplicate and collinear vertices from the list
+// between start and end.
+func filterEarPoints(start, end *earNode) *earNode {
+	if start == nil {
+		return nil
+	}
+	if end == nil {
+		end = start
+	}
+	p := start
+	for {
+		again := false
+		if !p.steiner && (earEquals(p, p.next) || earArea(p.prev, p, p.next) == 0) {
+			removeEarNode(p)
+			p = p.prev
+			end = p
+			if p == p.next {
+				break
+			}
+			again = true
+		} else {
+			p = p.next
+		}
+		if !again && p == end {
+			break
+		}
+	}
+	return end
+}
+
+// earClipLinked clips ears from the list starting at ear. If no ear can be
+// found then it removes degenerate vertices (pass 0), cures local
+// self-intersections (pass 1), and finally splits the polygon in two (pass 2).
+func earClipLinked(ear *earNode, triangles *[]int, stride, pass int) {
+	if ear == nil {
+		return
+	}
+	stop := ear
+	for ear.prev != ear.next {
+		prev, next := ear.prev, ear.next
+		if isEar(ear) {
+			*triangles = append(*triangles, prev.i/stride, ear.i/stride, next.i/stride)
+			removeEarNode(ear)
+			ear = next.next
+			stop = next.next
+			continue
+		}
+		ear = next
+		if ear == stop {
+			switch pass {
+			case 0:
+				earClipLinked(filterEarPoints(ear, nil), triangles, stride, 1)
+			case 1:
+				ear = cureLocalIntersections(filterEarPoints(ear, nil), triangles, stride)
+				earClipLinked(ear, triangles, stride, 2)
+			case 2:
+				splitEarClip(ear, triangles, stride)
+			}
+			return
+		}
+	}
+}
+
+// isEar returns true if the triangle formed by ear and its neighbors is convex
+// and contains no other reflex vertex.
+func isEar(ear *earNode) bool {
+	a, b, c := ear.prev, ear, ear.next
+	if earArea(a, b, c) >= 0 {
+		return false
+	}
+	minX, maxX := math.Min(a.x, math.Min(b.x, c.x)), math.Max(a.x, math.Max(b.x, c.x))
+	minY, maxY := math.Min(a.y, math.Min(b.y, c.y)), math.Max(a.y, math.Max(b.y, c.y))
+	for p := c.next; p != a; p = p.next {
+		if p.x >= minX && p.x <= maxX && p.y >= minY && p.y <= maxY &&
+			earPointInTriangle(a.x, a.y, b.x, b.y, c.x, c.y, p.x, p.y) &&
+			earArea(p.prev, p, p.next) >= 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// cureLocalIntersections clips triangles where two consecutive edges of the
+// list cross.
+func cureLocalIntersections(start *earNode, triangles *[]int, stride int) *earNode {
+	p := start
+	for {
+		a, b := p.prev, p.next.next
+		if !earEquals(a, b) && earIntersects(a, p, p.next, b) && locallyInside(a, b) && locallyInside(b, a) {
+			*triangles = append(*triangles, a.i/stride, p.i/stride, b.i/stride)
+			removeEarNode(p)
+			removeEarNode(p.next)
+			p = b
+			start = b
+		}
+		p = p.next
+		if p == start {
+			break
+		}
+	}
+	return filterEarPoints(p, nil)
+}
+
+// splitEarClip splits the list along a valid diagonal and clips each half
+// separately.
+func splitEarClip(start *earNode, triangles *[]int, stride int) {
+	a := start
+	for {
+		for b := a.next.next; b != a.prev; b = b.next {
+			if a.i != b.i && isValidDiagonal(a, b) {
+				c := splitEarPolygon(a, b)
+				a = filterEarPoints(a, a.next)
+				c = filterEarPoints(c, c.next)
+				earClipLinked(a, triangles, stride, 0)
+				earClipLinked(c, triangles, stride, 0)
+				return
+			}
+		}
+		a = a.next
+		if a == start {
+			return
+		}
+	}
+}
+
+// eliminateHoles links every hole into the outer ring with a pair of bridge
+// edges, from left to right.
+func eliminateHoles(flatCoords []float64, ends []int, stride int, outer *earNode) *earNode {
+	var holes []*earNode
+	for i := 1; i < len(ends); i++ {
+		list := newEarList(flatCoords, ends[i-1], ends[i], stride, false)
+		if list == nil {
+			continue
+		}
+		if list == list.next {
+			list.steiner = true
+		}
+		holes = append(holes, leftmostEarNode(list))
+	}
+	sort.SliceStable(holes, func(i, j int) bool {
+		return holes[i].x < holes[j].x
+	})
+	for _, hole := range holes {
+		outer = eliminateHole(hole, outer)
+	}
+	return outer
+}
+
+func eliminateHole(hole, outer *earNode) *earNode {
+	bridge := findHoleBridge(hole, outer)
+	if bridge == nil {
+		return outer
+	}
+	bridgeReverse := splitEarPolygon(bridge, hole)
+	filterEarPoints(bridgeReverse, bridgeReverse.next)
+	return filterEarPoints(bridge, bridge.next)
+}
+
+// findHoleBridge returns a vertex of the outer ring that can be connected to
+// the leftmost vertex of a hole without crossing any edge.
+func findHoleBridge(hole, outer *earNode) *earNode {
+	hx, hy := hole.x, hole.y
+	qx := math.Inf(-1)
+	var m *earNode
+
+	// Find the segment of the outer ring closest to the hole on its left,
+	// intersecting a horizontal ray from the hole's leftmost vertex.
+	for p := outer; ; {
+		if hy <= p.y && hy >= p.next.y && p.next.y != p.y {
+			x := p.x + (hy-p.y)*(p.next.x-p.x)/(p.next.y-p.y)
+			if x <= hx && x > qx {
+				qx = x
+				if p.x < p.next.x {
+					m = p
+				} else {
+					m = p.next
+				}
+				if x == hx {
+					return m
+				}
+			}
+		}
+		p = p.next
+		if p == outer {
+			break
+		}
+	}
+	if m == nil {
+		return nil
+	}
+
+	// Look for vertices inside the triangle formed by the hole vertex, the
+	// intersection point, and the segment endpoint. If there are any then
+	// choose the one with the minimum angle to the ray.
+	stop := m
+	mx, my := m.x, m.y
+	tanMin := math.Inf(1)
+	for p := m; ; {
+		ax, cx := qx, hx
+		if hy < my {
+			ax, cx = hx, qx
+		}
+		if hx >= p.x && p.x >= mx && hx != p.x && earPointInTriangle(ax, hy, mx, my, cx, hy, p.x, p.y) {
+			tan := math.Abs(hy-p.y) / (hx - p.x)
+			if locallyInside(p, hole) &&
+				(tan < tanMin || (tan == tanMin && (p.x > m.x || (p.x == m.x && sectorContainsSector(m, p))))) {
+				m = p
+				tanMin = tan
+			}
+		}
+		p = p.next
+		if p == stop {
+			break
+		}
+	}
+	return m
+}
+
+// sectorContainsSector returns true if the sector at m contains the sector at
+// p.
+func sectorContainsSector(m, p *earNode) bool {
+	return earArea(m.prev, m, p.prev) < 0 && earArea(p.next, m, m.next) < 0
+}
+
+func leftmostEarNode(start *earNode) *earNode {
+	leftmost := start
+	for p := start.next; p != start; p = p.next {
+		if p.x < leftmost.x || (p.x == leftmost.x && p.y < leftmost.y) {
+			leftmost = p
+		}
+	}
+	return leftmost
+}
+
+// earPointInTriangle returns true if (px, py) lies inside or on the triangle
+// (ax, ay), (bx, by), (cx, cy).
+func earPointInTriangle(ax, ay, bx, by, cx, cy, px, py float64) bool {
+	return (cx-px)*(ay-py) >= (ax-px)*(cy-py) &&
+		(ax-px)*(by-py) >= (bx-px)*(ay-py) &&
+		(bx-px)*(cy-py) >= (cx-px)*(by-py)
+}
+
+// isValidDiagonal returns true if a diagonal from a to b lies inside the
+// polygon and does not intersect any of its edges.
+func isValidDiagonal(a, b *earNode) bool {
+	if a.next.i == b.i || a.prev.i == b.i || intersectsPolygon(a, b) {
+		return false
+	}
+	if locallyInside(a, b) && locallyInside(b, a) && middleInside(a, b) &&
+		(earArea(a.prev, a, b.prev) != 0 || earArea(a, b.prev, b) != 0) {
+		return true
+	}
+	return earEquals(a, b) && earArea(a.prev, a, a.next) > 0 && earArea(b.prev, b, b.next) > 0
+}
+
+// earArea returns twice the signed area of the triangle p, q, r. It is
+// negative if the triangle is counter-clockwise.
+func earArea(p, q, r *earNode) float64 {
+	return (q.y-p.y)*(r.x-q.x) - (q.x-p.x)*(r.y-q.y)
+}
+
+func earEquals(p, q *earNode) bool {
+	return p.x == q.x && p.y == q.y
+}
+
+// earIntersects returns true if the segments p1-q1 and p2-q2 intersect.
+func earIntersects(p1, q1, p2, q2 *earNode) bool {
+	o1 := earSign(earArea(p1, q1, p2))
+	o2 := earSign(earArea(p1, q1, q2))
+	o3 := earSign(earArea(p2, q2, p1))
+	o4 := earSign(earArea(p2, q2, q1))
+	switch {
+	case o1 != o2 && o3 != o4:
+		return true
+	case o1 == 0 && earOnSegment(p1, p2, q1):
+		return true
+	case o2 == 0 && earOnSegment(p1, q2, q1):
+		return true
+	case o3 == 0 && earOnSegment(p2, p1, q2):
+		return true
+	case o4 == 0 && earOnSegment(p2, q1, q2):
+		return true
+	default:
+		return false
+	}
+}
+
+// earOnSegment returns true if q, which must be collinear with p and r, lies
+// on the segment p-r.
+func earOnSegment(p, q, r *earNode) bool {
+	return q.x <= math.Max(p.x, r.x) && q.x >= math.Min(p.x, r.x) &&
+		q.y <= math.Max(p.y, r.y) && q.y >= math.Min(p.y, r.y)
+}
+
+func earSign(x float64) int {
+	switch {
+	case x > 0:
+		return 1
+	case x < 0:
+		return -1
+	default:
+		return 0
+	}
+}
+
+// intersectsPolygon returns true if the diagonal a-b intersects any edge of
+// the list that is not incident to a or b.
+func intersectsPolygon(a, b *earNode) bool {
+	for p := a; ; {
+		if p.i != a.i && p.next.i != a.i && p.i != b.i && p.next.i != b.i && earIntersects(p, p.next, a, b) {
+			return true
+		}
+		p = p.next
+		if p == a {
+			return false
+		}
+	}
+}
+
+// locallyInside returns true if the diagonal a-b lies inside the polygon in
+// the neighborhood of a.
+func locallyInside(a, b *earNode) bool {
+	if earArea(a.prev, a, a.next) < 0 {
+		return earArea(a, b, a.next) >= 0 && earArea(a, a.prev, b) >= 0
+	}
+	return earArea(a, b, a.prev) < 0 || earArea(a, a.next, b) < 0
+}
+
+// middleInside returns true if the midpoint of the diagonal a-b lies inside
+// the polygon.
+func middleInside(a, b *earNode) bool {
+	inside := false
+	px, py := (a.x+b.x)/2, (a.y+b.y)/2
+	for p := a; ; {
+		if (p.y > py) != (p.next.y > py) && p.next.y != p.y &&
+			px < (p.next.x-p.x)*(py-p.y)/(p.next.y-p.y)+p.x {
+			inside = !inside
+		}
+		p = p.next
+		if p == a {
+			return inside
+		}
+	}
+}
+
+// splitEarPolygon links a to b with a diagonal, splitting the list in two. If
+// a and b are in different lists then it merges them with a pair of bridge
+// edges. It returns the copy of b in the second list.
+func splitEarPolygon(a, b *earNode) *earNode {
+	a2 := &earNode{i: a.i, x: a.x, y: a.y}
+	b2 := &earNode{i: b.i, x: b.x, y: b.y}
+	an, bp := a.next, b.prev
+	a.next, b.prev = b, a
+	a2.next, an.prev = an, a2
+	b2.next, a2.prev = a2, b2
+	bp.next, b2.prev = b2, bp
+	return b2
+}
+
+func insertEarNode(i int, x, y float64, last *earNode) *earNode {
+	p := &earNode{i: i, x: x, y: y}
+	if last == nil {
+		p.prev, p.next = p, p
+	} else {
+		p.next, p.prev = last.next, last
+		last.next.prev = p
+		last.next = p
+	}
+	return p
+}
+
+func removeEarNode(p *earNode) {
+	p.next.prev = p.prev
+	p.prev.next = p.next
+}
+
+// earSignedArea returns twice the signed area of the ring in
+// flatCoords[start:end]. It is positive if the ring is counter-clockwise.
+func earSignedArea(flatCoords []float64, start, end, stride int) float64 {
+	sum := 0.0
+	for i, j := start, end-stride; i < end; i += stride {
+		sum += (flatCoords[j] - flatCoords[i]) * (flatCoords[i+1] + flatCoords[j+1])
+		j = i
+	}
+	return sum
+}
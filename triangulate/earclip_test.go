@@ -0,0 +1,124 @@
+package triangulate
+
+import (
+	"math"
+	"math/rand"
+	"strconv"
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+
+	"github.com/don4get/go-geom"
+)
+
+func TestEarClip(t *testing.T) {
+	for _, tc := range []struct {
+		name         string
+		p            *geom.Polygon
+		numTriangles int
+	}{
+		{
+			name: "empty",
+			p:    geom.NewPolygon(geom.XY),
+		},
+		{
+			name:         "triangle",
+			p:            geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{{{0, 0}, {1, 0}, {0, 1}, {0, 0}}}),
+			numTriangles: 1,
+		},
+		{
+			name:         "clockwise_square",
+			p:            geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{{{0, 0}, {0, 1}, {1, 1}, {1, 0}, {0, 0}}}),
+			numTriangles: 2,
+		},
+		{
+			name: "u_shape",
+			p: geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{
+				{{0, 0}, {3, 0}, {3, 3}, {2, 3}, {2, 1}, {1, 1}, {1, 3}, {0, 3}, {0, 0}},
+			}),
+			numTriangles: 6,
+		},
+		{
+			name: "square_with_hole",
+			p: geom.NewPolygon(geom.XYZ).MustSetCoords([][]geom.Coord{
+				{{0, 0, 1}, {4, 0, 2}, {4, 4, 3}, {0, 4, 4}, {0, 0, 1}},
+				{{1, 1, 5}, {1, 3, 6}, {3, 3, 7}, {3, 1, 8}, {1, 1, 5}},
+			}),
+			numTriangles: 8,
+		},
+		{
+			name: "square_with_two_holes",
+			p: geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{
+				{{0, 0}, {6, 0}, {6, 3}, {0, 3}, {0, 0}},
+				{{1, 1}, {1, 2}, {2, 2}, {2, 1}, {1, 1}},
+				{{4, 1}, {5, 1}, {5, 2}, {4, 2}, {4, 1}},
+			}),
+			numTriangles: 12,
+		},
+		{
+			name: "collinear_vertices",
+			p: geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{
+				{{0, 0}, {1, 0}, {2, 0}, {2, 2}, {0, 2}, {0, 0}},
+			}),
+			numTriangles: 3,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			indexes := EarClip(tc.p)
+			assert.Equal(t, 3*tc.numTriangles, len(indexes))
+			assertEarClip(t, tc.p, indexes)
+		})
+	}
+}
+
+func TestEarClipStar(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for _, n := range []int{10, 100, 1000} {
+		t.Run(strconv.Itoa(n), func(t *testing.T) {
+			var coords []geom.Coord
+			for i := range n {
+				theta := 2 * math.Pi * float64(i) / float64(n)
+				radius := 1 + 9*r.Float64()
+				coords = append(coords, geom.Coord{radius * math.Cos(theta), radius * math.Sin(theta)})
+			}
+			coords = append(coords, coords[0])
+			hole := []geom.Coord{{-0.5, -0.5}, {-0.5, 0.5}, {0.5, 0.5}, {0.5, -0.5}, {-0.5, -0.5}}
+			p := geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{coords, hole})
+			indexes := EarClip(p)
+			assert.Equal(t, 3*(n+4), len(indexes))
+			assertEarClip(t, p, indexes)
+		})
+	}
+}
+
+// assertEarClip asserts that the triangles in indexes are counter-clockwise,
+// only reference vertices that do not close a ring, and cover p.
+func assertEarClip(t *testing.T, p *geom.Polygon, indexes []int) {
+	t.Helper()
+	stride := p.GetStride()
+	closing := make(map[int]bool)
+	for _, end := range p.GetEnds() {
+		closing[end/stride-1] = true
+	}
+	flatCoords := p.GetFlatCoords()
+	area := 0.0
+	for i := 0; i < len(indexes); i += 3 {
+		var c [3]geom.Coord
+		for k := range 3 {
+			assert.False(t, closing[indexes[i+k]])
+			c[k] = flatCoords[indexes[i+k]*stride : indexes[i+k]*stride+2]
+		}
+		a := ((c[1][0]-c[0][0])*(c[2][1]-c[0][1]) - (c[2][0]-c[0][0])*(c[1][1]-c[0][1])) / 2
+		assert.True(t, a > 0)
+		area += a
+	}
+	expected := 0.0
+	for i := range p.NumLinearRings() {
+		if i == 0 {
+			expected += math.Abs(p.LinearRing(i).Area())
+		} else {
+			expected -= math.Abs(p.LinearRing(i).Area())
+		}
+	}
+	assert.True(t, math.Abs(expected-area) < 1e-9)
+}
@@ -0,0 +1,330 @@
+package triangulate
+
+import (
+	"sort"
+
+	"github.com/don4get/go-geom"
+	"github.com/don4get/go-geom/bigxy"
+	"github.com/don4get/go-geom/sorting"
+	"github.com/don4get/go-geom/transform"
+	"github.com/don4get/go-geom/xy"
+	"github.com/don4get/go-geom/xy/orientation"
+)
+
+// ghost is the index of the vertex at infinity. Every edge on the convex hull
+// of the mesh is shared with a ghost triangle, which makes the mesh
+// topologically closed and removes special cases from insertion.
+const ghost = -1
+
+// A triangle is a triangle in a mesh. The vertices are stored in
+// counter-clockwise order and, for ghost triangles, the ghost vertex is always
+// stored last. n[i] is the index of the triangle opposite v[i].
+type triangle struct {
+	v    [3]int
+	n    [3]int
+	dead bool
+}
+
+// A mesh is a triangle mesh built incrementally with the Bowyer-Watson
+// algorithm.
+type mesh struct {
+	layout      geom.Layout
+	stride      int
+	coords      []float64
+	tris        []triangle
+	free        []int
+	vt          []int
+	last        int
+	constrained map[edgeKey]bool
+
+	// Scratch space reused between insertions.
+	stamp    []int
+	curStamp int
+	cavity   []int
+	boundary []cavityEdge
+	newEdges map[[2]int]int
+}
+
+// A cavityEdge is an edge on the boundary of the region of triangles that
+// conflict with a new vertex.
+type cavityEdge struct {
+	a, b    int
+	outside int
+}
+
+// newMesh returns a new mesh over the unique xy vertices in flatCoords. The
+// vertices are sorted to improve the locality of insertions.
+func newMesh(layout geom.Layout, flatCoords []float64) *mesh {
+	stride := layout.Stride()
+	coords := transform.UniqueCoords(layout, comparator{}, flatCoords)
+	if len(coords) != 0 {
+		sort.Sort(sorting.NewFlatCoordSorting2D(layout, coords))
+	}
+	m := &mesh{
+		layout:      layout,
+		stride:      stride,
+		coords:      coords,
+		constrained: make(map[edgeKey]bool),
+		newEdges:    make(map[[2]int]int),
+	}
+	m.vt = make([]int, m.numVertices())
+	for i := range m.vt {
+		m.vt[i] = -1
+	}
+	return m
+}
+
+// newMeshFromGeom returns a new mesh over the vertices of g.
+func newMeshFromGeom(g geom.T) (*mesh, error) {
+	layout, flatCoords, err := collectVertices(g)
+	if err != nil {
+		return nil, err
+	}
+	if len(flatCoords) > 0 && layout.Stride() < 2 {
+		return nil, geom.ErrUnsupportedLayout(layout)
+	}
+	return newMesh(layout, flatCoords), nil
+}
+
+func (m *mesh) numVertices() int {
+	if m.stride == 0 {
+		return 0
+	}
+	return len(m.coords) / m.stride
+}
+
+// xy returns the x and y ordinates of vertex i.
+func (m *mesh) xy(i int) geom.Coord {
+	return m.coords[i*m.stride : i*m.stride+2]
+}
+
+// orient returns the orientation of vertex c relative to the vector from vertex
+// a to vertex b.
+func (m *mesh) orient(a, b, c int) orientation.Type {
+	return xy.OrientationIndex(m.xy(a), m.xy(b), m.xy(c))
+}
+
+// between returns true if vertex p, which must be collinear with vertices a and
+// b, lies strictly between them.
+func (m *mesh) between(a, b, p int) bool {
+	ca, cb, cp := m.xy(a), m.xy(b), m.xy(p)
+	dim := 0
+	if ca[0] == cb[0] {
+		dim = 1
+	}
+	return (ca[dim] < cp[dim] && cp[dim] < cb[dim]) || (cb[dim] < cp[dim] && cp[dim] < ca[dim])
+}
+
+// build triangulates all the vertices of m.
+func (m *mesh) build() {
+	n := m.numVertices()
+	if n < 3 {
+		return
+	}
+	k := 2
+	for k < n && m.orient(0, 1, k) == orientation.Collinear {
+		k++
+	}
+	if k == n {
+		return
+	}
+	a, b := 0, 1
+	if m.orient(a, b, k) == orientation.Clockwise {
+		a, b = b, a
+	}
+	t := m.newTriangle(a, b, k)
+	g0 := m.newTriangle(b, a, ghost)
+	g1 := m.newTriangle(k, b, ghost)
+	g2 := m.newTriangle(a, k, ghost)
+	m.tris[t].n = [3]int{g1, g2, g0}
+	m.tris[g0].n = [3]int{g2, g1, t}
+	m.tris[g1].n = [3]int{g0, g2, t}
+	m.tris[g2].n = [3]int{g1, g0, t}
+	m.vt[a], m.vt[b], m.vt[k] = t, t, t
+	m.last = t
+	for i := 2; i < n; i++ {
+		if i != k {
+			m.insert(i)
+		}
+	}
+}
+
+// newTriangle allocates a new triangle with vertices a, b, and c, rotating them
+// so that any ghost vertex is last.
+func (m *mesh) newTriangle(a, b, c int) int {
+	switch ghost {
+	case a:
+		a, b, c = b, c, a
+	case b:
+		a, b, c = c, a, b
+	}
+	tri := triangle{v: [3]int{a, b, c}, n: [3]int{-1, -1, -1}}
+	if len(m.free) > 0 {
+		t := m.free[len(m.free)-1]
+		m.free = m.free[:len(m.free)-1]
+		m.tris[t] = tri
+		return t
+	}
+	m.tris = append(m.tris, tri)
+	m.stamp = append(m.stamp, 0)
+	return len(m.tris) - 1
+}
+
+func (m *mesh) isGhost(t int) bool {
+	return m.tris[t].v[2] == ghost
+}
+
+// inConflict returns true if inserting vertex p destroys triangle t, i.e. if p
+// lies strictly inside t's circumcircle. A ghost triangle is in conflict if p
+// lies outside its hull edge, or on the interior of the hull edge.
+func (m *mesh) inConflict(t, p int) bool {
+	v := m.tris[t].v
+	if v[2] == ghost {
+		switch m.orient(v[0], v[1], p) {
+		case orientation.CounterClockwise:
+			return true
+		case orientation.Collinear:
+			return m.between(v[0], v[1], p)
+		default:
+			return false
+		}
+	}
+	return bigxy.IsInCircle(m.xy(v[0]), m.xy(v[1]), m.xy(v[2]), m.xy(p))
+}
+
+// locate returns a triangle that is in conflict with vertex p, by walking from
+// the most recently created triangle.
+func (m *mesh) locate(p int) int {
+	t := m.last
+	if m.isGhost(t) {
+		t = m.tris[t].n[2]
+	}
+	for offset := 0; ; offset++ {
+		if m.isGhost(t) {
+			return t
+		}
+		tri := &m.tris[t]
+		next := -1
+		for i := range 3 {
+			k := (i + offset) % 3
+			if m.orient(tri.v[(k+1)%3], tri.v[(k+2)%3], p) == orientation.Clockwise {
+				next = tri.n[k]
+				break
+			}
+		}
+		if next == -1 {
+			return t
+		}
+		t = next
+	}
+}
+
+// insert inserts vertex p into m.
+func (m *mesh) insert(p int) {
+	m.curStamp++
+	start := m.locate(p)
+	m.cavity = append(m.cavity[:0], start)
+	m.boundary = m.boundary[:0]
+	m.stamp[start] = m.curStamp
+	for i := 0; i < len(m.cavity); i++ {
+		t := m.cavity[i]
+		for k := range 3 {
+			nb := m.tris[t].n[k]
+			if m.stamp[nb] == m.curStamp {
+				continue
+			}
+			if m.inConflict(nb, p) {
+				m.stamp[nb] = m.curStamp
+				m.cavity = append(m.cavity, nb)
+				continue
+			}
+			v := m.tris[t].v
+			m.boundary = append(m.boundary, cavityEdge{
+				a:       v[(k+1)%3],
+				b:       v[(k+2)%3],
+				outside: nb,
+			})
+		}
+	}
+	m.retriangulate(p)
+}
+
+// retriangulate replaces the triangles in the cavity with a fan of triangles
+// connecting p to every edge on the cavity's boundary.
+func (m *mesh) retriangulate(p int) {
+	for _, t := range m.cavity {
+		m.tris[t].dead = true
+		m.free = append(m.free, t)
+	}
+	clear(m.newEdges)
+	first := len(m.cavity)
+	for _, e := range m.boundary {
+		t := m.newTriangle(e.a, e.b, p)
+		tri := &m.tris[t]
+		for k := range 3 {
+			a, b := tri.v[(k+1)%3], tri.v[(k+2)%3]
+			if a == e.a && b == e.b {
+				tri.n[k] = e.outside
+			} else {
+				m.newEdges[[2]int{a, b}] = t
+			}
+		}
+		m.setNeighbor(e.outside, e.b, e.a, t)
+		for _, v := range tri.v {
+			if v != ghost {
+				m.vt[v] = t
+			}
+		}
+		if tri.v[2] != ghost {
+			m.last = t
+		}
+		m.cavity = append(m.cavity, t)
+	}
+	for _, t := range m.cavity[first:] {
+		tri := &m.tris[t]
+		for k := range 3 {
+			if tri.n[k] == -1 {
+				tri.n[k] = m.newEdges[[2]int{tri.v[(k+2)%3], tri.v[(k+1)%3]}]
+			}
+		}
+	}
+}
+
+// setNeighbor sets the neighbor of triangle t across its edge from a to b to
+// nb.
+func (m *mesh) setNeighbor(t, a, b, nb int) {
+	tri := &m.tris[t]
+	for k := range 3 {
+		if tri.v[(k+1)%3] == a && tri.v[(k+2)%3] == b {
+			tri.n[k] = nb
+			return
+		}
+	}
+}
+
+// triangulation returns the live, non-ghost triangles of m.
+func (m *mesh) triangulation() *Triangulation {
+	t := &Triangulation{
+		layout: m.layout,
+		stride: m.stride,
+		sites:  m.coords,
+	}
+	for i := range m.tris {
+		tri := &m.tris[i]
+		if tri.dead || tri.v[2] == ghost {
+			continue
+		}
+		t.triangles = append(t.triangles, tri.v[0], tri.v[1], tri.v[2])
+	}
+	return t
+}
+
+type comparator struct{}
+
+func (comparator) IsEquals(x, y geom.Coord) bool {
+	return x[0] == y[0] && x[1] == y[1]
+}
+
+func (comparator) IsLess(x, y geom.Coord) bool {
+	return sorting.IsLess2D(x, y)
+}
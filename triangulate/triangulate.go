@@ -0,0 +1,153 @@
+// Package triangulate computes Delaunay triangulations, constrained Delaunay
+// triangulations, ear clipping triangulations of polygons, Voronoi diagrams,
+// concave hulls, and alpha shapes of planar (xy) geometries.
+//
+// Only the x and y ordinates are used to build the triangulations. Any other
+// ordinates (e.g. Z values of sensor readings) are carried through unchanged on
+// the vertices of the resulting geometries, unless the input is a
+// GeometryCollection whose geometries have different layouts, in which case the
+// results have the XY layout.
+package triangulate
+
+import (
+	"github.com/don4get/go-geom"
+)
+
+// A Triangulation is a set of triangles over a set of unique sites.
+type Triangulation struct {
+	layout    geom.Layout
+	stride    int
+	sites     []float64
+	triangles []int
+}
+
+// Layout returns the layout of t's sites.
+func (t *Triangulation) Layout() geom.Layout {
+	return t.layout
+}
+
+// NumSites returns the number of unique sites in t.
+func (t *Triangulation) NumSites() int {
+	if t.stride == 0 {
+		return 0
+	}
+	return len(t.sites) / t.stride
+}
+
+// Site returns the ith site of t.
+func (t *Triangulation) Site(i int) geom.Coord {
+	return t.sites[i*t.stride : (i+1)*t.stride]
+}
+
+// FlatSites returns the flat coordinates of all the sites in t.
+func (t *Triangulation) FlatSites() []float64 {
+	return t.sites
+}
+
+// NumTriangles returns the number of triangles in t.
+func (t *Triangulation) NumTriangles() int {
+	return len(t.triangles) / 3
+}
+
+// Triangles returns the indexes of the sites of each triangle, three per
+// triangle. The sites of each triangle are in counter-clockwise order.
+func (t *Triangulation) Triangles() []int {
+	return t.triangles
+}
+
+// MultiPolygon returns the triangles of t as a MultiPolygon. Each triangle is
+// a counter-clockwise ring of four coordinates.
+func (t *Triangulation) MultiPolygon() *geom.MultiPolygon {
+	flatCoords := make([]float64, 0, 4*t.stride*len(t.triangles)/3)
+	endss := make([][]int, 0, len(t.triangles)/3)
+	for i := 0; i < len(t.triangles); i += 3 {
+		for _, j := range []int{t.triangles[i], t.triangles[i+1], t.triangles[i+2], t.triangles[i]} {
+			flatCoords = append(flatCoords, t.Site(j)...)
+		}
+		endss = append(endss, []int{len(flatCoords)})
+	}
+	return geom.NewMultiPolygonFlat(t.layout, flatCoords, endss)
+}
+
+// Edges returns the unique edges of t as a MultiLineString.
+func (t *Triangulation) Edges() *geom.MultiLineString {
+	seen := make(map[edgeKey]struct{}, len(t.triangles))
+	var flatCoords []float64
+	var ends []int
+	for i := 0; i < len(t.triangles); i += 3 {
+		for k := range 3 {
+			a, b := t.triangles[i+k], t.triangles[i+(k+1)%3]
+			key := makeEdgeKey(a, b)
+			if _, ok := seen[key]; ok {
+				continue
+			}
+			seen[key] = struct{}{}
+			flatCoords = append(flatCoords, t.Site(a)...)
+			flatCoords = append(flatCoords, t.Site(b)...)
+			ends = append(ends, len(flatCoords))
+		}
+	}
+	return geom.NewMultiLineStringFlat(t.layout, flatCoords, ends)
+}
+
+// An edgeKey identifies an undirected edge between two sites.
+type edgeKey [2]int
+
+func makeEdgeKey(a, b int) edgeKey {
+	if a > b {
+		a, b = b, a
+	}
+	return edgeKey{a, b}
+}
+
+// collectVertices returns the layout and flat coordinates of all vertices in
+// g, descending into GeometryCollections. If the geometries in a
+// GeometryCollection have different layouts then only their x and y ordinates
+// are returned. An empty GeometryCollection has the XY layout.
+func collectVertices(g geom.T) (geom.Layout, []float64, error) {
+	gc, ok := g.(*geom.GeometryCollection)
+	if !ok {
+		return g.GetLayout(), g.GetFlatCoords(), nil
+	}
+	var layouts []geom.Layout
+	var flatCoordss [][]float64
+	for _, child := range gc.Geoms() {
+		childLayout, childFlatCoords, err := collectVertices(child)
+		if err != nil {
+			return geom.NoLayout, nil, err
+		}
+		if len(childFlatCoords) == 0 {
+			continue
+		}
+		layouts = append(layouts, childLayout)
+		flatCoordss = append(flatCoordss, childFlatCoords)
+	}
+	if len(layouts) == 0 {
+		if layout := gc.GetLayout(); layout != geom.NoLayout {
+			return layout, nil, nil
+		}
+		return geom.XY, nil, nil
+	}
+	layout := layouts[0]
+	for _, childLayout := range layouts[1:] {
+		if childLayout != layout {
+			layout = geom.XY
+			break
+		}
+	}
+	var flatCoords []float64
+	for i, childFlatCoords := range flatCoordss {
+		if layouts[i] == layout {
+			flatCoords = append(flatCoords, childFlatCoords...)
+			continue
+		}
+		stride := layouts[i].Stride()
+		if stride < 2 {
+			return geom.NoLayout, nil, geom.ErrUnsupportedLayout(layouts[i])
+		}
+		for j := 0; j < len(childFlatCoords); j += stride {
+			flatCoords = append(flatCoords, childFlatCoords[j], childFlatCoords[j+1])
+		}
+	}
+	return layout, flatCoords, nil
+}
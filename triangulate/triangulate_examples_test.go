@@ -0,0 +1,69 @@
+package triangulate_test
+
+import (
+	"fmt"
+
+	"github.com/don4get/go-geom"
+	"github.com/don4get/go-geom/triangulate"
+)
+
+func ExampleDelaunay() {
+	points := geom.NewMultiPointFlat(geom.XY, []float64{0, 0, 1, 0, 0, 1, 1, 1, 0.5, 0.5})
+
+	t, err := triangulate.Delaunay(points)
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println(t.NumSites(), t.NumTriangles(), t.MultiPolygon().Area())
+	// Output: 5 4 1
+}
+
+func ExampleConstrainedDelaunay() {
+	polygon := geom.NewPolygonFlat(geom.XY, []float64{0, 0, 2, 0, 2, 1, 1, 1, 1, 2, 0, 2, 0, 0}, []int{14})
+
+	t, err := triangulate.ConstrainedDelaunay(polygon)
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println(t.NumTriangles(), t.MultiPolygon().Area())
+	// Output: 4 3
+}
+
+func ExampleVoronoi() {
+	points := geom.NewMultiPointFlat(geom.XY, []float64{1, 1, 3, 1})
+
+	cells, err := triangulate.Voronoi(points, geom.NewBounds(geom.XY).Set(0, 0, 4, 2))
+	if err != nil {
+		panic(err)
+	}
+
+	for i := range cells.NumPolygons() {
+		fmt.Println(cells.Polygon(i).Area())
+	}
+	// Output:
+	// 4
+	// 4
+}
+
+func ExampleEarClip() {
+	polygon := geom.NewPolygonFlat(geom.XY, []float64{0, 0, 2, 0, 2, 2, 0, 2, 0, 0}, []int{10})
+
+	indexes := triangulate.EarClip(polygon)
+
+	fmt.Println(indexes)
+	// Output: [2 3 0 0 1 2]
+}
+
+func ExampleConcaveHull() {
+	points := geom.NewMultiPointFlat(geom.XY, []float64{0, 0, 1, 0, 2, 0, 0, 1, 0, 2, 1, 2, 2, 2, 1, 1})
+
+	hull, err := triangulate.ConcaveHull(points, 0, false)
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println(hull.(*geom.Polygon).Area())
+	// Output: 3
+}
@@ -0,0 +1,147 @@
+package triangulate
+
+import (
+	"math"
+
+	"github.com/don4get/go-geom"
+)
+
+// Voronoi returns the Voronoi diagram of the vertices of g clipped to
+// envelope. The result contains one cell for each unique vertex of g, in the
+// same order as the sites of the Delaunay triangulation of g. Cells that lie
+// entirely outside envelope are empty. If envelope is nil then the bounds of g,
+// expanded by half their size, are used.
+func Voronoi(g geom.T, envelope *geom.Bounds) (*geom.MultiPolygon, error) {
+	m, err := newMeshFromGeom(g)
+	if err != nil {
+		return nil, err
+	}
+	n := m.numVertices()
+	cells := geom.NewMultiPolygon(geom.XY)
+	if n == 0 {
+		return cells, nil
+	}
+
+	sitesMinX, sitesMinY := math.Inf(1), math.Inf(1)
+	sitesMaxX, sitesMaxY := math.Inf(-1), math.Inf(-1)
+	for i := range n {
+		c := m.xy(i)
+		sitesMinX, sitesMinY = math.Min(sitesMinX, c[0]), math.Min(sitesMinY, c[1])
+		sitesMaxX, sitesMaxY = math.Max(sitesMaxX, c[0]), math.Max(sitesMaxY, c[1])
+	}
+	var minX, minY, maxX, maxY float64
+	if envelope == nil {
+		size := math.Max(sitesMaxX-sitesMinX, sitesMaxY-sitesMinY)
+		if size == 0 {
+			size = 1
+		}
+		minX, minY = sitesMinX-size/2, sitesMinY-size/2
+		maxX, maxY = sitesMaxX+size/2, sitesMaxY+size/2
+	} else {
+		minX, minY, maxX, maxY = envelope.Min(0), envelope.Min(1), envelope.Max(0), envelope.Max(1)
+	}
+
+	// Surround the sites with a frame of distant vertices so that every site
+	// is interior to the triangulation and has a bounded cell. The frame is far
+	// enough away that it does not affect the cells inside the envelope.
+	frameMinX, frameMinY := math.Min(minX, sitesMinX), math.Min(minY, sitesMinY)
+	frameMaxX, frameMaxY := math.Max(maxX, sitesMaxX), math.Max(maxY, sitesMaxY)
+	cx, cy := (frameMinX+frameMaxX)/2, (frameMinY+frameMaxY)/2
+	r := 10 * math.Max(math.Max(frameMaxX-frameMinX, frameMaxY-frameMinY), 1)
+	for _, c := range [][2]float64{{cx - r, cy - r}, {cx + r, cy - r}, {cx + r, cy + r}, {cx - r, cy + r}} {
+		coord := make([]float64, m.stride)
+		coord[0], coord[1] = c[0], c[1]
+		m.coords = append(m.coords, coord...)
+		m.vt = append(m.vt, -1)
+	}
+	m.build()
+
+	var ring []float64
+	for i := range n {
+		ring = ring[:0]
+		start := m.vt[i]
+		t := start
+		for {
+			tri := &m.tris[t]
+			ccx, ccy := circumcenter(m.xy(tri.v[0]), m.xy(tri.v[1]), m.xy(tri.v[2]))
+			if k := len(ring); k == 0 || ring[k-2] != ccx || ring[k-1] != ccy {
+				ring = append(ring, ccx, ccy)
+			}
+			t = tri.n[(vertexIndex(tri, i)+1)%3]
+			if t == start {
+				break
+			}
+		}
+		if k := len(ring); k > 2 && ring[0] == ring[k-2] && ring[1] == ring[k-1] {
+			ring = ring[:k-2]
+		}
+		flatCoords := clipToRectangle(ring, minX, minY, maxX, maxY)
+		var ends []int
+		if len(flatCoords) > 0 {
+			flatCoords = append(flatCoords, flatCoords[0], flatCoords[1])
+			ends = []int{len(flatCoords)}
+		}
+		if err := cells.Push(geom.NewPolygonFlat(geom.XY, flatCoords, ends)); err != nil {
+			return nil, err
+		}
+	}
+	return cells, nil
+}
+
+// circumcenter returns the center of the circle passing through a, b, and c.
+func circumcenter(a, b, c geom.Coord) (float64, float64) {
+	bx, by := b[0]-a[0], b[1]-a[1]
+	cx, cy := c[0]-a[0], c[1]-a[1]
+	d := 2 * (bx*cy - by*cx)
+	b2, c2 := bx*bx+by*by, cx*cx+cy*cy
+	return a[0] + (cy*b2-by*c2)/d, a[1] + (bx*c2-cx*b2)/d
+}
+
+// clipToRectangle clips the open, convex xy ring in flatCoords to the
+// rectangle with the given bounds using the Sutherland-Hodgman algorithm.
+func clipToRectangle(flatCoords []float64, minX, minY, maxX, maxY float64) []float64 {
+	for _, edge := range []struct {
+		dim     int
+		value   float64
+		keepMin bool
+	}{
+		{dim: 0, value: minX, keepMin: false},
+		{dim: 0, value: maxX, keepMin: true},
+		{dim: 1, value: minY, keepMin: false},
+		{dim: 1, value: maxY, keepMin: true},
+	} {
+		inside := func(i int) bool {
+			if edge.keepMin {
+				return flatCoords[i+edge.dim] <= edge.value
+			}
+			return flatCoords[i+edge.dim] >= edge.value
+		}
+		var clipped []float64
+		n := len(flatCoords)
+		for i := 0; i < n; i += 2 {
+			j := (i + n - 2) % n
+			if inside(i) {
+				if !inside(j) {
+					clipped = append(clipped, intersect(flatCoords[j:j+2], flatCoords[i:i+2], edge.dim, edge.value)...)
+				}
+				clipped = append(clipped, flatCoords[i], flatCoords[i+1])
+			} else if inside(j) {
+				clipped = append(clipped, intersect(flatCoords[j:j+2], flatCoords[i:i+2], edge.dim, edge.value)...)
+			}
+		}
+		flatCoords = clipped
+		if len(flatCoords) == 0 {
+			return nil
+		}
+	}
+	return flatCoords
+}
+
+// intersect returns the point on the segment from a to b where dimension dim
+// equals value.
+func intersect(a, b []float64, dim int, value float64) []float64 {
+	t := (value - a[dim]) / (b[dim] - a[dim])
+	c := []float64{a[0] + t*(b[0]-a[0]), a[1] + t*(b[1]-a[1])}
+	c[dim] = value
+	return c
+}
@@ -0,0 +1,106 @@
+package triangulate
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+
+	"github.com/don4get/go-geom"
+	"github.com/don4get/go-geom/xy"
+)
+
+func TestVoronoi(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		g        geom.T
+		envelope *geom.Bounds
+		expected *geom.MultiPolygon
+	}{
+		{
+			name:     "empty",
+			g:        geom.NewMultiPoint(geom.XY),
+			expected: geom.NewMultiPolygon(geom.XY),
+		},
+		{
+			name:     "single",
+			g:        geom.NewPointFlat(geom.XY, []float64{1, 1}),
+			envelope: geom.NewBounds(geom.XY).Set(0, 0, 2, 2),
+			expected: geom.NewMultiPolygon(geom.XY).MustSetCoords([][][]geom.Coord{
+				{{{0, 0}, {2, 0}, {2, 2}, {0, 2}, {0, 0}}},
+			}),
+		},
+		{
+			name:     "pair",
+			g:        geom.NewMultiPointFlat(geom.XY, []float64{1, 1, 3, 1}),
+			envelope: geom.NewBounds(geom.XY).Set(0, 0, 4, 2),
+			expected: geom.NewMultiPolygon(geom.XY).MustSetCoords([][][]geom.Coord{
+				{{{0, 0}, {2, 0}, {2, 2}, {0, 2}, {0, 0}}},
+				{{{2, 0}, {4, 0}, {4, 2}, {2, 2}, {2, 0}}},
+			}),
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			cells, err := Voronoi(tc.g, tc.envelope)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected.NumPolygons(), cells.NumPolygons())
+			for i := range tc.expected.NumPolygons() {
+				assertSameRing(t, tc.expected.Polygon(i).GetFlatCoords(), cells.Polygon(i).GetFlatCoords())
+			}
+		})
+	}
+}
+
+func TestVoronoiRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(3))
+	n := 200
+	flatCoords := make([]float64, 0, 2*n)
+	for range n {
+		flatCoords = append(flatCoords, r.Float64(), r.Float64())
+	}
+	mp := geom.NewMultiPointFlat(geom.XY, flatCoords)
+	envelope := geom.NewBounds(geom.XY).Set(-1, -1, 2, 2)
+	cells, err := Voronoi(mp, envelope)
+	assert.NoError(t, err)
+	tri, err := Delaunay(mp)
+	assert.NoError(t, err)
+	assert.Equal(t, n, cells.NumPolygons())
+
+	// The cells tile the envelope and each contains its own site.
+	assert.True(t, math.Abs(9-math.Abs(cells.Area())) < 1e-9)
+	for i := range n {
+		assert.True(t, xy.IsPointInRing(geom.XY, tri.Site(i), cells.Polygon(i).GetFlatCoords()))
+	}
+}
+
+func TestVoronoiGrid(t *testing.T) {
+	cells, err := Voronoi(grid(3, 3), geom.NewBounds(geom.XY).Set(-0.5, -0.5, 2.5, 2.5))
+	assert.NoError(t, err)
+	assert.Equal(t, 9, cells.NumPolygons())
+	for i := range 9 {
+		assert.Equal(t, 10, len(cells.Polygon(i).GetFlatCoords()))
+		assert.True(t, math.Abs(1-math.Abs(cells.Polygon(i).Area())) < 1e-9)
+	}
+}
+
+// assertSameRing asserts that two closed rings have the same vertices in the
+// same cyclic order, possibly starting at a different vertex.
+func assertSameRing(t *testing.T, expected, actual []float64) {
+	t.Helper()
+	assert.Equal(t, len(expected), len(actual))
+	n := len(expected) - 2
+	for offset := 0; offset < n; offset += 2 {
+		same := true
+		for i := 0; i < n; i++ {
+			if math.Abs(expected[i]-actual[(i+offset)%n]) > 1e-9 {
+				same = false
+				break
+			}
+		}
+		if same {
+			return
+		}
+	}
+	t.Errorf("expected ring %v, got %v", expected, actual)
+}
@@ -0,0 +1,549 @@
+package xy
+
+import (
+	"math"
+
+	"github.com/don4get/go-geom"
+)
+
+// ClipToBounds clips the geometry to the xy extent of the bounds, which is much cheaper than
+// a general overlay.
+//
+// Lines are clipped with the Liang-Barsky algorithm. A LineString or LinearRing that leaves
+// and re-enters the bounds is split, so the result is a LineString if a single part remains
+// and a MultiLineString otherwise. Polygon rings are cut into the pieces inside the bounds,
+// which are joined along the edges of the bounds into valid rings, so a concave Polygon
+// that leaves and re-enters the bounds becomes a MultiPolygon, and a hole that crosses the
+// edge of the bounds becomes part of the exterior ring. A Polygon that does not overlap the
+// bounds becomes empty. Triangles become Polygons, TINs become MultiPolygons, and curved
+// geometries are linearized first. Points and MultiPoints keep only the points inside the bounds, and
+// the parts of collections that become empty are dropped.
+//
+// Ordinates other than x and y, such as Z and M, are interpolated at the new vertices. The
+// layout and SRID of the geometry are preserved.
+func ClipToBounds(g geom.T, b *geom.Bounds) (geom.T, error) {
+	c := clipper{
+		minX: b.Min(0),
+		minY: b.Min(1),
+		maxX: b.Max(0),
+		maxY: b.Max(1),
+	}
+	return c.clip(g)
+}
+
+// clipper clips geometries to a rectangle.
+type clipper struct {
+	minX, minY, maxX, maxY float64
+}
+
+func (c clipper) clip(g geom.T) (geom.T, error) {
+	layout, stride := g.GetLayout(), g.GetStride()
+	switch g := g.(type) {
+	case *geom.Point:
+		if g.IsEmpty() || !c.contains(g.FlatCoords) {
+			return geom.NewPointEmpty(layout).SetSRID(g.Srid), nil
+		}
+		return g.Clone(), nil
+	case *geom.MultiPoint:
+		mp := geom.NewMultiPoint(layout).SetSRID(g.Srid)
+		for i := range g.NumPoints() {
+			if p := g.Point(i); !p.IsEmpty() && c.contains(p.FlatCoords) {
+				if err := mp.Push(p); err != nil {
+					return nil, err
+				}
+			}
+		}
+		return mp, nil
+	case *geom.LineString, *geom.LinearRing:
+		flatCoords, ends := c.clipLine(nil, nil, g.GetFlatCoords(), stride)
+		if len(ends) <= 1 {
+			return geom.NewLineStringFlat(layout, flatCoords).SetSRID(g.GetSRID()), nil
+		}
+		return geom.NewMultiLineStringFlat(layout, flatCoords, ends).SetSRID(g.GetSRID()), nil
+	case *geom.MultiLineString:
+		var flatCoords []float64
+		var ends []int
+		for i := range g.NumLineStrings() {
+			flatCoords, ends = c.clipLine(flatCoords, ends, g.LineString(i).FlatCoords, stride)
+		}
+		return geom.NewMultiLineStringFlat(layout, flatCoords, ends).SetSRID(g.Srid), nil
+	case *geom.Polygon:
+		return c.clipPolygonOrMultiPolygon(layout, g.FlatCoords, g.Ends, g.Srid), nil
+	case *geom.Triangle:
+		return c.clipPolygonOrMultiPolygon(layout, g.FlatCoords, g.Ends, g.Srid), nil
+	case *geom.MultiPolygon:
+		flatCoords, endss := c.clipPolygons(g.FlatCoords, g.Endss, layout)
+		return geom.NewMultiPolygonFlat(layout, flatCoords, endss).SetSRID(g.Srid), nil
+	case *geom.TIN:
+		flatCoords, endss := c.clipPolygons(g.FlatCoords, g.Endss, layout)
+		return geom.NewMultiPolygonFlat(layout, flatCoords, endss).SetSRID(g.Srid), nil
+	case *geom.PolyhedralSurface:
+		flatCoords, endss := c.clipPolygons(g.FlatCoords, g.Endss, layout)
+		return geom.NewPolyhedralSurfaceFlat(layout, flatCoords, endss).SetSRID(g.Srid), nil
+	case *geom.GeometryCollection:
+		gc := geom.NewGeometryCollection().SetSRID(g.GetSRID())
+		for _, child := range g.Geoms() {
+			clipped, err := c.clip(child)
+			if err != nil {
+				return nil, err
+			}
+			if !clipped.IsEmpty() {
+				if err := gc.Push(clipped); err != nil {
+					return nil, err
+				}
+			}
+		}
+		return collectionLayout(gc, layout), nil
+	case *geom.CircularString, *geom.CompoundCurve, *geom.CurvePolygon, *geom.MultiCurve, *geom.MultiSurface:
+		linear, err := geom.Linearize(g, linearizeSegmentsPerQuadrant)
+		if err != nil {
+			return nil, err
+		}
+		return c.clip(linear)
+	default:
+		return nil, geom.ErrUnsupportedType{Value: g}
+	}
+}
+
+// contains returns whether the coordinate at the start of flatCoords is inside the rectangle.
+func (c clipper) contains(flatCoords []float64) bool {
+	x, y := flatCoords[0], flatCoords[1]
+	return c.minX <= x && x <= c.maxX && c.minY <= y && y <= c.maxY
+}
+
+// clipLine appends the parts of the line in lineFlatCoords that are inside the rectangle to
+// flatCoords and their ends to ends.
+func (c clipper) clipLine(flatCoords []float64, ends []int, lineFlatCoords []float64, stride int) ([]float64, []int) {
+	partStart := len(flatCoords)
+	endPart := func() {
+		if len(flatCoords)-partStart >= 2*stride {
+			ends = append(ends, len(flatCoords))
+		} else {
+			flatCoords = flatCoords[:partStart]
+		}
+		partStart = len(flatCoords)
+	}
+	for i := stride; i < len(lineFlatCoords); i += stride {
+		a, b := lineFlatCoords[i-stride:i], lineFlatCoords[i:i+stride]
+		t0, t1, ok := c.clipSegment(a, b)
+		if !ok {
+			endPart()
+			continue
+		}
+		if t0 > 0 {
+			endPart()
+		}
+		if len(flatCoords) == partStart {
+			flatCoords = appendInterpolated(flatCoords, a, b, t0)
+		}
+		if t1 > t0 {
+			flatCoords = appendInterpolated(flatCoords, a, b, t1)
+		}
+		if t1 < 1 {
+			endPart()
+		}
+	}
+	endPart()
+	return flatCoords, ends
+}
+
+// clipSegment returns the parameters of the start and end of the part of the segment from
+// a to b that is inside the rectangle, using the Liang-Barsky algorithm.
+func (c clipper) clipSegment(a, b []float64) (float64, float64, bool) {
+	t0, t1 := 0.0, 1.0
+	dx, dy := b[0]-a[0], b[1]-a[1]
+	for _, pq := range [4][2]float64{
+		{-dx, a[0] - c.minX},
+		{dx, c.maxX - a[0]},
+		{-dy, a[1] - c.minY},
+		{dy, c.maxY - a[1]},
+	} {
+		p, q := pq[0], pq[1]
+		switch {
+		case p == 0:
+			if q < 0 {
+				return 0, 0, false
+			}
+		case p < 0:
+			t := q / p
+			if t > t1 {
+				return 0, 0, false
+			}
+			t0 = max(t0, t)
+		default:
+			t := q / p
+			if t < t0 {
+				return 0, 0, false
+			}
+			t1 = min(t1, t)
+		}
+	}
+	return t0, t1, true
+}
+
+// clipPolygons clips each of the polygons in flatCoords and endss, dropping the polygons
+// that become empty.
+func (c clipper) clipPolygons(flatCoords []float64, endss [][]int, layout geom.Layout) ([]float64, [][]int) {
+	var clippedFlatCoords []float64
+	var clippedEndss [][]int
+	offset := 0
+	for _, ends := range endss {
+		if len(ends) == 0 {
+			continue
+		}
+		clippedFlatCoords, clippedEndss = c.clipPolygon(clippedFlatCoords, clippedEndss, flatCoords[offset:ends[len(ends)-1]], relativeEnds(ends, offset), layout)
+		offset = ends[len(ends)-1]
+	}
+	return clippedFlatCoords, clippedEndss
+}
+
+// relativeEnds returns ends relative to offset.
+func relativeEnds(ends []int, offset int) []int {
+	relative := make([]int, len(ends))
+	for i, end := range ends {
+		relative[i] = end - offset
+	}
+	return relative
+}
+
+// clipPolygonOrMultiPolygon clips the polygon in flatCoords and ends and returns a Polygon
+// if at most one polygon remains and a MultiPolygon otherwise.
+func (c clipper) clipPolygonOrMultiPolygon(layout geom.Layout, flatCoords []float64, ends []int, srid int) geom.T {
+	clippedFlatCoords, clippedEndss := c.clipPolygon(nil, nil, flatCoords, ends, layout)
+	switch len(clippedEndss) {
+	case 0:
+		return geom.NewPolygon(layout).SetSRID(srid)
+	case 1:
+		return geom.NewPolygonFlat(layout, clippedFlatCoords, clippedEndss[0]).SetSRID(srid)
+	default:
+		return geom.NewMultiPolygonFlat(layout, clippedFlatCoords, clippedEndss).SetSRID(srid)
+	}
+}
+
+// clipPolygon appends the polygons that remain of the polygon in polygonFlatCoords and
+// polygonEnds, clipped to the rectangle, to flatCoords and their ends to endss.
+//
+// Each ring is oriented so that the interior of the polygon is on its left and is cut into
+// the pieces that are inside the rectangle, which start and end on its boundary. The pieces
+// of all rings are joined into new exterior rings by following the boundary of the
+// rectangle counter-clockwise from the end of each piece to the start of the next. Rings that
+// are entirely inside the rectangle are kept, and holes are assigned to the exterior ring
+// that contains them. The new exterior rings have the orientation of the input exterior
+// ring, and the rings that are kept keep their orientation.
+func (c clipper) clipPolygon(flatCoords []float64, endss [][]int, polygonFlatCoords []float64, polygonEnds []int, layout geom.Layout) ([]float64, [][]int) {
+	if len(polygonEnds) == 0 || c.maxX <= c.minX || c.maxY <= c.minY {
+		return flatCoords, endss
+	}
+	stride := layout.Stride()
+	var pieces []clipPiece
+	var insideShell []float64
+	var insideHoles [][]float64
+	outsideHoleContainsRect := false
+	center := geom.Coord{(c.minX + c.maxX) / 2, (c.minY + c.maxY) / 2}
+	exteriorContainsRect, reversed := false, false
+	offset := 0
+	for i, end := range polygonEnds {
+		ring := polygonFlatCoords[offset:end]
+		offset = end
+		if len(ring) < 4*stride {
+			if i == 0 {
+				return flatCoords, endss
+			}
+			continue
+		}
+		// SignedArea is positive for clockwise rings. Exterior rings are made
+		// counter-clockwise and holes clockwise.
+		clockwise := SignedArea(layout, ring) > 0
+		if i == 0 {
+			reversed = clockwise
+		}
+		start := c.outsideCoordIndex(ring, stride)
+		switch {
+		case start < 0 && i == 0:
+			insideShell = ring
+		case start < 0:
+			insideHoles = append(insideHoles, ring)
+		default:
+			if clockwise == (i == 0) {
+				ring = reversedRing(ring, stride)
+				start = len(ring) - stride - start
+			}
+			n := len(pieces)
+			pieces = c.appendPieces(pieces, ring, start, stride)
+			if len(pieces) == n {
+				containsRect := IsPointInRing(layout, center, ring)
+				if i == 0 {
+					exteriorContainsRect = containsRect
+				} else if containsRect {
+					outsideHoleContainsRect = true
+				}
+			}
+		}
+	}
+	shells := c.joinPieces(pieces, stride)
+	if len(pieces) == 0 && exteriorContainsRect && !outsideHoleContainsRect {
+		shells = append(shells, c.rectRing(stride))
+	}
+	if reversed {
+		for i, shell := range shells {
+			shells[i] = reversedRing(shell, stride)
+		}
+	}
+	if insideShell != nil {
+		shells = append(shells, insideShell)
+	}
+
+	// Assign the holes to the shells that contain them.
+	holes := make([][][]float64, len(shells))
+	for _, hole := range insideHoles {
+		for i, shell := range shells {
+			if IsPointInRing(layout, hole[:stride], shell) {
+				holes[i] = append(holes[i], hole)
+				break
+			}
+		}
+	}
+
+	for i, shell := range shells {
+		var ends []int
+		for _, ring := range append([][]float64{shell}, holes[i]...) {
+			flatCoords = append(flatCoords, ring...)
+			ends = append(ends, len(flatCoords))
+		}
+		endss = append(endss, ends)
+	}
+	return flatCoords, endss
+}
+
+// A clipPiece is a part of a ring inside the rectangle that starts and ends on its boundary.
+type clipPiece struct {
+	flatCoords []float64
+	start, end float64 // positions of the first and last coordinates on the boundary
+}
+
+// outsideCoordIndex returns the index in the closed ring of a coordinate strictly outside
+// the rectangle, or -1 if the ring is entirely inside the rectangle.
+func (c clipper) outsideCoordIndex(ring []float64, stride int) int {
+	for i := 0; i < len(ring)-stride; i += stride {
+		if !c.contains(ring[i : i+stride]) {
+			return i
+		}
+	}
+	return -1
+}
+
+// appendPieces appends the pieces of the closed ring that are inside the rectangle to
+// pieces. The coordinate at start must be outside the rectangle.
+func (c clipper) appendPieces(pieces []clipPiece, ring []float64, start, stride int) []clipPiece {
+	// Rotate the ring to start and end at a coordinate outside the rectangle, so that every
+	// piece starts and ends on its boundary.
+	rotated := make([]float64, 0, len(ring))
+	rotated = append(rotated, ring[start:len(ring)-stride]...)
+	rotated = append(rotated, ring[:start+stride]...)
+	flatCoords, ends := c.clipLine(nil, nil, rotated, stride)
+	offset := 0
+	for _, end := range ends {
+		piece := flatCoords[offset:end]
+		offset = end
+		pieces = append(pieces, clipPiece{
+			flatCoords: piece,
+			start:      c.snapToBoundary(piece[:stride]),
+			end:        c.snapToBoundary(piece[len(piece)-stride:]),
+		})
+	}
+	return pieces
+}
+
+// joinPieces joins pieces into closed rings by following the boundary of the rectangle
+// counter-clockwise from the end of each piece to the start of the nearest piece, adding the
+// corners of the rectangle that are passed. Rings that collapse are dropped.
+func (c clipper) joinPieces(pieces []clipPiece, stride int) [][]float64 {
+	perimeter := 2 * (c.maxX - c.minX + c.maxY - c.minY)
+	used := make([]bool, len(pieces))
+	var rings [][]float64
+	for first := range pieces {
+		if used[first] {
+			continue
+		}
+		used[first] = true
+		ring := append([]float64(nil), pieces[first].flatCoords...)
+		current := first
+		for {
+			next, distance := first, math.Inf(1)
+			for i, piece := range pieces {
+				if used[i] && i != first {
+					continue
+				}
+				d := math.Mod(piece.start-pieces[current].end+perimeter, perimeter)
+				if d < distance {
+					next, distance = i, d
+				}
+			}
+			ring = c.appendCorners(ring, pieces[current].end, distance, pieces[next].flatCoords[:stride], stride)
+			if next == first {
+				break
+			}
+			used[next] = true
+			ring = append(ring, pieces[next].flatCoords...)
+			current = next
+		}
+		ring = removeSpikes(removeRepeatedCoords(ring, stride), stride)
+		if len(ring) >= 3*stride {
+			rings = append(rings, append(ring, ring[:stride]...))
+		}
+	}
+	return rings
+}
+
+// appendCorners appends the corners of the rectangle that are strictly between the position
+// from on its boundary and distance further counter-clockwise to ring, followed by next. The
+// ordinates other than x and y are interpolated along the boundary between the last
+// coordinate of ring and next.
+func (c clipper) appendCorners(ring []float64, from, distance float64, next []float64, stride int) []float64 {
+	prev := ring[len(ring)-stride:]
+	perimeter := 2 * (c.maxX - c.minX + c.maxY - c.minY)
+	corners := c.corners()
+	for i := range corners {
+		// Visit the corners in counter-clockwise order from the first one after from.
+		corner := corners[(c.cornerIndex(from)+i)%len(corners)]
+		d := math.Mod(corner.position-from+perimeter, perimeter)
+		if d == 0 || d >= distance {
+			continue
+		}
+		coord := appendInterpolated(nil, prev, next, d/distance)
+		coord[0], coord[1] = corner.x, corner.y
+		ring = append(ring, coord...)
+	}
+	return append(ring, next...)
+}
+
+// A clipCorner is a corner of the rectangle.
+type clipCorner struct {
+	x, y     float64
+	position float64 // position on the boundary
+}
+
+// corners returns the corners of the rectangle in counter-clockwise order from its minimum.
+func (c clipper) corners() [4]clipCorner {
+	width, height := c.maxX-c.minX, c.maxY-c.minY
+	return [4]clipCorner{
+		{c.minX, c.minY, 0},
+		{c.maxX, c.minY, width},
+		{c.maxX, c.maxY, width + height},
+		{c.minX, c.maxY, 2*width + height},
+	}
+}
+
+// cornerIndex returns the index of the first corner at or after the position on the
+// boundary.
+func (c clipper) cornerIndex(position float64) int {
+	corners := c.corners()
+	for i, corner := range corners {
+		if position <= corner.position {
+			return i
+		}
+	}
+	return 0
+}
+
+// rectRing returns the boundary of the rectangle as a counter-clockwise closed ring, with
+// ordinates other than x and y set to zero.
+func (c clipper) rectRing(stride int) []float64 {
+	var ring []float64
+	corners := c.corners()
+	for _, corner := range append(corners[:], corners[0]) {
+		coord := make([]float64, stride)
+		coord[0], coord[1] = corner.x, corner.y
+		ring = append(ring, coord...)
+	}
+	return ring
+}
+
+// snapToBoundary moves the coordinate, which must be on or very close to the boundary of the
+// rectangle, exactly onto the closest edge and returns its position on the boundary, measured
+// counter-clockwise from the minimum corner.
+func (c clipper) snapToBoundary(coord []float64) float64 {
+	width, height := c.maxX-c.minX, c.maxY-c.minY
+	x, y := math.Max(c.minX, math.Min(c.maxX, coord[0])), math.Max(c.minY, math.Min(c.maxY, coord[1]))
+	bottom, right, top, left := y-c.minY, c.maxX-x, c.maxY-y, x-c.minX
+	switch math.Min(math.Min(bottom, right), math.Min(top, left)) {
+	case bottom:
+		coord[0], coord[1] = x, c.minY
+		return x - c.minX
+	case right:
+		coord[0], coord[1] = c.maxX, y
+		return width + y - c.minY
+	case top:
+		coord[0], coord[1] = x, c.maxY
+		return width + height + c.maxX - x
+	default:
+		coord[0], coord[1] = c.minX, y
+		return 2*width + height + c.maxY - y
+	}
+}
+
+// reversedRing returns a copy of the ring with its coordinates in reverse order.
+func reversedRing(ring []float64, stride int) []float64 {
+	reversed := make([]float64, 0, len(ring))
+	for i := len(ring) - stride; i >= 0; i -= stride {
+		reversed = append(reversed, ring[i:i+stride]...)
+	}
+	return reversed
+}
+
+// removeRepeatedCoords removes consecutive repeated coordinates from the open ring coords,
+// including a last coordinate that repeats the first.
+func removeRepeatedCoords(coords []float64, stride int) []float64 {
+	var result []float64
+	for i := 0; i < len(coords); i += stride {
+		coord := coords[i : i+stride]
+		if n := len(result); n != 0 && equalXY(result[n-stride:], coord) {
+			continue
+		}
+		result = append(result, coord...)
+	}
+	for len(result) > stride && equalXY(result[len(result)-stride:], result[:stride]) {
+		result = result[:len(result)-stride]
+	}
+	return result
+}
+
+// removeSpikes removes the coordinates of the open ring coords at which the ring turns back
+// on itself, which form zero-width spikes, until none remain.
+func removeSpikes(coords []float64, stride int) []float64 {
+	for removed := true; removed && len(coords) >= 3*stride; {
+		removed = false
+		n := len(coords) / stride
+		for i := range n {
+			a := coords[((i+n-1)%n)*stride:]
+			b := coords[i*stride:]
+			c := coords[((i+1)%n)*stride:]
+			abx, aby, bcx, bcy := b[0]-a[0], b[1]-a[1], c[0]-b[0], c[1]-b[1]
+			if abx*bcy-aby*bcx == 0 && abx*bcx+aby*bcy <= 0 {
+				coords = removeRepeatedCoords(append(coords[:i*stride:i*stride], coords[(i+1)*stride:]...), stride)
+				removed = true
+				break
+			}
+		}
+	}
+	return coords
+}
+
+func equalXY(a, b []float64) bool {
+	return a[0] == b[0] && a[1] == b[1]
+}
+
+// appendInterpolated appends the coordinate at parameter t between a and b to flatCoords,
+// interpolating every ordinate.
+func appendInterpolated(flatCoords, a, b []float64, t float64) []float64 {
+	switch t {
+	case 0:
+		return append(flatCoords, a...)
+	case 1:
+		return append(flatCoords, b...)
+	}
+	for i := range a {
+		flatCoords = append(flatCoords, a[i]+t*(b[i]-a[i]))
+	}
+	return flatCoords
+}
@@ -0,0 +1,21 @@
+package xy_test
+
+import (
+	"fmt"
+
+	"github.com/don4get/go-geom"
+	"github.com/don4get/go-geom/xy"
+)
+
+func ExampleClipToBounds() {
+	line := geom.NewLineStringFlat(geom.XYM, []float64{5, 5, 0, 15, 5, 1, 15, 8, 2, 5, 8, 3})
+	bounds := geom.NewBounds(geom.XY).Set(0, 0, 10, 10)
+
+	clipped, err := xy.ClipToBounds(line, bounds)
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println(clipped.GetFlatCoords(), clipped.GetEnds())
+	// Output: [5 5 0 10 5 0.5 10 8 2.5 5 8 3] [6 12]
+}
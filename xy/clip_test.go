@@ -0,0 +1,205 @@
+package xy
+
+import (
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+
+	"github.com/don4get/go-geom"
+)
+
+func TestClipToBounds(t *testing.T) {
+	bounds := geom.NewBounds(geom.XY).Set(0, 0, 10, 10)
+	for _, tc := range []struct {
+		name     string
+		g        geom.T
+		expected geom.T
+	}{
+		{
+			name:     "point inside",
+			g:        geom.NewPointFlat(geom.XYZ, []float64{5, 5, 1}).SetSRID(4326),
+			expected: geom.NewPointFlat(geom.XYZ, []float64{5, 5, 1}).SetSRID(4326),
+		},
+		{
+			name:     "point outside",
+			g:        geom.NewPointFlat(geom.XY, []float64{15, 5}),
+			expected: geom.NewPointEmpty(geom.XY),
+		},
+		{
+			name:     "multipoint",
+			g:        geom.NewMultiPointFlat(geom.XY, []float64{1, 1, 11, 1, 10, 10}),
+			expected: geom.NewMultiPointFlat(geom.XY, []float64{1, 1, 10, 10}),
+		},
+		{
+			name:     "line inside",
+			g:        geom.NewLineStringFlat(geom.XY, []float64{1, 1, 2, 2, 3, 1}),
+			expected: geom.NewLineStringFlat(geom.XY, []float64{1, 1, 2, 2, 3, 1}),
+		},
+		{
+			name:     "line crossing with z and m",
+			g:        geom.NewLineStringFlat(geom.XYZM, []float64{-10, 5, 0, 100, 20, 5, 30, 400}),
+			expected: geom.NewLineStringFlat(geom.XYZM, []float64{0, 5, 10, 200, 10, 5, 20, 300}),
+		},
+		{
+			name: "line leaving and re-entering",
+			g:    geom.NewLineStringFlat(geom.XYM, []float64{5, 5, 0, 15, 5, 1, 15, 8, 2, 5, 8, 3}),
+			expected: geom.NewMultiLineStringFlat(geom.XYM, []float64{
+				5, 5, 0, 10, 5, 0.5,
+				10, 8, 2.5, 5, 8, 3,
+			}, []int{6, 12}),
+		},
+		{
+			name:     "line outside",
+			g:        geom.NewLineStringFlat(geom.XY, []float64{-5, -5, -1, 20}),
+			expected: geom.NewLineString(geom.XY),
+		},
+		{
+			name:     "line touching corner",
+			g:        geom.NewLineStringFlat(geom.XY, []float64{-5, 5, 5, -5}),
+			expected: geom.NewLineString(geom.XY),
+		},
+		{
+			name: "multilinestring",
+			g: geom.NewMultiLineStringFlat(geom.XY, []float64{
+				-5, 1, 15, 1,
+				20, 20, 30, 30,
+				1, 2, 3, 4,
+			}, []int{4, 8, 12}),
+			expected: geom.NewMultiLineStringFlat(geom.XY, []float64{
+				0, 1, 10, 1,
+				1, 2, 3, 4,
+			}, []int{4, 8}),
+		},
+		{
+			name: "polygon containing bounds",
+			g:    geom.NewPolygonFlat(geom.XY, []float64{-5, -5, 15, -5, 15, 15, -5, 15, -5, -5}, []int{10}),
+			expected: geom.NewPolygonFlat(geom.XY, []float64{
+				0, 0, 10, 0, 10, 10, 0, 10, 0, 0,
+			}, []int{10}),
+		},
+		{
+			name: "polygon crossing with z",
+			g: geom.NewPolygonFlat(geom.XYZ, []float64{
+				5, 5, 0, 15, 5, 10, 15, 8, 10, 5, 8, 0, 5, 5, 0,
+			}, []int{15}),
+			expected: geom.NewPolygonFlat(geom.XYZ, []float64{
+				10, 8, 5, 5, 8, 0, 5, 5, 0, 10, 5, 5, 10, 8, 5,
+			}, []int{15}),
+		},
+		{
+			name: "polygon with hole outside bounds",
+			g: geom.NewPolygonFlat(geom.XY, []float64{
+				-5, 0, 5, 0, 5, 5, -5, 5, -5, 0,
+				-4, 1, -2, 1, -2, 2, -4, 1,
+				1, 1, 2, 1, 2, 2, 1, 1,
+			}, []int{10, 18, 26}),
+			expected: geom.NewPolygonFlat(geom.XY, []float64{
+				0, 0, 5, 0, 5, 5, 0, 5, 0, 0,
+				1, 1, 2, 1, 2, 2, 1, 1,
+			}, []int{10, 18}),
+		},
+		{
+			name: "u-shaped polygon split into two",
+			g: geom.NewPolygonFlat(geom.XY, []float64{
+				2, 5, 4, 5, 4, 12, 6, 12, 6, 5, 8, 5, 8, 15, 2, 15, 2, 5,
+			}, []int{18}),
+			expected: geom.NewMultiPolygonFlat(geom.XY, []float64{
+				6, 10, 6, 5, 8, 5, 8, 10, 6, 10,
+				2, 10, 2, 5, 4, 5, 4, 10, 2, 10,
+			}, [][]int{{10}, {20}}),
+		},
+		{
+			name: "clockwise u-shaped polygon with z",
+			g: geom.NewPolygonFlat(geom.XYZ, []float64{
+				2, 2, 0, 2, 15, 1, 4, 15, 2, 4, 5, 3, 6, 5, 4, 6, 15, 5, 8, 15, 6, 8, 2, 7, 2, 2, 0,
+			}, []int{27}),
+			expected: geom.NewPolygonFlat(geom.XYZ, []float64{
+				2, 10, 8.0 / 13, 4, 10, 2.5, 4, 5, 3, 6, 5, 4, 6, 10, 4.5, 8, 10, 83.0 / 13, 8, 2, 7, 2, 2, 0, 2, 10, 8.0 / 13,
+			}, []int{27}),
+		},
+		{
+			name: "polygon with hole crossing bounds",
+			g: geom.NewPolygonFlat(geom.XY, []float64{
+				2, 2, 15, 2, 15, 8, 2, 8, 2, 2,
+				5, 4, 5, 6, 12, 6, 12, 4, 5, 4,
+			}, []int{10, 20}),
+			expected: geom.NewPolygonFlat(geom.XY, []float64{
+				10, 8, 2, 8, 2, 2, 10, 2, 10, 4, 5, 4, 5, 6, 10, 6, 10, 8,
+			}, []int{18}),
+		},
+		{
+			name: "polygon with hole containing bounds",
+			g: geom.NewPolygonFlat(geom.XY, []float64{
+				-20, -20, 30, -20, 30, 30, -20, 30, -20, -20,
+				-10, -10, -10, 20, 20, 20, 20, -10, -10, -10,
+			}, []int{10, 20}),
+			expected: geom.NewPolygon(geom.XY),
+		},
+		{
+			name: "polygon with hole around bounds",
+			g: geom.NewPolygonFlat(geom.XY, []float64{
+				-20, -20, 30, -20, 30, 30, -20, 30, -20, -20,
+				-10, -10, -10, -5, -5, -5, -5, -10, -10, -10,
+				2, 2, 2, 4, 4, 4, 4, 2, 2, 2,
+			}, []int{10, 20, 30}),
+			expected: geom.NewPolygonFlat(geom.XY, []float64{
+				0, 0, 10, 0, 10, 10, 0, 10, 0, 0,
+				2, 2, 2, 4, 4, 4, 4, 2, 2, 2,
+			}, []int{10, 20}),
+		},
+		{
+			name:     "polygon outside",
+			g:        geom.NewPolygonFlat(geom.XY, []float64{20, 20, 30, 20, 30, 30, 20, 20}, []int{8}),
+			expected: geom.NewPolygon(geom.XY),
+		},
+		{
+			name: "multipolygon",
+			g: geom.NewMultiPolygonFlat(geom.XY, []float64{
+				20, 20, 30, 20, 30, 30, 20, 20,
+				1, 1, 12, 1, 1, 12, 1, 1,
+			}, [][]int{{8}, {16}}),
+			expected: geom.NewMultiPolygonFlat(geom.XY, []float64{
+				10, 3, 3, 10, 1, 10, 1, 1, 10, 1, 10, 3,
+			}, [][]int{{12}}),
+		},
+		{
+			name:     "triangle",
+			g:        geom.NewTriangleFlat(geom.XY, []float64{-2, 0, 4, 0, -2, 6, -2, 0}, []int{8}),
+			expected: geom.NewPolygonFlat(geom.XY, []float64{0, 0, 4, 0, 0, 4, 0, 0}, []int{8}),
+		},
+		{
+			name:     "triangle touching corner",
+			g:        geom.NewTriangleFlat(geom.XY, []float64{-1, -1, 1, -1, -1, 1, -1, -1}, []int{8}),
+			expected: geom.NewPolygon(geom.XY),
+		},
+		{
+			name: "geometry collection",
+			g: geom.NewGeometryCollection().MustPush(
+				geom.NewPointFlat(geom.XY, []float64{20, 20}),
+				geom.NewLineStringFlat(geom.XY, []float64{5, 5, 15, 5}),
+			).MustSetLayout(geom.XY),
+			expected: geom.NewGeometryCollection().MustPush(
+				geom.NewLineStringFlat(geom.XY, []float64{5, 5, 10, 5}),
+			).MustSetLayout(geom.XY),
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := ClipToBounds(tc.g, bounds)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestClipToBoundsCurve(t *testing.T) {
+	// A semicircle of radius 10 centered on the origin, of which only the
+	// first quadrant is inside the bounds.
+	g := geom.NewCircularString(geom.XY).MustSetCoords([]geom.Coord{{10, 0}, {0, 10}, {-10, 0}})
+	actual, err := ClipToBounds(g, geom.NewBounds(geom.XY).Set(0, 0, 10, 10))
+	assert.NoError(t, err)
+	ls, ok := actual.(*geom.LineString)
+	assert.True(t, ok)
+	assert.Equal(t, geom.Coord{10, 0}, ls.Coord(0))
+	end := ls.Coord(ls.NumCoords() - 1)
+	assert.True(t, end[0] == 0 && end[1] > 9.99)
+}
@@ -21,6 +21,7 @@ type convexHullCalculator struct {
 // A convex hull is the smallest convex geometry that contains
 // all the points in the input geometry
 // Uses the Graham Scan algorithm
+// See triangulate.ConcaveHull for a tighter, concave outline
 func ConvexHull(geometry geom.T) geom.T {
 	// copy coords because the algorithm reorders them
 	calc := convexHullCalculator{
@@ -182,6 +183,10 @@ func (calc *convexHullCalculator) reduce(inputPts []float64) []float64 {
 		reducedSet.Insert(polyPts[i : i+calc.stride])
 	}
 
+	// close the ring so that points outside its last edge are not
+	// considered to be inside it
+	polyRing := append(polyPts[:len(polyPts):len(polyPts)], polyPts[0:calc.stride]...)
+
 	/**
 	 * Add all unique points not in the interior poly.
 	 * CGAlgorithms.isPointInRing is not defined for points actually on the ring,
@@ -190,7 +195,7 @@ func (calc *convexHullCalculator) reduce(inputPts []float64) []float64 {
 	 */
 	for i := 0; i < len(inputPts); i += calc.stride {
 		pt := geom.Coord(inputPts[i : i+calc.stride])
-		if !IsPointInRing(calc.layout, pt, polyPts) {
+		if !IsPointInRing(calc.layout, pt, polyRing) {
 			reducedSet.Insert(pt)
 		}
 	}
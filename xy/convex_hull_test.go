@@ -1,6 +1,8 @@
 package xy
 
 import (
+	"math"
+	"math/rand"
 	"reflect"
 	"testing"
 
@@ -244,3 +246,22 @@ func TestGrahamScan(t *testing.T) {
 		t.Fatalf("calc.grahamScan(...) mutated the input coords.  Expected \n\t%v\nbut was\n\t%v", internal.TestRing.GetFlatCoords(), coords)
 	}
 }
+
+func TestConvexHullReduce(t *testing.T) {
+	// More than 50 points triggers the reduction heuristic. Points outside the
+	// edge between the last and the first points of the octagon must not be
+	// discarded.
+	r := rand.New(rand.NewSource(1))
+	cos, sin := math.Cos(1.4), math.Sin(1.4)
+	var flatCoords []float64
+	for range 1000 {
+		x, y := r.Float64()*6-3, r.Float64()*2-1
+		flatCoords = append(flatCoords, x*cos-y*sin, x*sin+y*cos)
+	}
+	convexHull := ConvexHullFlat(geom.XY, flatCoords)
+	for i := 0; i < len(flatCoords); i += 2 {
+		if !IsPointInRing(geom.XY, flatCoords[i:i+2], convexHull.GetFlatCoords()) {
+			t.Errorf("%v is outside the convex hull %v", flatCoords[i:i+2], convexHull.GetFlatCoords())
+		}
+	}
+}
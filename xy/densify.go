@@ -0,0 +1,161 @@
+package xy
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/don4get/go-geom"
+)
+
+// geodesicEarthRadius is the mean radius of the Earth, in meters, used by
+// DensifyGeodesic.
+const geodesicEarthRadius = 6371008.8
+
+// Densify returns a copy of g with vertices inserted so that no segment is longer than
+// maxSegmentLength. Each segment that is too long is divided into the smallest number of
+// equal parts that are short enough. Ordinates other than x and y, such as Z and M, are
+// interpolated linearly.
+//
+// Triangles become Polygons, TINs become MultiPolygons, and curved geometries are
+// linearized first. Points are unchanged. The layout and SRID of g are preserved.
+func Densify(g geom.T, maxSegmentLength float64) (geom.T, error) {
+	if !(maxSegmentLength > 0) {
+		return nil, fmt.Errorf("%v is not a valid maximum segment length", maxSegmentLength)
+	}
+	return densify(g, func(flatCoords, a, b []float64) []float64 {
+		n := math.Ceil(math.Hypot(b[0]-a[0], b[1]-a[1]) / maxSegmentLength)
+		for i := 1.0; i < n; i++ {
+			flatCoords = appendInterpolated(flatCoords, a, b, i/n)
+		}
+		return flatCoords
+	})
+}
+
+// DensifyGeodesic is like Densify, but for geometries whose x and y ordinates are
+// longitudes and latitudes in degrees. Segments are great circle arcs on a sphere with the
+// mean radius of the Earth, maxSegmentLength is in meters, and the inserted vertices are
+// on the great circles. Segments between antipodal points, whose great circle is not
+// defined, are not densified.
+func DensifyGeodesic(g geom.T, maxSegmentLength float64) (geom.T, error) {
+	if !(maxSegmentLength > 0) {
+		return nil, fmt.Errorf("%v is not a valid maximum segment length", maxSegmentLength)
+	}
+	return densify(g, func(flatCoords, a, b []float64) []float64 {
+		lon1, lat1 := a[0]*math.Pi/180, a[1]*math.Pi/180
+		lon2, lat2 := b[0]*math.Pi/180, b[1]*math.Pi/180
+		// The central angle between a and b, from the haversine formula.
+		h := math.Pow(math.Sin((lat2-lat1)/2), 2) + math.Cos(lat1)*math.Cos(lat2)*math.Pow(math.Sin((lon2-lon1)/2), 2)
+		delta := 2 * math.Asin(math.Sqrt(min(1, h)))
+		sinDelta := math.Sin(delta)
+		if sinDelta == 0 {
+			return flatCoords
+		}
+		n := math.Ceil(delta * geodesicEarthRadius / maxSegmentLength)
+		for i := 1.0; i < n; i++ {
+			t := i / n
+			// Spherical linear interpolation between the unit vectors of a and b.
+			wa, wb := math.Sin((1-t)*delta)/sinDelta, math.Sin(t*delta)/sinDelta
+			x := wa*math.Cos(lat1)*math.Cos(lon1) + wb*math.Cos(lat2)*math.Cos(lon2)
+			y := wa*math.Cos(lat1)*math.Sin(lon1) + wb*math.Cos(lat2)*math.Sin(lon2)
+			z := wa*math.Sin(lat1) + wb*math.Sin(lat2)
+			flatCoords = appendInterpolated(flatCoords, a, b, t)
+			coord := flatCoords[len(flatCoords)-len(a):]
+			coord[0] = math.Atan2(y, x) * 180 / math.Pi
+			coord[1] = math.Atan2(z, math.Hypot(x, y)) * 180 / math.Pi
+		}
+		return flatCoords
+	})
+}
+
+// densify returns a copy of g in which appendVertices has appended the vertices to insert
+// between each pair of consecutive vertices a and b.
+func densify(g geom.T, appendVertices func(flatCoords, a, b []float64) []float64) (geom.T, error) {
+	layout, stride := g.GetLayout(), g.GetStride()
+	densifyLines := func(flatCoords []float64, ends []int) ([]float64, []int) {
+		var densified []float64
+		densifiedEnds := make([]int, 0, len(ends))
+		offset := 0
+		for _, end := range ends {
+			densified = densifyLine(densified, flatCoords[offset:end], stride, appendVertices)
+			densifiedEnds = append(densifiedEnds, len(densified))
+			offset = end
+		}
+		return densified, densifiedEnds
+	}
+	densifyPolygons := func(flatCoords []float64, endss [][]int) ([]float64, [][]int) {
+		var densified []float64
+		densifiedEndss := make([][]int, 0, len(endss))
+		offset := 0
+		for _, ends := range endss {
+			densifiedEnds := make([]int, 0, len(ends))
+			for _, end := range ends {
+				densified = densifyLine(densified, flatCoords[offset:end], stride, appendVertices)
+				densifiedEnds = append(densifiedEnds, len(densified))
+				offset = end
+			}
+			densifiedEndss = append(densifiedEndss, densifiedEnds)
+		}
+		return densified, densifiedEndss
+	}
+
+	switch g := g.(type) {
+	case *geom.Point:
+		return g.Clone(), nil
+	case *geom.MultiPoint:
+		return g.Clone(), nil
+	case *geom.LineString:
+		return geom.NewLineStringFlat(layout, densifyLine(nil, g.FlatCoords, stride, appendVertices)).SetSRID(g.Srid), nil
+	case *geom.LinearRing:
+		return geom.NewLinearRingFlat(layout, densifyLine(nil, g.FlatCoords, stride, appendVertices)).SetSRID(g.Srid), nil
+	case *geom.MultiLineString:
+		flatCoords, ends := densifyLines(g.FlatCoords, g.Ends)
+		return geom.NewMultiLineStringFlat(layout, flatCoords, ends).SetSRID(g.Srid), nil
+	case *geom.Polygon:
+		flatCoords, ends := densifyLines(g.FlatCoords, g.Ends)
+		return geom.NewPolygonFlat(layout, flatCoords, ends).SetSRID(g.Srid), nil
+	case *geom.Triangle:
+		flatCoords, ends := densifyLines(g.FlatCoords, g.Ends)
+		return geom.NewPolygonFlat(layout, flatCoords, ends).SetSRID(g.Srid), nil
+	case *geom.MultiPolygon:
+		flatCoords, endss := densifyPolygons(g.FlatCoords, g.Endss)
+		return geom.NewMultiPolygonFlat(layout, flatCoords, endss).SetSRID(g.Srid), nil
+	case *geom.TIN:
+		flatCoords, endss := densifyPolygons(g.FlatCoords, g.Endss)
+		return geom.NewMultiPolygonFlat(layout, flatCoords, endss).SetSRID(g.Srid), nil
+	case *geom.PolyhedralSurface:
+		flatCoords, endss := densifyPolygons(g.FlatCoords, g.Endss)
+		return geom.NewPolyhedralSurfaceFlat(layout, flatCoords, endss).SetSRID(g.Srid), nil
+	case *geom.GeometryCollection:
+		gc := geom.NewGeometryCollection().SetSRID(g.GetSRID())
+		for _, child := range g.Geoms() {
+			densified, err := densify(child, appendVertices)
+			if err != nil {
+				return nil, err
+			}
+			if err := gc.Push(densified); err != nil {
+				return nil, err
+			}
+		}
+		return collectionLayout(gc, layout), nil
+	case *geom.CircularString, *geom.CompoundCurve, *geom.CurvePolygon, *geom.MultiCurve, *geom.MultiSurface:
+		linear, err := geom.Linearize(g, linearizeSegmentsPerQuadrant)
+		if err != nil {
+			return nil, err
+		}
+		return densify(linear, appendVertices)
+	default:
+		return nil, geom.ErrUnsupportedType{Value: g}
+	}
+}
+
+// densifyLine appends the line in lineFlatCoords to flatCoords, with the vertices
+// returned by appendVertices inserted between each pair of consecutive vertices.
+func densifyLine(flatCoords, lineFlatCoords []float64, stride int, appendVertices func(flatCoords, a, b []float64) []float64) []float64 {
+	for i := 0; i < len(lineFlatCoords); i += stride {
+		if i > 0 {
+			flatCoords = appendVertices(flatCoords, lineFlatCoords[i-stride:i], lineFlatCoords[i:i+stride])
+		}
+		flatCoords = append(flatCoords, lineFlatCoords[i:i+stride]...)
+	}
+	return flatCoords
+}
@@ -0,0 +1,20 @@
+package xy_test
+
+import (
+	"fmt"
+
+	"github.com/don4get/go-geom"
+	"github.com/don4get/go-geom/xy"
+)
+
+func ExampleDensify() {
+	line := geom.NewLineStringFlat(geom.XYM, []float64{0, 0, 0, 4, 0, 100})
+
+	densified, err := xy.Densify(line, 1)
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println(densified.GetFlatCoords())
+	// Output: [0 0 0 1 0 25 2 0 50 3 0 75 4 0 100]
+}
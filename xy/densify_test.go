@@ -0,0 +1,138 @@
+package xy
+
+import (
+	"math"
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+
+	"github.com/don4get/go-geom"
+)
+
+func TestDensify(t *testing.T) {
+	for _, tc := range []struct {
+		name             string
+		g                geom.T
+		maxSegmentLength float64
+		expected         geom.T
+	}{
+		{
+			name:             "point",
+			g:                geom.NewPointFlat(geom.XY, []float64{1, 2}),
+			maxSegmentLength: 1,
+			expected:         geom.NewPointFlat(geom.XY, []float64{1, 2}),
+		},
+		{
+			name:             "linestring with z and m",
+			g:                geom.NewLineStringFlat(geom.XYZM, []float64{0, 0, 0, 10, 3, 0, 30, 40, 3, 1, 30, 40}).SetSRID(3857),
+			maxSegmentLength: 1,
+			expected: geom.NewLineStringFlat(geom.XYZM, []float64{
+				0, 0, 0, 10,
+				1, 0, 10, 20,
+				2, 0, 20, 30,
+				3, 0, 30, 40,
+				3, 1, 30, 40,
+			}).SetSRID(3857),
+		},
+		{
+			name:             "segment not a multiple of the maximum length",
+			g:                geom.NewLineStringFlat(geom.XY, []float64{0, 0, 0, 6}),
+			maxSegmentLength: 4,
+			expected:         geom.NewLineStringFlat(geom.XY, []float64{0, 0, 0, 3, 0, 6}),
+		},
+		{
+			name:             "polygon",
+			g:                geom.NewPolygonFlat(geom.XY, []float64{0, 0, 2, 0, 2, 2, 0, 0}, []int{8}),
+			maxSegmentLength: 1.5,
+			expected: geom.NewPolygonFlat(geom.XY, []float64{
+				0, 0, 1, 0, 2, 0, 2, 1, 2, 2, 1, 1, 0, 0,
+			}, []int{14}),
+		},
+		{
+			name:             "triangle becomes polygon",
+			g:                geom.NewTriangleFlat(geom.XY, []float64{0, 0, 2, 0, 0, 1, 0, 0}, []int{8}),
+			maxSegmentLength: 1.5,
+			expected: geom.NewPolygonFlat(geom.XY, []float64{
+				0, 0, 1, 0, 2, 0, 1, 0.5, 0, 1, 0, 0,
+			}, []int{12}),
+		},
+		{
+			name: "multipolygon",
+			g: geom.NewMultiPolygonFlat(geom.XY, []float64{
+				0, 0, 2, 0, 0, 1, 0, 0,
+				5, 5, 6, 5, 5, 6, 5, 5,
+			}, [][]int{{8}, {16}}),
+			maxSegmentLength: 1.5,
+			expected: geom.NewMultiPolygonFlat(geom.XY, []float64{
+				0, 0, 1, 0, 2, 0, 1, 0.5, 0, 1, 0, 0,
+				5, 5, 6, 5, 5, 6, 5, 5,
+			}, [][]int{{12}, {20}}),
+		},
+		{
+			name: "geometry collection",
+			g: geom.NewGeometryCollection().MustPush(
+				geom.NewPointFlat(geom.XY, []float64{0, 0}),
+				geom.NewLineStringFlat(geom.XY, []float64{0, 0, 2, 0}),
+			),
+			maxSegmentLength: 1,
+			expected: geom.NewGeometryCollection().MustPush(
+				geom.NewPointFlat(geom.XY, []float64{0, 0}),
+				geom.NewLineStringFlat(geom.XY, []float64{0, 0, 1, 0, 2, 0}),
+			).MustSetLayout(geom.XY),
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := Densify(tc.g, tc.maxSegmentLength)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestDensifyCurve(t *testing.T) {
+	g := geom.NewCircularStringFlat(geom.XY, []float64{-1, 0, 0, 1, 1, 0})
+	actual, err := Densify(g, 0.01)
+	assert.NoError(t, err)
+	flatCoords := actual.GetFlatCoords()
+	for i := 2; i < len(flatCoords); i += 2 {
+		assert.True(t, math.Hypot(flatCoords[i]-flatCoords[i-2], flatCoords[i+1]-flatCoords[i-1]) <= 0.01+1e-12)
+		assert.True(t, math.Abs(math.Hypot(flatCoords[i], flatCoords[i+1])-1) < 1e-3)
+	}
+}
+
+func TestDensifyGeodesic(t *testing.T) {
+	// A quarter of the equator, about 10008 km long, becomes 11 segments of 910 km.
+	line := geom.NewLineStringFlat(geom.XYZ, []float64{0, 0, 0, 90, 0, 110})
+	actual, err := DensifyGeodesic(line, 1000000)
+	assert.NoError(t, err)
+	flatCoords := actual.GetFlatCoords()
+	assert.Equal(t, 12*3, len(flatCoords))
+	for i := 0; i < len(flatCoords); i += 3 {
+		assert.True(t, math.Abs(flatCoords[i]-float64(i/3)*90/11) < 1e-9)
+		assert.True(t, math.Abs(flatCoords[i+1]) < 1e-9)
+		assert.True(t, math.Abs(flatCoords[i+2]-float64(i/3)*10) < 1e-9)
+	}
+
+	// Points on the great circle from Paris to New York pass north of both.
+	line = geom.NewLineStringFlat(geom.XY, []float64{2.35, 48.86, -74.01, 40.71})
+	actual, err = DensifyGeodesic(line, 100000)
+	assert.NoError(t, err)
+	flatCoords = actual.GetFlatCoords()
+	assert.Equal(t, 2*(59+1), len(flatCoords))
+	maxLat := 0.0
+	for i := 1; i < len(flatCoords); i += 2 {
+		maxLat = max(maxLat, flatCoords[i])
+	}
+	assert.True(t, maxLat > 52)
+	assert.Equal(t, []float64{2.35, 48.86}, flatCoords[:2])
+	assert.Equal(t, []float64{-74.01, 40.71}, flatCoords[len(flatCoords)-2:])
+}
+
+func TestDensifyErrors(t *testing.T) {
+	for _, maxSegmentLength := range []float64{0, -1, math.NaN()} {
+		_, err := Densify(geom.NewLineString(geom.XY), maxSegmentLength)
+		assert.Error(t, err)
+		_, err = DensifyGeodesic(geom.NewLineString(geom.XY), maxSegmentLength)
+		assert.Error(t, err)
+	}
+}
@@ -0,0 +1,139 @@
+package xy
+
+import (
+	"container/heap"
+	"fmt"
+	"math"
+
+	"github.com/don4get/go-geom"
+	"github.com/don4get/go-geom/xy/internal"
+)
+
+// MaximumInscribedCircle computes the largest circle that fits inside a Polygon or
+// MultiPolygon. Its center is the pole of inaccessibility, the interior point farthest from
+// the boundary, which is a good position for a label. The center is found to within tolerance
+// by recursively subdividing the bounds of the geometry into cells and discarding those that
+// cannot contain a better center (the polylabel algorithm). If tolerance is not positive then
+// one millionth of the size of the geometry is used.
+//
+// It returns the xy center and the radius of the circle. If the geometry is empty then the
+// center is nil.
+func MaximumInscribedCircle(geometry geom.T, tolerance float64) (center geom.Coord, radius float64, err error) {
+	var rings [][]float64
+	switch g := geometry.(type) {
+	case *geom.Polygon:
+		rings = polygonRings(g)
+	case *geom.MultiPolygon:
+		for i := range g.NumPolygons() {
+			rings = append(rings, polygonRings(g.Polygon(i))...)
+		}
+	default:
+		return nil, 0, fmt.Errorf("%v is not a supported type for maximum inscribed circle calculation", g)
+	}
+	if len(rings) == 0 {
+		return nil, 0, nil
+	}
+	layout := geometry.GetLayout()
+	bounds := geometry.GetBounds()
+	minX, minY, maxX, maxY := bounds.Min(0), bounds.Min(1), bounds.Max(0), bounds.Max(1)
+	width, height := maxX-minX, maxY-minY
+	if math.Min(width, height) == 0 {
+		return geom.Coord{minX, minY}, 0, nil
+	}
+	if tolerance <= 0 {
+		tolerance = 1e-6 * math.Max(width, height)
+	}
+
+	distance := func(x, y float64) float64 {
+		return signedDistanceToRings(layout, rings, geom.Coord{x, y})
+	}
+	newCell := func(x, y, h float64) inscribedCell {
+		d := distance(x, y)
+		return inscribedCell{x: x, y: y, h: h, d: d, max: d + h*math.Sqrt2}
+	}
+
+	// Cover the bounds with a single square cell, which is subdivided as
+	// needed, rather than with a grid of cells the size of the smaller side,
+	// which would need a huge number of cells for a thin geometry. Start with
+	// the centroid as the best guess.
+	var cells inscribedCells
+	heap.Push(&cells, newCell(minX+width/2, minY+height/2, math.Max(width, height)/2))
+	best := newCell(minX+width/2, minY+height/2, 0)
+	if centroid, err := Centroid(geometry); err == nil && centroid != nil {
+		if c := newCell(centroid[0], centroid[1], 0); c.d > best.d {
+			best = c
+		}
+	}
+
+	for cells.Len() > 0 {
+		cell := heap.Pop(&cells).(inscribedCell) //nolint:forcetypeassert
+		if cell.d > best.d {
+			best = cell
+		}
+		if cell.max-best.d <= tolerance {
+			continue
+		}
+		h := cell.h / 2
+		heap.Push(&cells, newCell(cell.x-h, cell.y-h, h))
+		heap.Push(&cells, newCell(cell.x+h, cell.y-h, h))
+		heap.Push(&cells, newCell(cell.x-h, cell.y+h, h))
+		heap.Push(&cells, newCell(cell.x+h, cell.y+h, h))
+	}
+	return geom.Coord{best.x, best.y}, math.Max(best.d, 0), nil
+}
+
+func polygonRings(polygon *geom.Polygon) [][]float64 {
+	var rings [][]float64
+	for i := range polygon.NumLinearRings() {
+		if ring := polygon.LinearRing(i).FlatCoords; len(ring) > 0 {
+			rings = append(rings, ring)
+		}
+	}
+	return rings
+}
+
+// signedDistanceToRings returns the distance from p to the nearest ring, positive if p is
+// inside an odd number of rings and negative otherwise.
+func signedDistanceToRings(layout geom.Layout, rings [][]float64, p geom.Coord) float64 {
+	stride := layout.Stride()
+	inside := false
+	minDistance := math.Inf(1)
+	for _, ring := range rings {
+		for i := stride; i < len(ring); i += stride {
+			a, b := geom.Coord(ring[i-stride:i-stride+2]), geom.Coord(ring[i:i+2])
+			if (a[1] > p[1]) != (b[1] > p[1]) && p[0] < (b[0]-a[0])*(p[1]-a[1])/(b[1]-a[1])+a[0] {
+				inside = !inside
+			}
+			minDistance = math.Min(minDistance, DistanceFromPointToLine(p, a, b))
+		}
+		if len(ring) == stride {
+			minDistance = math.Min(minDistance, internal.Distance2D(p, ring[:2]))
+		}
+	}
+	if inside {
+		return minDistance
+	}
+	return -minDistance
+}
+
+// An inscribedCell is a square cell with center (x, y) and half-size h. d is the signed
+// distance from its center to the boundary and max is the largest distance possible within
+// the cell.
+type inscribedCell struct {
+	x, y, h, d, max float64
+}
+
+// inscribedCells is a max-heap of cells ordered by their largest possible distance.
+type inscribedCells []inscribedCell
+
+func (c inscribedCells) Len() int           { return len(c) }
+func (c inscribedCells) Less(i, j int) bool { return c[i].max > c[j].max }
+func (c inscribedCells) Swap(i, j int)      { c[i], c[j] = c[j], c[i] }
+func (c *inscribedCells) Push(x any)        { *c = append(*c, x.(inscribedCell)) } //nolint:forcetypeassert
+
+func (c *inscribedCells) Pop() any {
+	n := len(*c)
+	x := (*c)[n-1]
+	*c = (*c)[:n-1]
+	return x
+}
@@ -0,0 +1,81 @@
+package xy
+
+import (
+	"math"
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+
+	"github.com/don4get/go-geom"
+	"github.com/don4get/go-geom/xy/internal"
+)
+
+func TestMaximumInscribedCircle(t *testing.T) {
+	for _, tc := range []struct {
+		name           string
+		geometry       geom.T
+		expectedCenter geom.Coord
+		expectedRadius float64
+		expectedErr    bool
+	}{
+		{
+			name:        "line_string",
+			geometry:    geom.NewLineStringFlat(geom.XY, []float64{0, 0, 1, 1}),
+			expectedErr: true,
+		},
+		{
+			name:     "empty",
+			geometry: geom.NewPolygon(geom.XY),
+		},
+		{
+			name:           "square",
+			geometry:       geom.NewPolygonFlat(geom.XY, []float64{0, 0, 4, 0, 4, 4, 0, 4, 0, 0}, []int{10}),
+			expectedCenter: geom.Coord{2, 2},
+			expectedRadius: 2,
+		},
+		{
+			name:           "rectangle",
+			geometry:       geom.NewPolygonFlat(geom.XYZ, []float64{0, 0, 1, 10, 0, 1, 10, 2, 1, 0, 2, 1, 0, 0, 1}, []int{15}),
+			expectedRadius: 1,
+		},
+		{
+			name: "square_with_hole",
+			geometry: geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{
+				{{0, 0}, {10, 0}, {10, 10}, {0, 10}, {0, 0}},
+				{{1, 1}, {1, 9}, {9, 9}, {9, 1}, {1, 1}},
+			}),
+			expectedRadius: 2 - math.Sqrt2, // In a corner, touching a corner of the hole.
+		},
+		{
+			name:           "thin_far_from_origin",
+			geometry:       geom.NewPolygonFlat(geom.XY, []float64{1e9, 0, 1e9 + 1, 0, 1e9 + 1, 1e-8, 1e9, 1e-8, 1e9, 0}, []int{10}),
+			expectedRadius: 5e-9,
+		},
+		{
+			name: "multi_polygon",
+			geometry: geom.NewMultiPolygon(geom.XY).MustSetCoords([][][]geom.Coord{
+				{{{0, 0}, {2, 0}, {2, 2}, {0, 2}, {0, 0}}},
+				{{{5, 0}, {11, 0}, {11, 6}, {5, 6}, {5, 0}}},
+			}),
+			expectedCenter: geom.Coord{8, 3},
+			expectedRadius: 3,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			center, radius, err := MaximumInscribedCircle(tc.geometry, 1e-4)
+			if tc.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			if tc.expectedRadius == 0 {
+				assert.Zero(t, center)
+				return
+			}
+			assert.True(t, math.Abs(tc.expectedRadius-radius) <= 1e-4)
+			if tc.expectedCenter != nil {
+				assert.True(t, internal.Distance2D(tc.expectedCenter, center) < 1e-3)
+			}
+		})
+	}
+}
@@ -0,0 +1,169 @@
+// Package linemerge joins lines that share end points into maximal lines.
+package linemerge
+
+import (
+	"github.com/don4get/go-geom"
+)
+
+// Merge joins lines, which must be LineStrings, LinearRings or MultiLineStrings, into
+// maximal LineStrings. Lines are joined at the end points that are shared by exactly two
+// lines, reversing lines where needed, so a merged line ends wherever three or more lines
+// meet or where a line ends alone. Loops whose end points are all shared by exactly two
+// lines become closed LineStrings. Lines with fewer than two distinct coordinates are
+// dropped. The layout of the result is the layout of the first line, and all lines must
+// have the same layout.
+func Merge(lines ...geom.T) (*geom.MultiLineString, error) {
+	layout := geom.NoLayout
+	if len(lines) != 0 {
+		layout = lines[0].GetLayout()
+	}
+	m := &merger{
+		nodeIndexes: make(map[[2]float64]int),
+	}
+	for _, g := range lines {
+		if g.GetLayout() != layout {
+			return nil, geom.ErrLayoutMismatch{Got: g.GetLayout(), Want: layout}
+		}
+		switch g := g.(type) {
+		case *geom.LineString, *geom.LinearRing:
+			m.addEdge(g.GetFlatCoords(), g.GetStride())
+		case *geom.MultiLineString:
+			for i := range g.NumLineStrings() {
+				m.addEdge(g.LineString(i).FlatCoords, g.Stride)
+			}
+		default:
+			return nil, geom.ErrUnsupportedType{Value: g}
+		}
+	}
+
+	mls := geom.NewMultiLineString(layout)
+	push := func(flatCoords []float64) error {
+		return mls.Push(geom.NewLineStringFlat(layout, flatCoords))
+	}
+	// Start from the nodes where merged lines end, and then merge the remaining loops.
+	for i, n := range m.nodes {
+		if len(n.edges) == 2 {
+			continue
+		}
+		for _, e := range n.edges {
+			if !m.edges[e].visited {
+				if err := push(m.walk(i, e)); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+	for i, e := range m.edges {
+		if !e.visited {
+			if err := push(m.walk(e.start, i)); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return mls, nil
+}
+
+// An edge is an input line between two nodes.
+type edge struct {
+	flatCoords []float64
+	stride     int
+	start, end int
+	visited    bool
+}
+
+// A node is an end point of one or more edges. A closed edge appears twice in its node's
+// edges.
+type node struct {
+	edges []int
+}
+
+// merger merges edges.
+type merger struct {
+	edges       []edge
+	nodes       []node
+	nodeIndexes map[[2]float64]int
+}
+
+// addEdge adds the line in flatCoords to m, unless it has fewer than two distinct
+// coordinates.
+func (m *merger) addEdge(flatCoords []float64, stride int) {
+	n := len(flatCoords)
+	if n < 2*stride || allEqualXY(flatCoords, stride) {
+		return
+	}
+	e := len(m.edges)
+	start := m.node(flatCoords[0], flatCoords[1])
+	end := m.node(flatCoords[n-stride], flatCoords[n-stride+1])
+	m.edges = append(m.edges, edge{
+		flatCoords: flatCoords,
+		stride:     stride,
+		start:      start,
+		end:        end,
+	})
+	m.nodes[start].edges = append(m.nodes[start].edges, e)
+	m.nodes[end].edges = append(m.nodes[end].edges, e)
+}
+
+// node returns the index of the node at x, y, adding it if needed.
+func (m *merger) node(x, y float64) int {
+	key := [2]float64{x, y}
+	if i, ok := m.nodeIndexes[key]; ok {
+		return i
+	}
+	i := len(m.nodes)
+	m.nodes = append(m.nodes, node{})
+	m.nodeIndexes[key] = i
+	return i
+}
+
+// walk returns the coordinates of the merged line that leaves node n along edge e,
+// marking its edges as visited.
+func (m *merger) walk(n, e int) []float64 {
+	var flatCoords []float64
+	for {
+		edge := &m.edges[e]
+		edge.visited = true
+		coords, next := edge.flatCoords, edge.end
+		if edge.start != n {
+			coords, next = reverse(coords, edge.stride), edge.start
+		}
+		if len(flatCoords) != 0 {
+			// Skip the coordinate shared with the previous edge.
+			coords = coords[edge.stride:]
+		}
+		flatCoords = append(flatCoords, coords...)
+
+		n = next
+		edges := m.nodes[n].edges
+		if len(edges) != 2 {
+			return flatCoords
+		}
+		switch {
+		case !m.edges[edges[0]].visited:
+			e = edges[0]
+		case !m.edges[edges[1]].visited:
+			e = edges[1]
+		default:
+			return flatCoords
+		}
+	}
+}
+
+// allEqualXY returns whether all the coordinates in flatCoords have the same x and y.
+func allEqualXY(flatCoords []float64, stride int) bool {
+	for i := stride; i < len(flatCoords); i += stride {
+		if flatCoords[i] != flatCoords[0] || flatCoords[i+1] != flatCoords[1] {
+			return false
+		}
+	}
+	return true
+}
+
+// reverse returns a copy of flatCoords with its coordinates in reverse order.
+func reverse(flatCoords []float64, stride int) []float64 {
+	reversed := make([]float64, 0, len(flatCoords))
+	for i := len(flatCoords) - stride; i >= 0; i -= stride {
+		reversed = append(reversed, flatCoords[i:i+stride]...)
+	}
+	return reversed
+}
@@ -0,0 +1,24 @@
+package linemerge_test
+
+import (
+	"fmt"
+
+	"github.com/don4get/go-geom"
+	"github.com/don4get/go-geom/xy/linemerge"
+)
+
+func ExampleMerge() {
+	segments := geom.NewMultiLineStringFlat(geom.XY, []float64{
+		1, 0, 2, 0,
+		0, 0, 1, 0,
+		3, 0, 2, 0,
+	}, []int{4, 8, 12})
+
+	merged, err := linemerge.Merge(segments)
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println(merged.FlatCoords, merged.Ends)
+	// Output: [0 0 1 0 2 0 3 0] [8]
+}
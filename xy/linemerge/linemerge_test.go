@@ -0,0 +1,86 @@
+package linemerge
+
+import (
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+
+	"github.com/don4get/go-geom"
+)
+
+func TestMerge(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		lines    []geom.T
+		expected *geom.MultiLineString
+	}{
+		{
+			name:     "empty",
+			expected: geom.NewMultiLineString(geom.NoLayout),
+		},
+		{
+			name: "chain",
+			lines: []geom.T{
+				geom.NewMultiLineStringFlat(geom.XY, []float64{
+					1, 0, 2, 0,
+					0, 0, 1, 0,
+					3, 0, 2, 0,
+				}, []int{4, 8, 12}),
+			},
+			expected: geom.NewMultiLineStringFlat(geom.XY, []float64{0, 0, 1, 0, 2, 0, 3, 0}, []int{8}),
+		},
+		{
+			name: "junction",
+			lines: []geom.T{
+				geom.NewLineStringFlat(geom.XY, []float64{0, 0, 1, 0}),
+				geom.NewLineStringFlat(geom.XY, []float64{1, 0, 2, 0}),
+				geom.NewLineStringFlat(geom.XY, []float64{1, 0, 1, 1}),
+				geom.NewLineStringFlat(geom.XY, []float64{1, 1, 1, 2}),
+			},
+			expected: geom.NewMultiLineStringFlat(geom.XY, []float64{
+				0, 0, 1, 0,
+				1, 0, 2, 0,
+				1, 0, 1, 1, 1, 2,
+			}, []int{4, 8, 14}),
+		},
+		{
+			name: "loop",
+			lines: []geom.T{
+				geom.NewLineStringFlat(geom.XYZ, []float64{0, 0, 1, 1, 0, 2, 1, 1, 3}),
+				geom.NewLineStringFlat(geom.XYZ, []float64{0, 0, 1, 0, 1, 4, 1, 1, 3}),
+			},
+			expected: geom.NewMultiLineStringFlat(geom.XYZ, []float64{
+				0, 0, 1, 1, 0, 2, 1, 1, 3, 0, 1, 4, 0, 0, 1,
+			}, []int{15}),
+		},
+		{
+			name: "closed line",
+			lines: []geom.T{
+				geom.NewLinearRingFlat(geom.XY, []float64{0, 0, 1, 0, 1, 1, 0, 0}),
+			},
+			expected: geom.NewMultiLineStringFlat(geom.XY, []float64{0, 0, 1, 0, 1, 1, 0, 0}, []int{8}),
+		},
+		{
+			name: "degenerate lines",
+			lines: []geom.T{
+				geom.NewLineStringFlat(geom.XY, []float64{0, 0, 0, 0}),
+				geom.NewLineString(geom.XY),
+				geom.NewLineStringFlat(geom.XY, []float64{0, 0, 1, 0}),
+			},
+			expected: geom.NewMultiLineStringFlat(geom.XY, []float64{0, 0, 1, 0}, []int{4}),
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := Merge(tc.lines...)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestMergeErrors(t *testing.T) {
+	_, err := Merge(geom.NewPoint(geom.XY))
+	assert.Error(t, err)
+	_, err = Merge(geom.NewLineString(geom.XY), geom.NewLineString(geom.XYM))
+	assert.Equal[error](t, geom.ErrLayoutMismatch{Got: geom.XYM, Want: geom.XY}, err)
+}
@@ -0,0 +1,77 @@
+package xy
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/don4get/go-geom"
+	"github.com/don4get/go-geom/xy/internal"
+)
+
+// MinimumEnclosingCircle computes the smallest circle that contains all the points of the
+// geometry using Welzl's algorithm over the vertices of its convex hull. It returns the xy
+// center and the radius of the circle. If the geometry is empty then the center is nil.
+func MinimumEnclosingCircle(geometry geom.T) (center geom.Coord, radius float64) {
+	points := convexHullVertices(geometry)
+	if len(points) == 0 {
+		return nil, 0
+	}
+
+	// Welzl's algorithm runs in expected linear time if the points are visited in random
+	// order. A fixed seed keeps the result deterministic.
+	r := rand.New(rand.NewSource(1))
+	r.Shuffle(len(points), func(i, j int) {
+		points[i], points[j] = points[j], points[i]
+	})
+
+	center, radius = points[0], 0
+	for i := 1; i < len(points); i++ {
+		if inCircle(center, radius, points[i]) {
+			continue
+		}
+		center, radius = points[i], 0
+		for j := range i {
+			if inCircle(center, radius, points[j]) {
+				continue
+			}
+			center, radius = circleFromDiameter(points[i], points[j])
+			for k := range j {
+				if !inCircle(center, radius, points[k]) {
+					center, radius = circleFromTriangle(points[i], points[j], points[k])
+				}
+			}
+		}
+	}
+	return center, radius
+}
+
+func inCircle(center geom.Coord, radius float64, p geom.Coord) bool {
+	return internal.Distance2D(center, p) <= radius*(1+1e-12)
+}
+
+func circleFromDiameter(a, b geom.Coord) (geom.Coord, float64) {
+	center := geom.Coord{(a[0] + b[0]) / 2, (a[1] + b[1]) / 2}
+	return center, internal.Distance2D(center, a)
+}
+
+// circleFromTriangle returns the circumcircle of a, b and c, or the circle with the longest
+// side as its diameter if they are collinear.
+func circleFromTriangle(a, b, c geom.Coord) (geom.Coord, float64) {
+	bx, by := b[0]-a[0], b[1]-a[1]
+	cx, cy := c[0]-a[0], c[1]-a[1]
+	d := 2 * (bx*cy - by*cx)
+	if d == 0 {
+		ab, bc, ca := internal.Distance2D(a, b), internal.Distance2D(b, c), internal.Distance2D(c, a)
+		switch {
+		case ab >= bc && ab >= ca:
+			return circleFromDiameter(a, b)
+		case bc >= ca:
+			return circleFromDiameter(b, c)
+		default:
+			return circleFromDiameter(c, a)
+		}
+	}
+	b2, c2 := bx*bx+by*by, cx*cx+cy*cy
+	center := geom.Coord{a[0] + (cy*b2-by*c2)/d, a[1] + (bx*c2-cx*b2)/d}
+	return center, math.Max(internal.Distance2D(center, a), math.Max(internal.Distance2D(center, b), internal.Distance2D(center, c)))
+}
@@ -0,0 +1,29 @@
+package xy_test
+
+import (
+	"fmt"
+
+	"github.com/don4get/go-geom"
+	"github.com/don4get/go-geom/xy"
+)
+
+func ExampleMinimumEnclosingCircle() {
+	points := geom.NewMultiPointFlat(geom.XY, []float64{0, 0, 0, 6, 8, 0, 1, 1})
+
+	center, radius := xy.MinimumEnclosingCircle(points)
+
+	fmt.Println(center, radius)
+	// Output: [4 3] 5
+}
+
+func ExampleMaximumInscribedCircle() {
+	polygon := geom.NewPolygonFlat(geom.XY, []float64{0, 0, 4, 0, 4, 4, 0, 4, 0, 0}, []int{10})
+
+	center, radius, err := xy.MaximumInscribedCircle(polygon, 0.01)
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println(center, radius)
+	// Output: [2 2] 2
+}
@@ -0,0 +1,91 @@
+package xy
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+
+	"github.com/don4get/go-geom"
+	"github.com/don4get/go-geom/xy/internal"
+)
+
+func TestMinimumEnclosingCircle(t *testing.T) {
+	for _, tc := range []struct {
+		name           string
+		geometry       geom.T
+		expectedCenter geom.Coord
+		expectedRadius float64
+	}{
+		{
+			name:     "empty",
+			geometry: geom.NewMultiPoint(geom.XY),
+		},
+		{
+			name:           "point",
+			geometry:       geom.NewPointFlat(geom.XYZ, []float64{1, 2, 3}),
+			expectedCenter: geom.Coord{1, 2},
+		},
+		{
+			name:           "two_points",
+			geometry:       geom.NewLineStringFlat(geom.XY, []float64{0, 0, 4, 0}),
+			expectedCenter: geom.Coord{2, 0},
+			expectedRadius: 2,
+		},
+		{
+			name:           "obtuse_triangle",
+			geometry:       geom.NewLineStringFlat(geom.XY, []float64{0, 0, 4, 0, 2, 1}),
+			expectedCenter: geom.Coord{2, 0},
+			expectedRadius: 2,
+		},
+		{
+			name:           "square",
+			geometry:       geom.NewPolygonFlat(geom.XY, []float64{0, 0, 2, 0, 2, 2, 0, 2, 0, 0}, []int{10}),
+			expectedCenter: geom.Coord{1, 1},
+			expectedRadius: math.Sqrt2,
+		},
+		{
+			name: "collection",
+			geometry: geom.NewGeometryCollection().MustPush(
+				geom.NewPointFlat(geom.XY, []float64{0, 0}),
+				geom.NewPointFlat(geom.XYM, []float64{0, 6, 1}),
+				geom.NewPointFlat(geom.XY, []float64{8, 0}),
+			),
+			expectedCenter: geom.Coord{4, 3},
+			expectedRadius: 5,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			center, radius := MinimumEnclosingCircle(tc.geometry)
+			if tc.expectedCenter == nil {
+				assert.Zero(t, center)
+				return
+			}
+			assert.True(t, internal.Distance2D(tc.expectedCenter, center) < 1e-9)
+			assert.True(t, math.Abs(tc.expectedRadius-radius) < 1e-9)
+		})
+	}
+}
+
+func TestMinimumEnclosingCircleRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for range 20 {
+		var flatCoords []float64
+		for range 200 {
+			flatCoords = append(flatCoords, r.NormFloat64(), r.NormFloat64())
+		}
+		center, radius := MinimumEnclosingCircle(geom.NewMultiPointFlat(geom.XY, flatCoords))
+
+		// Every point is inside the circle and at least two are on it.
+		onCircle := 0
+		for i := 0; i < len(flatCoords); i += 2 {
+			d := internal.Distance2D(center, flatCoords[i:i+2])
+			assert.True(t, d <= radius+1e-9)
+			if math.Abs(d-radius) < 1e-9 {
+				onCircle++
+			}
+		}
+		assert.True(t, onCircle >= 2)
+	}
+}
@@ -0,0 +1,171 @@
+package xy
+
+import (
+	"math"
+
+	"github.com/don4get/go-geom"
+	"github.com/don4get/go-geom/xy/internal"
+)
+
+// MinimumAreaRectangle computes the minimum-area rotated rectangle enclosing the geometry.
+// The rectangle is found with the rotating calipers algorithm over the convex hull of the
+// geometry, using the fact that one side of the rectangle is collinear with an edge of the hull.
+//
+// The result is an xy Polygon with a counter-clockwise ring. If all the points of the geometry
+// are collinear then the result is the xy LineString between the two extreme points, and if the
+// geometry contains a single unique point then the result is an xy Point. If the geometry is
+// empty then the result is nil.
+func MinimumAreaRectangle(geometry geom.T) geom.T {
+	hull := convexHullVertices(geometry)
+	switch len(hull) {
+	case 0:
+		return nil
+	case 1:
+		return geom.NewPointFlat(geom.XY, []float64{hull[0][0], hull[0][1]})
+	case 2:
+		return geom.NewLineStringFlat(geom.XY, []float64{hull[0][0], hull[0][1], hull[1][0], hull[1][1]})
+	}
+
+	n := len(hull)
+	minArea := math.Inf(1)
+	var rectangle []float64
+	j, k, l := 1, 1, -1
+	for i := range n {
+		p0, p1 := hull[i], hull[(i+1)%n]
+		u := unitVector(p0, p1)
+		normal := geom.Coord{-u[1], u[0]}
+
+		// Advance the calipers: j is the farthest point from the edge, k the
+		// farthest point along the edge and l the farthest point behind it.
+		for dot(sub(hull[(j+1)%n], p0), normal) > dot(sub(hull[j], p0), normal) {
+			j = (j + 1) % n
+		}
+		for dot(sub(hull[(k+1)%n], p0), u) > dot(sub(hull[k], p0), u) {
+			k = (k + 1) % n
+		}
+		if l == -1 {
+			l = j
+		}
+		for dot(sub(hull[(l+1)%n], p0), u) < dot(sub(hull[l], p0), u) {
+			l = (l + 1) % n
+		}
+
+		height := dot(sub(hull[j], p0), normal)
+		minU, maxU := dot(sub(hull[l], p0), u), dot(sub(hull[k], p0), u)
+		if area := height * (maxU - minU); area < minArea {
+			minArea = area
+			rectangle = []float64{
+				p0[0] + minU*u[0], p0[1] + minU*u[1],
+				p0[0] + maxU*u[0], p0[1] + maxU*u[1],
+				p0[0] + maxU*u[0] + height*normal[0], p0[1] + maxU*u[1] + height*normal[1],
+				p0[0] + minU*u[0] + height*normal[0], p0[1] + minU*u[1] + height*normal[1],
+				p0[0] + minU*u[0], p0[1] + minU*u[1],
+			}
+		}
+	}
+	return geom.NewPolygonFlat(geom.XY, rectangle, []int{len(rectangle)})
+}
+
+// MinimumWidth computes the minimum width of the geometry, which is the smallest distance
+// between two parallel lines that enclose all of its points. It is zero if the geometry has
+// fewer than three points or if all its points are collinear.
+func MinimumWidth(geometry geom.T) float64 {
+	hull := convexHullVertices(geometry)
+	n := len(hull)
+	if n < 3 {
+		return 0
+	}
+	minWidth := math.Inf(1)
+	j := 1
+	for i := range n {
+		p0, p1 := hull[i], hull[(i+1)%n]
+		u := unitVector(p0, p1)
+		normal := geom.Coord{-u[1], u[0]}
+		for dot(sub(hull[(j+1)%n], p0), normal) > dot(sub(hull[j], p0), normal) {
+			j = (j + 1) % n
+		}
+		minWidth = math.Min(minWidth, dot(sub(hull[j], p0), normal))
+	}
+	return minWidth
+}
+
+// Diameter computes the diameter of the geometry, which is the largest distance between any
+// two of its points. The pairs of antipodal points are found with the rotating calipers
+// algorithm over the convex hull of the geometry.
+func Diameter(geometry geom.T) float64 {
+	hull := convexHullVertices(geometry)
+	n := len(hull)
+	switch n {
+	case 0, 1:
+		return 0
+	case 2:
+		return internal.Distance2D(hull[0], hull[1])
+	}
+	diameter := 0.0
+	j := 1
+	for i := range n {
+		p0, p1 := hull[i], hull[(i+1)%n]
+		for math.Abs(area2(p0, p1, hull[(j+1)%n])) > math.Abs(area2(p0, p1, hull[j])) {
+			j = (j + 1) % n
+		}
+		diameter = math.Max(diameter, math.Max(internal.Distance2D(p0, hull[j]), internal.Distance2D(p1, hull[j])))
+	}
+	return diameter
+}
+
+// convexHullVertices returns the unique xy vertices of the convex hull of the geometry in
+// counter-clockwise order.
+func convexHullVertices(geometry geom.T) []geom.Coord {
+	flatCoords := appendXY(nil, geometry)
+	if len(flatCoords) == 0 {
+		return nil
+	}
+	hull := ConvexHullFlat(geom.XY, flatCoords)
+	hullFlatCoords, stride := hull.GetFlatCoords(), hull.GetStride()
+	var vertices []geom.Coord
+	switch hull.(type) {
+	case *geom.Polygon:
+		// The hull is clockwise and closed.
+		for i := len(hullFlatCoords) - stride; i > 0; i -= stride {
+			vertices = append(vertices, geom.Coord{hullFlatCoords[i], hullFlatCoords[i+1]})
+		}
+	default:
+		for i := 0; i < len(hullFlatCoords); i += stride {
+			c := geom.Coord{hullFlatCoords[i], hullFlatCoords[i+1]}
+			if len(vertices) == 0 || !vertices[len(vertices)-1].Equal(geom.XY, c) {
+				vertices = append(vertices, c)
+			}
+		}
+	}
+	return vertices
+}
+
+// appendXY appends the xy ordinates of all the points of the geometry to flatCoords,
+// descending into GeometryCollections.
+func appendXY(flatCoords []float64, geometry geom.T) []float64 {
+	if gc, ok := geometry.(*geom.GeometryCollection); ok {
+		for _, g := range gc.Geoms() {
+			flatCoords = appendXY(flatCoords, g)
+		}
+		return flatCoords
+	}
+	geometryFlatCoords, stride := geometry.GetFlatCoords(), geometry.GetStride()
+	for i := 0; i+1 < len(geometryFlatCoords); i += stride {
+		flatCoords = append(flatCoords, geometryFlatCoords[i], geometryFlatCoords[i+1])
+	}
+	return flatCoords
+}
+
+func unitVector(from, to geom.Coord) geom.Coord {
+	dx, dy := to[0]-from[0], to[1]-from[1]
+	length := math.Hypot(dx, dy)
+	return geom.Coord{dx / length, dy / length}
+}
+
+func sub(a, b geom.Coord) geom.Coord {
+	return geom.Coord{a[0] - b[0], a[1] - b[1]}
+}
+
+func dot(a, b geom.Coord) float64 {
+	return a[0]*b[0] + a[1]*b[1]
+}
@@ -0,0 +1,24 @@
+package xy_test
+
+import (
+	"fmt"
+
+	"github.com/don4get/go-geom"
+	"github.com/don4get/go-geom/xy"
+)
+
+func ExampleMinimumAreaRectangle() {
+	points := geom.NewMultiPointFlat(geom.XY, []float64{0, 0, 4, 0, 4, 2, 1, 2, 2, 1})
+
+	rectangle := xy.MinimumAreaRectangle(points)
+
+	fmt.Println(rectangle.GetFlatCoords())
+	// Output: [0 0 4 0 4 2 0 2 0 0]
+}
+
+func ExampleMinimumWidth() {
+	rectangle := geom.NewPolygonFlat(geom.XY, []float64{0, 0, 4, 0, 4, 3, 0, 3, 0, 0}, []int{10})
+
+	fmt.Println(xy.MinimumWidth(rectangle), xy.Diameter(rectangle))
+	// Output: 3 5
+}
@@ -0,0 +1,204 @@
+package xy
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+
+	"github.com/don4get/go-geom"
+	"github.com/don4get/go-geom/xy/internal"
+)
+
+func TestMinimumAreaRectangle(t *testing.T) {
+	for _, tc := range []struct {
+		name         string
+		geometry     geom.T
+		expectedType geom.T
+		expectedArea float64
+	}{
+		{
+			name:     "empty",
+			geometry: geom.NewMultiPoint(geom.XY),
+		},
+		{
+			name:         "point",
+			geometry:     geom.NewMultiPointFlat(geom.XYZ, []float64{1, 2, 3, 1, 2, 4}),
+			expectedType: &geom.Point{},
+		},
+		{
+			name:         "collinear",
+			geometry:     geom.NewLineStringFlat(geom.XY, []float64{0, 0, 1, 1, 3, 3}),
+			expectedType: &geom.LineString{},
+		},
+		{
+			name:         "axis_aligned",
+			geometry:     geom.NewLineStringFlat(geom.XY, []float64{0, 0, 4, 0, 4, 2, 0, 2, 2, 1}),
+			expectedType: &geom.Polygon{},
+			expectedArea: 8,
+		},
+		{
+			name:         "rotated_square",
+			geometry:     geom.NewLineStringFlat(geom.XY, []float64{1, 0, 2, 1, 1, 2, 0, 1}),
+			expectedType: &geom.Polygon{},
+			expectedArea: 2,
+		},
+		{
+			name:         "triangle",
+			geometry:     geom.NewPolygonFlat(geom.XYM, []float64{0, 0, 1, 4, 0, 2, 0, 3, 3, 0, 0, 1}, []int{12}),
+			expectedType: &geom.Polygon{},
+			expectedArea: 12,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			rectangle := MinimumAreaRectangle(tc.geometry)
+			if tc.expectedType == nil {
+				assert.Zero(t, rectangle)
+				return
+			}
+			assert.Equal(t, fmt.Sprintf("%T", tc.expectedType), fmt.Sprintf("%T", rectangle))
+			if polygon, ok := rectangle.(*geom.Polygon); ok {
+				assert.Equal(t, geom.XY, polygon.GetLayout())
+				assert.True(t, math.Abs(tc.expectedArea-polygon.Area()) < 1e-9)
+				assertEnclosesXY(t, polygon, tc.geometry)
+			}
+		})
+	}
+}
+
+func TestMinimumAreaRectangleRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	angle := r.Float64() * math.Pi
+	cos, sin := math.Cos(angle), math.Sin(angle)
+	var flatCoords []float64
+	for range 1000 {
+		x, y := 6*r.Float64()-3, 2*r.Float64()-1
+		flatCoords = append(flatCoords, 10+x*cos-y*sin, 20+x*sin+y*cos)
+	}
+	flatCoords = append(flatCoords, 10-3*cos, 20-3*sin, 10+3*cos, 20+3*sin, 10+sin, 20-cos, 10-sin, 20+cos)
+	multiPoint := geom.NewMultiPointFlat(geom.XY, flatCoords)
+
+	polygon, ok := MinimumAreaRectangle(multiPoint).(*geom.Polygon)
+	assert.True(t, ok)
+	assert.True(t, polygon.Area() <= 12+1e-9)
+	assertEnclosesXY(t, polygon, multiPoint)
+
+	// Compare against projecting every point onto every hull edge.
+	hull := convexHullVertices(multiPoint)
+	minArea, minWidth := math.Inf(1), math.Inf(1)
+	for i := range hull {
+		u := unitVector(hull[i], hull[(i+1)%len(hull)])
+		minU, maxU, maxV := math.Inf(1), math.Inf(-1), 0.0
+		for j := 0; j < len(flatCoords); j += 2 {
+			d := sub(flatCoords[j:j+2], hull[i])
+			minU, maxU = math.Min(minU, dot(d, u)), math.Max(maxU, dot(d, u))
+			maxV = math.Max(maxV, math.Abs(d[0]*u[1]-d[1]*u[0]))
+		}
+		minArea, minWidth = math.Min(minArea, (maxU-minU)*maxV), math.Min(minWidth, maxV)
+	}
+	assert.True(t, math.Abs(minArea-polygon.Area()) < 1e-9)
+	assert.True(t, math.Abs(minWidth-MinimumWidth(multiPoint)) < 1e-9)
+}
+
+func TestMinimumWidth(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		geometry geom.T
+		expected float64
+	}{
+		{
+			name:     "point",
+			geometry: geom.NewPointFlat(geom.XY, []float64{1, 2}),
+		},
+		{
+			name:     "collinear",
+			geometry: geom.NewLineStringFlat(geom.XY, []float64{0, 0, 1, 1, 3, 3}),
+		},
+		{
+			name:     "rectangle",
+			geometry: geom.NewLineStringFlat(geom.XY, []float64{0, 0, 4, 0, 4, 2, 0, 2}),
+			expected: 2,
+		},
+		{
+			name:     "triangle",
+			geometry: geom.NewLineStringFlat(geom.XY, []float64{0, 0, 3, 0, 0, 4}),
+			expected: 2.4,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.True(t, math.Abs(tc.expected-MinimumWidth(tc.geometry)) < 1e-9)
+		})
+	}
+}
+
+func TestDiameter(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		geometry geom.T
+		expected float64
+	}{
+		{
+			name:     "empty",
+			geometry: geom.NewLineString(geom.XY),
+		},
+		{
+			name:     "point",
+			geometry: geom.NewPointFlat(geom.XY, []float64{1, 2}),
+		},
+		{
+			name:     "collinear",
+			geometry: geom.NewLineStringFlat(geom.XY, []float64{0, 0, 1, 1, 3, 3}),
+			expected: 3 * math.Sqrt2,
+		},
+		{
+			name:     "triangle",
+			geometry: geom.NewLineStringFlat(geom.XY, []float64{0, 0, 3, 0, 0, 4}),
+			expected: 5,
+		},
+		{
+			name:     "ring",
+			geometry: internal.TestRing,
+			expected: diameterBruteForce(internal.TestRing.GetFlatCoords()),
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.True(t, math.Abs(tc.expected-Diameter(tc.geometry)) < 1e-9)
+		})
+	}
+}
+
+func TestDiameterRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	for range 20 {
+		var flatCoords []float64
+		for range 100 {
+			flatCoords = append(flatCoords, r.NormFloat64(), r.NormFloat64())
+		}
+		expected := diameterBruteForce(flatCoords)
+		assert.True(t, math.Abs(expected-Diameter(geom.NewMultiPointFlat(geom.XY, flatCoords))) < 1e-9)
+	}
+}
+
+func diameterBruteForce(flatCoords []float64) float64 {
+	diameter := 0.0
+	for i := 0; i < len(flatCoords); i += 2 {
+		for j := i + 2; j < len(flatCoords); j += 2 {
+			diameter = math.Max(diameter, internal.Distance2D(flatCoords[i:i+2], flatCoords[j:j+2]))
+		}
+	}
+	return diameter
+}
+
+// assertEnclosesXY asserts that every point of geometry is inside or on the boundary of
+// polygon, allowing for rounding errors.
+func assertEnclosesXY(t *testing.T, polygon *geom.Polygon, geometry geom.T) {
+	t.Helper()
+	ring := polygon.LinearRing(0).FlatCoords
+	flatCoords, stride := geometry.GetFlatCoords(), geometry.GetStride()
+	for i := 0; i < len(flatCoords); i += stride {
+		p := geom.Coord(flatCoords[i : i+2])
+		assert.True(t, IsPointInRing(geom.XY, p, ring) || DistanceFromPointToLineString(geom.XY, p, ring) < 1e-9)
+	}
+}
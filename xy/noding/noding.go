@@ -0,0 +1,408 @@
+// Package noding finds the intersections between the segments of lines and splits lines at
+// them.
+//
+// Candidate pairs of segments are found by breaking each line into monotone chains, runs of
+// segments whose x and y ordinates both never decrease or never increase, and sweeping
+// along the x axis over the chains' envelopes. Pairs of segments are then intersected with
+// the lineintersector.RobustLineIntersector.
+package noding
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/don4get/go-geom"
+	"github.com/don4get/go-geom/xy/lineintersection"
+	"github.com/don4get/go-geom/xy/lineintersector"
+)
+
+// An Intersection is an intersection between two segments. Lines are numbered in the order
+// that they are passed, with each LineString of a MultiLineString counting as a separate
+// line. Segment i of a line runs from its coordinate i to its coordinate i+1. Line1 and
+// Segment1 always sort before Line2 and Segment2.
+type Intersection struct {
+	Line1, Segment1 int
+	Line2, Segment2 int
+	// Type is either lineintersection.PointIntersection or
+	// lineintersection.CollinearIntersection.
+	Type lineintersection.Type
+	// Coords contains the xy intersection point, or the xy end points of the overlap
+	// for a collinear intersection.
+	Coords []geom.Coord
+}
+
+// Intersections returns all the intersections between the segments of lines, which must be
+// LineStrings, LinearRings or MultiLineStrings with the same layout, sorted by line and
+// segment. The intersections between consecutive segments of a line at their shared
+// coordinate are not reported.
+func Intersections(lines ...geom.T) ([]Intersection, error) {
+	n, err := newNoder(lines)
+	if err != nil {
+		return nil, err
+	}
+	return n.intersections(), nil
+}
+
+// IsSimple returns whether g, which must be a LineString, LinearRing or MultiLineString,
+// is simple, i.e. whether none of its lines intersect themselves or each other except at
+// their end points. A closed line may touch itself at its start and end point.
+func IsSimple(g geom.T) (bool, error) {
+	n, err := newNoder([]geom.T{g})
+	if err != nil {
+		return false, err
+	}
+	for _, i := range n.intersections() {
+		if i.Line1 == i.Line2 || i.Type != lineintersection.PointIntersection {
+			return false, nil
+		}
+		if !n.isBoundary(i.Line1, i.Coords[0]) || !n.isBoundary(i.Line2, i.Coords[0]) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// Split splits lines, which must be LineStrings, LinearRings or MultiLineStrings, at all
+// of their intersections with themselves and with each other, and returns all the
+// resulting lines. Ordinates other than x and y are interpolated at the new coordinates.
+// Lines with fewer than two coordinates, such as empty lines, are dropped. The layout of
+// the result is the layout of the first line, and all lines must have the same layout.
+func Split(lines ...geom.T) (*geom.MultiLineString, error) {
+	n, err := newNoder(lines)
+	if err != nil {
+		return nil, err
+	}
+	nodes := make([][]node, len(n.lines))
+	addNode := func(line, segment int, coord geom.Coord) {
+		l := n.lines[line]
+		start := l.flatCoords[segment*l.stride:]
+		end := l.flatCoords[(segment+1)*l.stride:]
+		nodes[line] = append(nodes[line], node{segment: segment, t: segmentFraction(start, end, coord)})
+	}
+	for _, i := range n.intersections() {
+		for _, coord := range i.Coords {
+			addNode(i.Line1, i.Segment1, coord)
+			addNode(i.Line2, i.Segment2, coord)
+		}
+	}
+
+	layout := geom.NoLayout
+	if len(lines) != 0 {
+		layout = lines[0].GetLayout()
+	}
+	mls := geom.NewMultiLineString(layout)
+	for i, l := range n.lines {
+		// Lines without segments are kept by the noder so that the line numbers of
+		// intersections match the input, but have nothing to split.
+		if l.numSegments() < 1 {
+			continue
+		}
+		for _, part := range l.split(nodes[i]) {
+			if err := mls.Push(geom.NewLineStringFlat(layout, part)); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return mls, nil
+}
+
+// A line is a line to be noded.
+type line struct {
+	flatCoords []float64
+	stride     int
+	closed     bool
+}
+
+// numSegments returns the number of segments in l.
+func (l *line) numSegments() int {
+	return len(l.flatCoords)/l.stride - 1
+}
+
+// coord returns the xy coordinate at index i of l.
+func (l *line) coord(i int) geom.Coord {
+	return geom.Coord{l.flatCoords[i*l.stride], l.flatCoords[i*l.stride+1]}
+}
+
+// split returns the parts of l between nodes.
+func (l *line) split(nodes []node) [][]float64 {
+	// Move the nodes at the end of a segment to the start of the next one, and drop the
+	// nodes at the ends of the line.
+	normalized := make([]node, 0, len(nodes))
+	for _, n := range nodes {
+		switch {
+		case n.t >= 1:
+			n = node{segment: n.segment + 1}
+		case n.t <= 0:
+			n.t = 0
+		}
+		if n.t == 0 && (n.segment == 0 || n.segment == l.numSegments()) {
+			continue
+		}
+		normalized = append(normalized, n)
+	}
+	slices.SortFunc(normalized, func(a, b node) int {
+		return cmp.Or(cmp.Compare(a.segment, b.segment), cmp.Compare(a.t, b.t))
+	})
+
+	var parts [][]float64
+	part := slices.Clone(l.flatCoords[:l.stride])
+	for segment := range l.numSegments() {
+		start := l.flatCoords[segment*l.stride : (segment+1)*l.stride]
+		end := l.flatCoords[(segment+1)*l.stride : (segment+2)*l.stride]
+		for len(normalized) != 0 && normalized[0].segment == segment {
+			nodeCoord := start
+			if t := normalized[0].t; t != 0 {
+				nodeCoord = interpolate(start, end, t)
+				part = append(part, nodeCoord...)
+			}
+			parts = appendPart(parts, part, l.stride)
+			part = slices.Clone(nodeCoord)
+			normalized = normalized[1:]
+		}
+		part = append(part, end...)
+	}
+	return appendPart(parts, part, l.stride)
+}
+
+// appendPart appends part to parts after removing its consecutive duplicate coordinates, if
+// it has at least two distinct coordinates.
+func appendPart(parts [][]float64, part []float64, stride int) [][]float64 {
+	var deduped []float64
+	for i := 0; i < len(part); i += stride {
+		if n := len(deduped); n == 0 || !equalXY(deduped[n-stride:], part[i:i+stride]) {
+			deduped = append(deduped, part[i:i+stride]...)
+		}
+	}
+	if len(deduped) < 2*stride {
+		return parts
+	}
+	return append(parts, deduped)
+}
+
+// A node is a point at which a line is split, at fraction t along one of its segments.
+type node struct {
+	segment int
+	t       float64
+}
+
+// A chain is a monotone chain, a run of segments of a line in which the x and y ordinates
+// never change direction. Its envelope is therefore the envelope of its end points.
+type chain struct {
+	line       int
+	start, end int
+	minX, maxX float64
+}
+
+// noder finds intersections between the segments of lines.
+type noder struct {
+	lines  []*line
+	chains []chain
+}
+
+func newNoder(gs []geom.T) (*noder, error) {
+	n := &noder{}
+	for _, g := range gs {
+		if g.GetLayout() != gs[0].GetLayout() {
+			return nil, geom.ErrLayoutMismatch{Got: g.GetLayout(), Want: gs[0].GetLayout()}
+		}
+		switch g := g.(type) {
+		case *geom.LineString, *geom.LinearRing:
+			n.addLine(g.GetFlatCoords(), g.GetStride())
+		case *geom.MultiLineString:
+			for i := range g.NumLineStrings() {
+				n.addLine(g.LineString(i).FlatCoords, g.Stride)
+			}
+		default:
+			return nil, geom.ErrUnsupportedType{Value: g}
+		}
+	}
+	slices.SortFunc(n.chains, func(a, b chain) int {
+		return cmp.Compare(a.minX, b.minX)
+	})
+	return n, nil
+}
+
+// addLine adds a line and its monotone chains to n.
+func (n *noder) addLine(flatCoords []float64, stride int) {
+	l := &line{
+		flatCoords: flatCoords,
+		stride:     stride,
+	}
+	if len(flatCoords) > stride {
+		l.closed = equalXY(flatCoords, flatCoords[len(flatCoords)-stride:])
+	}
+	lineIndex := len(n.lines)
+	n.lines = append(n.lines, l)
+
+	for start := 0; start < l.numSegments(); {
+		quadrant, end := -1, start
+		for ; end < l.numSegments(); end++ {
+			if q := l.quadrant(end); q == -1 {
+				continue
+			} else if quadrant == -1 {
+				quadrant = q
+			} else if q != quadrant {
+				break
+			}
+		}
+		x0, x1 := l.flatCoords[start*stride], l.flatCoords[end*stride]
+		n.chains = append(n.chains, chain{
+			line:  lineIndex,
+			start: start,
+			end:   end,
+			minX:  min(x0, x1),
+			maxX:  max(x0, x1),
+		})
+		start = end
+	}
+}
+
+// repeats returns whether the coordinates from index i to index j of l are all equal.
+func (l *line) repeats(i, j int) bool {
+	for k := i + 1; k <= j; k++ {
+		if !equalXY(l.flatCoords[i*l.stride:], l.flatCoords[k*l.stride:]) {
+			return false
+		}
+	}
+	return true
+}
+
+// quadrant returns the quadrant of the direction of segment i of l, or -1 if the segment has
+// zero length.
+func (l *line) quadrant(i int) int {
+	dx := l.flatCoords[(i+1)*l.stride] - l.flatCoords[i*l.stride]
+	dy := l.flatCoords[(i+1)*l.stride+1] - l.flatCoords[i*l.stride+1]
+	switch {
+	case dx == 0 && dy == 0:
+		return -1
+	case dx >= 0 && dy >= 0:
+		return 0
+	case dx < 0 && dy >= 0:
+		return 1
+	case dx < 0:
+		return 2
+	default:
+		return 3
+	}
+}
+
+// intersections returns all the non-trivial intersections between the segments of n's
+// lines, sorted.
+func (n *noder) intersections() []Intersection {
+	var result []Intersection
+	for i, c1 := range n.chains {
+		for _, c2 := range n.chains[i+1:] {
+			if c2.minX > c1.maxX {
+				break
+			}
+			result = n.overlap(result, c1, c1.start, c1.end, c2, c2.start, c2.end)
+		}
+	}
+	slices.SortFunc(result, func(a, b Intersection) int {
+		return cmp.Or(
+			cmp.Compare(a.Line1, b.Line1),
+			cmp.Compare(a.Segment1, b.Segment1),
+			cmp.Compare(a.Line2, b.Line2),
+			cmp.Compare(a.Segment2, b.Segment2),
+		)
+	})
+	return result
+}
+
+// overlap appends the intersections between the segments from start1 to end1 of c1 and
+// the segments from start2 to end2 of c2 to result, recursively halving the ranges of
+// segments while their envelopes overlap.
+func (n *noder) overlap(result []Intersection, c1 chain, start1, end1 int, c2 chain, start2, end2 int) []Intersection {
+	l1, l2 := n.lines[c1.line], n.lines[c2.line]
+	if !envelopesOverlap(l1.coord(start1), l1.coord(end1), l2.coord(start2), l2.coord(end2)) {
+		return result
+	}
+	if end1-start1 == 1 && end2-start2 == 1 {
+		return n.intersect(result, c1.line, start1, c2.line, start2)
+	}
+	if end1-start1 >= end2-start2 {
+		mid := (start1 + end1) / 2
+		result = n.overlap(result, c1, start1, mid, c2, start2, end2)
+		return n.overlap(result, c1, mid, end1, c2, start2, end2)
+	}
+	mid := (start2 + end2) / 2
+	result = n.overlap(result, c1, start1, end1, c2, start2, mid)
+	return n.overlap(result, c1, start1, end1, c2, mid, end2)
+}
+
+// intersect appends the intersection between segment segment1 of line line1 and segment
+// segment2 of line line2 to result, unless it is trivial.
+func (n *noder) intersect(result []Intersection, line1, segment1, line2, segment2 int) []Intersection {
+	if line1 > line2 || line1 == line2 && segment1 > segment2 {
+		line1, segment1, line2, segment2 = line2, segment2, line1, segment1
+	}
+	l1, l2 := n.lines[line1], n.lines[line2]
+	if l1.quadrant(segment1) == -1 || l2.quadrant(segment2) == -1 {
+		// Zero length segments are covered by their neighbors.
+		return result
+	}
+	r := lineintersector.LineIntersectsLine(lineintersector.RobustLineIntersector{},
+		l1.coord(segment1), l1.coord(segment1+1), l2.coord(segment2), l2.coord(segment2+1))
+	if !r.HasIntersection() {
+		return result
+	}
+	coords := make([]geom.Coord, 0, 2)
+	for _, c := range r.Intersection() {
+		coords = append(coords, geom.Coord{c[0], c[1]})
+	}
+	if line1 == line2 && r.Type() == lineintersection.PointIntersection {
+		// Consecutive segments always meet at their shared coordinate, as do the first
+		// and last segments of a closed line. Repeated coordinates may separate them.
+		if equalXY(coords[0], l1.coord(segment1+1)) && l1.repeats(segment1+1, segment2) {
+			return result
+		}
+		if l1.closed && equalXY(coords[0], l1.coord(0)) && l1.repeats(0, segment1) && l1.repeats(segment2+1, l1.numSegments()) {
+			return result
+		}
+	}
+	return append(result, Intersection{
+		Line1:    line1,
+		Segment1: segment1,
+		Line2:    line2,
+		Segment2: segment2,
+		Type:     r.Type(),
+		Coords:   coords,
+	})
+}
+
+// isBoundary returns whether coord is a boundary point of line i, i.e. one of the end
+// points of a line that is not closed.
+func (n *noder) isBoundary(i int, coord geom.Coord) bool {
+	l := n.lines[i]
+	return !l.closed && (equalXY(coord, l.coord(0)) || equalXY(coord, l.coord(l.numSegments())))
+}
+
+// envelopesOverlap returns whether the envelope of a1 and a2 overlaps the envelope of b1 and
+// b2.
+func envelopesOverlap(a1, a2, b1, b2 geom.Coord) bool {
+	return min(a1[0], a2[0]) <= max(b1[0], b2[0]) && min(b1[0], b2[0]) <= max(a1[0], a2[0]) &&
+		min(a1[1], a2[1]) <= max(b1[1], b2[1]) && min(b1[1], b2[1]) <= max(a1[1], a2[1])
+}
+
+// segmentFraction returns the fraction along the segment from start to end of coord, which
+// is assumed to be on the segment.
+func segmentFraction(start, end []float64, coord geom.Coord) float64 {
+	dx, dy := end[0]-start[0], end[1]-start[1]
+	if dx == 0 && dy == 0 {
+		return 0
+	}
+	return ((coord[0]-start[0])*dx + (coord[1]-start[1])*dy) / (dx*dx + dy*dy)
+}
+
+// interpolate returns the coordinate at fraction t along the segment from start to end.
+func interpolate(start, end []float64, t float64) []float64 {
+	coord := make([]float64, len(start))
+	for i := range start {
+		coord[i] = start[i] + t*(end[i]-start[i])
+	}
+	return coord
+}
+
+func equalXY(a, b []float64) bool {
+	return a[0] == b[0] && a[1] == b[1]
+}
@@ -0,0 +1,39 @@
+package noding_test
+
+import (
+	"fmt"
+
+	"github.com/don4get/go-geom"
+	"github.com/don4get/go-geom/xy/noding"
+)
+
+func ExampleIsSimple() {
+	track := geom.NewLineStringFlat(geom.XY, []float64{0, 0, 10, 0, 10, 10, 5, 10, 5, -5})
+
+	simple, err := noding.IsSimple(track)
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println(simple)
+	// Output: false
+}
+
+func ExampleSplit() {
+	line1 := geom.NewLineStringFlat(geom.XY, []float64{0, 0, 10, 10})
+	line2 := geom.NewLineStringFlat(geom.XY, []float64{0, 10, 10, 0})
+
+	split, err := noding.Split(line1, line2)
+	if err != nil {
+		panic(err)
+	}
+
+	for i := range split.NumLineStrings() {
+		fmt.Println(split.LineString(i).FlatCoords)
+	}
+	// Output:
+	// [0 0 5 5]
+	// [5 5 10 10]
+	// [0 10 5 5]
+	// [5 5 10 0]
+}
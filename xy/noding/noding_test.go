@@ -0,0 +1,273 @@
+package noding
+
+import (
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+
+	"github.com/don4get/go-geom"
+	"github.com/don4get/go-geom/xy/lineintersection"
+)
+
+func TestIntersections(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		lines    []geom.T
+		expected []Intersection
+	}{
+		{
+			name: "crossing lines",
+			lines: []geom.T{
+				geom.NewLineStringFlat(geom.XY, []float64{0, 0, 10, 10}),
+				geom.NewLineStringFlat(geom.XY, []float64{0, 10, 10, 0}),
+			},
+			expected: []Intersection{
+				{Line1: 0, Segment1: 0, Line2: 1, Segment2: 0, Type: lineintersection.PointIntersection, Coords: []geom.Coord{{5, 5}}},
+			},
+		},
+		{
+			name: "disjoint lines",
+			lines: []geom.T{
+				geom.NewLineStringFlat(geom.XY, []float64{0, 0, 10, 0}),
+				geom.NewLineStringFlat(geom.XY, []float64{0, 1, 10, 1}),
+			},
+		},
+		{
+			name: "self-intersecting line",
+			lines: []geom.T{
+				geom.NewLineStringFlat(geom.XY, []float64{0, 0, 10, 0, 10, 10, 5, 10, 5, -5}),
+			},
+			expected: []Intersection{
+				{Line1: 0, Segment1: 0, Line2: 0, Segment2: 3, Type: lineintersection.PointIntersection, Coords: []geom.Coord{{5, 0}}},
+			},
+		},
+		{
+			name: "collinear overlap",
+			lines: []geom.T{
+				geom.NewMultiLineStringFlat(geom.XY, []float64{0, 0, 10, 0, 5, 0, 15, 0}, []int{4, 8}),
+			},
+			expected: []Intersection{
+				{Line1: 0, Segment1: 0, Line2: 1, Segment2: 0, Type: lineintersection.CollinearIntersection, Coords: []geom.Coord{{5, 0}, {10, 0}}},
+			},
+		},
+		{
+			name: "many segments",
+			lines: []geom.T{
+				geom.NewLineStringFlat(geom.XY, []float64{0, 0, 1, 1, 2, 0, 3, 1, 4, 0, 5, 1, 6, 0}),
+				geom.NewLineStringFlat(geom.XY, []float64{0, 0.5, 6, 0.5}),
+			},
+			expected: []Intersection{
+				{Line1: 0, Segment1: 0, Line2: 1, Segment2: 0, Type: lineintersection.PointIntersection, Coords: []geom.Coord{{0.5, 0.5}}},
+				{Line1: 0, Segment1: 1, Line2: 1, Segment2: 0, Type: lineintersection.PointIntersection, Coords: []geom.Coord{{1.5, 0.5}}},
+				{Line1: 0, Segment1: 2, Line2: 1, Segment2: 0, Type: lineintersection.PointIntersection, Coords: []geom.Coord{{2.5, 0.5}}},
+				{Line1: 0, Segment1: 3, Line2: 1, Segment2: 0, Type: lineintersection.PointIntersection, Coords: []geom.Coord{{3.5, 0.5}}},
+				{Line1: 0, Segment1: 4, Line2: 1, Segment2: 0, Type: lineintersection.PointIntersection, Coords: []geom.Coord{{4.5, 0.5}}},
+				{Line1: 0, Segment1: 5, Line2: 1, Segment2: 0, Type: lineintersection.PointIntersection, Coords: []geom.Coord{{5.5, 0.5}}},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := Intersections(tc.lines...)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestIsSimple(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		g        geom.T
+		expected bool
+	}{
+		{
+			name:     "empty",
+			g:        geom.NewLineString(geom.XY),
+			expected: true,
+		},
+		{
+			name:     "simple line",
+			g:        geom.NewLineStringFlat(geom.XY, []float64{0, 0, 1, 1, 2, 0, 3, 1}),
+			expected: true,
+		},
+		{
+			name:     "repeated coordinates",
+			g:        geom.NewLineStringFlat(geom.XY, []float64{0, 0, 1, 1, 1, 1, 1, 1, 2, 0}),
+			expected: true,
+		},
+		{
+			name:     "self-intersecting track",
+			g:        geom.NewLineStringFlat(geom.XYZ, []float64{0, 0, 0, 10, 0, 1, 10, 10, 2, 5, 10, 3, 5, -5, 4}),
+			expected: false,
+		},
+		{
+			name:     "touching itself",
+			g:        geom.NewLineStringFlat(geom.XY, []float64{0, 0, 10, 0, 10, 10, 5, 0}),
+			expected: false,
+		},
+		{
+			name:     "backtracking",
+			g:        geom.NewLineStringFlat(geom.XY, []float64{0, 0, 10, 0, 5, 0}),
+			expected: false,
+		},
+		{
+			name:     "closed ring",
+			g:        geom.NewLinearRingFlat(geom.XY, []float64{0, 0, 10, 0, 10, 10, 0, 10, 0, 0}),
+			expected: true,
+		},
+		{
+			name:     "bow tie",
+			g:        geom.NewLinearRingFlat(geom.XY, []float64{0, 0, 10, 10, 10, 0, 0, 10, 0, 0}),
+			expected: false,
+		},
+		{
+			name:     "multilinestring touching at end points",
+			g:        geom.NewMultiLineStringFlat(geom.XY, []float64{0, 0, 10, 0, 10, 0, 10, 10, 10, 0, 20, 0}, []int{4, 8, 12}),
+			expected: true,
+		},
+		{
+			name:     "multilinestring touching at an interior point",
+			g:        geom.NewMultiLineStringFlat(geom.XY, []float64{0, 0, 10, 0, 5, 0, 5, 10}, []int{4, 8}),
+			expected: false,
+		},
+		{
+			name:     "multilinestring touching a closed line",
+			g:        geom.NewMultiLineStringFlat(geom.XY, []float64{0, 0, 10, 0, 10, 10, 0, 0, 0, 0, -5, 0}, []int{8, 12}),
+			expected: false,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := IsSimple(tc.g)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestSplit(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		lines    []geom.T
+		expected *geom.MultiLineString
+	}{
+		{
+			name: "crossing lines",
+			lines: []geom.T{
+				geom.NewLineStringFlat(geom.XY, []float64{0, 0, 10, 10}),
+				geom.NewLineStringFlat(geom.XY, []float64{0, 10, 10, 0}),
+			},
+			expected: geom.NewMultiLineStringFlat(geom.XY, []float64{
+				0, 0, 5, 5,
+				5, 5, 10, 10,
+				0, 10, 5, 5,
+				5, 5, 10, 0,
+			}, []int{4, 8, 12, 16}),
+		},
+		{
+			name: "self-intersecting track with z",
+			lines: []geom.T{
+				geom.NewLineStringFlat(geom.XYZ, []float64{0, 0, 0, 10, 0, 10, 10, 10, 20, 5, 10, 30, 5, -5, 45}),
+			},
+			expected: geom.NewMultiLineStringFlat(geom.XYZ, []float64{
+				0, 0, 0, 5, 0, 5,
+				5, 0, 5, 10, 0, 10, 10, 10, 20, 5, 10, 30, 5, 0, 40,
+				5, 0, 40, 5, -5, 45,
+			}, []int{6, 21, 27}),
+		},
+		{
+			name: "node at a vertex",
+			lines: []geom.T{
+				geom.NewLineStringFlat(geom.XY, []float64{0, 0, 5, 0, 10, 0}),
+				geom.NewLineStringFlat(geom.XY, []float64{5, -5, 5, 5}),
+			},
+			expected: geom.NewMultiLineStringFlat(geom.XY, []float64{
+				0, 0, 5, 0,
+				5, 0, 10, 0,
+				5, -5, 5, 0,
+				5, 0, 5, 5,
+			}, []int{4, 8, 12, 16}),
+		},
+		{
+			name: "touching at end points",
+			lines: []geom.T{
+				geom.NewLineStringFlat(geom.XY, []float64{0, 0, 5, 0}),
+				geom.NewLineStringFlat(geom.XY, []float64{5, 0, 5, 5}),
+			},
+			expected: geom.NewMultiLineStringFlat(geom.XY, []float64{
+				0, 0, 5, 0,
+				5, 0, 5, 5,
+			}, []int{4, 8}),
+		},
+		{
+			name: "collinear overlap",
+			lines: []geom.T{
+				geom.NewLineStringFlat(geom.XY, []float64{0, 0, 10, 0}),
+				geom.NewLineStringFlat(geom.XY, []float64{5, 0, 15, 0}),
+			},
+			expected: geom.NewMultiLineStringFlat(geom.XY, []float64{
+				0, 0, 5, 0,
+				5, 0, 10, 0,
+				5, 0, 10, 0,
+				10, 0, 15, 0,
+			}, []int{4, 8, 12, 16}),
+		},
+		{
+			name: "closed ring crossed by a line",
+			lines: []geom.T{
+				geom.NewLinearRingFlat(geom.XY, []float64{0, 0, 10, 0, 10, 10, 0, 10, 0, 0}),
+				geom.NewLineStringFlat(geom.XY, []float64{5, 5, 15, 5}),
+			},
+			expected: geom.NewMultiLineStringFlat(geom.XY, []float64{
+				0, 0, 10, 0, 10, 5,
+				10, 5, 10, 10, 0, 10, 0, 0,
+				5, 5, 10, 5,
+				10, 5, 15, 5,
+			}, []int{6, 14, 18, 22}),
+		},
+		{
+			name: "empty line",
+			lines: []geom.T{
+				geom.NewLineString(geom.XY),
+				geom.NewLineStringFlat(geom.XY, []float64{0, 0, 1, 1}),
+			},
+			expected: geom.NewMultiLineStringFlat(geom.XY, []float64{0, 0, 1, 1}, []int{4}),
+		},
+		{
+			name: "multilinestring with empty part",
+			lines: []geom.T{
+				geom.NewMultiLineStringFlat(geom.XY, []float64{0, 0, 1, 1}, []int{4, 4}),
+				geom.NewLineStringFlat(geom.XY, []float64{0, 1, 1, 0}),
+			},
+			expected: geom.NewMultiLineStringFlat(geom.XY, []float64{
+				0, 0, 0.5, 0.5,
+				0.5, 0.5, 1, 1,
+				0, 1, 0.5, 0.5,
+				0.5, 0.5, 1, 0,
+			}, []int{4, 8, 12, 16}),
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := Split(tc.lines...)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestUnsupportedType(t *testing.T) {
+	_, err := Intersections(geom.NewPointFlat(geom.XY, []float64{0, 0}))
+	assert.Error(t, err)
+	_, err = IsSimple(geom.NewPolygon(geom.XY))
+	assert.Error(t, err)
+	_, err = Split(geom.NewMultiPoint(geom.XY))
+	assert.Error(t, err)
+}
+
+func TestLayoutMismatch(t *testing.T) {
+	xy := geom.NewLineStringFlat(geom.XY, []float64{0, 0, 1, 1})
+	xyz := geom.NewLineStringFlat(geom.XYZ, []float64{0, 1, 0, 1, 0, 0})
+	_, err := Intersections(xy, xyz)
+	assert.Equal[error](t, geom.ErrLayoutMismatch{Got: geom.XYZ, Want: geom.XY}, err)
+	_, err = Split(xy, xyz)
+	assert.Equal[error](t, geom.ErrLayoutMismatch{Got: geom.XYZ, Want: geom.XY}, err)
+}
@@ -0,0 +1,436 @@
+// Package polygonize builds polygons from noded linework.
+//
+// The lines form a planar graph whose nodes are the end points of the lines. Dangles, the
+// lines that end at a node without any other lines, are removed first, repeatedly. The
+// faces of the remaining graph are then traced by always turning as far right as possible
+// at each node. Cut edges, the lines that have the same face on both sides, are removed,
+// and the faces are traced again. Counter-clockwise faces become the exterior rings of
+// polygons and clockwise faces, the outlines of connected parts of the graph, become the
+// holes of the smallest polygon that contains them.
+package polygonize
+
+import (
+	"cmp"
+	"encoding/binary"
+	"math"
+	"slices"
+
+	"github.com/don4get/go-geom"
+	"github.com/don4get/go-geom/xy"
+	"github.com/don4get/go-geom/xy/location"
+)
+
+// A Result is the result of polygonizing lines.
+type Result struct {
+	// Polygons are the polygons formed by the lines. Their exterior rings are
+	// counter-clockwise and their holes are clockwise.
+	Polygons []*geom.Polygon
+	// Dangles are the lines that have an end point that is not shared with any other
+	// line, once the other dangles have been removed.
+	Dangles []*geom.LineString
+	// CutEdges are the lines that have the same polygon, or no polygon, on both sides.
+	CutEdges []*geom.LineString
+}
+
+// Polygonize builds the polygons formed by lines, which must be LineStrings, LinearRings
+// or MultiLineStrings. The lines must be correctly noded, i.e. they must only meet at their
+// end points; noding.Split nodes arbitrary lines. Duplicate lines, in either direction,
+// are only used once, and lines with fewer than two distinct coordinates are dropped. All
+// lines must have the same layout, which is the layout of the result.
+func Polygonize(lines ...geom.T) (*Result, error) {
+	layout := geom.NoLayout
+	if len(lines) != 0 {
+		layout = lines[0].GetLayout()
+	}
+	p := &polygonizer{
+		layout:      layout,
+		stride:      layout.Stride(),
+		nodeIndexes: make(map[[2]float64]int),
+		edgeKeys:    make(map[string]struct{}),
+	}
+	for _, g := range lines {
+		if g.GetLayout() != layout {
+			return nil, geom.ErrLayoutMismatch{Got: g.GetLayout(), Want: layout}
+		}
+		switch g := g.(type) {
+		case *geom.LineString, *geom.LinearRing:
+			p.addEdge(g.GetFlatCoords())
+		case *geom.MultiLineString:
+			for i := range g.NumLineStrings() {
+				p.addEdge(g.LineString(i).FlatCoords)
+			}
+		default:
+			return nil, geom.ErrUnsupportedType{Value: g}
+		}
+	}
+	for _, n := range p.nodes {
+		slices.SortFunc(n.out, func(a, b int) int {
+			return cmp.Compare(p.angle(a), p.angle(b))
+		})
+	}
+
+	result := &Result{}
+	for _, e := range p.removeDangles() {
+		result.Dangles = append(result.Dangles, geom.NewLineStringFlat(layout, p.edges[e].flatCoords))
+	}
+	for _, e := range p.removeCutEdges() {
+		result.CutEdges = append(result.CutEdges, geom.NewLineStringFlat(layout, p.edges[e].flatCoords))
+	}
+	polygons, err := p.polygons()
+	if err != nil {
+		return nil, err
+	}
+	result.Polygons = polygons
+	return result, nil
+}
+
+// An edge is an input line. Each edge has two directed edges: directed edge 2*i runs along
+// edge i from its first coordinate to its last, and directed edge 2*i+1 runs back.
+type edge struct {
+	flatCoords []float64
+	nodes      [2]int
+	removed    bool
+}
+
+// A node is an end point of one or more edges.
+type node struct {
+	// out contains the directed edges that leave the node, sorted by angle.
+	out []int
+}
+
+// polygonizer builds polygons from the planar graph formed by noded lines.
+type polygonizer struct {
+	layout      geom.Layout
+	stride      int
+	edges       []edge
+	nodes       []*node
+	nodeIndexes map[[2]float64]int
+	edgeKeys    map[string]struct{}
+	// next contains the directed edge that follows each directed edge around its face.
+	next []int
+}
+
+// addEdge adds the line in flatCoords to p, unless it has fewer than two distinct
+// coordinates or duplicates an edge that has already been added.
+func (p *polygonizer) addEdge(flatCoords []float64) {
+	n := len(flatCoords)
+	if n < 2*p.stride || p.firstDistinct(flatCoords, 0, p.stride) == -1 {
+		return
+	}
+	key := p.edgeKey(flatCoords)
+	if _, ok := p.edgeKeys[key]; ok {
+		return
+	}
+	p.edgeKeys[key] = struct{}{}
+
+	e := len(p.edges)
+	start := p.node(flatCoords[0], flatCoords[1])
+	end := p.node(flatCoords[n-p.stride], flatCoords[n-p.stride+1])
+	p.edges = append(p.edges, edge{
+		flatCoords: flatCoords,
+		nodes:      [2]int{start, end},
+	})
+	p.nodes[start].out = append(p.nodes[start].out, 2*e)
+	p.nodes[end].out = append(p.nodes[end].out, 2*e+1)
+}
+
+// edgeKey returns a key that is the same for lines with the same xy coordinates in either
+// direction.
+func (p *polygonizer) edgeKey(flatCoords []float64) string {
+	n := len(flatCoords) / p.stride
+	forward := true
+	for i := range n {
+		a, b := flatCoords[i*p.stride:], flatCoords[(n-1-i)*p.stride:]
+		if a[0] != b[0] {
+			forward = a[0] < b[0]
+			break
+		}
+		if a[1] != b[1] {
+			forward = a[1] < b[1]
+			break
+		}
+	}
+	key := make([]byte, 0, 16*n)
+	for i := range n {
+		j := i
+		if !forward {
+			j = n - 1 - i
+		}
+		key = binary.LittleEndian.AppendUint64(key, math.Float64bits(flatCoords[j*p.stride]))
+		key = binary.LittleEndian.AppendUint64(key, math.Float64bits(flatCoords[j*p.stride+1]))
+	}
+	return string(key)
+}
+
+// node returns the index of the node at x, y, adding it if needed.
+func (p *polygonizer) node(x, y float64) int {
+	key := [2]float64{x, y}
+	if i, ok := p.nodeIndexes[key]; ok {
+		return i
+	}
+	i := len(p.nodes)
+	p.nodes = append(p.nodes, &node{})
+	p.nodeIndexes[key] = i
+	return i
+}
+
+// from returns the node that directed edge d leaves.
+func (p *polygonizer) from(d int) int {
+	return p.edges[d/2].nodes[d%2]
+}
+
+// to returns the node that directed edge d arrives at.
+func (p *polygonizer) to(d int) int {
+	return p.from(d ^ 1)
+}
+
+// coords returns the coordinates of directed edge d, in its direction.
+func (p *polygonizer) coords(d int) []float64 {
+	flatCoords := p.edges[d/2].flatCoords
+	if d%2 == 0 {
+		return flatCoords
+	}
+	reversed := make([]float64, 0, len(flatCoords))
+	for i := len(flatCoords) - p.stride; i >= 0; i -= p.stride {
+		reversed = append(reversed, flatCoords[i:i+p.stride]...)
+	}
+	return reversed
+}
+
+// angle returns the angle at which directed edge d leaves its node.
+func (p *polygonizer) angle(d int) float64 {
+	flatCoords := p.edges[d/2].flatCoords
+	start, step := 0, p.stride
+	if d%2 == 1 {
+		start, step = len(flatCoords)-p.stride, -p.stride
+	}
+	i := p.firstDistinct(flatCoords, start, step)
+	return math.Atan2(flatCoords[i+1]-flatCoords[start+1], flatCoords[i]-flatCoords[start])
+}
+
+// firstDistinct returns the offset of the first coordinate in flatCoords after the one at
+// start, stepping by step, that differs from it in x or y, or -1 if there is none.
+func (p *polygonizer) firstDistinct(flatCoords []float64, start, step int) int {
+	for i := start + step; 0 <= i && i < len(flatCoords); i += step {
+		if flatCoords[i] != flatCoords[start] || flatCoords[i+1] != flatCoords[start+1] {
+			return i
+		}
+	}
+	return -1
+}
+
+// degree returns the number of directed edges that leave node n and have not been
+// removed.
+func (p *polygonizer) degree(n int) int {
+	degree := 0
+	for _, d := range p.nodes[n].out {
+		if !p.edges[d/2].removed {
+			degree++
+		}
+	}
+	return degree
+}
+
+// removeDangles repeatedly removes the edges with an end point of degree one, and returns
+// them.
+func (p *polygonizer) removeDangles() []int {
+	var stack []int
+	for i := range p.nodes {
+		if p.degree(i) == 1 {
+			stack = append(stack, i)
+		}
+	}
+	var dangles []int
+	for len(stack) != 0 {
+		n := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for _, d := range p.nodes[n].out {
+			if e := &p.edges[d/2]; !e.removed {
+				e.removed = true
+				dangles = append(dangles, d/2)
+				if other := p.to(d); p.degree(other) == 1 {
+					stack = append(stack, other)
+				}
+			}
+		}
+	}
+	slices.Sort(dangles)
+	return dangles
+}
+
+// computeNext computes the directed edge that follows each directed edge around its face,
+// which is the next directed edge clockwise around the node that it arrives at.
+func (p *polygonizer) computeNext() {
+	p.next = make([]int, 2*len(p.edges))
+	for _, n := range p.nodes {
+		var out []int
+		for _, d := range n.out {
+			if !p.edges[d/2].removed {
+				out = append(out, d)
+			}
+		}
+		for i, d := range out {
+			p.next[d^1] = out[(i+len(out)-1)%len(out)]
+		}
+	}
+}
+
+// faces returns the directed edges around each face.
+func (p *polygonizer) faces() [][]int {
+	p.computeNext()
+	visited := make([]bool, 2*len(p.edges))
+	var faces [][]int
+	for d := range visited {
+		if visited[d] || p.edges[d/2].removed {
+			continue
+		}
+		var face []int
+		for next := d; !visited[next]; next = p.next[next] {
+			visited[next] = true
+			face = append(face, next)
+		}
+		faces = append(faces, face)
+	}
+	return faces
+}
+
+// removeCutEdges removes the edges that have the same face on both sides, and returns
+// them.
+func (p *polygonizer) removeCutEdges() []int {
+	faceIndexes := make([]int, 2*len(p.edges))
+	for i, face := range p.faces() {
+		for _, d := range face {
+			faceIndexes[d] = i
+		}
+	}
+	var cutEdges []int
+	for e := range p.edges {
+		if !p.edges[e].removed && faceIndexes[2*e] == faceIndexes[2*e+1] {
+			p.edges[e].removed = true
+			cutEdges = append(cutEdges, e)
+		}
+	}
+	return cutEdges
+}
+
+// A ring is a closed ring of coordinates with its xy extent.
+type ring struct {
+	flatCoords             []float64
+	area                   float64
+	minX, minY, maxX, maxY float64
+}
+
+// polygons returns the polygons formed by the faces of the graph.
+func (p *polygonizer) polygons() ([]*geom.Polygon, error) {
+	var shells, holes []*ring
+	for _, face := range p.faces() {
+		for _, r := range p.minimalRings(face) {
+			// xy.SignedArea is positive for clockwise rings.
+			switch area := xy.SignedArea(p.layout, r.flatCoords); {
+			case area < 0:
+				r.area = -area
+				shells = append(shells, r)
+			case area > 0:
+				r.area = area
+				holes = append(holes, r)
+			}
+		}
+	}
+
+	shellHoles := make([][]*ring, len(shells))
+	for _, hole := range holes {
+		best := -1
+		for i, shell := range shells {
+			if (best == -1 || shell.area < shells[best].area) && p.contains(shell, hole) {
+				best = i
+			}
+		}
+		// Holes that are not inside any shell are the outlines of the graph.
+		if best != -1 {
+			shellHoles[best] = append(shellHoles[best], hole)
+		}
+	}
+
+	polygons := make([]*geom.Polygon, 0, len(shells))
+	for i, shell := range shells {
+		polygon := geom.NewPolygonFlat(p.layout, shell.flatCoords, []int{len(shell.flatCoords)})
+		for _, hole := range shellHoles[i] {
+			if err := polygon.Push(geom.NewLinearRingFlat(p.layout, hole.flatCoords)); err != nil {
+				return nil, err
+			}
+		}
+		polygons = append(polygons, polygon)
+	}
+	return polygons, nil
+}
+
+// minimalRings splits the face formed by the directed edges in face into rings that do not
+// pass through the same node twice.
+func (p *polygonizer) minimalRings(face []int) []*ring {
+	var rings []*ring
+	var path []int
+	pathIndexes := make(map[int]int)
+	for _, d := range face {
+		n := p.from(d)
+		if i, ok := pathIndexes[n]; ok {
+			rings = append(rings, p.ring(path[i:]))
+			for _, d := range path[i:] {
+				delete(pathIndexes, p.from(d))
+			}
+			path = path[:i]
+		}
+		pathIndexes[n] = len(path)
+		path = append(path, d)
+	}
+	return append(rings, p.ring(path))
+}
+
+// ring returns the ring formed by the directed edges in path.
+func (p *polygonizer) ring(path []int) *ring {
+	var flatCoords []float64
+	for _, d := range path {
+		coords := p.coords(d)
+		if len(flatCoords) != 0 {
+			// Skip the coordinate shared with the previous directed edge.
+			coords = coords[p.stride:]
+		}
+		flatCoords = append(flatCoords, coords...)
+	}
+	r := &ring{
+		flatCoords: flatCoords,
+		minX:       math.Inf(1),
+		minY:       math.Inf(1),
+		maxX:       math.Inf(-1),
+		maxY:       math.Inf(-1),
+	}
+	for i := 0; i < len(flatCoords); i += p.stride {
+		r.minX, r.maxX = min(r.minX, flatCoords[i]), max(r.maxX, flatCoords[i])
+		r.minY, r.maxY = min(r.minY, flatCoords[i+1]), max(r.maxY, flatCoords[i+1])
+	}
+	return r
+}
+
+// contains returns whether hole is inside shell. As the lines are noded, hole is inside
+// shell if any of its vertices, or failing that the midpoints of its segments, that are
+// not on shell's boundary are inside shell.
+func (p *polygonizer) contains(shell, hole *ring) bool {
+	if hole.minX < shell.minX || shell.maxX < hole.maxX || hole.minY < shell.minY || shell.maxY < hole.maxY {
+		return false
+	}
+	for i := 0; i < len(hole.flatCoords); i += p.stride {
+		c := geom.Coord{hole.flatCoords[i], hole.flatCoords[i+1]}
+		if l := xy.LocatePointInRing(p.layout, c, shell.flatCoords); l != location.Boundary {
+			return l == location.Interior
+		}
+	}
+	for i := p.stride; i < len(hole.flatCoords); i += p.stride {
+		c := geom.Coord{
+			(hole.flatCoords[i-p.stride] + hole.flatCoords[i]) / 2,
+			(hole.flatCoords[i-p.stride+1] + hole.flatCoords[i+1]) / 2,
+		}
+		if l := xy.LocatePointInRing(p.layout, c, shell.flatCoords); l != location.Boundary {
+			return l == location.Interior
+		}
+	}
+	return false
+}
@@ -0,0 +1,35 @@
+package polygonize_test
+
+import (
+	"fmt"
+
+	"github.com/don4get/go-geom"
+	"github.com/don4get/go-geom/xy/polygonize"
+)
+
+func ExamplePolygonize() {
+	edges := geom.NewMultiLineStringFlat(geom.XY, []float64{
+		0, 0, 1, 0,
+		1, 0, 1, 1,
+		1, 1, 0, 1,
+		0, 1, 0, 0,
+		1, 0, 2, 0, 2, 1, 1, 1,
+		1, 1, 1, 2,
+	}, []int{4, 8, 12, 16, 24, 28})
+
+	result, err := polygonize.Polygonize(edges)
+	if err != nil {
+		panic(err)
+	}
+
+	for _, polygon := range result.Polygons {
+		fmt.Println(polygon.FlatCoords)
+	}
+	for _, dangle := range result.Dangles {
+		fmt.Println("dangle:", dangle.FlatCoords)
+	}
+	// Output:
+	// [0 0 1 0 1 1 0 1 0 0]
+	// [1 1 1 0 2 0 2 1 1 1]
+	// dangle: [1 1 1 2]
+}
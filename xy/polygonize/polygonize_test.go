@@ -0,0 +1,120 @@
+package polygonize
+
+import (
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+
+	"github.com/don4get/go-geom"
+)
+
+func TestPolygonize(t *testing.T) {
+	for _, tc := range []struct {
+		name             string
+		lines            []geom.T
+		expectedPolygons []*geom.Polygon
+		expectedDangles  []*geom.LineString
+		expectedCutEdges []*geom.LineString
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name: "adjacent squares",
+			lines: []geom.T{
+				geom.NewMultiLineStringFlat(geom.XY, []float64{
+					0, 0, 1, 0,
+					1, 0, 1, 1,
+					1, 1, 0, 1,
+					0, 1, 0, 0,
+				}, []int{4, 8, 12, 16}),
+				geom.NewLineStringFlat(geom.XY, []float64{1, 0, 2, 0, 2, 1, 1, 1}),
+			},
+			expectedPolygons: []*geom.Polygon{
+				geom.NewPolygonFlat(geom.XY, []float64{0, 0, 1, 0, 1, 1, 0, 1, 0, 0}, []int{10}),
+				geom.NewPolygonFlat(geom.XY, []float64{1, 1, 1, 0, 2, 0, 2, 1, 1, 1}, []int{10}),
+			},
+		},
+		{
+			name: "diagonal",
+			lines: []geom.T{
+				geom.NewMultiLineStringFlat(geom.XY, []float64{
+					0, 0, 1, 0,
+					1, 0, 1, 1,
+					1, 1, 0, 1,
+					0, 1, 0, 0,
+					0, 0, 1, 1,
+				}, []int{4, 8, 12, 16, 20}),
+			},
+			expectedPolygons: []*geom.Polygon{
+				geom.NewPolygonFlat(geom.XY, []float64{0, 0, 1, 0, 1, 1, 0, 0}, []int{8}),
+				geom.NewPolygonFlat(geom.XY, []float64{1, 1, 0, 1, 0, 0, 1, 1}, []int{8}),
+			},
+		},
+		{
+			name: "hole, dangle and cut edge",
+			lines: []geom.T{
+				geom.NewLinearRingFlat(geom.XY, []float64{0, 0, 10, 0, 10, 10, 0, 10, 0, 0}),
+				geom.NewLinearRingFlat(geom.XY, []float64{4, 4, 6, 4, 6, 6, 4, 6, 4, 4}),
+				geom.NewLineStringFlat(geom.XY, []float64{0, 0, 4, 4}),
+				geom.NewLineStringFlat(geom.XY, []float64{0, 0, -5, -5, -5, -6}),
+				geom.NewLineStringFlat(geom.XY, []float64{4, 4, 4, 6, 6, 6, 6, 4, 4, 4}),
+			},
+			expectedPolygons: []*geom.Polygon{
+				geom.NewPolygonFlat(geom.XY, []float64{
+					0, 0, 10, 0, 10, 10, 0, 10, 0, 0,
+					4, 4, 4, 6, 6, 6, 6, 4, 4, 4,
+				}, []int{10, 20}),
+				geom.NewPolygonFlat(geom.XY, []float64{4, 4, 6, 4, 6, 6, 4, 6, 4, 4}, []int{10}),
+			},
+			expectedDangles: []*geom.LineString{
+				geom.NewLineStringFlat(geom.XY, []float64{0, 0, -5, -5, -5, -6}),
+			},
+			expectedCutEdges: []*geom.LineString{
+				geom.NewLineStringFlat(geom.XY, []float64{0, 0, 4, 4}),
+			},
+		},
+		{
+			name: "hole touching the exterior ring",
+			lines: []geom.T{
+				geom.NewLineStringFlat(geom.XYZ, []float64{0, 0, 1, 10, 0, 2, 10, 10, 3, 0, 10, 4, 0, 0, 1}),
+				geom.NewLineStringFlat(geom.XYZ, []float64{0, 0, 1, 5, 2, 5, 2, 5, 6, 0, 0, 1}),
+			},
+			expectedPolygons: []*geom.Polygon{
+				geom.NewPolygonFlat(geom.XYZ, []float64{
+					0, 0, 1, 10, 0, 2, 10, 10, 3, 0, 10, 4, 0, 0, 1,
+					0, 0, 1, 2, 5, 6, 5, 2, 5, 0, 0, 1,
+				}, []int{15, 27}),
+				geom.NewPolygonFlat(geom.XYZ, []float64{0, 0, 1, 5, 2, 5, 2, 5, 6, 0, 0, 1}, []int{12}),
+			},
+		},
+		{
+			name: "dangling chain",
+			lines: []geom.T{
+				geom.NewLineStringFlat(geom.XY, []float64{0, 0, 1, 0}),
+				geom.NewLineStringFlat(geom.XY, []float64{1, 0, 2, 0}),
+			},
+			expectedDangles: []*geom.LineString{
+				geom.NewLineStringFlat(geom.XY, []float64{0, 0, 1, 0}),
+				geom.NewLineStringFlat(geom.XY, []float64{1, 0, 2, 0}),
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := Polygonize(tc.lines...)
+			assert.NoError(t, err)
+			assert.Equal(t, &Result{
+				Polygons: tc.expectedPolygons,
+				Dangles:  tc.expectedDangles,
+				CutEdges: tc.expectedCutEdges,
+			}, actual)
+		})
+	}
+}
+
+func TestPolygonizeErrors(t *testing.T) {
+	_, err := Polygonize(geom.NewPolygon(geom.XY))
+	assert.Error(t, err)
+	_, err = Polygonize(geom.NewLineString(geom.XY), geom.NewLineString(geom.XYZ))
+	assert.Equal[error](t, geom.ErrLayoutMismatch{Got: geom.XYZ, Want: geom.XY}, err)
+}
@@ -0,0 +1,325 @@
+package xy
+
+import (
+	"math"
+	"slices"
+
+	"github.com/don4get/go-geom"
+)
+
+// SnapToGrid returns a copy of g with each ordinate rounded to the nearest multiple of
+// the grid size for its dimension, like PostGIS's ST_SnapToGrid. size is indexed by
+// ordinate in g's layout, so for an XYM geometry size[2] is the grid size of the M
+// ordinates. Ordinates with a zero or missing grid size are not snapped.
+//
+// Consecutive vertices that snap to the same xy position are merged. LineStrings that
+// collapse to fewer than two vertices become empty, Polygon rings that collapse to fewer
+// than four vertices are dropped, and a Polygon whose exterior ring collapses becomes
+// empty. The parts of multi-geometries and collections that become empty are dropped.
+// The vertices of curved geometries are snapped but never merged, so that their arcs
+// keep their three points. The layout and SRID of g are preserved.
+func SnapToGrid(g geom.T, size geom.Coord) (geom.T, error) {
+	return snap(g, func(coord []float64) {
+		for i := range min(len(coord), len(size)) {
+			if size[i] != 0 {
+				// Adding zero turns negative zeros into positive zeros.
+				coord[i] = math.RoundToEven(coord[i]/size[i])*size[i] + 0
+			}
+		}
+	})
+}
+
+// SnapToGeometry returns a copy of g with each vertex that is within tolerance of
+// reference moved onto reference. A vertex is moved onto the nearest vertex of reference
+// within tolerance if there is one, and otherwise onto the nearest point of the nearest
+// segment of reference within tolerance. Only the x and y ordinates are moved; other
+// ordinates, such as Z and M, keep their values from g. Curved reference geometries are
+// linearized first.
+//
+// Snapping closes small gaps and slivers between adjacent geometries. Vertices that are
+// snapped to the same position are merged, and collapsed parts are handled as by
+// SnapToGrid. The layout and SRID of g are preserved.
+func SnapToGeometry(g, reference geom.T, tolerance float64) (geom.T, error) {
+	var points, lines [][]float64
+	if err := appendSequences(&points, &lines, reference); err != nil {
+		return nil, err
+	}
+	vertices := slices.Concat(points, lines)
+	return snap(g, func(coord []float64) {
+		x, y := coord[0], coord[1]
+		best, bestX, bestY := tolerance, 0.0, 0.0
+		found := false
+		consider := func(vx, vy float64) {
+			if d := math.Hypot(vx-x, vy-y); d <= best {
+				best, bestX, bestY, found = d, vx, vy, true
+			}
+		}
+		// Prefer vertices over segments.
+		for _, seq := range vertices {
+			for i := 0; i < len(seq); i += 2 {
+				consider(seq[i], seq[i+1])
+			}
+		}
+		if !found {
+			for _, line := range lines {
+				for i := 2; i < len(line); i += 2 {
+					consider(closestOnSegment(x, y, line[i-2], line[i-1], line[i], line[i+1]))
+				}
+			}
+		}
+		if found {
+			coord[0], coord[1] = bestX, bestY
+		}
+	})
+}
+
+// appendSequences appends the xy ordinates of the points of g to points and the xy
+// ordinates of its lines and rings to lines. Reducing every sequence to xy pairs lets
+// the geometries of a GeometryCollection have different layouts.
+func appendSequences(points, lines *[][]float64, g geom.T) error {
+	switch g := g.(type) {
+	case *geom.Point:
+		if !g.IsEmpty() {
+			*points = append(*points, appendXY(nil, g))
+		}
+	case *geom.MultiPoint:
+		*points = append(*points, appendXY(nil, g))
+	case *geom.LineString, *geom.LinearRing:
+		*lines = append(*lines, appendXY(nil, g))
+	case *geom.MultiLineString, *geom.Polygon, *geom.Triangle:
+		*lines = appendSplit(*lines, g.GetFlatCoords(), 0, g.GetEnds(), g.GetStride())
+	case *geom.MultiPolygon, *geom.TIN, *geom.PolyhedralSurface:
+		offset := 0
+		for _, ends := range g.GetEndss() {
+			*lines = appendSplit(*lines, g.GetFlatCoords(), offset, ends, g.GetStride())
+			if len(ends) != 0 {
+				offset = ends[len(ends)-1]
+			}
+		}
+	case *geom.GeometryCollection:
+		for _, g := range g.Geoms() {
+			if err := appendSequences(points, lines, g); err != nil {
+				return err
+			}
+		}
+	case *geom.CircularString, *geom.CompoundCurve, *geom.CurvePolygon, *geom.MultiCurve, *geom.MultiSurface:
+		linear, err := geom.Linearize(g, linearizeSegmentsPerQuadrant)
+		if err != nil {
+			return err
+		}
+		return appendSequences(points, lines, linear)
+	default:
+		return geom.ErrUnsupportedType{Value: g}
+	}
+	return nil
+}
+
+// appendSplit appends the xy ordinates of the parts of flatCoords delimited by ends,
+// starting at offset, to sequences.
+func appendSplit(sequences [][]float64, flatCoords []float64, offset int, ends []int, stride int) [][]float64 {
+	for _, end := range ends {
+		var sequence []float64
+		for i := offset; i < end; i += stride {
+			sequence = append(sequence, flatCoords[i], flatCoords[i+1])
+		}
+		sequences = append(sequences, sequence)
+		offset = end
+	}
+	return sequences
+}
+
+// closestOnSegment returns the point on the segment from x1, y1 to x2, y2 that is
+// closest to x, y.
+func closestOnSegment(x, y, x1, y1, x2, y2 float64) (float64, float64) {
+	dx, dy := x2-x1, y2-y1
+	if dx == 0 && dy == 0 {
+		return x1, y1
+	}
+	t := max(0, min(1, ((x-x1)*dx+(y-y1)*dy)/(dx*dx+dy*dy)))
+	return x1 + t*dx, y1 + t*dy
+}
+
+// snap returns a copy of g with snapCoord applied to each of its coordinates, merging
+// consecutive vertices that end up with the same xy position and dropping the parts
+// that collapse.
+func snap(g geom.T, snapCoord func([]float64)) (geom.T, error) {
+	layout, stride := g.GetLayout(), g.GetStride()
+	switch g := g.(type) {
+	case *geom.Point:
+		if g.IsEmpty() {
+			return g.Clone(), nil
+		}
+		return geom.NewPointFlat(layout, snapFlatCoords(g.FlatCoords, stride, snapCoord)).SetSRID(g.Srid), nil
+	case *geom.MultiPoint:
+		flatCoords := snapFlatCoords(g.FlatCoords, stride, snapCoord)
+		ends := slices.Clone(g.Ends)
+		return geom.NewMultiPointFlat(layout, flatCoords, geom.NewMultiPointFlatOptionWithEnds(ends)).SetSRID(g.Srid), nil
+	case *geom.LineString:
+		flatCoords := snapLine(nil, g.FlatCoords, stride, 2, snapCoord)
+		return geom.NewLineStringFlat(layout, flatCoords).SetSRID(g.Srid), nil
+	case *geom.LinearRing:
+		flatCoords := snapLine(nil, g.FlatCoords, stride, 4, snapCoord)
+		return geom.NewLinearRingFlat(layout, flatCoords).SetSRID(g.Srid), nil
+	case *geom.MultiLineString:
+		flatCoords, ends := snapLines(nil, nil, g.FlatCoords, 0, g.Ends, stride, 2, snapCoord)
+		return geom.NewMultiLineStringFlat(layout, flatCoords, ends).SetSRID(g.Srid), nil
+	case *geom.Polygon:
+		flatCoords, ends := snapPolygon(nil, g.FlatCoords, 0, g.Ends, stride, snapCoord)
+		return geom.NewPolygonFlat(layout, flatCoords, ends).SetSRID(g.Srid), nil
+	case *geom.Triangle:
+		flatCoords, ends := snapPolygon(nil, g.FlatCoords, 0, g.Ends, stride, snapCoord)
+		return geom.NewTriangleFlat(layout, flatCoords, ends).SetSRID(g.Srid), nil
+	case *geom.MultiPolygon:
+		flatCoords, endss := snapPolygons(g.FlatCoords, g.Endss, stride, snapCoord)
+		return geom.NewMultiPolygonFlat(layout, flatCoords, endss).SetSRID(g.Srid), nil
+	case *geom.TIN:
+		flatCoords, endss := snapPolygons(g.FlatCoords, g.Endss, stride, snapCoord)
+		return geom.NewTINFlat(layout, flatCoords, endss).SetSRID(g.Srid), nil
+	case *geom.PolyhedralSurface:
+		flatCoords, endss := snapPolygons(g.FlatCoords, g.Endss, stride, snapCoord)
+		return geom.NewPolyhedralSurfaceFlat(layout, flatCoords, endss).SetSRID(g.Srid), nil
+	case *geom.GeometryCollection:
+		gc := geom.NewGeometryCollection().SetSRID(g.GetSRID())
+		for _, child := range g.Geoms() {
+			snapped, err := snap(child, snapCoord)
+			if err != nil {
+				return nil, err
+			}
+			if !snapped.IsEmpty() {
+				if err := gc.Push(snapped); err != nil {
+					return nil, err
+				}
+			}
+		}
+		return collectionLayout(gc, layout), nil
+	case *geom.CircularString, *geom.CompoundCurve, *geom.CurvePolygon, *geom.MultiCurve, *geom.MultiSurface:
+		return snapCurve(g, snapCoord)
+	default:
+		return nil, geom.ErrUnsupportedType{Value: g}
+	}
+}
+
+// snapCurve returns a copy of the curved geometry g, or of a component of one, with
+// snapCoord applied to each of its coordinates.
+func snapCurve(g geom.T, snapCoord func([]float64)) (geom.T, error) {
+	layout, stride := g.GetLayout(), g.GetStride()
+	var components []geom.T
+	var result interface {
+		geom.T
+		Push(...geom.T) error
+	}
+	switch g := g.(type) {
+	case *geom.LineString:
+		return geom.NewLineStringFlat(layout, snapFlatCoords(g.FlatCoords, stride, snapCoord)).SetSRID(g.Srid), nil
+	case *geom.LinearRing:
+		return geom.NewLinearRingFlat(layout, snapFlatCoords(g.FlatCoords, stride, snapCoord)).SetSRID(g.Srid), nil
+	case *geom.CircularString:
+		return geom.NewCircularStringFlat(layout, snapFlatCoords(g.FlatCoords, stride, snapCoord)).SetSRID(g.Srid), nil
+	case *geom.Polygon:
+		return geom.NewPolygonFlat(layout, snapFlatCoords(g.FlatCoords, stride, snapCoord), slices.Clone(g.Ends)).SetSRID(g.Srid), nil
+	case *geom.CompoundCurve:
+		components, result = g.Curves(), geom.NewCompoundCurve(layout).SetSRID(g.GetSRID())
+	case *geom.CurvePolygon:
+		components, result = g.Rings(), geom.NewCurvePolygon(layout).SetSRID(g.GetSRID())
+	case *geom.MultiCurve:
+		components, result = g.Curves(), geom.NewMultiCurve(layout).SetSRID(g.GetSRID())
+	case *geom.MultiSurface:
+		components, result = g.Surfaces(), geom.NewMultiSurface(layout).SetSRID(g.GetSRID())
+	default:
+		return nil, geom.ErrUnsupportedType{Value: g}
+	}
+	for _, component := range components {
+		snapped, err := snapCurve(component, snapCoord)
+		if err != nil {
+			return nil, err
+		}
+		if err := result.Push(snapped); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// snapFlatCoords returns a copy of flatCoords with snapCoord applied to each coordinate.
+func snapFlatCoords(flatCoords []float64, stride int, snapCoord func([]float64)) []float64 {
+	snapped := slices.Clone(flatCoords)
+	for i := 0; i < len(snapped); i += stride {
+		snapCoord(snapped[i : i+stride])
+	}
+	return snapped
+}
+
+// snapLine appends the line in lineFlatCoords to flatCoords with snapCoord applied to
+// each coordinate and consecutive duplicate coordinates merged, if at least minCoords
+// coordinates remain.
+func snapLine(flatCoords, lineFlatCoords []float64, stride, minCoords int, snapCoord func([]float64)) []float64 {
+	start := len(flatCoords)
+	for i := 0; i < len(lineFlatCoords); i += stride {
+		flatCoords = append(flatCoords, lineFlatCoords[i:i+stride]...)
+		coord := flatCoords[len(flatCoords)-stride:]
+		snapCoord(coord)
+		if n := len(flatCoords) - stride; n > start && equalXY(flatCoords[n-stride:], coord) {
+			flatCoords = flatCoords[:n]
+		}
+	}
+	if len(flatCoords)-start < minCoords*stride {
+		return flatCoords[:start]
+	}
+	return flatCoords
+}
+
+// snapLines appends the lines in linesFlatCoords delimited by linesEnds, starting at
+// offset, to flatCoords and their ends to ends, dropping the lines with fewer than
+// minCoords coordinates.
+func snapLines(flatCoords []float64, ends []int, linesFlatCoords []float64, offset int, linesEnds []int, stride, minCoords int, snapCoord func([]float64)) ([]float64, []int) {
+	for _, end := range linesEnds {
+		n := len(flatCoords)
+		flatCoords = snapLine(flatCoords, linesFlatCoords[offset:end], stride, minCoords, snapCoord)
+		if len(flatCoords) != n {
+			ends = append(ends, len(flatCoords))
+		}
+		offset = end
+	}
+	return flatCoords, ends
+}
+
+// snapPolygon appends the rings of the polygon delimited by polygonEnds in
+// polygonFlatCoords, starting at offset, to flatCoords and returns the new flat
+// coordinates and the polygon's ends. If the exterior ring collapses then no rings are
+// appended.
+func snapPolygon(flatCoords, polygonFlatCoords []float64, offset int, polygonEnds []int, stride int, snapCoord func([]float64)) ([]float64, []int) {
+	var ends []int
+	for i, end := range polygonEnds {
+		n := len(flatCoords)
+		flatCoords = snapLine(flatCoords, polygonFlatCoords[offset:end], stride, 4, snapCoord)
+		offset = end
+		if len(flatCoords) == n {
+			if i == 0 {
+				return flatCoords, nil
+			}
+			continue
+		}
+		ends = append(ends, len(flatCoords))
+	}
+	return flatCoords, ends
+}
+
+// snapPolygons snaps each of the polygons in flatCoords and endss, dropping the polygons
+// that become empty.
+func snapPolygons(flatCoords []float64, endss [][]int, stride int, snapCoord func([]float64)) ([]float64, [][]int) {
+	var snappedFlatCoords []float64
+	var snappedEndss [][]int
+	offset := 0
+	for _, ends := range endss {
+		if len(ends) == 0 {
+			continue
+		}
+		var snappedEnds []int
+		snappedFlatCoords, snappedEnds = snapPolygon(snappedFlatCoords, flatCoords, offset, ends, stride, snapCoord)
+		if len(snappedEnds) != 0 {
+			snappedEndss = append(snappedEndss, snappedEnds)
+		}
+		offset = ends[len(ends)-1]
+	}
+	return snappedFlatCoords, snappedEndss
+}
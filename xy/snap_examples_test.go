@@ -0,0 +1,33 @@
+package xy_test
+
+import (
+	"fmt"
+
+	"github.com/don4get/go-geom"
+	"github.com/don4get/go-geom/xy"
+)
+
+func ExampleSnapToGrid() {
+	line := geom.NewLineStringFlat(geom.XYZ, []float64{0.2, 0.1, 12.3, 0.4, -0.1, 12.7, 5.2, 4.9, 18.1})
+
+	snapped, err := xy.SnapToGrid(line, geom.Coord{1, 1, 0.5})
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println(snapped.GetFlatCoords())
+	// Output: [0 0 12.5 5 5 18]
+}
+
+func ExampleSnapToGeometry() {
+	a := geom.NewPolygonFlat(geom.XY, []float64{0, 0, 10, 0, 10, 10, 0, 10, 0, 0}, []int{10})
+	b := geom.NewPolygonFlat(geom.XY, []float64{10.001, 0, 20, 0, 20, 10, 9.999, 10, 10.001, 0}, []int{10})
+
+	snapped, err := xy.SnapToGeometry(b, a, 0.01)
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println(snapped.GetFlatCoords())
+	// Output: [10 0 20 0 20 10 10 10 10 0]
+}
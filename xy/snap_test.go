@@ -0,0 +1,206 @@
+package xy
+
+import (
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+
+	"github.com/don4get/go-geom"
+)
+
+func TestSnapToGrid(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		g        geom.T
+		size     geom.Coord
+		expected geom.T
+	}{
+		{
+			name:     "point",
+			g:        geom.NewPointFlat(geom.XYZ, []float64{1.26, -0.24, 3.14159}).SetSRID(4326),
+			size:     geom.Coord{0.5, 0.5, 0.01},
+			expected: geom.NewPointFlat(geom.XYZ, []float64{1.5, 0, 3.14}).SetSRID(4326),
+		},
+		{
+			name:     "empty point",
+			g:        geom.NewPointEmpty(geom.XY),
+			size:     geom.Coord{1, 1},
+			expected: geom.NewPointEmpty(geom.XY),
+		},
+		{
+			name:     "missing sizes are not snapped",
+			g:        geom.NewPointFlat(geom.XYM, []float64{1.2, 3.4, 5.6}),
+			size:     geom.Coord{1},
+			expected: geom.NewPointFlat(geom.XYM, []float64{1, 3.4, 5.6}),
+		},
+		{
+			name:     "multipoint keeps duplicates",
+			g:        geom.NewMultiPointFlat(geom.XY, []float64{0.1, 0.1, 0.2, 0.2}),
+			size:     geom.Coord{1, 1},
+			expected: geom.NewMultiPointFlat(geom.XY, []float64{0, 0, 0, 0}),
+		},
+		{
+			name:     "linestring removes repeated vertices",
+			g:        geom.NewLineStringFlat(geom.XYM, []float64{0, 0, 1, 0.1, 0.1, 2, 1.9, 0, 3, 2, 0.1, 4}),
+			size:     geom.Coord{1, 1},
+			expected: geom.NewLineStringFlat(geom.XYM, []float64{0, 0, 1, 2, 0, 3}),
+		},
+		{
+			name:     "linestring collapses",
+			g:        geom.NewLineStringFlat(geom.XY, []float64{0, 0, 0.1, 0.1}),
+			size:     geom.Coord{1, 1},
+			expected: geom.NewLineString(geom.XY),
+		},
+		{
+			name: "multilinestring drops collapsed lines",
+			g: geom.NewMultiLineStringFlat(geom.XY, []float64{
+				0, 0, 0.1, 0.1,
+				0, 0, 2.1, 0,
+			}, []int{4, 8}),
+			size:     geom.Coord{1, 1},
+			expected: geom.NewMultiLineStringFlat(geom.XY, []float64{0, 0, 2, 0}, []int{4}),
+		},
+		{
+			name: "polygon drops collapsed holes",
+			g: geom.NewPolygonFlat(geom.XY, []float64{
+				0, 0, 10.2, 0, 10.2, 9.9, 0, 9.9, 0, 0,
+				4, 4, 4.2, 4, 4.2, 4.2, 4, 4, 4, 4,
+			}, []int{10, 20}),
+			size: geom.Coord{1, 1},
+			expected: geom.NewPolygonFlat(geom.XY, []float64{
+				0, 0, 10, 0, 10, 10, 0, 10, 0, 0,
+			}, []int{10}),
+		},
+		{
+			name:     "polygon collapses",
+			g:        geom.NewPolygonFlat(geom.XY, []float64{0, 0, 0.2, 0, 0.2, 0.2, 0, 0}, []int{8}),
+			size:     geom.Coord{1, 1},
+			expected: geom.NewPolygonFlat(geom.XY, nil, nil),
+		},
+		{
+			name: "multipolygon drops collapsed polygons",
+			g: geom.NewMultiPolygonFlat(geom.XY, []float64{
+				0, 0, 0.2, 0, 0.2, 0.2, 0, 0,
+				0, 0, 2, 0, 2, 2, 0, 0,
+			}, [][]int{{8}, {16}}),
+			size:     geom.Coord{1, 1},
+			expected: geom.NewMultiPolygonFlat(geom.XY, []float64{0, 0, 2, 0, 2, 2, 0, 0}, [][]int{{8}}),
+		},
+		{
+			name: "geometry collection drops empty geometries",
+			g: geom.NewGeometryCollection().MustPush(
+				geom.NewLineStringFlat(geom.XY, []float64{0, 0, 0.1, 0.1}),
+				geom.NewPointFlat(geom.XY, []float64{0.9, 1.1}),
+			).SetSRID(3857),
+			size: geom.Coord{1, 1},
+			expected: geom.NewGeometryCollection().MustPush(
+				geom.NewPointFlat(geom.XY, []float64{1, 1}),
+			).MustSetLayout(geom.XY).SetSRID(3857),
+		},
+		{
+			name:     "circular string keeps its vertices",
+			g:        geom.NewCircularStringFlat(geom.XY, []float64{0, 0, 0.9, 1.1, 2.1, 0}),
+			size:     geom.Coord{1, 1},
+			expected: geom.NewCircularStringFlat(geom.XY, []float64{0, 0, 1, 1, 2, 0}),
+		},
+		{
+			name: "compound curve",
+			g: geom.NewCompoundCurve(geom.XY).MustPush(
+				geom.NewCircularStringFlat(geom.XY, []float64{0, 0, 0.9, 1.1, 2.1, 0}),
+				geom.NewLineStringFlat(geom.XY, []float64{2.1, 0, 3.9, 0}),
+			),
+			size: geom.Coord{1, 1},
+			expected: geom.NewCompoundCurve(geom.XY).MustPush(
+				geom.NewCircularStringFlat(geom.XY, []float64{0, 0, 1, 1, 2, 0}),
+				geom.NewLineStringFlat(geom.XY, []float64{2, 0, 4, 0}),
+			),
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := SnapToGrid(tc.g, tc.size)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestSnapToGeometry(t *testing.T) {
+	reference := geom.NewPolygonFlat(geom.XY, []float64{0, 0, 10, 0, 10, 10, 0, 10, 0, 0}, []int{10})
+	for _, tc := range []struct {
+		name      string
+		g         geom.T
+		reference geom.T
+		tolerance float64
+		expected  geom.T
+	}{
+		{
+			name:      "point to vertex",
+			g:         geom.NewPointFlat(geom.XYZ, []float64{10.05, 9.98, 7}),
+			reference: reference,
+			tolerance: 0.1,
+			expected:  geom.NewPointFlat(geom.XYZ, []float64{10, 10, 7}),
+		},
+		{
+			name:      "point to segment",
+			g:         geom.NewPointFlat(geom.XY, []float64{10.05, 5}),
+			reference: reference,
+			tolerance: 0.1,
+			expected:  geom.NewPointFlat(geom.XY, []float64{10, 5}),
+		},
+		{
+			name:      "point out of tolerance",
+			g:         geom.NewPointFlat(geom.XY, []float64{10.5, 5}),
+			reference: reference,
+			tolerance: 0.1,
+			expected:  geom.NewPointFlat(geom.XY, []float64{10.5, 5}),
+		},
+		{
+			name:      "vertices preferred to segments",
+			g:         geom.NewPointFlat(geom.XY, []float64{10.01, 9.95}),
+			reference: reference,
+			tolerance: 0.1,
+			expected:  geom.NewPointFlat(geom.XY, []float64{10, 10}),
+		},
+		{
+			name: "adjacent polygon with a sliver",
+			g: geom.NewPolygonFlat(geom.XY, []float64{
+				10.01, 0, 20, 0, 20, 10, 9.99, 10, 10.01, 0,
+			}, []int{10}).SetSRID(2154),
+			reference: reference,
+			tolerance: 0.05,
+			expected: geom.NewPolygonFlat(geom.XY, []float64{
+				10, 0, 20, 0, 20, 10, 10, 10, 10, 0,
+			}, []int{10}).SetSRID(2154),
+		},
+		{
+			name:      "merged vertices",
+			g:         geom.NewLineStringFlat(geom.XY, []float64{-1, 0, -0.01, 0, 0.01, 0.01, 5, -1}),
+			reference: reference,
+			tolerance: 0.05,
+			expected:  geom.NewLineStringFlat(geom.XY, []float64{-1, 0, 0, 0, 5, -1}),
+		},
+		{
+			name:      "to multipoint",
+			g:         geom.NewLineStringFlat(geom.XY, []float64{0.1, 0, 1, 1}),
+			reference: geom.NewMultiPointFlat(geom.XY, []float64{0, 0, 5, 5}),
+			tolerance: 0.5,
+			expected:  geom.NewLineStringFlat(geom.XY, []float64{0, 0, 1, 1}),
+		},
+		{
+			name: "to collection with mixed layouts",
+			g:    geom.NewPointFlat(geom.XY, []float64{5.2, 5.1}),
+			reference: geom.NewGeometryCollection().MustPush(
+				geom.NewLineStringFlat(geom.XY, []float64{0, 0, 1, 1}),
+				geom.NewPointFlat(geom.XYZ, []float64{5, 5, 3}),
+			),
+			tolerance: 0.5,
+			expected:  geom.NewPointFlat(geom.XY, []float64{5, 5}),
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := SnapToGeometry(tc.g, tc.reference, tc.tolerance)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
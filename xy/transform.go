@@ -0,0 +1,17 @@
+package xy
+
+import "github.com/don4get/go-geom"
+
+// linearizeSegmentsPerQuadrant is the number of segments per quarter circle used to
+// linearize curved geometries before they are transformed.
+const linearizeSegmentsPerQuadrant = 32
+
+// collectionLayout sets the layout of gc, the result of transforming a GeometryCollection
+// with the given layout, back to layout unless its geometries have mixed layouts, and
+// returns gc.
+func collectionLayout(gc *geom.GeometryCollection, layout geom.Layout) *geom.GeometryCollection {
+	if gc.CheckLayout(layout) == nil {
+		gc.MustSetLayout(layout)
+	}
+	return gc
+}
@@ -0,0 +1,164 @@
+package xyz
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	geom "github.com/don4get/go-geom"
+)
+
+// ErrSurfaceNotClosed is returned by Volume when the surface does not enclose
+// a volume, i.e. when some edge is not shared by exactly two faces.
+var ErrSurfaceNotClosed = errors.New("xyz: surface is not closed")
+
+// SurfaceArea calculates the area of the surface g in 3d space, unlike Area
+// which calculates the area projected onto the xy plane. g must be a Polygon,
+// MultiPolygon, Triangle, TIN or PolyhedralSurface. If g has no z ordinate
+// then z is taken to be 0.
+func SurfaceArea(g geom.T) (float64, error) {
+	faces, err := surfaceFaces(g)
+	if err != nil {
+		return 0, err
+	}
+	area := 0.0
+	for _, f := range faces {
+		for i, end := range f.ends {
+			ringArea := VectorLength(f.vectorArea(f.start(i), end)) / 2
+			if i == 0 {
+				area += ringArea
+			} else {
+				area -= ringArea
+			}
+		}
+	}
+	return area, nil
+}
+
+// Volume calculates the volume enclosed by the closed surface g. g must be a
+// MultiPolygon, TIN or PolyhedralSurface whose faces have a consistent
+// orientation. ErrSurfaceNotClosed is returned if g is not closed.
+func Volume(g geom.T) (float64, error) {
+	faces, err := surfaceFaces(g)
+	if err != nil {
+		return 0, err
+	}
+	switch g.(type) {
+	case *geom.MultiPolygon, *geom.TIN, *geom.PolyhedralSurface:
+	default:
+		return 0, fmt.Errorf("%v is not a supported type for volume calculation", g)
+	}
+
+	origin := geom.Coord{0, 0, 0}
+	edges := make(map[[2][3]float64]int)
+	volume := 0.0
+	for _, f := range faces {
+		var shellNormal geom.Coord
+		for i, end := range f.ends {
+			start := f.start(i)
+			for j := start; j < end-f.stride; j += f.stride {
+				a, b := f.point(j), f.point(j+f.stride)
+				if a == b {
+					continue
+				}
+				if b[0] < a[0] || b[0] == a[0] && (b[1] < a[1] || b[1] == a[1] && b[2] < a[2]) {
+					a, b = b, a
+				}
+				edges[[2][3]float64{a, b}]++
+			}
+
+			// By the divergence theorem, a planar ring contributes the dot
+			// product of any of its points with its vector area to six times
+			// the volume. Holes are forced to the opposite orientation of
+			// the shell.
+			normal := f.vectorArea(start, end)
+			if i == 0 {
+				shellNormal = normal
+			} else if VectorDot(origin, normal, origin, shellNormal) > 0 {
+				normal = geom.Coord{-normal[0], -normal[1], -normal[2]}
+			}
+			volume += VectorDot(origin, f.coord(start), origin, normal)
+		}
+	}
+	if len(edges) == 0 {
+		return 0, ErrSurfaceNotClosed
+	}
+	for _, n := range edges {
+		if n != 2 {
+			return 0, ErrSurfaceNotClosed
+		}
+	}
+	return math.Abs(volume) / 6, nil
+}
+
+// face is a single planar polygon of a surface, stored as a slice of flat
+// coordinates.
+type face struct {
+	flatCoords []float64
+	offset     int
+	ends       []int
+	stride     int
+	zIndex     int
+}
+
+// start returns the index of the first coordinate of the ith ring of f.
+func (f face) start(i int) int {
+	if i == 0 {
+		return f.offset
+	}
+	return f.ends[i-1]
+}
+
+// point returns the x, y and z ordinates of the coordinate at index i.
+func (f face) point(i int) [3]float64 {
+	p := [3]float64{f.flatCoords[i], f.flatCoords[i+1], 0}
+	if f.zIndex != -1 {
+		p[2] = f.flatCoords[i+f.zIndex]
+	}
+	return p
+}
+
+// coord returns the coordinate at index i as an xyz geom.Coord.
+func (f face) coord(i int) geom.Coord {
+	p := f.point(i)
+	return p[:]
+}
+
+// vectorArea returns the vector normal to the ring between start and end
+// whose length is twice the ring's area, summing the cross products of a fan
+// of triangles from the ring's first coordinate.
+func (f face) vectorArea(start, end int) geom.Coord {
+	n := geom.Coord{0, 0, 0}
+	first := f.coord(start)
+	for i := start + f.stride; i < end-2*f.stride; i += f.stride {
+		c := VectorCross(first, f.coord(i), first, f.coord(i+f.stride))
+		n[0] += c[0]
+		n[1] += c[1]
+		n[2] += c[2]
+	}
+	return n
+}
+
+// surfaceFaces returns the faces of g.
+func surfaceFaces(g geom.T) ([]face, error) {
+	layout := g.GetLayout()
+	stride, zIndex := layout.Stride(), layout.ZIndex()
+	switch g := g.(type) {
+	case *geom.Polygon, *geom.Triangle:
+		return []face{{g.GetFlatCoords(), 0, g.GetEnds(), stride, zIndex}}, nil
+	case *geom.MultiPolygon, *geom.TIN, *geom.PolyhedralSurface:
+		flatCoords := g.GetFlatCoords()
+		faces := make([]face, 0, len(g.GetEndss()))
+		offset := 0
+		for _, ends := range g.GetEndss() {
+			if len(ends) == 0 {
+				continue
+			}
+			faces = append(faces, face{flatCoords, offset, ends, stride, zIndex})
+			offset = ends[len(ends)-1]
+		}
+		return faces, nil
+	default:
+		return nil, fmt.Errorf("%v is not a supported type for surface calculation", g)
+	}
+}
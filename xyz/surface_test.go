@@ -0,0 +1,132 @@
+package xyz_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+
+	"github.com/don4get/go-geom"
+	"github.com/don4get/go-geom/xyz"
+)
+
+// unitCubeFaces are the faces of the unit cube, oriented outwards.
+var unitCubeFaces = [][][]geom.Coord{
+	{{{0, 0, 0}, {0, 1, 0}, {1, 1, 0}, {1, 0, 0}, {0, 0, 0}}},
+	{{{0, 0, 1}, {1, 0, 1}, {1, 1, 1}, {0, 1, 1}, {0, 0, 1}}},
+	{{{0, 0, 0}, {1, 0, 0}, {1, 0, 1}, {0, 0, 1}, {0, 0, 0}}},
+	{{{0, 1, 0}, {0, 1, 1}, {1, 1, 1}, {1, 1, 0}, {0, 1, 0}}},
+	{{{0, 0, 0}, {0, 0, 1}, {0, 1, 1}, {0, 1, 0}, {0, 0, 0}}},
+	{{{1, 0, 0}, {1, 1, 0}, {1, 1, 1}, {1, 0, 1}, {1, 0, 0}}},
+}
+
+func TestSurfaceArea(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		g        geom.T
+		expected float64
+	}{
+		{
+			name:     "triangle",
+			g:        geom.NewTriangle(geom.XYZ).MustSetCoords([][]geom.Coord{{{0, 0, 0}, {1, 0, 0}, {0, 1, 1}, {0, 0, 0}}}),
+			expected: math.Sqrt2 / 2,
+		},
+		{
+			name: "polygon with hole",
+			g: geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{
+				{{0, 0}, {4, 0}, {4, 4}, {0, 4}, {0, 0}},
+				{{1, 1}, {1, 3}, {3, 3}, {3, 1}, {1, 1}},
+			}),
+			expected: 12,
+		},
+		{
+			name: "vertical polygon",
+			g: geom.NewPolygon(geom.XYZM).MustSetCoords([][]geom.Coord{
+				{{0, 0, 0, 5}, {2, 0, 0, 5}, {2, 0, 3, 5}, {0, 0, 3, 5}, {0, 0, 0, 5}},
+			}),
+			expected: 6,
+		},
+		{
+			name:     "unit cube",
+			g:        geom.NewPolyhedralSurface(geom.XYZ).MustSetCoords(unitCubeFaces),
+			expected: 6,
+		},
+		{
+			name: "tin",
+			g: geom.NewTIN(geom.XYZ).MustSetCoords([][][]geom.Coord{
+				{{{0, 0, 0}, {1, 0, 0}, {0, 1, 1}, {0, 0, 0}}},
+				{{{1, 0, 0}, {1, 1, 1}, {0, 1, 1}, {1, 0, 0}}},
+			}),
+			expected: math.Sqrt2,
+		},
+		{
+			name:     "empty",
+			g:        geom.NewTIN(geom.XYZ),
+			expected: 0,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := xyz.SurfaceArea(tc.g)
+			assert.NoError(t, err)
+			assert.True(t, math.Abs(tc.expected-actual) < 1e-9)
+		})
+	}
+}
+
+func TestSurfaceAreaUnsupportedType(t *testing.T) {
+	_, err := xyz.SurfaceArea(geom.NewLineString(geom.XYZ))
+	assert.Error(t, err)
+}
+
+func TestVolume(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		g        geom.T
+		expected float64
+	}{
+		{
+			name:     "unit cube",
+			g:        geom.NewPolyhedralSurface(geom.XYZ).MustSetCoords(unitCubeFaces),
+			expected: 1,
+		},
+		{
+			name:     "unit cube multipolygon",
+			g:        geom.NewMultiPolygon(geom.XYZ).MustSetCoords(unitCubeFaces),
+			expected: 1,
+		},
+		{
+			name: "tetrahedron",
+			g: geom.NewTIN(geom.XYZ).MustSetCoords([][][]geom.Coord{
+				{{{0, 0, 0}, {0, 1, 0}, {1, 0, 0}, {0, 0, 0}}},
+				{{{0, 0, 0}, {1, 0, 0}, {0, 0, 1}, {0, 0, 0}}},
+				{{{0, 0, 0}, {0, 0, 1}, {0, 1, 0}, {0, 0, 0}}},
+				{{{1, 0, 0}, {0, 1, 0}, {0, 0, 1}, {1, 0, 0}}},
+			}),
+			expected: 1.0 / 6,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := xyz.Volume(tc.g)
+			assert.NoError(t, err)
+			assert.True(t, math.Abs(tc.expected-actual) < 1e-9)
+		})
+	}
+}
+
+func TestVolumeNotClosed(t *testing.T) {
+	g := geom.NewPolyhedralSurface(geom.XYZ).MustSetCoords(unitCubeFaces[1:])
+	_, err := xyz.Volume(g)
+	assert.IsError(t, err, xyz.ErrSurfaceNotClosed)
+
+	_, err = xyz.Volume(geom.NewPolyhedralSurface(geom.XYZ))
+	assert.IsError(t, err, xyz.ErrSurfaceNotClosed)
+
+	_, err = xyz.Volume(geom.NewTriangle(geom.XYZ))
+	assert.Error(t, err)
+}
+
+func TestVectorCross(t *testing.T) {
+	origin := geom.Coord{0, 0, 0}
+	assert.Equal(t, geom.Coord{0, 0, 1}, xyz.VectorCross(origin, geom.Coord{1, 0, 0}, origin, geom.Coord{0, 1, 0}))
+	assert.Equal(t, geom.Coord{0, -1, 1}, xyz.VectorCross(geom.Coord{1, 1, 1}, geom.Coord{2, 1, 1}, geom.Coord{1, 1, 1}, geom.Coord{1, 2, 2}))
+}
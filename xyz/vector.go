@@ -17,6 +17,17 @@ func VectorDot(v1Start, v1End, v2Start, v2End geom.Coord) float64 {
 	return v1Startv2Endx*v2Startv2Endx + v1Startv2Endy*v2Startv2Endy + v1Startv2Endz*v2Startv2Endz
 }
 
+// VectorCross calculates the cross product of two vectors
+func VectorCross(v1Start, v1End, v2Start, v2End geom.Coord) geom.Coord {
+	v1x := v1End[0] - v1Start[0]
+	v1y := v1End[1] - v1Start[1]
+	v1z := v1End[2] - v1Start[2]
+	v2x := v2End[0] - v2Start[0]
+	v2y := v2End[1] - v2Start[1]
+	v2z := v2End[2] - v2Start[2]
+	return geom.Coord{v1y*v2z - v1z*v2y, v1z*v2x - v1x*v2z, v1x*v2y - v1y*v2x}
+}
+
 // VectorNormalize creates a coordinate that is the normalized vector from 0,0,0 to vector
 func VectorNormalize(vector geom.Coord) geom.Coord {
 	vLen := VectorLength(vector)